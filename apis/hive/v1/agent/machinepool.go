@@ -0,0 +1,11 @@
+package agent
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// MachinePool stores the configuration for a machine pool of a cluster installed with the agent install strategy.
+// The workers of the machine pool are inventory hosts, whose Agents are bound to the cluster.
+type MachinePool struct {
+	// AgentSelector is a label selector for the Agents of the inventory hosts that may be bound to the cluster as
+	// workers of the machine pool. Agents that are already bound to a cluster are not selected.
+	AgentSelector metav1.LabelSelector `json:"agentSelector"`
+}
@@ -20,3 +20,20 @@ func (in *BareMetalPlatform) DeepCopy() *BareMetalPlatform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePool) DeepCopyInto(out *MachinePool) {
+	*out = *in
+	in.AgentSelector.DeepCopyInto(&out.AgentSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePool.
+func (in *MachinePool) DeepCopy() *MachinePool {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePool)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -13,8 +13,38 @@ type MachinePool struct {
 
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// SpotVMOptions allows users to configure instances to be run using Azure Spot VMs.
+	// +optional
+	SpotVMOptions *SpotVMOptions `json:"spotVMOptions,omitempty"`
+}
+
+// SpotVMOptions defines the options available to a user when configuring
+// Machines to run on Spot VMs.
+// Most users should provide an empty struct.
+type SpotVMOptions struct {
+	// MaxPrice is the maximum price, in US dollars per hour, the user is willing to pay for their VMs. VMs are evicted
+	// when the price rises above it. -1 is the On-Demand price, in which case VMs are only evicted for capacity.
+	// Default: On-Demand price
+	// +optional
+	MaxPrice *string `json:"maxPrice,omitempty"`
+
+	// EvictionPolicy is what happens to the VMs when they are evicted. Only Deallocate is supported, as it is the
+	// only eviction policy of single instance Spot VMs, which the machine API creates.
+	// Default: Deallocate
+	// +kubebuilder:validation:Enum=Deallocate
+	// +optional
+	EvictionPolicy SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
 }
 
+// SpotEvictionPolicy is what happens to Spot VMs when they are evicted.
+type SpotEvictionPolicy string
+
+const (
+	// SpotEvictionPolicyDeallocate stops the evicted VMs and keeps their disks.
+	SpotEvictionPolicyDeallocate SpotEvictionPolicy = "Deallocate"
+)
+
 // OSDisk defines the disk for machines on Azure.
 type OSDisk struct {
 	// DiskSizeGB defines the size of disk in GB.
@@ -38,4 +68,8 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.OSDisk.DiskSizeGB != 0 {
 		a.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
 	}
+
+	if required.SpotVMOptions != nil {
+		a.SpotVMOptions = required.SpotVMOptions
+	}
 }
@@ -13,6 +13,11 @@ func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 		copy(*out, *in)
 	}
 	out.OSDisk = in.OSDisk
+	if in.SpotVMOptions != nil {
+		in, out := &in.SpotVMOptions, &out.SpotVMOptions
+		*out = new(SpotVMOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -74,3 +79,24 @@ func (in *Platform) DeepCopy() *Platform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotVMOptions) DeepCopyInto(out *SpotVMOptions) {
+	*out = *in
+	if in.MaxPrice != nil {
+		in, out := &in.MaxPrice, &out.MaxPrice
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotVMOptions.
+func (in *SpotVMOptions) DeepCopy() *SpotVMOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotVMOptions)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -57,7 +57,7 @@ const (
 
 // ClusterPowerState is used to indicate whether a cluster is running or in a
 // hibernating state.
-// +kubebuilder:validation:Enum="";Running;Hibernating
+// +kubebuilder:validation:Enum="";Running;Hibernating;WorkersHibernating
 type ClusterPowerState string
 
 const (
@@ -68,6 +68,11 @@ const (
 	// HibernatingClusterPowerState is used to stop the machines belonging to a cluster
 	// and move it to a hibernating state.
 	HibernatingClusterPowerState ClusterPowerState = "Hibernating"
+
+	// WorkersHibernatingClusterPowerState is used to scale the Hive-managed MachinePools of a cluster
+	// to zero and stop its remaining worker machines while the control plane keeps running, leaving
+	// the cluster API available.
+	WorkersHibernatingClusterPowerState ClusterPowerState = "WorkersHibernating"
 )
 
 // ClusterDeploymentSpec defines the desired state of ClusterDeployment
@@ -136,7 +141,8 @@ type ClusterDeploymentSpec struct {
 	ClusterPoolRef *ClusterPoolReference `json:"clusterPoolRef,omitempty"`
 
 	// PowerState indicates whether a cluster should be running or hibernating. When omitted,
-	// PowerState defaults to the Running state.
+	// PowerState defaults to the Running state. WorkersHibernating hibernates only the worker
+	// machines of the cluster and keeps the control plane running.
 	// +optional
 	PowerState ClusterPowerState `json:"powerState,omitempty"`
 
@@ -146,6 +152,11 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernationHooks are Jobs run by the hibernation controller before the machines of the cluster are stopped
+	// and after the cluster has resumed.
+	// +optional
+	HibernationHooks *HibernationHooks `json:"hibernationHooks,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
@@ -193,6 +204,15 @@ type MachineManagement struct {
 type CentralMachineManagement struct {
 }
 
+// MachineAPIImages are the images of the machine API controllers run for central machine management.
+type MachineAPIImages struct {
+	// MachineAPIOperator is the image of the machine API operator, which contains the MachineSet controller.
+	MachineAPIOperator string `json:"machineAPIOperator"`
+
+	// MachineControllers is the image of the machine controller of the platform of the cluster.
+	MachineControllers string `json:"machineControllers"`
+}
+
 // Provisioning contains settings used only for initial cluster provisioning.
 type Provisioning struct {
 	// InstallConfigSecretRef is the reference to a secret that contains an openshift-install
@@ -239,6 +259,49 @@ type ClusterImageSetReference struct {
 	Name string `json:"name"`
 }
 
+// HibernationHooks configures the Jobs run by the hibernation controller before the machines of a
+// cluster are stopped and after the cluster has resumed.
+type HibernationHooks struct {
+	// PreStop is run before the machines of the cluster are stopped. Machines are only stopped once the
+	// hook Job has completed, or has failed with the Ignore failure policy.
+	// +optional
+	PreStop *HibernationHook `json:"preStop,omitempty"`
+
+	// PostResume is run once all machines of the cluster are running and its nodes are ready. The cluster
+	// is only reported as running once the hook Job has completed, or has failed with the Ignore failure policy.
+	// +optional
+	PostResume *HibernationHook `json:"postResume,omitempty"`
+}
+
+// HibernationHook is a Job run on the hub with the admin kubeconfig of the cluster mounted.
+type HibernationHook struct {
+	// PodTemplateRef references a PodTemplate in the namespace of the ClusterDeployment which is used
+	// for the pod of the hook Job. The admin kubeconfig of the cluster is mounted in all containers of the
+	// pod and the KUBECONFIG environment variable points to it.
+	PodTemplateRef corev1.LocalObjectReference `json:"podTemplateRef"`
+
+	// FailurePolicy determines how a failure of the hook Job is handled. Defaults to Fail.
+	// +optional
+	FailurePolicy HibernationHookFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Timeout is the time the hook Job is allowed to run before it is considered failed. Defaults to 30 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// HibernationHookFailurePolicy determines how the hibernation controller handles a failed hook Job.
+// +kubebuilder:validation:Enum="";Fail;Ignore
+type HibernationHookFailurePolicy string
+
+const (
+	// FailHibernationHookFailurePolicy stops the transition of the cluster when the hook Job fails. The
+	// hook is run again once the failed Job is deleted.
+	FailHibernationHookFailurePolicy HibernationHookFailurePolicy = "Fail"
+
+	// IgnoreHibernationHookFailurePolicy continues the transition of the cluster when the hook Job fails.
+	IgnoreHibernationHookFailurePolicy HibernationHookFailurePolicy = "Ignore"
+)
+
 // ClusterPoolReference is a reference to a ClusterPool
 type ClusterPoolReference struct {
 	// Namespace is the namespace where the ClusterPool resides.
@@ -291,6 +354,11 @@ type ClusterDeploymentStatus struct {
 	// +optional
 	CLIImage *string `json:"cliImage,omitempty"`
 
+	// MachineAPIImages are the images of the machine API controllers that Hive runs in the target namespace of
+	// clusters whose machines are managed centrally, resolved from the release image.
+	// +optional
+	MachineAPIImages *MachineAPIImages `json:"machineAPIImages,omitempty"`
+
 	// Conditions includes more detailed status for the cluster deployment
 	// +optional
 	Conditions []ClusterDeploymentCondition `json:"conditions,omitempty"`
@@ -316,6 +384,46 @@ type ClusterDeploymentStatus struct {
 	// perform the installation.
 	// +optional
 	Platform *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// HibernationMode is the kind of hibernation that is active for the cluster. It is Hibernating when
+	// all machines of the cluster are stopped, WorkersHibernating when only the worker machines are stopped,
+	// and empty when the cluster is running.
+	// +optional
+	HibernationMode ClusterPowerState `json:"hibernationMode,omitempty"`
+
+	// PowerStateDurations records how long the cluster has spent in each power state since Hive started
+	// tracking it.
+	// +optional
+	PowerStateDurations *ClusterPowerStateDurations `json:"powerStateDurations,omitempty"`
+}
+
+// ClusterPowerStateDurations is the time a cluster has spent in each power state.
+type ClusterPowerStateDurations struct {
+	// Running is the total time the machines of the cluster have been running.
+	Running metav1.Duration `json:"running"`
+
+	// Hibernating is the total time all machines of the cluster have been stopped.
+	Hibernating metav1.Duration `json:"hibernating"`
+
+	// WorkersHibernating is the total time only the worker machines of the cluster have been stopped.
+	WorkersHibernating metav1.Duration `json:"workersHibernating"`
+
+	// LastUpdateTime is the time up to which the durations have been accumulated. Any time after it is
+	// spent in the power state of the last entry of History.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// History lists the most recent power state changes of the cluster, oldest first.
+	// +optional
+	History []ClusterPowerStateChange `json:"history,omitempty"`
+}
+
+// ClusterPowerStateChange records the cluster entering a power state.
+type ClusterPowerStateChange struct {
+	// PowerState is the power state the cluster entered.
+	PowerState ClusterPowerState `json:"powerState"`
+
+	// Time is when the cluster entered the power state.
+	Time metav1.Time `json:"time"`
 }
 
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
@@ -376,6 +484,10 @@ const (
 	// SyncSetFailedCondition indicates if any syncset for a cluster deployment failed
 	SyncSetFailedCondition ClusterDeploymentConditionType = "SyncSetFailed"
 
+	// SyncSetResourcesUnhealthyCondition indicates if any resource applied by a syncset with health checks
+	// enabled is not healthy in the cluster
+	SyncSetResourcesUnhealthyCondition ClusterDeploymentConditionType = "SyncSetResourcesUnhealthy"
+
 	// RelocationFailedCondition indicates if a relocation to another Hive instance has failed
 	RelocationFailedCondition ClusterDeploymentConditionType = "RelocationFailed"
 
@@ -454,6 +566,18 @@ const (
 	// SyncSetsNotAppliedReason is used as the reason when SyncSets have not yet been applied
 	// for the cluster based on ClusterSync.Status.FirstSucessTime
 	SyncSetsNotAppliedReason = "SyncSetsNotApplied"
+	// PreStopHookRunningHibernationReason is used when the pre-stop hook Job is running before the
+	// machines of the cluster are stopped.
+	PreStopHookRunningHibernationReason = "PreStopHookRunning"
+	// PreStopHookFailedHibernationReason is used when the pre-stop hook Job failed and its failure
+	// policy prevents the machines of the cluster from being stopped.
+	PreStopHookFailedHibernationReason = "PreStopHookFailed"
+	// PostResumeHookRunningHibernationReason is used when the post-resume hook Job is running after
+	// the machines of the cluster have been started.
+	PostResumeHookRunningHibernationReason = "PostResumeHookRunning"
+	// PostResumeHookFailedHibernationReason is used when the post-resume hook Job failed and its failure
+	// policy prevents the cluster from being reported as running.
+	PostResumeHookFailedHibernationReason = "PostResumeHookFailed"
 )
 
 // InitializedConditionReason is used when a condition is initialized for the first time, and the status of the
@@ -0,0 +1,153 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// FinalizerDNSRecord is used on DNSRecords to ensure we successfully delete
+	// the records from the DNS provider before cleaning up the API object.
+	FinalizerDNSRecord string = "hive.openshift.io/dnsrecord"
+)
+
+// DNSRecordSpec defines the desired state of DNSRecord
+type DNSRecordSpec struct {
+	// DNSZoneRef references the DNSZone, in the same namespace, that hosts the records.
+	DNSZoneRef corev1.LocalObjectReference `json:"dnsZoneRef"`
+
+	// Name is the fully qualified domain name of the records, e.g. "www.mycluster.example.com".
+	// It must be in the zone of the DNSZone. Wildcard names such as "*.mycluster.example.com" are allowed.
+	Name string `json:"name"`
+
+	// Type is the type of the records.
+	Type DNSRecordType `json:"type"`
+
+	// TTL is the time to live of the records in seconds. Defaults to 60.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTL int64 `json:"ttl,omitempty"`
+
+	// Values are the values of the records, one per record, in the zone file format of the type of the records.
+	// For example, "192.0.2.1" for A records, "10 mail.example.com." for MX records or
+	// "0 issue \"letsencrypt.org\"" for CAA records. TXT values are the text of the records, without quotes.
+	// CNAME records can have a single value.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// DNSRecordType is the type of the records of a DNSRecord
+// +kubebuilder:validation:Enum=A;AAAA;CAA;CNAME;MX;SRV;TXT
+type DNSRecordType string
+
+const (
+	// DNSRecordTypeA is the type of IPv4 address records
+	DNSRecordTypeA DNSRecordType = "A"
+	// DNSRecordTypeAAAA is the type of IPv6 address records
+	DNSRecordTypeAAAA DNSRecordType = "AAAA"
+	// DNSRecordTypeCAA is the type of certification authority authorization records
+	DNSRecordTypeCAA DNSRecordType = "CAA"
+	// DNSRecordTypeCNAME is the type of canonical name records
+	DNSRecordTypeCNAME DNSRecordType = "CNAME"
+	// DNSRecordTypeMX is the type of mail exchange records
+	DNSRecordTypeMX DNSRecordType = "MX"
+	// DNSRecordTypeSRV is the type of service locator records
+	DNSRecordTypeSRV DNSRecordType = "SRV"
+	// DNSRecordTypeTXT is the type of text records
+	DNSRecordTypeTXT DNSRecordType = "TXT"
+)
+
+// DNSRecordStatus defines the observed state of DNSRecord
+type DNSRecordStatus struct {
+	// OwnedRecordSet is the record set in the DNS provider that is owned by the DNSRecord. Hive claims the
+	// record set before creating it, and only updates and deletes record sets that are owned by a DNSRecord.
+	// +optional
+	OwnedRecordSet *DNSRecordSetReference `json:"ownedRecordSet,omitempty"`
+
+	// LastSyncTimestamp is the time that the records were last sync'd.
+	// +optional
+	LastSyncTimestamp *metav1.Time `json:"lastSyncTimestamp,omitempty"`
+
+	// LastSyncGeneration is the generation of the DNSRecord that was last sync'd.
+	// +optional
+	LastSyncGeneration int64 `json:"lastSyncGeneration,omitempty"`
+
+	// Conditions includes more detailed status for the DNSRecord
+	// +optional
+	Conditions []DNSRecordCondition `json:"conditions,omitempty"`
+}
+
+// DNSRecordSetReference identifies a record set of a DNSZone
+type DNSRecordSetReference struct {
+	// DNSZone is the name of the DNSZone that hosts the record set.
+	DNSZone string `json:"dnsZone"`
+
+	// Name is the fully qualified domain name of the record set.
+	Name string `json:"name"`
+
+	// Type is the type of the record set.
+	Type DNSRecordType `json:"type"`
+}
+
+// DNSRecordCondition contains details for the current condition of a DNSRecord
+type DNSRecordCondition struct {
+	// Type is the type of the condition.
+	Type DNSRecordConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DNSRecordConditionType is a valid value for DNSRecordCondition.Type
+type DNSRecordConditionType string
+
+const (
+	// DNSRecordReadyCondition is true when the records in the DNS provider match the DNSRecord
+	DNSRecordReadyCondition DNSRecordConditionType = "Ready"
+	// DNSRecordConflictCondition is true when the record set of the DNSRecord is already managed by the
+	// installer, by another DNSRecord, or exists in the DNS provider without being owned by the DNSRecord
+	DNSRecordConflictCondition DNSRecordConditionType = "Conflict"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSRecord is the Schema for the dnsrecords API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="DNSZone",type="string",JSONPath=".spec.dnsZoneRef.name"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:resource:path=dnsrecords,scope=Namespaced
+type DNSRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSRecordSpec   `json:"spec,omitempty"`
+	Status DNSRecordStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSRecordList contains a list of DNSRecord
+type DNSRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSRecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSRecord{}, &DNSRecordList{})
+}
@@ -27,6 +27,19 @@ type DNSZoneSpec struct {
 	// +optional
 	LinkToParentDomain bool `json:"linkToParentDomain,omitempty"`
 
+	// DNSSEC specifies whether the zone should be signed with DNSSEC. When the zone is linked to its parent
+	// domain, the DS records of the key signing keys of the zone are published in the parent domain.
+	// DNSSEC is only supported for zones in AWS Route53 and GCP Cloud DNS.
+	// +optional
+	DNSSEC bool `json:"dnssec,omitempty"`
+
+	// Private specifies whether the zone is a private zone, which can only be resolved from the networks
+	// associated with it: the VPCs of an AWS zone, the networks of a GCP zone or the virtual networks of an
+	// Azure zone. Private zones are not linked to their parent domain, and cannot be signed with DNSSEC.
+	// Private zones are not supported for RFC 2136.
+	// +optional
+	Private bool `json:"private,omitempty"`
+
 	// AWS specifies AWS-specific cloud configuration
 	// +optional
 	AWS *AWSDNSZoneSpec `json:"aws,omitempty"`
@@ -38,6 +51,10 @@ type DNSZoneSpec struct {
 	// Azure specifes Azure-specific cloud configuration
 	// +optional
 	Azure *AzureDNSZoneSpec `json:"azure,omitempty"`
+
+	// RFC2136 specifies the configuration of a zone managed with RFC 2136 dynamic updates
+	// +optional
+	RFC2136 *RFC2136DNSZoneSpec `json:"rfc2136,omitempty"`
 }
 
 // AWSDNSZoneSpec contains AWS-specific DNSZone specifications
@@ -62,6 +79,24 @@ type AWSDNSZoneSpec struct {
 	// For AWS China, use cn-northwest-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// DNSSECKMSKeyARN is the ARN of the customer managed KMS key used by Route53 for the key signing key of
+	// the zone. The key must be in us-east-1 and have the ECC_NIST_P256 key spec.
+	// Required when DNSSEC is enabled.
+	// +optional
+	DNSSECKMSKeyARN string `json:"dnssecKMSKeyARN,omitempty"`
+
+	// VPCs are the VPCs that a private zone is associated with. At least one VPC is required for private zones.
+	// +optional
+	VPCs []AWSDNSZoneVPC `json:"vpcs,omitempty"`
+}
+
+// AWSDNSZoneVPC is a VPC associated with a private DNSZone
+type AWSDNSZoneVPC struct {
+	// VPCID is the ID of the VPC.
+	VPCID string `json:"vpcID"`
+	// Region is the AWS region of the VPC.
+	Region string `json:"region"`
 }
 
 // AWSResourceTag represents a tag that is applied to an AWS cloud resource
@@ -79,6 +114,11 @@ type GCPDNSZoneSpec struct {
 	// Secret should have a key named 'osServiceAccount.json'.
 	// The credentials must specify the project to use.
 	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Networks are the URLs of the VPC networks that a private zone is visible to, e.g.
+	// "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/my-network".
+	// +optional
+	Networks []string `json:"networks,omitempty"`
 }
 
 // AzureDNSZoneSpec contains Azure-specific DNSZone specifications
@@ -91,6 +131,36 @@ type AzureDNSZoneSpec struct {
 
 	// ResourceGroupName specifies the Azure resource group in which the Hosted Zone should be created.
 	ResourceGroupName string `json:"resourceGroupName"`
+
+	// VirtualNetworks are the virtual networks, in the subscription of the credentials, that a private zone
+	// is linked to. Private zones are created with Azure Private DNS.
+	// +optional
+	VirtualNetworks []AzureDNSZoneVirtualNetwork `json:"virtualNetworks,omitempty"`
+}
+
+// AzureDNSZoneVirtualNetwork is a virtual network linked to a private DNSZone
+type AzureDNSZoneVirtualNetwork struct {
+	// ResourceGroupName is the resource group of the virtual network.
+	ResourceGroupName string `json:"resourceGroupName"`
+	// Name is the name of the virtual network.
+	Name string `json:"name"`
+}
+
+// RFC2136DNSZoneSpec contains the specifications of a DNSZone managed with RFC 2136 dynamic updates.
+// RFC 2136 cannot create zones, so the zone must be configured on the name server beforehand.
+type RFC2136DNSZoneSpec struct {
+	// Server is the address of the primary name server of the zone, which accepts dynamic updates
+	// and zone transfers authenticated with the TSIG key. The port defaults to 53.
+	Server string `json:"server"`
+
+	// TSIGSecretRef references a secret that contains the TSIG key used to authenticate with the
+	// name server.
+	// Secret should have keys named 'tsig-key-name' and 'tsig-secret', and may have a key named
+	// 'tsig-algorithm', which defaults to hmac-sha256.
+	// If unset, the TSIG key of the managed domain of the zone in HiveConfig is used. It is read from
+	// the namespace of Hive, and is not copied to the namespace of the DNSZone.
+	// +optional
+	TSIGSecretRef corev1.LocalObjectReference `json:"tsigSecretRef,omitempty"`
 }
 
 // DNSZoneStatus defines the observed state of DNSZone
@@ -119,6 +189,10 @@ type DNSZoneStatus struct {
 	// AzureDNSZoneStatus contains status information specific to Azure
 	Azure *AzureDNSZoneStatus `json:"azure,omitempty"`
 
+	// DNSSEC contains the DNSSEC signing status of the zone. It is only set while the zone is signed.
+	// +optional
+	DNSSEC *DNSSECStatus `json:"dnssec,omitempty"`
+
 	// Conditions includes more detailed status for the DNSZone
 	// +optional
 	Conditions []DNSZoneCondition `json:"conditions,omitempty"`
@@ -142,6 +216,33 @@ type GCPDNSZoneStatus struct {
 	ZoneName *string `json:"zoneName,omitempty"`
 }
 
+// DNSSECStatus contains the DNSSEC signing status of a DNS zone
+type DNSSECStatus struct {
+	// Signing is true when the dns provider signs the zone.
+	Signing bool `json:"signing"`
+
+	// KeySigningKeys are the key signing keys of the zone.
+	// +optional
+	KeySigningKeys []DNSSECKeySigningKey `json:"keySigningKeys,omitempty"`
+}
+
+// DNSSECKeySigningKey contains the status of a key signing key of a DNS zone
+type DNSSECKeySigningKey struct {
+	// Name is the name or ID of the key in the dns provider.
+	Name string `json:"name"`
+
+	// KeyTag is the key tag of the key.
+	KeyTag int64 `json:"keyTag"`
+
+	// Active is true when the key is used to sign the zone.
+	Active bool `json:"active"`
+
+	// DSRecord is the value of the DS record for the key to publish in the parent domain, in the zone file
+	// format, e.g. "12345 13 2 4A1B...".
+	// +optional
+	DSRecord string `json:"dsRecord,omitempty"`
+}
+
 // DNSZoneCondition contains details for the current condition of a DNSZone
 type DNSZoneCondition struct {
 	// Type is the type of the condition.
@@ -170,6 +271,9 @@ const (
 	ZoneAvailableDNSZoneCondition DNSZoneConditionType = "ZoneAvailable"
 	// ParentLinkCreatedCondition is true if the parent link has been created
 	ParentLinkCreatedCondition DNSZoneConditionType = "ParentLinkCreated"
+	// DSRecordsCreatedCondition is true if the DS records of the DNSSEC keys of the zone have been
+	// created in the parent domain
+	DSRecordsCreatedCondition DNSZoneConditionType = "DSRecordsCreated"
 	// DomainNotManaged is true if we try to reconcile a DNSZone and the HiveConfig
 	// does not contain a ManagedDNS entry for the domain in the DNSZone
 	DomainNotManaged DNSZoneConditionType = "DomainNotManaged"
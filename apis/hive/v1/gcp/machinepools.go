@@ -13,6 +13,11 @@ type MachinePool struct {
 	//
 	// +optional
 	OSDisk OSDisk `json:"osDisk"`
+
+	// Preemptible indicates that the instances are preemptible VMs. Preemptible VMs are cheaper, but GCP may stop them
+	// at any time, and stops them after 24 hours.
+	// +optional
+	Preemptible bool `json:"preemptible,omitempty"`
 }
 
 // OSDisk defines the disk for machines on GCP.
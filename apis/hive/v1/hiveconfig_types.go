@@ -69,6 +69,13 @@ type HiveConfigSpec struct {
 	// The default reapply interval is two hours.
 	SyncSetReapplyInterval string `json:"syncSetReapplyInterval,omitempty"`
 
+	// ClusterSyncReplicaWeights are the relative shares of the clusters synced by the replicas of the clustersync
+	// StatefulSet, keyed by the ordinal of the replica. Replicas that are not listed have a weight of 100. Lowering the
+	// weight of a replica moves some of its clusters to the other replicas, and a weight of 0 drains the replica.
+	// Keys which are not ordinals, i.e. non-negative integers, are ignored.
+	// +optional
+	ClusterSyncReplicaWeights map[string]ClusterSyncReplicaWeight `json:"clusterSyncReplicaWeights,omitempty"`
+
 	// MaintenanceMode can be set to true to disable the hive controllers in situations where we need to ensure
 	// nothing is running that will add or act upon finalizers on Hive types. This should rarely be needed.
 	// Sets replicas to 0 for the hive-controllers deployment to accomplish this.
@@ -268,6 +275,10 @@ var FeatureSets = map[FeatureSet]*FeatureGatesEnabled{
 	},
 }
 
+// ClusterSyncReplicaWeight is the relative share of the clusters synced by a replica of the clustersync StatefulSet.
+// +kubebuilder:validation:Minimum=0
+type ClusterSyncReplicaWeight int32
+
 // HiveConfigStatus defines the observed state of Hive
 type HiveConfigStatus struct {
 	// AggregatorClientCAHash keeps an md5 hash of the aggregator client CA
@@ -383,6 +394,16 @@ type ManageDNSConfig struct {
 	// +optional
 	Azure *ManageDNSAzureConfig `json:"azure,omitempty"`
 
+	// RFC2136 contains the settings for managing DNS with RFC 2136 dynamic updates, for name servers
+	// such as BIND or PowerDNS.
+	// +optional
+	RFC2136 *ManageDNSRFC2136Config `json:"rfc2136,omitempty"`
+
+	// DNSSEC specifies whether the DNS zones that hive creates for clusters in the domains are signed with
+	// DNSSEC. Only supported for AWS and GCP.
+	// +optional
+	DNSSEC bool `json:"dnssec,omitempty"`
+
 	// As other cloud providers are supported, additional fields will be
 	// added for each of those cloud providers. Only a single cloud provider
 	// may be configured at a time.
@@ -425,6 +446,12 @@ type ManageDNSAWSConfig struct {
 	// For AWS China, use cn-northwest-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// DNSSECKMSKeyARN is the ARN of the customer managed KMS key used for the key signing keys of the DNS
+	// zones that hive creates for clusters when DNSSEC is enabled. The key must be in us-east-1 and have the
+	// ECC_NIST_P256 key spec.
+	// +optional
+	DNSSECKMSKeyARN string `json:"dnssecKMSKeyARN,omitempty"`
 }
 
 // ManageDNSGCPConfig contains GCP-specific info to manage a given domain.
@@ -457,6 +484,20 @@ type ManageDNSAzureConfig struct {
 	ResourceGroupName string `json:"resourceGroupName"`
 }
 
+// ManageDNSRFC2136Config contains the info to manage a given domain with RFC 2136 dynamic updates.
+type ManageDNSRFC2136Config struct {
+	// Server is the address of the primary name server of the domains, which accepts dynamic updates
+	// and zone transfers authenticated with the TSIG key. The port defaults to 53.
+	Server string `json:"server"`
+
+	// TSIGSecretRef references a secret in the TargetNamespace that contains the TSIG key used to
+	// authenticate with the name server.
+	// Secret should have keys named 'tsig-key-name' and 'tsig-secret', the latter base64 encoded as in
+	// the BIND key configuration, and may have a key named 'tsig-algorithm', which defaults to
+	// hmac-sha256.
+	TSIGSecretRef corev1.LocalObjectReference `json:"tsigSecretRef"`
+}
+
 // ControllerConfig contains the configuration for a controller
 type ControllerConfig struct {
 	// ConcurrentReconciles specifies number of concurrent reconciles for a controller
@@ -480,7 +521,7 @@ type ControllerConfig struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync;selectorsyncsetrollout;resourcecollector;dnsrecord
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -503,30 +544,33 @@ func (c ControllerNames) Contains(controllerName ControllerName) bool {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
-	ClusterClaimControllerName         ControllerName = "clusterclaim"
-	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
-	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
-	ClusterpoolControllerName          ControllerName = "clusterpool"
-	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
-	ClusterProvisionControllerName     ControllerName = "clusterProvision"
-	ClusterRelocateControllerName      ControllerName = "clusterRelocate"
-	ClusterStateControllerName         ControllerName = "clusterState"
-	ClusterVersionControllerName       ControllerName = "clusterversion"
-	ControlPlaneCertsControllerName    ControllerName = "controlPlaneCerts"
-	DNSEndpointControllerName          ControllerName = "dnsendpoint"
-	DNSZoneControllerName              ControllerName = "dnszone"
-	FakeClusterInstallControllerName   ControllerName = "fakeclusterinstall"
-	HibernationControllerName          ControllerName = "hibernation"
-	RemoteIngressControllerName        ControllerName = "remoteingress"
-	RemoteMachinesetControllerName     ControllerName = "remotemachineset"
-	SyncIdentityProviderControllerName ControllerName = "syncidentityprovider"
-	UnreachableControllerName          ControllerName = "unreachable"
-	VeleroBackupControllerName         ControllerName = "velerobackup"
-	MetricsControllerName              ControllerName = "metrics"
-	ClustersyncControllerName          ControllerName = "clustersync"
-	MachineManagementControllerName    ControllerName = "machineManagement"
-	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
-	HiveControllerName                 ControllerName = "hive"
+	ClusterClaimControllerName           ControllerName = "clusterclaim"
+	ClusterDeploymentControllerName      ControllerName = "clusterDeployment"
+	ClusterDeprovisionControllerName     ControllerName = "clusterDeprovision"
+	ClusterpoolControllerName            ControllerName = "clusterpool"
+	ClusterpoolNamespaceControllerName   ControllerName = "clusterpoolnamespace"
+	ClusterProvisionControllerName       ControllerName = "clusterProvision"
+	ClusterRelocateControllerName        ControllerName = "clusterRelocate"
+	ClusterStateControllerName           ControllerName = "clusterState"
+	ClusterVersionControllerName         ControllerName = "clusterversion"
+	ControlPlaneCertsControllerName      ControllerName = "controlPlaneCerts"
+	DNSEndpointControllerName            ControllerName = "dnsendpoint"
+	DNSRecordControllerName              ControllerName = "dnsrecord"
+	DNSZoneControllerName                ControllerName = "dnszone"
+	FakeClusterInstallControllerName     ControllerName = "fakeclusterinstall"
+	HibernationControllerName            ControllerName = "hibernation"
+	RemoteIngressControllerName          ControllerName = "remoteingress"
+	RemoteMachinesetControllerName       ControllerName = "remotemachineset"
+	SyncIdentityProviderControllerName   ControllerName = "syncidentityprovider"
+	UnreachableControllerName            ControllerName = "unreachable"
+	VeleroBackupControllerName           ControllerName = "velerobackup"
+	MetricsControllerName                ControllerName = "metrics"
+	ClustersyncControllerName            ControllerName = "clustersync"
+	SelectorSyncSetRolloutControllerName ControllerName = "selectorsyncsetrollout"
+	ResourceCollectorControllerName      ControllerName = "resourcecollector"
+	MachineManagementControllerName      ControllerName = "machineManagement"
+	AWSPrivateLinkControllerName         ControllerName = "awsprivatelink"
+	HiveControllerName                   ControllerName = "hive"
 )
 
 // SpecificControllerConfig contains the configuration for a specific controller
@@ -3,7 +3,10 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/openshift/hive/apis/hive/v1/agent"
 	"github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/apis/hive/v1/gcp"
@@ -54,6 +57,152 @@ type MachinePoolSpec struct {
 	// This list will overwrite any modifications made to Node taints on an ongoing basis.
 	// +optional
 	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// HealthCheck configures the remediation of the unhealthy machines of the machine pool. When set, a
+	// MachineHealthCheck targeting the MachineSets of the machine pool is created in the remote cluster.
+	// +optional
+	HealthCheck *MachinePoolHealthCheck `json:"healthCheck,omitempty"`
+
+	// UpdateStrategy is how the machines of the machine pool are replaced when the platform configuration of the
+	// machine pool changes. The platform configuration can only be changed when an update strategy is set.
+	// +optional
+	UpdateStrategy *MachinePoolUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// ZoneDistribution distributes the replicas of the machine pool across its zones by weight or by fixed count,
+	// rather than evenly. Zones that are not listed have a weight of 1. When auto-scaling, both the minimum and the
+	// maximum replicas are distributed. Only supported on AWS, Azure and GCP.
+	// +optional
+	ZoneDistribution []MachinePoolZoneDistribution `json:"zoneDistribution,omitempty"`
+
+	// NodeConfig configures the nodes of the machine pool beyond their labels and taints. When set, the nodes of the
+	// machine pool are put in a MachineConfigPool named after the machine pool in the remote cluster, and the kubelet
+	// configuration, sysctls and MachineConfigs are applied to that MachineConfigPool only. Not supported for the
+	// worker machine pool, whose nodes are in the worker MachineConfigPool of the remote cluster.
+	// +optional
+	NodeConfig *MachinePoolNodeConfig `json:"nodeConfig,omitempty"`
+}
+
+// MachinePoolNodeConfig is the configuration of the nodes of a machine pool, applied by the machine config operator
+// of the remote cluster.
+type MachinePoolNodeConfig struct {
+	// Kubelet is the configuration of the kubelet of the nodes, as in the kubeletConfig of a KubeletConfig, for
+	// instance maxPods, systemReserved or evictionHard.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Kubelet *runtime.RawExtension `json:"kubelet,omitempty"`
+
+	// Sysctls are the kernel parameters of the nodes, by name.
+	// +optional
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+
+	// MachineConfigs are additional MachineConfigs for the nodes.
+	// +optional
+	MachineConfigs []MachinePoolMachineConfig `json:"machineConfigs,omitempty"`
+}
+
+// MachinePoolMachineConfig is a MachineConfig for the nodes of a machine pool.
+type MachinePoolMachineConfig struct {
+	// Name is the name of the MachineConfig within the machine pool. The MachineConfig in the remote cluster is named
+	// 99-<machine pool name>-<name>.
+	Name string `json:"name"`
+
+	// Spec is the spec of the MachineConfig, for instance its Ignition config or kernel arguments.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// MachinePoolZoneDistribution is the share of the replicas of a machine pool for a zone. At most one of Weight and
+// Replicas may be set.
+type MachinePoolZoneDistribution struct {
+	// Zone is the name of the zone.
+	Zone string `json:"zone"`
+
+	// Weight is the weight of the zone. The replicas that are not fixed by other zones are distributed across the
+	// weighted zones in proportion to their weight. A zone with a weight of 0 has no replicas.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Replicas is the fixed number of replicas of the zone. When auto-scaling, it is both the minimum and the maximum
+	// replicas of the zone.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// MachinePoolUpdateStrategyType is a valid value for MachinePoolUpdateStrategy.Type
+// +kubebuilder:validation:Enum=OnDelete;RollingUpdate
+type MachinePoolUpdateStrategyType string
+
+const (
+	// OnDeleteMachinePoolUpdateStrategyType updates the MachineSets of the machine pool in place. Existing machines
+	// are kept, and only the machines created afterwards use the new platform configuration.
+	OnDeleteMachinePoolUpdateStrategyType MachinePoolUpdateStrategyType = "OnDelete"
+
+	// RollingUpdateMachinePoolUpdateStrategyType creates replacement MachineSets with the new platform configuration,
+	// and gradually scales them up while scaling down the outdated MachineSets.
+	RollingUpdateMachinePoolUpdateStrategyType MachinePoolUpdateStrategyType = "RollingUpdate"
+)
+
+// MachinePoolUpdateStrategy details how the machines of the machine pool are replaced.
+type MachinePoolUpdateStrategy struct {
+	// Type is the type of the update strategy.
+	Type MachinePoolUpdateStrategyType `json:"type"`
+
+	// RollingUpdate configures the RollingUpdate strategy.
+	// +optional
+	RollingUpdate *MachinePoolRollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// MachinePoolRollingUpdate configures the pace of the replacement of the machines of the machine pool.
+type MachinePoolRollingUpdate struct {
+	// MaxSurge is the number or percentage of machines that can be created above the replicas of the machine pool
+	// during the rollout. Percentages are rounded up. Defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the number or percentage of the replicas of the machine pool that can be unavailable during
+	// the rollout. Percentages are rounded down. Defaults to 0.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// MachinePoolHealthCheck details when the machines of the machine pool are considered unhealthy and remediated.
+type MachinePoolHealthCheck struct {
+	// UnhealthyConditions are the node conditions that determine whether a node is considered unhealthy. The
+	// conditions are combined in a logical OR. Defaults to the Ready condition being False or Unknown for 5 minutes.
+	// +optional
+	UnhealthyConditions []MachinePoolUnhealthyCondition `json:"unhealthyConditions,omitempty"`
+
+	// MaxUnhealthy is the number or percentage of the machines of the machine pool that may be unhealthy before
+	// remediation stops. Defaults to 100%.
+	// +optional
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// NodeStartupTimeout is the duration after which a machine without a node is considered to have failed.
+	// Defaults to 10 minutes.
+	// +optional
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+}
+
+// MachinePoolUnhealthyCondition is a node condition type and status with a timeout. A node is considered unhealthy
+// when the condition has had the status for at least the timeout.
+type MachinePoolUnhealthyCondition struct {
+	// Type is the type of the node condition.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MinLength=1
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status is the status of the node condition.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MinLength=1
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Timeout is how long the node condition must have had the status for the node to be considered unhealthy.
+	Timeout metav1.Duration `json:"timeout"`
 }
 
 // MachinePoolAutoscaling details how the machine pool is to be auto-scaled.
@@ -80,6 +229,9 @@ type MachinePoolPlatform struct {
 	VSphere *vsphere.MachinePool `json:"vsphere,omitempty"`
 	// Ovirt is the configuration used when installing on oVirt.
 	Ovirt *ovirt.MachinePool `json:"ovirt,omitempty"`
+	// Agent is the configuration used when installing with the agent install strategy. Its workers are inventory
+	// hosts rather than machines.
+	Agent *agent.MachinePool `json:"agent,omitempty"`
 }
 
 // MachinePoolStatus defines the observed state of MachinePool
@@ -91,9 +243,50 @@ type MachinePoolStatus struct {
 	// MachineSets is the status of the machine sets for the machine pool on the remote cluster.
 	MachineSets []MachineSetStatus `json:"machineSets,omitempty"`
 
+	// Machines is the status of the machines of the machine sets for the machine pool on the remote cluster. At most
+	// 50 machines are reported, the machines that failed or whose node is not ready first.
+	// +optional
+	Machines []MachineStatus `json:"machines,omitempty"`
+
+	// Hosts is the status of the inventory hosts bound to the cluster for the machine pool, when the cluster was
+	// installed with the agent install strategy.
+	// +optional
+	Hosts []MachinePoolHostStatus `json:"hosts,omitempty"`
+
 	// Conditions includes more detailed status for the cluster deployment
 	// +optional
 	Conditions []MachinePoolCondition `json:"conditions,omitempty"`
+
+	// Rollout is the progress of the replacement of the machines of the machine pool when the RollingUpdate update
+	// strategy is used.
+	// +optional
+	Rollout *MachinePoolRolloutStatus `json:"rollout,omitempty"`
+}
+
+// MachinePoolRolloutStatus is the progress of the replacement of the machines of a machine pool.
+type MachinePoolRolloutStatus struct {
+	// UpdatedReplicas is the number of replicas of the MachineSets with the current platform configuration.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// UpdatedReadyReplicas is the number of ready replicas of the MachineSets with the current platform
+	// configuration.
+	UpdatedReadyReplicas int32 `json:"updatedReadyReplicas"`
+
+	// OutdatedReplicas is the number of replicas of the MachineSets with a previous platform configuration, which
+	// are being scaled down.
+	OutdatedReplicas int32 `json:"outdatedReplicas"`
+
+	// OutdatedMachineSets are the names of the MachineSets with a previous platform configuration.
+	// +optional
+	OutdatedMachineSets []string `json:"outdatedMachineSets,omitempty"`
+
+	// StartTime is the time the last rollout started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the last rollout completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 // MachineSetStatus is the status of a machineset in the remote cluster.
@@ -127,6 +320,52 @@ type MachineSetStatus struct {
 	ErrorMessage *string `json:"errorMessage,omitempty"`
 }
 
+// MachineStatus is the status of a machine in the remote cluster.
+type MachineStatus struct {
+	// Name is the name of the machine.
+	Name string `json:"name"`
+
+	// MachineSet is the name of the machine set of the machine.
+	MachineSet string `json:"machineSet"`
+
+	// Phase is the phase of the machine, such as Provisioning, Running or Failed.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// ProviderID is the identifier of the instance of the machine in the cloud provider.
+	// +optional
+	ProviderID string `json:"providerID,omitempty"`
+
+	// NodeName is the name of the node of the machine.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// NodeReady is whether the node of the machine is ready.
+	// +optional
+	NodeReady bool `json:"nodeReady,omitempty"`
+
+	// FailureMessage is the error reported for the machine, when it failed.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// MachinePoolHostStatus is the status of an inventory host bound to the cluster for a machine pool.
+type MachinePoolHostStatus struct {
+	// Name is the name of the Agent of the host.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Agent of the host.
+	Namespace string `json:"namespace"`
+
+	// Hostname is the hostname of the host, which is the name of its node.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Installed is whether the host has been installed and joined the cluster.
+	// +optional
+	Installed bool `json:"installed,omitempty"`
+}
+
 // MachinePoolCondition contains details for the current condition of a machine pool
 type MachinePoolCondition struct {
 	// Type is the type of the condition.
@@ -165,6 +404,17 @@ const (
 	// UnsupportedConfigurationMachinePoolCondition is true when the configuration of the MachinePool is unsupported
 	// by the cluster.
 	UnsupportedConfigurationMachinePoolCondition MachinePoolConditionType = "UnsupportedConfiguration"
+
+	// MachinesFailedToProvisionMachinePoolCondition is true when some machines of the machine pool failed to be
+	// provisioned.
+	MachinesFailedToProvisionMachinePoolCondition MachinePoolConditionType = "MachinesFailedToProvision"
+
+	// NodesNotReadyMachinePoolCondition is true when the nodes of some machines of the machine pool are not ready.
+	NodesNotReadyMachinePoolCondition MachinePoolConditionType = "NodesNotReady"
+
+	// InsufficientHostsMachinePoolCondition is true when there are not enough unbound inventory hosts matching the
+	// agent selector of the machine pool to reach its replicas.
+	InsufficientHostsMachinePoolCondition MachinePoolConditionType = "InsufficientHosts"
 )
 
 // +genclient
@@ -0,0 +1,196 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ResourceCollectorSpec defines the resources to collect from the selected clusters
+type ResourceCollectorSpec struct {
+	// ClusterDeploymentSelector is a LabelSelector indicating which clusters the resources are collected from.
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// Resources is the list of the resources to collect from the clusters.
+	// +kubebuilder:validation:MinItems=1
+	Resources []CollectedResourceSelector `json:"resources"`
+
+	// Interval is the time between two collections from a cluster. Defaults to 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MaxSizeBytes is the maximum size of the resources collected from a cluster. Resources beyond the limit are
+	// dropped and the collection is marked as truncated. Defaults to 262144.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=1048576
+	// +optional
+	MaxSizeBytes *int64 `json:"maxSizeBytes,omitempty"`
+}
+
+// CollectedResourceSelector selects resources to collect from a cluster
+type CollectedResourceSelector struct {
+	// APIVersion is the API version of the resources, e.g. "v1" or "operators.coreos.com/v1alpha1".
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the resources, e.g. "Node".
+	// Secrets cannot be collected.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace the resources are collected from. Resources are collected from all namespaces when
+	// omitted.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// LabelSelector restricts the collected resources to the ones with matching labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Fields are the JSONPath projections of the resources that are collected. The whole resources are collected
+	// when omitted.
+	// +optional
+	Fields []CollectedField `json:"fields,omitempty"`
+}
+
+// CollectedField is a JSONPath projection of a collected resource
+type CollectedField struct {
+	// Name is the name of the field in the collected resource.
+	Name string `json:"name"`
+
+	// JSONPath is the JSONPath expression of the field, e.g. "{.status.nodeInfo.kubeletVersion}". The braces may be
+	// omitted.
+	JSONPath string `json:"jsonPath"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceCollector describes resources to collect from the selected clusters back to the hub. The collected
+// resources are stored in a CollectedResourceSet in the namespace of each ClusterDeployment.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=resourcecollectors,scope=Cluster
+type ResourceCollector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ResourceCollectorSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceCollectorList contains a list of ResourceCollectors
+type ResourceCollectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceCollector `json:"items"`
+}
+
+// CollectedResourceSetSpec defines the origin of a CollectedResourceSet
+type CollectedResourceSetSpec struct {
+	// ResourceCollector is the name of the ResourceCollector that collected the resources.
+	ResourceCollector string `json:"resourceCollector"`
+}
+
+// CollectedResourceSetStatus contains the resources collected from a cluster
+type CollectedResourceSetStatus struct {
+	// ObservedGeneration is the generation of the ResourceCollector that was last collected.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAttemptTime is the last time that a collection was attempted.
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// LastCollectionTime is the last time that the resources were collected. Resources are stale when this is older
+	// than the interval of the ResourceCollector.
+	// +optional
+	LastCollectionTime *metav1.Time `json:"lastCollectionTime,omitempty"`
+
+	// SizeBytes is the size of the collected resources.
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// Truncated is true when some resources were dropped because of the size limit of the ResourceCollector.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+
+	// FailureMessage is the reason the last collection attempt failed.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// Resources contains the resources collected for each selector of the ResourceCollector.
+	// +optional
+	Resources []CollectedResourceList `json:"resources,omitempty"`
+}
+
+// CollectedResourceList contains the resources collected for a selector
+type CollectedResourceList struct {
+	// APIVersion is the API version of the resources.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the resources.
+	Kind string `json:"kind"`
+
+	// Items are the collected resources.
+	// +optional
+	Items []CollectedResource `json:"items,omitempty"`
+
+	// Truncated is true when some resources were dropped because of the size limit of the ResourceCollector.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Error is the reason the resources could not be collected.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// CollectedResource is a resource collected from a cluster
+type CollectedResource struct {
+	// Namespace is the namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Fields are the values of the JSONPath projections of the resource.
+	// +optional
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// Object is the whole resource, when no projections are specified.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +optional
+	Object *runtime.RawExtension `json:"object,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CollectedResourceSet contains the resources collected by a ResourceCollector from the cluster of a
+// ClusterDeployment.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ResourceCollector",type="string",JSONPath=".spec.resourceCollector"
+// +kubebuilder:printcolumn:name="LastCollection",type="date",JSONPath=".status.lastCollectionTime"
+// +kubebuilder:printcolumn:name="Truncated",type="boolean",JSONPath=".status.truncated"
+// +kubebuilder:resource:path=collectedresourcesets,scope=Namespaced
+type CollectedResourceSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CollectedResourceSetSpec   `json:"spec,omitempty"`
+	Status CollectedResourceSetStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CollectedResourceSetList contains a list of CollectedResourceSets
+type CollectedResourceSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CollectedResourceSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceCollector{}, &ResourceCollectorList{}, &CollectedResourceSet{}, &CollectedResourceSetList{})
+}
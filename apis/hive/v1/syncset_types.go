@@ -23,7 +23,7 @@ const (
 
 // SyncSetApplyBehavior is a string representing the behavior to use when
 // aplying a syncset to target cluster.
-// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate
+// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate;Audit;ServerSideApply
 type SyncSetApplyBehavior string
 
 const (
@@ -42,6 +42,48 @@ const (
 	// is not added to the target resource with the "lastApplied" value. It allows
 	// for syncing larger resources, but loses the ability to sync map entry deletes.
 	CreateOrUpdateSyncSetApplyBehavior SyncSetApplyBehavior = "CreateOrUpdate"
+
+	// AuditSyncSetApplyBehavior results in resources never getting applied to the target
+	// cluster. Instead, the resources are compared with the resources in the target cluster,
+	// and any drift is recorded in the status of the ClusterSync. Patches and secret mappings
+	// are not audited.
+	AuditSyncSetApplyBehavior SyncSetApplyBehavior = "Audit"
+
+	// ServerSideApplySyncSetApplyBehavior results in resources getting applied using server-side
+	// apply with the "hive" field manager. Hive only owns the fields set in the syncset resource,
+	// and does not need the "lastApplied" annotation.
+	ServerSideApplySyncSetApplyBehavior SyncSetApplyBehavior = "ServerSideApply"
+)
+
+// SyncSetTemplateMode is a string representing how the Resources and Patches of a
+// SyncSet are rendered before they are applied to a target cluster.
+// +kubebuilder:validation:Enum="";None;GoTemplate
+type SyncSetTemplateMode string
+
+const (
+	// NoneSyncSetTemplateMode is the default template mode. Resources and Patches are
+	// applied verbatim.
+	NoneSyncSetTemplateMode SyncSetTemplateMode = "None"
+
+	// GoTemplateSyncSetTemplateMode results in the string values of Resources and the
+	// Patches being rendered as Go templates for each target cluster, with values drawn
+	// from the ClusterDeployment of the cluster.
+	GoTemplateSyncSetTemplateMode SyncSetTemplateMode = "GoTemplate"
+)
+
+// SyncSetHealthCheckMode is a string representing whether the health of the Resources of a
+// SyncSet is assessed after they are applied to a target cluster.
+// +kubebuilder:validation:Enum="";None;Enabled
+type SyncSetHealthCheckMode string
+
+const (
+	// NoneSyncSetHealthCheckMode is the default health check mode. Resources are considered
+	// synced as soon as they are applied.
+	NoneSyncSetHealthCheckMode SyncSetHealthCheckMode = "None"
+
+	// EnabledSyncSetHealthCheckMode results in the health of the Resources being assessed
+	// after they are applied, and recorded in the ClusterSync of the target cluster.
+	EnabledSyncSetHealthCheckMode SyncSetHealthCheckMode = "Enabled"
 )
 
 // SyncSetPatchApplyMode is a string representing the mode with which to apply
@@ -82,6 +124,19 @@ type SyncObjectPatch struct {
 	PatchType string `json:"patchType,omitempty"`
 }
 
+// SyncSetDependency is a reference to a SyncSet or SelectorSyncSet that must be applied to a cluster
+// before the SyncSet or SelectorSyncSet that depends on it.
+type SyncSetDependency struct {
+	// Kind is the kind of the dependency, either SyncSet or SelectorSyncSet. Defaults to the kind
+	// of the SyncSet or SelectorSyncSet that depends on it.
+	// +kubebuilder:validation:Enum="";SyncSet;SelectorSyncSet
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the dependency. SyncSets are looked up in the namespace of the cluster.
+	Name string `json:"name"`
+}
+
 // SecretReference is a reference to a secret by name and namespace
 type SecretReference struct {
 	// Name is the name of the secret
@@ -101,6 +156,97 @@ type SecretMapping struct {
 
 	// TargetRef specifies the target name and namespace of the secret on the target cluster
 	TargetRef SecretReference `json:"targetRef"`
+
+	// Keys selects the keys of the source secret that are copied to the target secret, and
+	// optionally renames them. If empty, all of the keys are copied.
+	// +optional
+	Keys []SecretKeyMapping `json:"keys,omitempty"`
+
+	// AdditionalSources are other secrets on the management cluster whose keys are merged into
+	// the target secret along with the keys of the source secret. A key may only be provided by
+	// one of the secrets.
+	// +optional
+	AdditionalSources []SecretSource `json:"additionalSources,omitempty"`
+
+	// Type is the type of the target secret. If not present, the type of the source secret is used.
+	// The type of an existing secret cannot be changed, so the target secret must be deleted from
+	// the target cluster before its type is changed.
+	// +optional
+	Type corev1.SecretType `json:"type,omitempty"`
+
+	// DockerConfigJSON builds the .dockerconfigjson key of the target secret from the credentials of
+	// registries held in keys of the source secrets. The keys holding the credentials are not copied
+	// to the target secret. The type of the target secret defaults to kubernetes.io/dockerconfigjson.
+	// +optional
+	DockerConfigJSON []DockerRegistryCredentials `json:"dockerConfigJSON,omitempty"`
+}
+
+// DockerRegistryCredentials selects the keys holding the credentials of a registry that are built into
+// the .dockerconfigjson key of the target secret of a SecretMapping. The keys are those of the target
+// secret, after the keys of the source secrets are selected and renamed.
+type DockerRegistryCredentials struct {
+	// Registry is the server of the registry, e.g. quay.io. Exactly one of Registry and RegistryKey
+	// must be specified.
+	// +optional
+	Registry string `json:"registry,omitempty"`
+
+	// RegistryKey is the key holding the server of the registry.
+	// +optional
+	RegistryKey string `json:"registryKey,omitempty"`
+
+	// UsernameKey is the key holding the username for the registry.
+	UsernameKey string `json:"usernameKey"`
+
+	// PasswordKey is the key holding the password for the registry.
+	PasswordKey string `json:"passwordKey"`
+}
+
+// SecretSource is a secret whose keys are merged into the target secret of a SecretMapping
+type SecretSource struct {
+	// SecretRef specifies the name and namespace of a secret on the management cluster
+	SecretRef SecretReference `json:"secretRef"`
+
+	// Keys selects the keys of the secret that are copied to the target secret, and optionally
+	// renames them. If empty, all of the keys are copied.
+	// +optional
+	Keys []SecretKeyMapping `json:"keys,omitempty"`
+}
+
+// SecretKeyMapping selects a key of a source secret to copy to a target secret
+type SecretKeyMapping struct {
+	// Key is the key in the source secret
+	Key string `json:"key"`
+
+	// TargetKey is the key in the target secret. If not present, Key is used.
+	// +optional
+	TargetKey string `json:"targetKey,omitempty"`
+}
+
+// KustomizeSource is a kustomization tree, stored in ConfigMaps on the management cluster, which is built
+// into resources to sync.
+type KustomizeSource struct {
+	// Path is the directory of the kustomization to build, relative to the root of the tree.
+	// Defaults to the root of the tree.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ConfigMaps are the ConfigMaps holding the files of the kustomization tree.
+	// +kubebuilder:validation:MinItems=1
+	ConfigMaps []KustomizeConfigMap `json:"configMaps"`
+}
+
+// KustomizeConfigMap is a ConfigMap holding the files of a directory of a kustomization tree.
+type KustomizeConfigMap struct {
+	// Name is the name of the ConfigMap
+	Name string `json:"name"`
+	// Namespace is the namespace where the ConfigMap lives. If not present, it is assumed to be
+	// the same namespace as the syncset with the reference. It is required for SelectorSyncSets.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Path is the directory of the tree holding the files of the ConfigMap, one file for each key.
+	// Defaults to the root of the tree.
+	// +optional
+	Path string `json:"path,omitempty"`
 }
 
 // SyncConditionType is a valid value for SyncCondition.Type
@@ -222,6 +368,12 @@ type SyncSetCommonSpec struct {
 	// +optional
 	Secrets []SecretMapping `json:"secretMappings,omitempty"`
 
+	// Kustomize is a kustomization tree which is built into resources that are synced along with the
+	// Resources. The resources built from the same content are cached, and are tracked like the
+	// Resources so that they are deleted when the ResourceApplyMode is "Sync".
+	// +optional
+	Kustomize *KustomizeSource `json:"kustomize,omitempty"`
+
 	// ApplyBehavior indicates how resources in this syncset will be applied to the target
 	// cluster. The default value of "Apply" indicates that resources should be applied
 	// using the 'oc apply' command. If no value is set, "Apply" is assumed.
@@ -231,8 +383,40 @@ type SyncSetCommonSpec struct {
 	// the use of the 'oc apply' command, allowing larger resources to be synced, but losing
 	// some functionality of the 'oc apply' command such as the ability to remove annotations,
 	// labels, and other map entries in general.
+	// A value of "Audit" indicates that the resources will not be applied. Instead, any drift of
+	// the resources in the target cluster from the resources in this syncset is recorded.
+	// A value of "ServerSideApply" indicates that the resources will be applied using server-side
+	// apply, which allows syncing larger resources and sharing resources with other field managers.
 	// +optional
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
+
+	// ForceConflicts indicates whether hive takes ownership of the fields of resources that are owned
+	// by other field managers when the apply behavior is "ServerSideApply". Otherwise, resources with
+	// conflicting fields are not applied, and the conflicts are recorded in the ClusterSync of the cluster.
+	// +optional
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+
+	// TemplateMode indicates whether Resources and Patches are rendered for each target cluster
+	// before they are applied. The default value of "None" applies them verbatim.
+	// A value of "GoTemplate" renders every string value of the Resources, and the name, namespace
+	// and patch of the Patches, as a Go template. Templates can refer to the ClusterDeployment of
+	// the target cluster, e.g. {{ .ClusterName }}, {{ .BaseDomain }}, {{ .InfraID }}, {{ .Region }},
+	// {{ .Platform }}, {{ index .Labels "key" }}, {{ index .Annotations "key" }}, {{ .Spec }} and
+	// {{ .Status }}, and use a restricted, deterministic set of functions.
+	// +optional
+	TemplateMode SyncSetTemplateMode `json:"templateMode,omitempty"`
+
+	// DependsOn is the list of SyncSets and SelectorSyncSets that must have been applied successfully
+	// to a cluster before this one is applied to it. Until then, this one is reported as waiting.
+	// +optional
+	DependsOn []SyncSetDependency `json:"dependsOn,omitempty"`
+
+	// HealthCheckMode indicates whether the health of the Resources is assessed after they are
+	// applied. When Enabled, the rollout of Deployments, DaemonSets and StatefulSets, the completion
+	// of Jobs, and the Ready or Available conditions of other resources are checked, and each
+	// resource is reported as Healthy, Progressing or Degraded in the ClusterSync of the cluster.
+	// +optional
+	HealthCheckMode SyncSetHealthCheckMode `json:"healthCheckMode,omitempty"`
 }
 
 // SelectorSyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along
@@ -245,6 +429,40 @@ type SelectorSyncSetSpec struct {
 	// applies to in any namespace.
 	// +optional
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// RolloutStrategy indicates how a new generation of the SelectorSyncSet is rolled out to the
+	// matching clusters. If not set, a new generation is applied to all of the clusters at once.
+	// +optional
+	RolloutStrategy *SelectorSyncSetRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// SelectorSyncSetRolloutStrategy is a strategy for rolling out a new generation of a SelectorSyncSet
+// to the matching clusters progressively. The new generation is applied to the canary clusters
+// first, then to an increasing percentage of the other clusters, one batch at a time. Clusters
+// that are not part of the rollout yet keep the generation that they have applied.
+type SelectorSyncSetRolloutStrategy struct {
+	// CanarySelector is a LabelSelector indicating the clusters to which a new generation is applied
+	// before any of the batches.
+	// +optional
+	CanarySelector *metav1.LabelSelector `json:"canarySelector,omitempty"`
+
+	// BatchPercent is the percentage of the matching clusters that is added to the rollout in each
+	// batch.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	BatchPercent int `json:"batchPercent"`
+
+	// PauseDuration is how long to wait after all of the clusters in the rollout have applied the
+	// new generation before starting the next batch.
+	// +optional
+	PauseDuration *metav1.Duration `json:"pauseDuration,omitempty"`
+
+	// MaxFailurePercent is the percentage of the clusters in the rollout that may fail to apply the
+	// new generation. The rollout halts when it is exceeded, until the next generation.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxFailurePercent int `json:"maxFailurePercent,omitempty"`
 }
 
 // SyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along with
@@ -265,6 +483,71 @@ type SyncSetStatus struct {
 
 // SelectorSyncSetStatus defines the observed state of a SelectorSyncSet
 type SelectorSyncSetStatus struct {
+	// Rollout is the progress of the rollout of the latest generation of the SelectorSyncSet. This is
+	// only set when the SelectorSyncSet has a rollout strategy.
+	// +optional
+	Rollout *SelectorSyncSetRolloutStatus `json:"rollout,omitempty"`
+}
+
+// SelectorSyncSetRolloutPhase is the phase of the rollout of a SelectorSyncSet.
+// +kubebuilder:validation:Enum=Progressing;Paused;Halted;Complete
+type SelectorSyncSetRolloutPhase string
+
+const (
+	// ProgressingSelectorSyncSetRolloutPhase is the phase when the clusters in the rollout are
+	// applying the new generation.
+	ProgressingSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Progressing"
+
+	// PausedSelectorSyncSetRolloutPhase is the phase when the rollout is waiting for the pause
+	// duration to pass before starting the next batch.
+	PausedSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Paused"
+
+	// HaltedSelectorSyncSetRolloutPhase is the phase when too many of the clusters in the rollout
+	// failed to apply the new generation. The rollout does not progress until the next generation.
+	HaltedSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Halted"
+
+	// CompleteSelectorSyncSetRolloutPhase is the phase when the new generation has been rolled out
+	// to all of the matching clusters.
+	CompleteSelectorSyncSetRolloutPhase SelectorSyncSetRolloutPhase = "Complete"
+)
+
+// SelectorSyncSetRolloutStatus is the progress of the rollout of a generation of a SelectorSyncSet.
+type SelectorSyncSetRolloutStatus struct {
+	// Generation is the generation of the SelectorSyncSet that is being rolled out.
+	Generation int64 `json:"generation"`
+
+	// Phase is the phase of the rollout.
+	Phase SelectorSyncSetRolloutPhase `json:"phase"`
+
+	// AdmittedPercent is the percentage of the matching clusters, other than the canary clusters,
+	// that are in the rollout. It is zero while the new generation is applied to the canary clusters.
+	AdmittedPercent int `json:"admittedPercent"`
+
+	// TotalClusters is the number of clusters matching the SelectorSyncSet.
+	TotalClusters int `json:"totalClusters"`
+
+	// AdmittedClusters is the number of clusters in the rollout.
+	AdmittedClusters int `json:"admittedClusters"`
+
+	// UpdatedClusters is the number of clusters in the rollout that have applied the new generation
+	// successfully.
+	UpdatedClusters int `json:"updatedClusters"`
+
+	// FailedClusters is the number of clusters in the rollout that failed to apply the new generation.
+	FailedClusters int `json:"failedClusters"`
+
+	// BatchStartTime is the time when the latest batch was added to the rollout.
+	// +optional
+	BatchStartTime *metav1.Time `json:"batchStartTime,omitempty"`
+
+	// BatchCompletionTime is the time when all of the clusters in the rollout had applied the new
+	// generation. It is cleared when the next batch is added to the rollout.
+	// +optional
+	BatchCompletionTime *metav1.Time `json:"batchCompletionTime,omitempty"`
+
+	// Message is a human-readable description of the state of the rollout.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -15,6 +15,30 @@ type MachinePool struct {
 
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// Zones are the failure domains across which the machines of the pool are distributed, with a MachineSet for
+	// each zone. If empty, the machines are created in the cluster, datastore and network of the ClusterDeployment.
+	// +optional
+	Zones []MachinePoolZone `json:"zones,omitempty"`
+}
+
+// MachinePoolZone is a failure domain of a machine pool on vSphere.
+type MachinePoolZone struct {
+	// Name is the name of the zone. It is part of the name of the MachineSet of the zone, and is the zone in the zone
+	// distribution of the machine pool.
+	Name string `json:"name"`
+
+	// Cluster is the vCenter cluster in which the machines of the zone are created.
+	Cluster string `json:"cluster"`
+
+	// Datastore is the datastore of the machines of the zone. Defaults to the default datastore of the
+	// ClusterDeployment.
+	// +optional
+	Datastore string `json:"datastore,omitempty"`
+
+	// Network is the network of the machines of the zone. Defaults to the network of the ClusterDeployment.
+	// +optional
+	Network string `json:"network,omitempty"`
 }
 
 // OSDisk defines the disk for a virtual machine.
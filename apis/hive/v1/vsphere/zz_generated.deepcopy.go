@@ -8,6 +8,11 @@ package vsphere
 func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 	*out = *in
 	out.OSDisk = in.OSDisk
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]MachinePoolZone, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -21,6 +26,22 @@ func (in *MachinePool) DeepCopy() *MachinePool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolZone) DeepCopyInto(out *MachinePoolZone) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolZone.
+func (in *MachinePoolZone) DeepCopy() *MachinePoolZone {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OSDisk) DeepCopyInto(out *OSDisk) {
 	*out = *in
@@ -18,6 +18,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -82,6 +83,11 @@ func (in *AWSDNSZoneSpec) DeepCopyInto(out *AWSDNSZoneSpec) {
 		*out = make([]AWSResourceTag, len(*in))
 		copy(*out, *in)
 	}
+	if in.VPCs != nil {
+		in, out := &in.VPCs, &out.VPCs
+		*out = make([]AWSDNSZoneVPC, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -116,6 +122,22 @@ func (in *AWSDNSZoneStatus) DeepCopy() *AWSDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSDNSZoneVPC) DeepCopyInto(out *AWSDNSZoneVPC) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSDNSZoneVPC.
+func (in *AWSDNSZoneVPC) DeepCopy() *AWSDNSZoneVPC {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSDNSZoneVPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSPrivateLinkConfig) DeepCopyInto(out *AWSPrivateLinkConfig) {
 	*out = *in
@@ -234,6 +256,22 @@ func (in *AWSServiceProviderCredentials) DeepCopy() *AWSServiceProviderCredentia
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfig) DeepCopyInto(out *ArgoCDConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfig.
+func (in *ArgoCDConfig) DeepCopy() *ArgoCDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureClusterDeprovision) DeepCopyInto(out *AzureClusterDeprovision) {
 	*out = *in
@@ -259,6 +297,11 @@ func (in *AzureClusterDeprovision) DeepCopy() *AzureClusterDeprovision {
 func (in *AzureDNSZoneSpec) DeepCopyInto(out *AzureDNSZoneSpec) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.VirtualNetworks != nil {
+		in, out := &in.VirtualNetworks, &out.VirtualNetworks
+		*out = make([]AzureDNSZoneVirtualNetwork, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -288,6 +331,22 @@ func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDNSZoneVirtualNetwork) DeepCopyInto(out *AzureDNSZoneVirtualNetwork) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneVirtualNetwork.
+func (in *AzureDNSZoneVirtualNetwork) DeepCopy() *AzureDNSZoneVirtualNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDNSZoneVirtualNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 	*out = *in
@@ -729,6 +788,11 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.HibernationHooks != nil {
+		in, out := &in.HibernationHooks, &out.HibernationHooks
+		*out = new(HibernationHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
@@ -775,6 +839,11 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MachineAPIImages != nil {
+		in, out := &in.MachineAPIImages, &out.MachineAPIImages
+		*out = new(MachineAPIImages)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ClusterDeploymentCondition, len(*in))
@@ -805,6 +874,11 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PowerStateDurations != nil {
+		in, out := &in.PowerStateDurations, &out.PowerStateDurations
+		*out = new(ClusterPowerStateDurations)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1399,6 +1473,50 @@ func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPowerStateChange) DeepCopyInto(out *ClusterPowerStateChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPowerStateChange.
+func (in *ClusterPowerStateChange) DeepCopy() *ClusterPowerStateChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPowerStateChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPowerStateDurations) DeepCopyInto(out *ClusterPowerStateDurations) {
+	*out = *in
+	out.Running = in.Running
+	out.Hibernating = in.Hibernating
+	out.WorkersHibernating = in.WorkersHibernating
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ClusterPowerStateChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPowerStateDurations.
+func (in *ClusterPowerStateDurations) DeepCopy() *ClusterPowerStateDurations {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPowerStateDurations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
 	*out = *in
@@ -1763,6 +1881,207 @@ func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedField) DeepCopyInto(out *CollectedField) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedField.
+func (in *CollectedField) DeepCopy() *CollectedField {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedResource) DeepCopyInto(out *CollectedResource) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedResource.
+func (in *CollectedResource) DeepCopy() *CollectedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedResourceList) DeepCopyInto(out *CollectedResourceList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CollectedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedResourceList.
+func (in *CollectedResourceList) DeepCopy() *CollectedResourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedResourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedResourceSelector) DeepCopyInto(out *CollectedResourceSelector) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]CollectedField, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedResourceSelector.
+func (in *CollectedResourceSelector) DeepCopy() *CollectedResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedResourceSet) DeepCopyInto(out *CollectedResourceSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedResourceSet.
+func (in *CollectedResourceSet) DeepCopy() *CollectedResourceSet {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedResourceSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CollectedResourceSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedResourceSetList) DeepCopyInto(out *CollectedResourceSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CollectedResourceSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedResourceSetList.
+func (in *CollectedResourceSetList) DeepCopy() *CollectedResourceSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedResourceSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CollectedResourceSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedResourceSetSpec) DeepCopyInto(out *CollectedResourceSetSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedResourceSetSpec.
+func (in *CollectedResourceSetSpec) DeepCopy() *CollectedResourceSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedResourceSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectedResourceSetStatus) DeepCopyInto(out *CollectedResourceSetStatus) {
+	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastCollectionTime != nil {
+		in, out := &in.LastCollectionTime, &out.LastCollectionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]CollectedResourceList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectedResourceSetStatus.
+func (in *CollectedResourceSetStatus) DeepCopy() *CollectedResourceSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectedResourceSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
 	*out = *in
@@ -1912,7 +2231,7 @@ func (in *ControllersConfig) DeepCopy() *ControllersConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+func (in *DNSRecord) DeepCopyInto(out *DNSRecord) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1921,18 +2240,18 @@ func (in *DNSZone) DeepCopyInto(out *DNSZone) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
-func (in *DNSZone) DeepCopy() *DNSZone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecord.
+func (in *DNSRecord) DeepCopy() *DNSRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZone)
+	out := new(DNSRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZone) DeepCopyObject() runtime.Object {
+func (in *DNSRecord) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1940,31 +2259,31 @@ func (in *DNSZone) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
+func (in *DNSRecordCondition) DeepCopyInto(out *DNSRecordCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
-func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordCondition.
+func (in *DNSRecordCondition) DeepCopy() *DNSRecordCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneCondition)
+	out := new(DNSRecordCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+func (in *DNSRecordList) DeepCopyInto(out *DNSRecordList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSZone, len(*in))
+		*out = make([]DNSRecord, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1972,18 +2291,18 @@ func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
-func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordList.
+func (in *DNSRecordList) DeepCopy() *DNSRecordList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneList)
+	out := new(DNSRecordList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+func (in *DNSRecordList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1991,23 +2310,214 @@ func (in *DNSZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+func (in *DNSRecordSetReference) DeepCopyInto(out *DNSRecordSetReference) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneSpec)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneSpec)
-		**out = **in
-	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSetReference.
+func (in *DNSRecordSetReference) DeepCopy() *DNSRecordSetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordSpec) DeepCopyInto(out *DNSRecordSpec) {
+	*out = *in
+	out.DNSZoneRef = in.DNSZoneRef
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSpec.
+func (in *DNSRecordSpec) DeepCopy() *DNSRecordSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordStatus) DeepCopyInto(out *DNSRecordStatus) {
+	*out = *in
+	if in.OwnedRecordSet != nil {
+		in, out := &in.OwnedRecordSet, &out.OwnedRecordSet
+		*out = new(DNSRecordSetReference)
+		**out = **in
+	}
+	if in.LastSyncTimestamp != nil {
+		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DNSRecordCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordStatus.
+func (in *DNSRecordStatus) DeepCopy() *DNSRecordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECKeySigningKey) DeepCopyInto(out *DNSSECKeySigningKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECKeySigningKey.
+func (in *DNSSECKeySigningKey) DeepCopy() *DNSSECKeySigningKey {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECKeySigningKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECStatus) DeepCopyInto(out *DNSSECStatus) {
+	*out = *in
+	if in.KeySigningKeys != nil {
+		in, out := &in.KeySigningKeys, &out.KeySigningKeys
+		*out = make([]DNSSECKeySigningKey, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECStatus.
+func (in *DNSSECStatus) DeepCopy() *DNSSECStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
+func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
+func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136DNSZoneSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -2048,6 +2558,11 @@ func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
 		*out = new(AzureDNSZoneStatus)
 		**out = **in
 	}
+	if in.DNSSEC != nil {
+		in, out := &in.DNSSEC, &out.DNSSEC
+		*out = new(DNSSECStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]DNSZoneCondition, len(*in))
@@ -2068,6 +2583,22 @@ func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerRegistryCredentials) DeepCopyInto(out *DockerRegistryCredentials) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerRegistryCredentials.
+func (in *DockerRegistryCredentials) DeepCopy() *DockerRegistryCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerRegistryCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
 	*out = *in
@@ -2173,6 +2704,11 @@ func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
 func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2207,6 +2743,54 @@ func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationHook) DeepCopyInto(out *HibernationHook) {
+	*out = *in
+	out.PodTemplateRef = in.PodTemplateRef
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationHook.
+func (in *HibernationHook) DeepCopy() *HibernationHook {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationHooks) DeepCopyInto(out *HibernationHooks) {
+	*out = *in
+	if in.PreStop != nil {
+		in, out := &in.PreStop, &out.PreStop
+		*out = new(HibernationHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostResume != nil {
+		in, out := &in.PostResume, &out.PostResume
+		*out = new(HibernationHook)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationHooks.
+func (in *HibernationHooks) DeepCopy() *HibernationHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	*out = *in
@@ -2309,6 +2893,13 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 	in.Backup.DeepCopyInto(&out.Backup)
 	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
 	in.ServiceProviderCredentialsConfig.DeepCopyInto(&out.ServiceProviderCredentialsConfig)
+	if in.ClusterSyncReplicaWeights != nil {
+		in, out := &in.ClusterSyncReplicaWeights, &out.ClusterSyncReplicaWeights
+		*out = make(map[string]ClusterSyncReplicaWeight, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.MaintenanceMode != nil {
 		in, out := &in.MaintenanceMode, &out.MaintenanceMode
 		*out = new(bool)
@@ -2339,6 +2930,7 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(ReleaseImageVerificationConfigMapReference)
 		**out = **in
 	}
+	out.ArgoCD = in.ArgoCD
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = new(FeatureGateSelection)
@@ -2412,6 +3004,59 @@ func (in *KubeconfigSecretReference) DeepCopy() *KubeconfigSecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizeConfigMap) DeepCopyInto(out *KustomizeConfigMap) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeConfigMap.
+func (in *KustomizeConfigMap) DeepCopy() *KustomizeConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizeConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizeSource) DeepCopyInto(out *KustomizeSource) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]KustomizeConfigMap, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeSource.
+func (in *KustomizeSource) DeepCopy() *KustomizeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineAPIImages) DeepCopyInto(out *MachineAPIImages) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineAPIImages.
+func (in *MachineAPIImages) DeepCopy() *MachineAPIImages {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineAPIImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineManagement) DeepCopyInto(out *MachineManagement) {
 	*out = *in
@@ -2495,6 +3140,53 @@ func (in *MachinePoolCondition) DeepCopy() *MachinePoolCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolHealthCheck) DeepCopyInto(out *MachinePoolHealthCheck) {
+	*out = *in
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]MachinePoolUnhealthyCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.NodeStartupTimeout != nil {
+		in, out := &in.NodeStartupTimeout, &out.NodeStartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolHealthCheck.
+func (in *MachinePoolHealthCheck) DeepCopy() *MachinePoolHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolHostStatus) DeepCopyInto(out *MachinePoolHostStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolHostStatus.
+func (in *MachinePoolHostStatus) DeepCopy() *MachinePoolHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolList) DeepCopyInto(out *MachinePoolList) {
 	*out = *in
@@ -2528,6 +3220,23 @@ func (in *MachinePoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolMachineConfig) DeepCopyInto(out *MachinePoolMachineConfig) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolMachineConfig.
+func (in *MachinePoolMachineConfig) DeepCopy() *MachinePoolMachineConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolMachineConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolNameLease) DeepCopyInto(out *MachinePoolNameLease) {
 	*out = *in
@@ -2621,6 +3330,41 @@ func (in *MachinePoolNameLeaseStatus) DeepCopy() *MachinePoolNameLeaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolNodeConfig) DeepCopyInto(out *MachinePoolNodeConfig) {
+	*out = *in
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MachineConfigs != nil {
+		in, out := &in.MachineConfigs, &out.MachineConfigs
+		*out = make([]MachinePoolMachineConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolNodeConfig.
+func (in *MachinePoolNodeConfig) DeepCopy() *MachinePoolNodeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolNodeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 	*out = *in
@@ -2647,13 +3391,18 @@ func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 	if in.VSphere != nil {
 		in, out := &in.VSphere, &out.VSphere
 		*out = new(vsphere.MachinePool)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Ovirt != nil {
 		in, out := &in.Ovirt, &out.Ovirt
 		*out = new(ovirt.MachinePool)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(agent.MachinePool)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2667,6 +3416,61 @@ func (in *MachinePoolPlatform) DeepCopy() *MachinePoolPlatform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRollingUpdate) DeepCopyInto(out *MachinePoolRollingUpdate) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRollingUpdate.
+func (in *MachinePoolRollingUpdate) DeepCopy() *MachinePoolRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRolloutStatus) DeepCopyInto(out *MachinePoolRolloutStatus) {
+	*out = *in
+	if in.OutdatedMachineSets != nil {
+		in, out := &in.OutdatedMachineSets, &out.OutdatedMachineSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRolloutStatus.
+func (in *MachinePoolRolloutStatus) DeepCopy() *MachinePoolRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 	*out = *in
@@ -2696,6 +3500,28 @@ func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(MachinePoolHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(MachinePoolUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneDistribution != nil {
+		in, out := &in.ZoneDistribution, &out.ZoneDistribution
+		*out = make([]MachinePoolZoneDistribution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeConfig != nil {
+		in, out := &in.NodeConfig, &out.NodeConfig
+		*out = new(MachinePoolNodeConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2719,6 +3545,16 @@ func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Machines != nil {
+		in, out := &in.Machines, &out.Machines
+		*out = make([]MachineStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]MachinePoolHostStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]MachinePoolCondition, len(*in))
@@ -2726,6 +3562,11 @@ func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(MachinePoolRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2739,6 +3580,70 @@ func (in *MachinePoolStatus) DeepCopy() *MachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolUnhealthyCondition) DeepCopyInto(out *MachinePoolUnhealthyCondition) {
+	*out = *in
+	out.Timeout = in.Timeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolUnhealthyCondition.
+func (in *MachinePoolUnhealthyCondition) DeepCopy() *MachinePoolUnhealthyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolUnhealthyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolUpdateStrategy) DeepCopyInto(out *MachinePoolUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(MachinePoolRollingUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolUpdateStrategy.
+func (in *MachinePoolUpdateStrategy) DeepCopy() *MachinePoolUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolZoneDistribution) DeepCopyInto(out *MachinePoolZoneDistribution) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolZoneDistribution.
+func (in *MachinePoolZoneDistribution) DeepCopy() *MachinePoolZoneDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolZoneDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineSetStatus) DeepCopyInto(out *MachineSetStatus) {
 	*out = *in
@@ -2765,6 +3670,22 @@ func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineStatus.
+func (in *MachineStatus) DeepCopy() *MachineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
 	*out = *in
@@ -2822,6 +3743,11 @@ func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 		*out = new(ManageDNSAzureConfig)
 		**out = **in
 	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(ManageDNSRFC2136Config)
+		**out = **in
+	}
 	return
 }
 
@@ -2852,6 +3778,23 @@ func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSRFC2136Config) DeepCopyInto(out *ManageDNSRFC2136Config) {
+	*out = *in
+	out.TSIGSecretRef = in.TSIGSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSRFC2136Config.
+func (in *ManageDNSRFC2136Config) DeepCopy() *ManageDNSRFC2136Config {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSRFC2136Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
 	*out = *in
@@ -3021,6 +3964,23 @@ func (in *Provisioning) DeepCopy() *Provisioning {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RFC2136DNSZoneSpec) DeepCopyInto(out *RFC2136DNSZoneSpec) {
+	*out = *in
+	out.TSIGSecretRef = in.TSIGSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136DNSZoneSpec.
+func (in *RFC2136DNSZoneSpec) DeepCopy() *RFC2136DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RFC2136DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReleaseImageVerificationConfigMapReference) DeepCopyInto(out *ReleaseImageVerificationConfigMapReference) {
 	*out = *in
@@ -3037,11 +3997,138 @@ func (in *ReleaseImageVerificationConfigMapReference) DeepCopy() *ReleaseImageVe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceCollector) DeepCopyInto(out *ResourceCollector) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceCollector.
+func (in *ResourceCollector) DeepCopy() *ResourceCollector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceCollector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceCollector) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceCollectorList) DeepCopyInto(out *ResourceCollectorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceCollector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceCollectorList.
+func (in *ResourceCollectorList) DeepCopy() *ResourceCollectorList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceCollectorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceCollectorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceCollectorSpec) DeepCopyInto(out *ResourceCollectorSpec) {
+	*out = *in
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]CollectedResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxSizeBytes != nil {
+		in, out := &in.MaxSizeBytes, &out.MaxSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceCollectorSpec.
+func (in *ResourceCollectorSpec) DeepCopy() *ResourceCollectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceCollectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyMapping) DeepCopyInto(out *SecretKeyMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyMapping.
+func (in *SecretKeyMapping) DeepCopy() *SecretKeyMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretMapping) DeepCopyInto(out *SecretMapping) {
 	*out = *in
 	out.SourceRef = in.SourceRef
 	out.TargetRef = in.TargetRef
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SecretKeyMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalSources != nil {
+		in, out := &in.AdditionalSources, &out.AdditionalSources
+		*out = make([]SecretSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DockerConfigJSON != nil {
+		in, out := &in.DockerConfigJSON, &out.DockerConfigJSON
+		*out = make([]DockerRegistryCredentials, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3071,6 +4158,28 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSource) DeepCopyInto(out *SecretSource) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SecretKeyMapping, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSource.
+func (in *SecretSource) DeepCopy() *SecretSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncIdentityProvider) DeepCopyInto(out *SelectorSyncIdentityProvider) {
 	*out = *in
@@ -3156,7 +4265,7 @@ func (in *SelectorSyncSet) DeepCopyInto(out *SelectorSyncSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3211,11 +4320,66 @@ func (in *SelectorSyncSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStatus) DeepCopyInto(out *SelectorSyncSetRolloutStatus) {
+	*out = *in
+	if in.BatchStartTime != nil {
+		in, out := &in.BatchStartTime, &out.BatchStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BatchCompletionTime != nil {
+		in, out := &in.BatchCompletionTime, &out.BatchCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStatus.
+func (in *SelectorSyncSetRolloutStatus) DeepCopy() *SelectorSyncSetRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStrategy) DeepCopyInto(out *SelectorSyncSetRolloutStrategy) {
+	*out = *in
+	if in.CanarySelector != nil {
+		in, out := &in.CanarySelector, &out.CanarySelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PauseDuration != nil {
+		in, out := &in.PauseDuration, &out.PauseDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStrategy.
+func (in *SelectorSyncSetRolloutStrategy) DeepCopy() *SelectorSyncSetRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetSpec) DeepCopyInto(out *SelectorSyncSetSpec) {
 	*out = *in
 	in.SyncSetCommonSpec.DeepCopyInto(&out.SyncSetCommonSpec)
 	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(SelectorSyncSetRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3232,6 +4396,11 @@ func (in *SelectorSyncSetSpec) DeepCopy() *SelectorSyncSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetStatus) DeepCopyInto(out *SelectorSyncSetStatus) {
 	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(SelectorSyncSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3469,6 +4638,18 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
 		*out = make([]SecretMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(KustomizeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]SyncSetDependency, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -3484,6 +4665,22 @@ func (in *SyncSetCommonSpec) DeepCopy() *SyncSetCommonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetDependency) DeepCopyInto(out *SyncSetDependency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetDependency.
+func (in *SyncSetDependency) DeepCopy() *SyncSetDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetList) DeepCopyInto(out *SyncSetList) {
 	*out = *in
@@ -52,6 +52,11 @@ type SyncStatus struct {
 	// ObservedGeneration is the generation of the SyncSet or SelectorSyncSet that was last observed.
 	ObservedGeneration int64 `json:"observedGeneration"`
 
+	// RenderedHash is the hash of the rendered Resources and Patches of a SyncSet or SelectorSyncSet using
+	// templates. The SyncSet or SelectorSyncSet is applied again when the rendered content changes.
+	// +optional
+	RenderedHash string `json:"renderedHash,omitempty"`
+
 	// ResourcesToDelete is the list of resources in the cluster that should be deleted when the SyncSet or SelectorSyncSet
 	// is deleted or is no longer matched to the cluster.
 	// +optional
@@ -61,7 +66,7 @@ type SyncStatus struct {
 	Result SyncSetResult `json:"result"`
 
 	// FailureMessage is a message describing why the SyncSet or SelectorSyncSet could not be applied. This is only
-	// set when Result is Failure.
+	// set when Result is Failure or Waiting.
 	// +optional
 	FailureMessage string `json:"failureMessage,omitempty"`
 
@@ -71,8 +76,75 @@ type SyncStatus struct {
 	// FirstSuccessTime is the time when the SyncSet or SelectorSyncSet was first successfully applied to the cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// Health is the aggregate health of the resources of the SyncSet or SelectorSyncSet in the cluster. This is only
+	// set when the health check mode of the SyncSet or SelectorSyncSet is Enabled and it was applied successfully.
+	// +optional
+	Health ResourceHealth `json:"health,omitempty"`
+
+	// ResourceHealth is the health of each of the resources of the SyncSet or SelectorSyncSet in the cluster.
+	// +optional
+	ResourceHealth []SyncResourceHealth `json:"resourceHealth,omitempty"`
+
+	// DriftedResources is the list of resources of the SyncSet or SelectorSyncSet that differ from the resources in
+	// the cluster. This is only set when the apply behavior of the SyncSet or SelectorSyncSet is Audit.
+	// +optional
+	DriftedResources []SyncResourceDrift `json:"driftedResources,omitempty"`
+
+	// Conflicts is the list of resources of the SyncSet or SelectorSyncSet that could not be applied because some of
+	// their fields are owned by other field managers. This is only set when the apply behavior of the SyncSet or
+	// SelectorSyncSet is ServerSideApply.
+	// +optional
+	Conflicts []SyncResourceConflict `json:"conflicts,omitempty"`
+}
+
+// SyncResourceConflict is a resource of a SyncSet or SelectorSyncSet with fields owned by other field managers.
+type SyncResourceConflict struct {
+	SyncResourceReference `json:",inline"`
+
+	// Fields is the list of fields of the resource that are owned by other field managers.
+	Fields []string `json:"fields"`
+
+	// Message is the message of the conflict, naming the field managers that own the fields.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// SyncResourceDrift is a resource of a SyncSet or SelectorSyncSet that differs from the resource in the cluster.
+type SyncResourceDrift struct {
+	SyncResourceReference `json:",inline"`
+
+	// Diff is a summary of the fields of the resource that differ from the resource in the cluster.
+	Diff string `json:"diff"`
+}
+
+// SyncResourceHealth is the health of a resource that is synced to a cluster via a SyncSet or SelectorSyncSet.
+type SyncResourceHealth struct {
+	SyncResourceReference `json:",inline"`
+
+	// Health is the health of the resource.
+	Health ResourceHealth `json:"health"`
+
+	// Message is a message describing why the resource is not healthy.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ResourceHealth is the health of a resource in the cluster.
+// +kubebuilder:validation:Enum=Healthy;Progressing;Degraded
+type ResourceHealth string
+
+const (
+	// HealthyResourceHealth is the health of a resource that has been rolled out or has completed.
+	HealthyResourceHealth ResourceHealth = "Healthy"
+
+	// ProgressingResourceHealth is the health of a resource that is still rolling out or running.
+	ProgressingResourceHealth ResourceHealth = "Progressing"
+
+	// DegradedResourceHealth is the health of a resource that has failed, or that is missing from the cluster.
+	DegradedResourceHealth ResourceHealth = "Degraded"
+)
+
 // SyncResourceReference is a reference to a resource that is synced to a cluster via a SyncSet or SelectorSyncSet.
 type SyncResourceReference struct {
 	// APIVersion is the Group and Version of the resource.
@@ -91,7 +163,7 @@ type SyncResourceReference struct {
 }
 
 // SyncSetResult is the result of a sync attempt.
-// +kubebuilder:validation:Enum=Success;Failure
+// +kubebuilder:validation:Enum=Success;Failure;Waiting
 type SyncSetResult string
 
 const (
@@ -101,6 +173,11 @@ const (
 	// FailureSyncSetResult is the result when there was an error when attempting to apply the SyncSet or SelectorSyncSet
 	// to the cluster
 	FailureSyncSetResult SyncSetResult = "Failure"
+
+	// WaitingSyncSetResult is the result when the SyncSet or SelectorSyncSet could not be applied yet to the cluster
+	// because it is waiting for the SyncSets or SelectorSyncSets it depends on, or for the CustomResourceDefinitions
+	// it contains to be established.
+	WaitingSyncSetResult SyncSetResult = "Waiting"
 )
 
 // ClusterSyncCondition contains details for the current condition of a ClusterSync
@@ -21,6 +21,18 @@ type ClusterSyncLease struct {
 type ClusterSyncLeaseSpec struct {
 	// RenewTime is the time when SyncSets and SelectorSyncSets were last applied to the cluster.
 	RenewTime metav1.MicroTime `json:"renewTime"`
+
+	// HolderIdentity is the name of the clustersync replica that syncs the cluster.
+	// +optional
+	HolderIdentity string `json:"holderIdentity,omitempty"`
+
+	// AcquireTime is the time when the current holder started syncing the cluster.
+	// +optional
+	AcquireTime *metav1.MicroTime `json:"acquireTime,omitempty"`
+
+	// LeaseTransitions is the number of times the cluster was handed off between clustersync replicas.
+	// +optional
+	LeaseTransitions int32 `json:"leaseTransitions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
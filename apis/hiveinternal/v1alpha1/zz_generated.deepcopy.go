@@ -119,6 +119,10 @@ func (in *ClusterSyncLeaseList) DeepCopyObject() runtime.Object {
 func (in *ClusterSyncLeaseSpec) DeepCopyInto(out *ClusterSyncLeaseSpec) {
 	*out = *in
 	in.RenewTime.DeepCopyInto(&out.RenewTime)
+	if in.AcquireTime != nil {
+		in, out := &in.AcquireTime, &out.AcquireTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -329,6 +333,62 @@ func (in *FakeClusterInstallStatus) DeepCopy() *FakeClusterInstallStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceConflict) DeepCopyInto(out *SyncResourceConflict) {
+	*out = *in
+	out.SyncResourceReference = in.SyncResourceReference
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceConflict.
+func (in *SyncResourceConflict) DeepCopy() *SyncResourceConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceDrift) DeepCopyInto(out *SyncResourceDrift) {
+	*out = *in
+	out.SyncResourceReference = in.SyncResourceReference
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceDrift.
+func (in *SyncResourceDrift) DeepCopy() *SyncResourceDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceHealth) DeepCopyInto(out *SyncResourceHealth) {
+	*out = *in
+	out.SyncResourceReference = in.SyncResourceReference
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceHealth.
+func (in *SyncResourceHealth) DeepCopy() *SyncResourceHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncResourceReference) DeepCopyInto(out *SyncResourceReference) {
 	*out = *in
@@ -358,6 +418,23 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
 		*out = (*in).DeepCopy()
 	}
+	if in.ResourceHealth != nil {
+		in, out := &in.ResourceHealth, &out.ResourceHealth
+		*out = make([]SyncResourceHealth, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftedResources != nil {
+		in, out := &in.DriftedResources, &out.DriftedResources
+		*out = make([]SyncResourceDrift, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]SyncResourceConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -31,6 +31,7 @@ import (
 
 	openshiftapiv1 "github.com/openshift/api/config/v1"
 	_ "github.com/openshift/generic-admission-server/pkg/cmd"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -49,6 +50,7 @@ import (
 	"github.com/openshift/hive/pkg/controller/clusterversion"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
+	"github.com/openshift/hive/pkg/controller/dnsrecord"
 	"github.com/openshift/hive/pkg/controller/dnszone"
 	"github.com/openshift/hive/pkg/controller/fakeclusterinstall"
 	"github.com/openshift/hive/pkg/controller/hibernation"
@@ -56,6 +58,8 @@ import (
 	"github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
 	"github.com/openshift/hive/pkg/controller/remotemachineset"
+	"github.com/openshift/hive/pkg/controller/resourcecollector"
+	"github.com/openshift/hive/pkg/controller/selectorsyncsetrollout"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
@@ -75,30 +79,33 @@ const (
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
-	clusterclaim.ControllerName:         clusterclaim.Add,
-	clusterdeployment.ControllerName:    clusterdeployment.Add,
-	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
-	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
-	clusterprovision.ControllerName:     clusterprovision.Add,
-	clusterrelocate.ControllerName:      clusterrelocate.Add,
-	clusterstate.ControllerName:         clusterstate.Add,
-	clustersync.ControllerName:          clustersync.Add,
-	clusterversion.ControllerName:       clusterversion.Add,
-	controlplanecerts.ControllerName:    controlplanecerts.Add,
-	dnsendpoint.ControllerName:          dnsendpoint.Add,
-	dnszone.ControllerName:              dnszone.Add,
-	fakeclusterinstall.ControllerName:   fakeclusterinstall.Add,
-	metrics.ControllerName:              metrics.Add,
-	remoteingress.ControllerName:        remoteingress.Add,
-	remotemachineset.ControllerName:     remotemachineset.Add,
-	syncidentityprovider.ControllerName: syncidentityprovider.Add,
-	unreachable.ControllerName:          unreachable.Add,
-	velerobackup.ControllerName:         velerobackup.Add,
-	clusterpool.ControllerName:          clusterpool.Add,
-	hibernation.ControllerName:          hibernation.Add,
-	machinemanagement.ControllerName:    machinemanagement.Add,
-	awsprivatelink.ControllerName:       awsprivatelink.Add,
-	argocdregister.ControllerName:       argocdregister.Add,
+	clusterclaim.ControllerName:           clusterclaim.Add,
+	clusterdeployment.ControllerName:      clusterdeployment.Add,
+	clusterdeprovision.ControllerName:     clusterdeprovision.Add,
+	clusterpoolnamespace.ControllerName:   clusterpoolnamespace.Add,
+	clusterprovision.ControllerName:       clusterprovision.Add,
+	clusterrelocate.ControllerName:        clusterrelocate.Add,
+	clusterstate.ControllerName:           clusterstate.Add,
+	clustersync.ControllerName:            clustersync.Add,
+	clusterversion.ControllerName:         clusterversion.Add,
+	controlplanecerts.ControllerName:      controlplanecerts.Add,
+	dnsendpoint.ControllerName:            dnsendpoint.Add,
+	dnsrecord.ControllerName:              dnsrecord.Add,
+	dnszone.ControllerName:                dnszone.Add,
+	fakeclusterinstall.ControllerName:     fakeclusterinstall.Add,
+	metrics.ControllerName:                metrics.Add,
+	remoteingress.ControllerName:          remoteingress.Add,
+	remotemachineset.ControllerName:       remotemachineset.Add,
+	syncidentityprovider.ControllerName:   syncidentityprovider.Add,
+	unreachable.ControllerName:            unreachable.Add,
+	velerobackup.ControllerName:           velerobackup.Add,
+	clusterpool.ControllerName:            clusterpool.Add,
+	hibernation.ControllerName:            hibernation.Add,
+	machinemanagement.ControllerName:      machinemanagement.Add,
+	awsprivatelink.ControllerName:         awsprivatelink.Add,
+	argocdregister.ControllerName:         argocdregister.Add,
+	selectorsyncsetrollout.ControllerName: selectorsyncsetrollout.Add,
+	resourcecollector.ControllerName:      resourcecollector.Add,
 }
 
 type controllerManagerOptions struct {
@@ -207,6 +214,11 @@ func newRootCommand() *cobra.Command {
 					log.Fatal(err)
 				}
 
+				// Machines of clusters managed centrally are run from the hub
+				if err := machineapi.AddToScheme(mgr.GetScheme()); err != nil {
+					log.Fatal(err)
+				}
+
 				disabledControllersSet := sets.NewString(opts.DisabledControllers...)
 				// Setup all Controllers
 				for _, name := range opts.Controllers {
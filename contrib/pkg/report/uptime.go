@@ -0,0 +1,236 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UptimeReportOptions is the set of options for the desired report.
+type UptimeReportOptions struct {
+	// Start is the beginning of the time range of the report, in RFC3339 format.
+	Start string
+	// End is the end of the time range of the report, in RFC3339 format.
+	End string
+	// Namespace filters the report to only clusters in the given namespace.
+	Namespace string
+
+	start time.Time
+	end   time.Time
+}
+
+// namespaceUptime is the time the clusters of a namespace have spent in each power state.
+type namespaceUptime struct {
+	clusters int
+	times    map[hivev1.ClusterPowerState]time.Duration
+}
+
+// NewUptimeReportCommand creates a command that generates and outputs the cluster uptime report.
+func NewUptimeReportCommand() *cobra.Command {
+
+	opt := &UptimeReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "uptime",
+		Short: "Prints the time clusters spent running and hibernating per namespace",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Error("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.Start, "start", "", "Start of the reported time range in RFC3339 format. (i.e. 2021-04-01T00:00:00Z) Defaults to 30 days before the end.")
+	flags.StringVar(&opt.End, "end", "", "End of the reported time range in RFC3339 format. Defaults to now.")
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only include clusters in the given namespace.")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *UptimeReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *UptimeReportOptions) Validate(cmd *cobra.Command) error {
+	o.end = time.Now()
+	if o.End != "" {
+		end, err := time.Parse(time.RFC3339, o.End)
+		if err != nil {
+			return fmt.Errorf("invalid end time: %v", err)
+		}
+		o.end = end
+	}
+	o.start = o.end.Add(-30 * 24 * time.Hour)
+	if o.Start != "" {
+		start, err := time.Parse(time.RFC3339, o.Start)
+		if err != nil {
+			return fmt.Errorf("invalid start time: %v", err)
+		}
+		o.start = start
+	}
+	if !o.start.Before(o.end) {
+		return fmt.Errorf("start time must be before end time")
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *UptimeReportOptions) Run(dynClient client.Client) error {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := dynClient.List(context.Background(), cdList, client.InNamespace(o.Namespace)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	uptimes := map[string]*namespaceUptime{}
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if cd.Status.PowerStateDurations == nil {
+			continue
+		}
+		times, estimated := powerStateTimes(cd.Status.PowerStateDurations, o.start, o.end, now)
+		if estimated {
+			log.WithField("clusterDeployment", fmt.Sprintf("%s/%s", cd.Namespace, cd.Name)).
+				Warnf("power state history does not cover the start of the range, times before %s are estimated",
+					cd.Status.PowerStateDurations.History[0].Time.Format(time.RFC3339))
+		}
+		if len(times) == 0 {
+			continue
+		}
+		uptime, ok := uptimes[cd.Namespace]
+		if !ok {
+			uptime = &namespaceUptime{times: map[hivev1.ClusterPowerState]time.Duration{}}
+			uptimes[cd.Namespace] = uptime
+		}
+		uptime.clusters++
+		for state, d := range times {
+			uptime.times[state] += d
+		}
+	}
+
+	namespaces := make([]string, 0, len(uptimes))
+	for ns := range uptimes {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	fmt.Printf("Cluster uptime from %s to %s (hours)\n", o.start.Format(time.RFC3339), o.end.Format(time.RFC3339))
+	fmt.Printf("%-40s %10s %12s %12s %20s\n", "NAMESPACE", "CLUSTERS", "RUNNING", "HIBERNATING", "WORKERS HIBERNATING")
+	for _, ns := range namespaces {
+		uptime := uptimes[ns]
+		fmt.Printf("%-40s %10d %12.2f %12.2f %20.2f\n", ns, uptime.clusters,
+			uptime.times[hivev1.RunningClusterPowerState].Hours(),
+			uptime.times[hivev1.HibernatingClusterPowerState].Hours(),
+			uptime.times[hivev1.WorkersHibernatingClusterPowerState].Hours())
+	}
+	return nil
+}
+
+// powerStateTimes returns the time a cluster spent in each power state between start and end, based on the
+// power state history of the cluster. The history only keeps the most recent power state changes, so the time
+// before the oldest recorded change is taken from the accumulated durations of the cluster. That time can only be
+// placed exactly when the range covers all of it, otherwise it is apportioned to the range and estimated is true.
+func powerStateTimes(durations *hivev1.ClusterPowerStateDurations, start, end, now time.Time) (times map[hivev1.ClusterPowerState]time.Duration, estimated bool) {
+	times = map[hivev1.ClusterPowerState]time.Duration{}
+	if len(durations.History) == 0 {
+		return times, false
+	}
+
+	// The time spent in each power state since the oldest recorded change.
+	recorded := map[hivev1.ClusterPowerState]time.Duration{}
+	for i, change := range durations.History {
+		from := change.Time.Time
+		to := now
+		if i+1 < len(durations.History) {
+			to = durations.History[i+1].Time.Time
+		}
+		if to.After(from) {
+			recorded[change.PowerState] += to.Sub(from)
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			times[change.PowerState] += to.Sub(from)
+		}
+	}
+
+	// The time spent in each power state before the oldest recorded change is the accumulated time that is not
+	// covered by the history.
+	current := durations.History[len(durations.History)-1].PowerState
+	accumulated := map[hivev1.ClusterPowerState]time.Duration{
+		hivev1.RunningClusterPowerState:            durations.Running.Duration,
+		hivev1.HibernatingClusterPowerState:        durations.Hibernating.Duration,
+		hivev1.WorkersHibernatingClusterPowerState: durations.WorkersHibernating.Duration,
+	}
+	if now.After(durations.LastUpdateTime.Time) {
+		accumulated[current] += now.Sub(durations.LastUpdateTime.Time)
+	}
+	unrecorded := map[hivev1.ClusterPowerState]time.Duration{}
+	var unrecordedTotal time.Duration
+	for state, d := range accumulated {
+		if d -= recorded[state]; d > 0 {
+			unrecorded[state] = d
+			unrecordedTotal += d
+		}
+	}
+	if unrecordedTotal == 0 {
+		return times, false
+	}
+
+	// The unrecorded time directly precedes the oldest recorded change.
+	unrecordedEnd := durations.History[0].Time.Time
+	unrecordedStart := unrecordedEnd.Add(-unrecordedTotal)
+	from, to := unrecordedStart, unrecordedEnd
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+	if !to.After(from) {
+		return times, false
+	}
+	overlap := to.Sub(from)
+	for state, d := range unrecorded {
+		if overlap == unrecordedTotal {
+			times[state] += d
+		} else {
+			times[state] += time.Duration(float64(d) * float64(overlap) / float64(unrecordedTotal))
+		}
+	}
+	return times, overlap != unrecordedTotal
+}
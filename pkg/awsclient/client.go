@@ -100,6 +100,13 @@ type Client interface {
 	DeleteVPCAssociationAuthorization(*route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error)
 	AssociateVPCWithHostedZone(*route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error)
 	DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error)
+	GetDNSSEC(*route53.GetDNSSECInput) (*route53.GetDNSSECOutput, error)
+	EnableHostedZoneDNSSEC(*route53.EnableHostedZoneDNSSECInput) (*route53.EnableHostedZoneDNSSECOutput, error)
+	DisableHostedZoneDNSSEC(*route53.DisableHostedZoneDNSSECInput) (*route53.DisableHostedZoneDNSSECOutput, error)
+	CreateKeySigningKey(*route53.CreateKeySigningKeyInput) (*route53.CreateKeySigningKeyOutput, error)
+	ActivateKeySigningKey(*route53.ActivateKeySigningKeyInput) (*route53.ActivateKeySigningKeyOutput, error)
+	DeactivateKeySigningKey(*route53.DeactivateKeySigningKeyInput) (*route53.DeactivateKeySigningKeyOutput, error)
+	DeleteKeySigningKey(*route53.DeleteKeySigningKeyInput) (*route53.DeleteKeySigningKeyOutput, error)
 	// ResourceTagging
 	GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
 
@@ -281,6 +288,41 @@ func (c *awsClient) DisassociateVPCFromHostedZone(input *route53.DisassociateVPC
 	return c.route53Client.DisassociateVPCFromHostedZone(input)
 }
 
+func (c *awsClient) GetDNSSEC(input *route53.GetDNSSECInput) (*route53.GetDNSSECOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetDNSSEC").Inc()
+	return c.route53Client.GetDNSSEC(input)
+}
+
+func (c *awsClient) EnableHostedZoneDNSSEC(input *route53.EnableHostedZoneDNSSECInput) (*route53.EnableHostedZoneDNSSECOutput, error) {
+	metricAWSAPICalls.WithLabelValues("EnableHostedZoneDNSSEC").Inc()
+	return c.route53Client.EnableHostedZoneDNSSEC(input)
+}
+
+func (c *awsClient) DisableHostedZoneDNSSEC(input *route53.DisableHostedZoneDNSSECInput) (*route53.DisableHostedZoneDNSSECOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DisableHostedZoneDNSSEC").Inc()
+	return c.route53Client.DisableHostedZoneDNSSEC(input)
+}
+
+func (c *awsClient) CreateKeySigningKey(input *route53.CreateKeySigningKeyInput) (*route53.CreateKeySigningKeyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateKeySigningKey").Inc()
+	return c.route53Client.CreateKeySigningKey(input)
+}
+
+func (c *awsClient) ActivateKeySigningKey(input *route53.ActivateKeySigningKeyInput) (*route53.ActivateKeySigningKeyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ActivateKeySigningKey").Inc()
+	return c.route53Client.ActivateKeySigningKey(input)
+}
+
+func (c *awsClient) DeactivateKeySigningKey(input *route53.DeactivateKeySigningKeyInput) (*route53.DeactivateKeySigningKeyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeactivateKeySigningKey").Inc()
+	return c.route53Client.DeactivateKeySigningKey(input)
+}
+
+func (c *awsClient) DeleteKeySigningKey(input *route53.DeleteKeySigningKeyInput) (*route53.DeleteKeySigningKeyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteKeySigningKey").Inc()
+	return c.route53Client.DeleteKeySigningKey(input)
+}
+
 func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	metricAWSAPICalls.WithLabelValues("GetCallerIdentity").Inc()
 	return c.stsClient.GetCallerIdentity(input)
@@ -518,6 +518,111 @@ func (mr *MockClientMockRecorder) DisassociateVPCFromHostedZone(input interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateVPCFromHostedZone", reflect.TypeOf((*MockClient)(nil).DisassociateVPCFromHostedZone), input)
 }
 
+// GetDNSSEC mocks base method
+func (m *MockClient) GetDNSSEC(arg0 *route53.GetDNSSECInput) (*route53.GetDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSSEC", arg0)
+	ret0, _ := ret[0].(*route53.GetDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNSSEC indicates an expected call of GetDNSSEC
+func (mr *MockClientMockRecorder) GetDNSSEC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSSEC", reflect.TypeOf((*MockClient)(nil).GetDNSSEC), arg0)
+}
+
+// EnableHostedZoneDNSSEC mocks base method
+func (m *MockClient) EnableHostedZoneDNSSEC(arg0 *route53.EnableHostedZoneDNSSECInput) (*route53.EnableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableHostedZoneDNSSEC", arg0)
+	ret0, _ := ret[0].(*route53.EnableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableHostedZoneDNSSEC indicates an expected call of EnableHostedZoneDNSSEC
+func (mr *MockClientMockRecorder) EnableHostedZoneDNSSEC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableHostedZoneDNSSEC", reflect.TypeOf((*MockClient)(nil).EnableHostedZoneDNSSEC), arg0)
+}
+
+// DisableHostedZoneDNSSEC mocks base method
+func (m *MockClient) DisableHostedZoneDNSSEC(arg0 *route53.DisableHostedZoneDNSSECInput) (*route53.DisableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableHostedZoneDNSSEC", arg0)
+	ret0, _ := ret[0].(*route53.DisableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableHostedZoneDNSSEC indicates an expected call of DisableHostedZoneDNSSEC
+func (mr *MockClientMockRecorder) DisableHostedZoneDNSSEC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableHostedZoneDNSSEC", reflect.TypeOf((*MockClient)(nil).DisableHostedZoneDNSSEC), arg0)
+}
+
+// CreateKeySigningKey mocks base method
+func (m *MockClient) CreateKeySigningKey(arg0 *route53.CreateKeySigningKeyInput) (*route53.CreateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.CreateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKeySigningKey indicates an expected call of CreateKeySigningKey
+func (mr *MockClientMockRecorder) CreateKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeySigningKey", reflect.TypeOf((*MockClient)(nil).CreateKeySigningKey), arg0)
+}
+
+// ActivateKeySigningKey mocks base method
+func (m *MockClient) ActivateKeySigningKey(arg0 *route53.ActivateKeySigningKeyInput) (*route53.ActivateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.ActivateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateKeySigningKey indicates an expected call of ActivateKeySigningKey
+func (mr *MockClientMockRecorder) ActivateKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateKeySigningKey", reflect.TypeOf((*MockClient)(nil).ActivateKeySigningKey), arg0)
+}
+
+// DeactivateKeySigningKey mocks base method
+func (m *MockClient) DeactivateKeySigningKey(arg0 *route53.DeactivateKeySigningKeyInput) (*route53.DeactivateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.DeactivateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateKeySigningKey indicates an expected call of DeactivateKeySigningKey
+func (mr *MockClientMockRecorder) DeactivateKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateKeySigningKey", reflect.TypeOf((*MockClient)(nil).DeactivateKeySigningKey), arg0)
+}
+
+// DeleteKeySigningKey mocks base method
+func (m *MockClient) DeleteKeySigningKey(arg0 *route53.DeleteKeySigningKeyInput) (*route53.DeleteKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.DeleteKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteKeySigningKey indicates an expected call of DeleteKeySigningKey
+func (mr *MockClientMockRecorder) DeleteKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeySigningKey", reflect.TypeOf((*MockClient)(nil).DeleteKeySigningKey), arg0)
+}
+
 // GetResourcesPages mocks base method
 func (m *MockClient) GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
 	m.ctrl.T.Helper()
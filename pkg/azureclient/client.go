@@ -3,10 +3,12 @@ package azureclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -29,9 +31,26 @@ type Client interface {
 
 	// RecordSets
 	ListRecordSetsByZone(ctx context.Context, resourceGroupName string, zone string, suffix string) (RecordSetPage, error)
+	GetRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType) (dns.RecordSet, error)
 	CreateOrUpdateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType, recordSet dns.RecordSet) (dns.RecordSet, error)
 	DeleteRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType) error
 
+	// Private Zones
+	CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error)
+	DeletePrivateZone(ctx context.Context, resourceGroupName string, zone string) error
+	GetPrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error)
+
+	// Private RecordSets
+	ListPrivateRecordSetsByZone(ctx context.Context, resourceGroupName string, zone string) (PrivateRecordSetPage, error)
+	GetPrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType) (privatedns.RecordSet, error)
+	CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error)
+	DeletePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType) error
+
+	// Virtual Network Links
+	ListVirtualNetworkLinks(ctx context.Context, resourceGroupName string, zone string) (VirtualNetworkLinkPage, error)
+	CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string, virtualNetworkResourceGroupName string, virtualNetworkName string) error
+	DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string) error
+
 	// Virtual Machines
 	ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error)
 	DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error)
@@ -52,11 +71,29 @@ type RecordSetPage interface {
 	Values() []dns.RecordSet
 }
 
+// PrivateRecordSetPage is a page of results from listing the record sets of a private zone.
+type PrivateRecordSetPage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []privatedns.RecordSet
+}
+
+// VirtualNetworkLinkPage is a page of results from listing the virtual network links of a private zone.
+type VirtualNetworkLinkPage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []privatedns.VirtualNetworkLink
+}
+
 type azureClient struct {
-	resourceSKUsClient    *compute.ResourceSkusClient
-	recordSetsClient      *dns.RecordSetsClient
-	zonesClient           *dns.ZonesClient
-	virtualMachinesClient *compute.VirtualMachinesClient
+	subscriptionID            string
+	resourceSKUsClient        *compute.ResourceSkusClient
+	recordSetsClient          *dns.RecordSetsClient
+	zonesClient               *dns.ZonesClient
+	privateRecordSetsClient   *privatedns.RecordSetsClient
+	privateZonesClient        *privatedns.PrivateZonesClient
+	virtualNetworkLinksClient *privatedns.VirtualNetworkLinksClient
+	virtualMachinesClient     *compute.VirtualMachinesClient
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
@@ -92,6 +129,10 @@ func (c *azureClient) ListRecordSetsByZone(ctx context.Context, resourceGroupNam
 	return &page, err
 }
 
+func (c *azureClient) GetRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType) (dns.RecordSet, error) {
+	return c.recordSetsClient.Get(ctx, resourceGroupName, zone, recordSetName, recordType)
+}
+
 func (c *azureClient) GetZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error) {
 	return c.zonesClient.Get(ctx, resourceGroupName, zone)
 }
@@ -100,6 +141,81 @@ func (c *azureClient) CreateOrUpdateRecordSet(ctx context.Context, resourceGroup
 	return c.recordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zone, recordSetName, recordType, recordSet, "", "")
 }
 
+func (c *azureClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error) {
+	future, err := c.privateZonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, privatedns.PrivateZone{
+		Location: to.StringPtr("global"),
+	}, "", "")
+	if err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	if err := future.WaitForCompletionRef(ctx, c.privateZonesClient.Client); err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	return future.Result(*c.privateZonesClient)
+}
+
+func (c *azureClient) DeletePrivateZone(ctx context.Context, resourceGroupName string, zone string) error {
+	future, err := c.privateZonesClient.Delete(ctx, resourceGroupName, zone, "")
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.privateZonesClient.Client)
+}
+
+func (c *azureClient) GetPrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error) {
+	return c.privateZonesClient.Get(ctx, resourceGroupName, zone)
+}
+
+func (c *azureClient) ListPrivateRecordSetsByZone(ctx context.Context, resourceGroupName string, zone string) (PrivateRecordSetPage, error) {
+	page, err := c.privateRecordSetsClient.List(ctx, resourceGroupName, zone, nil, "")
+	return &page, err
+}
+
+func (c *azureClient) GetPrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType) (privatedns.RecordSet, error) {
+	return c.privateRecordSetsClient.Get(ctx, resourceGroupName, zone, recordType, recordSetName)
+}
+
+func (c *azureClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error) {
+	return c.privateRecordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zone, recordType, recordSetName, recordSet, "", "")
+}
+
+func (c *azureClient) DeletePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType) error {
+	_, err := c.privateRecordSetsClient.Delete(ctx, resourceGroupName, zone, recordType, recordSetName, "")
+	return err
+}
+
+func (c *azureClient) ListVirtualNetworkLinks(ctx context.Context, resourceGroupName string, zone string) (VirtualNetworkLinkPage, error) {
+	page, err := c.virtualNetworkLinksClient.List(ctx, resourceGroupName, zone, nil)
+	return &page, err
+}
+
+func (c *azureClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string, virtualNetworkResourceGroupName string, virtualNetworkName string) error {
+	virtualNetworkID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s",
+		c.subscriptionID, virtualNetworkResourceGroupName, virtualNetworkName)
+	future, err := c.virtualNetworkLinksClient.CreateOrUpdate(ctx, resourceGroupName, zone, linkName, privatedns.VirtualNetworkLink{
+		Location: to.StringPtr("global"),
+		VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+			VirtualNetwork:      &privatedns.SubResource{ID: to.StringPtr(virtualNetworkID)},
+			RegistrationEnabled: to.BoolPtr(false),
+		},
+	}, "", "")
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client)
+}
+
+func (c *azureClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string) error {
+	future, err := c.virtualNetworkLinksClient.Delete(ctx, resourceGroupName, zone, linkName, "")
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client)
+}
+
 func (c *azureClient) ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error) {
 	return c.virtualMachinesClient.ListAll(ctx, statusOnly)
 }
@@ -171,14 +287,27 @@ func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
 	zonesClient := dns.NewZonesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	zonesClient.Authorizer = authorizer
 
+	privateRecordSetsClient := privatedns.NewRecordSetsClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	privateRecordSetsClient.Authorizer = authorizer
+
+	privateZonesClient := privatedns.NewPrivateZonesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	privateZonesClient.Authorizer = authorizer
+
+	virtualNetworkLinksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
+	virtualNetworkLinksClient.Authorizer = authorizer
+
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
 	virtualMachinesClient.Authorizer = authorizer
 
 	return &azureClient{
-		resourceSKUsClient:    &resourceSKUsClient,
-		recordSetsClient:      &recordSetsClient,
-		zonesClient:           &zonesClient,
-		virtualMachinesClient: &virtualMachinesClient,
+		subscriptionID:            subscriptionID,
+		resourceSKUsClient:        &resourceSKUsClient,
+		recordSetsClient:          &recordSetsClient,
+		zonesClient:               &zonesClient,
+		privateRecordSetsClient:   &privateRecordSetsClient,
+		privateZonesClient:        &privateZonesClient,
+		virtualNetworkLinksClient: &virtualNetworkLinksClient,
+		virtualMachinesClient:     &virtualMachinesClient,
 	}, nil
 }
 
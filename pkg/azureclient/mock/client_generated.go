@@ -8,6 +8,7 @@ import (
 	context "context"
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	privatedns "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 	reflect "reflect"
@@ -110,6 +111,21 @@ func (mr *MockClientMockRecorder) ListRecordSetsByZone(ctx, resourceGroupName, z
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecordSetsByZone", reflect.TypeOf((*MockClient)(nil).ListRecordSetsByZone), ctx, resourceGroupName, zone, suffix)
 }
 
+// GetRecordSet mocks base method
+func (m *MockClient) GetRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType dns.RecordType) (dns.RecordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecordSet", ctx, resourceGroupName, zone, recordSetName, recordType)
+	ret0, _ := ret[0].(dns.RecordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecordSet indicates an expected call of GetRecordSet
+func (mr *MockClientMockRecorder) GetRecordSet(ctx, resourceGroupName, zone, recordSetName, recordType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordSet", reflect.TypeOf((*MockClient)(nil).GetRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType)
+}
+
 // CreateOrUpdateRecordSet mocks base method
 func (m *MockClient) CreateOrUpdateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType dns.RecordType, recordSet dns.RecordSet) (dns.RecordSet, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +155,152 @@ func (mr *MockClientMockRecorder) DeleteRecordSet(ctx, resourceGroupName, zone,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecordSet", reflect.TypeOf((*MockClient)(nil).DeleteRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType)
 }
 
+// CreateOrUpdatePrivateZone mocks base method
+func (m *MockClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(privatedns.PrivateZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateZone indicates an expected call of CreateOrUpdatePrivateZone
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateZone", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateZone), ctx, resourceGroupName, zone)
+}
+
+// DeletePrivateZone mocks base method
+func (m *MockClient) DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateZone indicates an expected call of DeletePrivateZone
+func (mr *MockClientMockRecorder) DeletePrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateZone", reflect.TypeOf((*MockClient)(nil).DeletePrivateZone), ctx, resourceGroupName, zone)
+}
+
+// GetPrivateZone mocks base method
+func (m *MockClient) GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(privatedns.PrivateZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateZone indicates an expected call of GetPrivateZone
+func (mr *MockClientMockRecorder) GetPrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateZone", reflect.TypeOf((*MockClient)(nil).GetPrivateZone), ctx, resourceGroupName, zone)
+}
+
+// ListPrivateRecordSetsByZone mocks base method
+func (m *MockClient) ListPrivateRecordSetsByZone(ctx context.Context, resourceGroupName, zone string) (azureclient.PrivateRecordSetPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrivateRecordSetsByZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(azureclient.PrivateRecordSetPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrivateRecordSetsByZone indicates an expected call of ListPrivateRecordSetsByZone
+func (mr *MockClientMockRecorder) ListPrivateRecordSetsByZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrivateRecordSetsByZone", reflect.TypeOf((*MockClient)(nil).ListPrivateRecordSetsByZone), ctx, resourceGroupName, zone)
+}
+
+// GetPrivateRecordSet mocks base method
+func (m *MockClient) GetPrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType) (privatedns.RecordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateRecordSet", ctx, resourceGroupName, zone, recordSetName, recordType)
+	ret0, _ := ret[0].(privatedns.RecordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateRecordSet indicates an expected call of GetPrivateRecordSet
+func (mr *MockClientMockRecorder) GetPrivateRecordSet(ctx, resourceGroupName, zone, recordSetName, recordType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateRecordSet", reflect.TypeOf((*MockClient)(nil).GetPrivateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType)
+}
+
+// CreateOrUpdatePrivateRecordSet mocks base method
+func (m *MockClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateRecordSet", ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+	ret0, _ := ret[0].(privatedns.RecordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateRecordSet indicates an expected call of CreateOrUpdatePrivateRecordSet
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateRecordSet(ctx, resourceGroupName, zone, recordSetName, recordType, recordSet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateRecordSet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+}
+
+// DeletePrivateRecordSet mocks base method
+func (m *MockClient) DeletePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateRecordSet", ctx, resourceGroupName, zone, recordSetName, recordType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateRecordSet indicates an expected call of DeletePrivateRecordSet
+func (mr *MockClientMockRecorder) DeletePrivateRecordSet(ctx, resourceGroupName, zone, recordSetName, recordType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateRecordSet", reflect.TypeOf((*MockClient)(nil).DeletePrivateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType)
+}
+
+// ListVirtualNetworkLinks mocks base method
+func (m *MockClient) ListVirtualNetworkLinks(ctx context.Context, resourceGroupName, zone string) (azureclient.VirtualNetworkLinkPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVirtualNetworkLinks", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(azureclient.VirtualNetworkLinkPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVirtualNetworkLinks indicates an expected call of ListVirtualNetworkLinks
+func (mr *MockClientMockRecorder) ListVirtualNetworkLinks(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVirtualNetworkLinks", reflect.TypeOf((*MockClient)(nil).ListVirtualNetworkLinks), ctx, resourceGroupName, zone)
+}
+
+// CreateOrUpdateVirtualNetworkLink mocks base method
+func (m *MockClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName, virtualNetworkResourceGroupName, virtualNetworkName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateVirtualNetworkLink", ctx, resourceGroupName, zone, linkName, virtualNetworkResourceGroupName, virtualNetworkName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateVirtualNetworkLink indicates an expected call of CreateOrUpdateVirtualNetworkLink
+func (mr *MockClientMockRecorder) CreateOrUpdateVirtualNetworkLink(ctx, resourceGroupName, zone, linkName, virtualNetworkResourceGroupName, virtualNetworkName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateVirtualNetworkLink), ctx, resourceGroupName, zone, linkName, virtualNetworkResourceGroupName, virtualNetworkName)
+}
+
+// DeleteVirtualNetworkLink mocks base method
+func (m *MockClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirtualNetworkLink", ctx, resourceGroupName, zone, linkName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVirtualNetworkLink indicates an expected call of DeleteVirtualNetworkLink
+func (mr *MockClientMockRecorder) DeleteVirtualNetworkLink(ctx, resourceGroupName, zone, linkName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).DeleteVirtualNetworkLink), ctx, resourceGroupName, zone, linkName)
+}
+
 // ListAllVirtualMachines mocks base method
 func (m *MockClient) ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error) {
 	m.ctrl.T.Helper()
@@ -313,3 +475,133 @@ func (mr *MockRecordSetPageMockRecorder) Values() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockRecordSetPage)(nil).Values))
 }
+
+// MockPrivateRecordSetPage is a mock of PrivateRecordSetPage interface
+type MockPrivateRecordSetPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivateRecordSetPageMockRecorder
+}
+
+// MockPrivateRecordSetPageMockRecorder is the mock recorder for MockPrivateRecordSetPage
+type MockPrivateRecordSetPageMockRecorder struct {
+	mock *MockPrivateRecordSetPage
+}
+
+// NewMockPrivateRecordSetPage creates a new mock instance
+func NewMockPrivateRecordSetPage(ctrl *gomock.Controller) *MockPrivateRecordSetPage {
+	mock := &MockPrivateRecordSetPage{ctrl: ctrl}
+	mock.recorder = &MockPrivateRecordSetPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPrivateRecordSetPage) EXPECT() *MockPrivateRecordSetPageMockRecorder {
+	return m.recorder
+}
+
+// NextWithContext mocks base method
+func (m *MockPrivateRecordSetPage) NextWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextWithContext indicates an expected call of NextWithContext
+func (mr *MockPrivateRecordSetPageMockRecorder) NextWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWithContext", reflect.TypeOf((*MockPrivateRecordSetPage)(nil).NextWithContext), ctx)
+}
+
+// NotDone mocks base method
+func (m *MockPrivateRecordSetPage) NotDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NotDone indicates an expected call of NotDone
+func (mr *MockPrivateRecordSetPageMockRecorder) NotDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotDone", reflect.TypeOf((*MockPrivateRecordSetPage)(nil).NotDone))
+}
+
+// Values mocks base method
+func (m *MockPrivateRecordSetPage) Values() []privatedns.RecordSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Values")
+	ret0, _ := ret[0].([]privatedns.RecordSet)
+	return ret0
+}
+
+// Values indicates an expected call of Values
+func (mr *MockPrivateRecordSetPageMockRecorder) Values() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockPrivateRecordSetPage)(nil).Values))
+}
+
+// MockVirtualNetworkLinkPage is a mock of VirtualNetworkLinkPage interface
+type MockVirtualNetworkLinkPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualNetworkLinkPageMockRecorder
+}
+
+// MockVirtualNetworkLinkPageMockRecorder is the mock recorder for MockVirtualNetworkLinkPage
+type MockVirtualNetworkLinkPageMockRecorder struct {
+	mock *MockVirtualNetworkLinkPage
+}
+
+// NewMockVirtualNetworkLinkPage creates a new mock instance
+func NewMockVirtualNetworkLinkPage(ctrl *gomock.Controller) *MockVirtualNetworkLinkPage {
+	mock := &MockVirtualNetworkLinkPage{ctrl: ctrl}
+	mock.recorder = &MockVirtualNetworkLinkPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVirtualNetworkLinkPage) EXPECT() *MockVirtualNetworkLinkPageMockRecorder {
+	return m.recorder
+}
+
+// NextWithContext mocks base method
+func (m *MockVirtualNetworkLinkPage) NextWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NextWithContext indicates an expected call of NextWithContext
+func (mr *MockVirtualNetworkLinkPageMockRecorder) NextWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWithContext", reflect.TypeOf((*MockVirtualNetworkLinkPage)(nil).NextWithContext), ctx)
+}
+
+// NotDone mocks base method
+func (m *MockVirtualNetworkLinkPage) NotDone() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotDone")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NotDone indicates an expected call of NotDone
+func (mr *MockVirtualNetworkLinkPageMockRecorder) NotDone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotDone", reflect.TypeOf((*MockVirtualNetworkLinkPage)(nil).NotDone))
+}
+
+// Values mocks base method
+func (m *MockVirtualNetworkLinkPage) Values() []privatedns.VirtualNetworkLink {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Values")
+	ret0, _ := ret[0].([]privatedns.VirtualNetworkLink)
+	return ret0
+}
+
+// Values indicates an expected call of Values
+func (mr *MockVirtualNetworkLinkPageMockRecorder) Values() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Values", reflect.TypeOf((*MockVirtualNetworkLinkPage)(nil).Values))
+}
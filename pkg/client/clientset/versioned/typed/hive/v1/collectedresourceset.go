@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	scheme "github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CollectedResourceSetsGetter has a method to return a CollectedResourceSetInterface.
+// A group's client should implement this interface.
+type CollectedResourceSetsGetter interface {
+	CollectedResourceSets(namespace string) CollectedResourceSetInterface
+}
+
+// CollectedResourceSetInterface has methods to work with CollectedResourceSet resources.
+type CollectedResourceSetInterface interface {
+	Create(ctx context.Context, collectedResourceSet *v1.CollectedResourceSet, opts metav1.CreateOptions) (*v1.CollectedResourceSet, error)
+	Update(ctx context.Context, collectedResourceSet *v1.CollectedResourceSet, opts metav1.UpdateOptions) (*v1.CollectedResourceSet, error)
+	UpdateStatus(ctx context.Context, collectedResourceSet *v1.CollectedResourceSet, opts metav1.UpdateOptions) (*v1.CollectedResourceSet, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.CollectedResourceSet, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.CollectedResourceSetList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.CollectedResourceSet, err error)
+	CollectedResourceSetExpansion
+}
+
+// collectedResourceSets implements CollectedResourceSetInterface
+type collectedResourceSets struct {
+	client rest.Interface
+	ns     string
+}
+
+// newCollectedResourceSets returns a CollectedResourceSets
+func newCollectedResourceSets(c *HiveV1Client, namespace string) *collectedResourceSets {
+	return &collectedResourceSets{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the collectedResourceSet, and returns the corresponding collectedResourceSet object, and an error if there is any.
+func (c *collectedResourceSets) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.CollectedResourceSet, err error) {
+	result = &v1.CollectedResourceSet{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of CollectedResourceSets that match those selectors.
+func (c *collectedResourceSets) List(ctx context.Context, opts metav1.ListOptions) (result *v1.CollectedResourceSetList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.CollectedResourceSetList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested collectedResourceSets.
+func (c *collectedResourceSets) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a collectedResourceSet and creates it.  Returns the server's representation of the collectedResourceSet, and an error, if there is any.
+func (c *collectedResourceSets) Create(ctx context.Context, collectedResourceSet *v1.CollectedResourceSet, opts metav1.CreateOptions) (result *v1.CollectedResourceSet, err error) {
+	result = &v1.CollectedResourceSet{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(collectedResourceSet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a collectedResourceSet and updates it. Returns the server's representation of the collectedResourceSet, and an error, if there is any.
+func (c *collectedResourceSets) Update(ctx context.Context, collectedResourceSet *v1.CollectedResourceSet, opts metav1.UpdateOptions) (result *v1.CollectedResourceSet, err error) {
+	result = &v1.CollectedResourceSet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		Name(collectedResourceSet.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(collectedResourceSet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *collectedResourceSets) UpdateStatus(ctx context.Context, collectedResourceSet *v1.CollectedResourceSet, opts metav1.UpdateOptions) (result *v1.CollectedResourceSet, err error) {
+	result = &v1.CollectedResourceSet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		Name(collectedResourceSet.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(collectedResourceSet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the collectedResourceSet and deletes it. Returns an error if one occurs.
+func (c *collectedResourceSets) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *collectedResourceSets) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched collectedResourceSet.
+func (c *collectedResourceSets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.CollectedResourceSet, err error) {
+	result = &v1.CollectedResourceSet{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("collectedresourcesets").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCollectedResourceSets implements CollectedResourceSetInterface
+type FakeCollectedResourceSets struct {
+	Fake *FakeHiveV1
+	ns   string
+}
+
+var collectedresourcesetsResource = schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "collectedresourcesets"}
+
+var collectedresourcesetsKind = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "CollectedResourceSet"}
+
+// Get takes name of the collectedResourceSet, and returns the corresponding collectedResourceSet object, and an error if there is any.
+func (c *FakeCollectedResourceSets) Get(ctx context.Context, name string, options v1.GetOptions) (result *hivev1.CollectedResourceSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(collectedresourcesetsResource, c.ns, name), &hivev1.CollectedResourceSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.CollectedResourceSet), err
+}
+
+// List takes label and field selectors, and returns the list of CollectedResourceSets that match those selectors.
+func (c *FakeCollectedResourceSets) List(ctx context.Context, opts v1.ListOptions) (result *hivev1.CollectedResourceSetList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(collectedresourcesetsResource, collectedresourcesetsKind, c.ns, opts), &hivev1.CollectedResourceSetList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &hivev1.CollectedResourceSetList{ListMeta: obj.(*hivev1.CollectedResourceSetList).ListMeta}
+	for _, item := range obj.(*hivev1.CollectedResourceSetList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested collectedResourceSets.
+func (c *FakeCollectedResourceSets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(collectedresourcesetsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a collectedResourceSet and creates it.  Returns the server's representation of the collectedResourceSet, and an error, if there is any.
+func (c *FakeCollectedResourceSets) Create(ctx context.Context, collectedResourceSet *hivev1.CollectedResourceSet, opts v1.CreateOptions) (result *hivev1.CollectedResourceSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(collectedresourcesetsResource, c.ns, collectedResourceSet), &hivev1.CollectedResourceSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.CollectedResourceSet), err
+}
+
+// Update takes the representation of a collectedResourceSet and updates it. Returns the server's representation of the collectedResourceSet, and an error, if there is any.
+func (c *FakeCollectedResourceSets) Update(ctx context.Context, collectedResourceSet *hivev1.CollectedResourceSet, opts v1.UpdateOptions) (result *hivev1.CollectedResourceSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(collectedresourcesetsResource, c.ns, collectedResourceSet), &hivev1.CollectedResourceSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.CollectedResourceSet), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCollectedResourceSets) UpdateStatus(ctx context.Context, collectedResourceSet *hivev1.CollectedResourceSet, opts v1.UpdateOptions) (*hivev1.CollectedResourceSet, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(collectedresourcesetsResource, "status", c.ns, collectedResourceSet), &hivev1.CollectedResourceSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.CollectedResourceSet), err
+}
+
+// Delete takes name of the collectedResourceSet and deletes it. Returns an error if one occurs.
+func (c *FakeCollectedResourceSets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(collectedresourcesetsResource, c.ns, name), &hivev1.CollectedResourceSet{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCollectedResourceSets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(collectedresourcesetsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &hivev1.CollectedResourceSetList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched collectedResourceSet.
+func (c *FakeCollectedResourceSets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *hivev1.CollectedResourceSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(collectedresourcesetsResource, c.ns, name, pt, data, subresources...), &hivev1.CollectedResourceSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.CollectedResourceSet), err
+}
@@ -48,6 +48,14 @@ func (c *FakeHiveV1) ClusterStates(namespace string) v1.ClusterStateInterface {
 	return &FakeClusterStates{c, namespace}
 }
 
+func (c *FakeHiveV1) CollectedResourceSets(namespace string) v1.CollectedResourceSetInterface {
+	return &FakeCollectedResourceSets{c, namespace}
+}
+
+func (c *FakeHiveV1) DNSRecords(namespace string) v1.DNSRecordInterface {
+	return &FakeDNSRecords{c, namespace}
+}
+
 func (c *FakeHiveV1) DNSZones(namespace string) v1.DNSZoneInterface {
 	return &FakeDNSZones{c, namespace}
 }
@@ -64,6 +72,10 @@ func (c *FakeHiveV1) MachinePoolNameLeases(namespace string) v1.MachinePoolNameL
 	return &FakeMachinePoolNameLeases{c, namespace}
 }
 
+func (c *FakeHiveV1) ResourceCollectors() v1.ResourceCollectorInterface {
+	return &FakeResourceCollectors{c}
+}
+
 func (c *FakeHiveV1) SelectorSyncIdentityProviders() v1.SelectorSyncIdentityProviderInterface {
 	return &FakeSelectorSyncIdentityProviders{c}
 }
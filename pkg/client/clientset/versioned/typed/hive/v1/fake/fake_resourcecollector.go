@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeResourceCollectors implements ResourceCollectorInterface
+type FakeResourceCollectors struct {
+	Fake *FakeHiveV1
+}
+
+var resourcecollectorsResource = schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "resourcecollectors"}
+
+var resourcecollectorsKind = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ResourceCollector"}
+
+// Get takes name of the resourceCollector, and returns the corresponding resourceCollector object, and an error if there is any.
+func (c *FakeResourceCollectors) Get(ctx context.Context, name string, options v1.GetOptions) (result *hivev1.ResourceCollector, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(resourcecollectorsResource, name), &hivev1.ResourceCollector{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ResourceCollector), err
+}
+
+// List takes label and field selectors, and returns the list of ResourceCollectors that match those selectors.
+func (c *FakeResourceCollectors) List(ctx context.Context, opts v1.ListOptions) (result *hivev1.ResourceCollectorList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(resourcecollectorsResource, resourcecollectorsKind, opts), &hivev1.ResourceCollectorList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &hivev1.ResourceCollectorList{ListMeta: obj.(*hivev1.ResourceCollectorList).ListMeta}
+	for _, item := range obj.(*hivev1.ResourceCollectorList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested resourceCollectors.
+func (c *FakeResourceCollectors) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(resourcecollectorsResource, opts))
+}
+
+// Create takes the representation of a resourceCollector and creates it.  Returns the server's representation of the resourceCollector, and an error, if there is any.
+func (c *FakeResourceCollectors) Create(ctx context.Context, resourceCollector *hivev1.ResourceCollector, opts v1.CreateOptions) (result *hivev1.ResourceCollector, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(resourcecollectorsResource, resourceCollector), &hivev1.ResourceCollector{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ResourceCollector), err
+}
+
+// Update takes the representation of a resourceCollector and updates it. Returns the server's representation of the resourceCollector, and an error, if there is any.
+func (c *FakeResourceCollectors) Update(ctx context.Context, resourceCollector *hivev1.ResourceCollector, opts v1.UpdateOptions) (result *hivev1.ResourceCollector, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(resourcecollectorsResource, resourceCollector), &hivev1.ResourceCollector{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ResourceCollector), err
+}
+
+// Delete takes name of the resourceCollector and deletes it. Returns an error if one occurs.
+func (c *FakeResourceCollectors) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(resourcecollectorsResource, name), &hivev1.ResourceCollector{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeResourceCollectors) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(resourcecollectorsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &hivev1.ResourceCollectorList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched resourceCollector.
+func (c *FakeResourceCollectors) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *hivev1.ResourceCollector, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(resourcecollectorsResource, name, pt, data, subresources...), &hivev1.ResourceCollector{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ResourceCollector), err
+}
@@ -20,6 +20,10 @@ type ClusterRelocateExpansion interface{}
 
 type ClusterStateExpansion interface{}
 
+type CollectedResourceSetExpansion interface{}
+
+type DNSRecordExpansion interface{}
+
 type DNSZoneExpansion interface{}
 
 type HiveConfigExpansion interface{}
@@ -28,6 +32,8 @@ type MachinePoolExpansion interface{}
 
 type MachinePoolNameLeaseExpansion interface{}
 
+type ResourceCollectorExpansion interface{}
+
 type SelectorSyncIdentityProviderExpansion interface{}
 
 type SelectorSyncSetExpansion interface{}
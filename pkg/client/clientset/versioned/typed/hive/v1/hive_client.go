@@ -19,10 +19,13 @@ type HiveV1Interface interface {
 	ClusterProvisionsGetter
 	ClusterRelocatesGetter
 	ClusterStatesGetter
+	CollectedResourceSetsGetter
+	DNSRecordsGetter
 	DNSZonesGetter
 	HiveConfigsGetter
 	MachinePoolsGetter
 	MachinePoolNameLeasesGetter
+	ResourceCollectorsGetter
 	SelectorSyncIdentityProvidersGetter
 	SelectorSyncSetsGetter
 	SyncIdentityProvidersGetter
@@ -70,6 +73,14 @@ func (c *HiveV1Client) ClusterStates(namespace string) ClusterStateInterface {
 	return newClusterStates(c, namespace)
 }
 
+func (c *HiveV1Client) CollectedResourceSets(namespace string) CollectedResourceSetInterface {
+	return newCollectedResourceSets(c, namespace)
+}
+
+func (c *HiveV1Client) DNSRecords(namespace string) DNSRecordInterface {
+	return newDNSRecords(c, namespace)
+}
+
 func (c *HiveV1Client) DNSZones(namespace string) DNSZoneInterface {
 	return newDNSZones(c, namespace)
 }
@@ -86,6 +97,10 @@ func (c *HiveV1Client) MachinePoolNameLeases(namespace string) MachinePoolNameLe
 	return newMachinePoolNameLeases(c, namespace)
 }
 
+func (c *HiveV1Client) ResourceCollectors() ResourceCollectorInterface {
+	return newResourceCollectors(c)
+}
+
 func (c *HiveV1Client) SelectorSyncIdentityProviders() SelectorSyncIdentityProviderInterface {
 	return newSelectorSyncIdentityProviders(c)
 }
@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	scheme "github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ResourceCollectorsGetter has a method to return a ResourceCollectorInterface.
+// A group's client should implement this interface.
+type ResourceCollectorsGetter interface {
+	ResourceCollectors() ResourceCollectorInterface
+}
+
+// ResourceCollectorInterface has methods to work with ResourceCollector resources.
+type ResourceCollectorInterface interface {
+	Create(ctx context.Context, resourceCollector *v1.ResourceCollector, opts metav1.CreateOptions) (*v1.ResourceCollector, error)
+	Update(ctx context.Context, resourceCollector *v1.ResourceCollector, opts metav1.UpdateOptions) (*v1.ResourceCollector, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ResourceCollector, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ResourceCollectorList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ResourceCollector, err error)
+	ResourceCollectorExpansion
+}
+
+// resourceCollectors implements ResourceCollectorInterface
+type resourceCollectors struct {
+	client rest.Interface
+}
+
+// newResourceCollectors returns a ResourceCollectors
+func newResourceCollectors(c *HiveV1Client) *resourceCollectors {
+	return &resourceCollectors{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the resourceCollector, and returns the corresponding resourceCollector object, and an error if there is any.
+func (c *resourceCollectors) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ResourceCollector, err error) {
+	result = &v1.ResourceCollector{}
+	err = c.client.Get().
+		Resource("resourcecollectors").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ResourceCollectors that match those selectors.
+func (c *resourceCollectors) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ResourceCollectorList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ResourceCollectorList{}
+	err = c.client.Get().
+		Resource("resourcecollectors").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested resourceCollectors.
+func (c *resourceCollectors) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("resourcecollectors").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a resourceCollector and creates it.  Returns the server's representation of the resourceCollector, and an error, if there is any.
+func (c *resourceCollectors) Create(ctx context.Context, resourceCollector *v1.ResourceCollector, opts metav1.CreateOptions) (result *v1.ResourceCollector, err error) {
+	result = &v1.ResourceCollector{}
+	err = c.client.Post().
+		Resource("resourcecollectors").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(resourceCollector).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a resourceCollector and updates it. Returns the server's representation of the resourceCollector, and an error, if there is any.
+func (c *resourceCollectors) Update(ctx context.Context, resourceCollector *v1.ResourceCollector, opts metav1.UpdateOptions) (result *v1.ResourceCollector, err error) {
+	result = &v1.ResourceCollector{}
+	err = c.client.Put().
+		Resource("resourcecollectors").
+		Name(resourceCollector.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(resourceCollector).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the resourceCollector and deletes it. Returns an error if one occurs.
+func (c *resourceCollectors) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("resourcecollectors").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *resourceCollectors) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("resourcecollectors").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched resourceCollector.
+func (c *resourceCollectors) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ResourceCollector, err error) {
+	result = &v1.ResourceCollector{}
+	err = c.client.Patch(pt).
+		Resource("resourcecollectors").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -56,6 +56,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterRelocates().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterstates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterStates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("collectedresourcesets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().CollectedResourceSets().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("dnsrecords"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().DNSRecords().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("dnszones"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().DNSZones().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("hiveconfigs"):
@@ -64,6 +68,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().MachinePools().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepoolnameleases"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().MachinePoolNameLeases().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("resourcecollectors"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ResourceCollectors().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("selectorsyncidentityproviders"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().SelectorSyncIdentityProviders().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("selectorsyncsets"):
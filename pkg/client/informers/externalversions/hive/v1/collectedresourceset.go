@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	versioned "github.com/openshift/hive/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/openshift/hive/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/openshift/hive/pkg/client/listers/hive/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// CollectedResourceSetInformer provides access to a shared informer and lister for
+// CollectedResourceSets.
+type CollectedResourceSetInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.CollectedResourceSetLister
+}
+
+type collectedResourceSetInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewCollectedResourceSetInformer constructs a new informer for CollectedResourceSet type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewCollectedResourceSetInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredCollectedResourceSetInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredCollectedResourceSetInformer constructs a new informer for CollectedResourceSet type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredCollectedResourceSetInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().CollectedResourceSets(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().CollectedResourceSets(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&hivev1.CollectedResourceSet{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *collectedResourceSetInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredCollectedResourceSetInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *collectedResourceSetInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&hivev1.CollectedResourceSet{}, f.defaultInformer)
+}
+
+func (f *collectedResourceSetInformer) Lister() v1.CollectedResourceSetLister {
+	return v1.NewCollectedResourceSetLister(f.Informer().GetIndexer())
+}
@@ -26,6 +26,10 @@ type Interface interface {
 	ClusterRelocates() ClusterRelocateInformer
 	// ClusterStates returns a ClusterStateInformer.
 	ClusterStates() ClusterStateInformer
+	// CollectedResourceSets returns a CollectedResourceSetInformer.
+	CollectedResourceSets() CollectedResourceSetInformer
+	// DNSRecords returns a DNSRecordInformer.
+	DNSRecords() DNSRecordInformer
 	// DNSZones returns a DNSZoneInformer.
 	DNSZones() DNSZoneInformer
 	// HiveConfigs returns a HiveConfigInformer.
@@ -34,6 +38,8 @@ type Interface interface {
 	MachinePools() MachinePoolInformer
 	// MachinePoolNameLeases returns a MachinePoolNameLeaseInformer.
 	MachinePoolNameLeases() MachinePoolNameLeaseInformer
+	// ResourceCollectors returns a ResourceCollectorInformer.
+	ResourceCollectors() ResourceCollectorInformer
 	// SelectorSyncIdentityProviders returns a SelectorSyncIdentityProviderInformer.
 	SelectorSyncIdentityProviders() SelectorSyncIdentityProviderInformer
 	// SelectorSyncSets returns a SelectorSyncSetInformer.
@@ -100,6 +106,16 @@ func (v *version) ClusterStates() ClusterStateInformer {
 	return &clusterStateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CollectedResourceSets returns a CollectedResourceSetInformer.
+func (v *version) CollectedResourceSets() CollectedResourceSetInformer {
+	return &collectedResourceSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DNSRecords returns a DNSRecordInformer.
+func (v *version) DNSRecords() DNSRecordInformer {
+	return &dNSRecordInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSZones returns a DNSZoneInformer.
 func (v *version) DNSZones() DNSZoneInformer {
 	return &dNSZoneInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -120,6 +136,11 @@ func (v *version) MachinePoolNameLeases() MachinePoolNameLeaseInformer {
 	return &machinePoolNameLeaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ResourceCollectors returns a ResourceCollectorInformer.
+func (v *version) ResourceCollectors() ResourceCollectorInformer {
+	return &resourceCollectorInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // SelectorSyncIdentityProviders returns a SelectorSyncIdentityProviderInformer.
 func (v *version) SelectorSyncIdentityProviders() SelectorSyncIdentityProviderInformer {
 	return &selectorSyncIdentityProviderInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	versioned "github.com/openshift/hive/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/openshift/hive/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/openshift/hive/pkg/client/listers/hive/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ResourceCollectorInformer provides access to a shared informer and lister for
+// ResourceCollectors.
+type ResourceCollectorInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ResourceCollectorLister
+}
+
+type resourceCollectorInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewResourceCollectorInformer constructs a new informer for ResourceCollector type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewResourceCollectorInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredResourceCollectorInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredResourceCollectorInformer constructs a new informer for ResourceCollector type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredResourceCollectorInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().ResourceCollectors().List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.HiveV1().ResourceCollectors().Watch(context.TODO(), options)
+			},
+		},
+		&hivev1.ResourceCollector{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *resourceCollectorInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredResourceCollectorInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *resourceCollectorInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&hivev1.ResourceCollector{}, f.defaultInformer)
+}
+
+func (f *resourceCollectorInformer) Lister() v1.ResourceCollectorLister {
+	return v1.NewResourceCollectorLister(f.Informer().GetIndexer())
+}
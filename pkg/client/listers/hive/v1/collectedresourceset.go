@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CollectedResourceSetLister helps list CollectedResourceSets.
+// All objects returned here must be treated as read-only.
+type CollectedResourceSetLister interface {
+	// List lists all CollectedResourceSets in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.CollectedResourceSet, err error)
+	// CollectedResourceSets returns an object that can list and get CollectedResourceSets.
+	CollectedResourceSets(namespace string) CollectedResourceSetNamespaceLister
+	CollectedResourceSetListerExpansion
+}
+
+// collectedResourceSetLister implements the CollectedResourceSetLister interface.
+type collectedResourceSetLister struct {
+	indexer cache.Indexer
+}
+
+// NewCollectedResourceSetLister returns a new CollectedResourceSetLister.
+func NewCollectedResourceSetLister(indexer cache.Indexer) CollectedResourceSetLister {
+	return &collectedResourceSetLister{indexer: indexer}
+}
+
+// List lists all CollectedResourceSets in the indexer.
+func (s *collectedResourceSetLister) List(selector labels.Selector) (ret []*v1.CollectedResourceSet, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.CollectedResourceSet))
+	})
+	return ret, err
+}
+
+// CollectedResourceSets returns an object that can list and get CollectedResourceSets.
+func (s *collectedResourceSetLister) CollectedResourceSets(namespace string) CollectedResourceSetNamespaceLister {
+	return collectedResourceSetNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// CollectedResourceSetNamespaceLister helps list and get CollectedResourceSets.
+// All objects returned here must be treated as read-only.
+type CollectedResourceSetNamespaceLister interface {
+	// List lists all CollectedResourceSets in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.CollectedResourceSet, err error)
+	// Get retrieves the CollectedResourceSet from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.CollectedResourceSet, error)
+	CollectedResourceSetNamespaceListerExpansion
+}
+
+// collectedResourceSetNamespaceLister implements the CollectedResourceSetNamespaceLister
+// interface.
+type collectedResourceSetNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all CollectedResourceSets in the indexer for a given namespace.
+func (s collectedResourceSetNamespaceLister) List(selector labels.Selector) (ret []*v1.CollectedResourceSet, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.CollectedResourceSet))
+	})
+	return ret, err
+}
+
+// Get retrieves the CollectedResourceSet from the indexer for a given namespace and name.
+func (s collectedResourceSetNamespaceLister) Get(name string) (*v1.CollectedResourceSet, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("collectedresourceset"), name)
+	}
+	return obj.(*v1.CollectedResourceSet), nil
+}
@@ -66,6 +66,22 @@ type ClusterStateListerExpansion interface{}
 // ClusterStateNamespaceLister.
 type ClusterStateNamespaceListerExpansion interface{}
 
+// CollectedResourceSetListerExpansion allows custom methods to be added to
+// CollectedResourceSetLister.
+type CollectedResourceSetListerExpansion interface{}
+
+// CollectedResourceSetNamespaceListerExpansion allows custom methods to be added to
+// CollectedResourceSetNamespaceLister.
+type CollectedResourceSetNamespaceListerExpansion interface{}
+
+// DNSRecordListerExpansion allows custom methods to be added to
+// DNSRecordLister.
+type DNSRecordListerExpansion interface{}
+
+// DNSRecordNamespaceListerExpansion allows custom methods to be added to
+// DNSRecordNamespaceLister.
+type DNSRecordNamespaceListerExpansion interface{}
+
 // DNSZoneListerExpansion allows custom methods to be added to
 // DNSZoneLister.
 type DNSZoneListerExpansion interface{}
@@ -94,6 +110,10 @@ type MachinePoolNameLeaseListerExpansion interface{}
 // MachinePoolNameLeaseNamespaceLister.
 type MachinePoolNameLeaseNamespaceListerExpansion interface{}
 
+// ResourceCollectorListerExpansion allows custom methods to be added to
+// ResourceCollectorLister.
+type ResourceCollectorListerExpansion interface{}
+
 // SelectorSyncIdentityProviderListerExpansion allows custom methods to be added to
 // SelectorSyncIdentityProviderLister.
 type SelectorSyncIdentityProviderListerExpansion interface{}
@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ResourceCollectorLister helps list ResourceCollectors.
+// All objects returned here must be treated as read-only.
+type ResourceCollectorLister interface {
+	// List lists all ResourceCollectors in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ResourceCollector, err error)
+	// Get retrieves the ResourceCollector from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ResourceCollector, error)
+	ResourceCollectorListerExpansion
+}
+
+// resourceCollectorLister implements the ResourceCollectorLister interface.
+type resourceCollectorLister struct {
+	indexer cache.Indexer
+}
+
+// NewResourceCollectorLister returns a new ResourceCollectorLister.
+func NewResourceCollectorLister(indexer cache.Indexer) ResourceCollectorLister {
+	return &resourceCollectorLister{indexer: indexer}
+}
+
+// List lists all ResourceCollectors in the indexer.
+func (s *resourceCollectorLister) List(selector labels.Selector) (ret []*v1.ResourceCollector, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ResourceCollector))
+	})
+	return ret, err
+}
+
+// Get retrieves the ResourceCollector from the index for a given name.
+func (s *resourceCollectorLister) Get(name string) (*v1.ResourceCollector, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("resourcecollector"), name)
+	}
+	return obj.(*v1.ResourceCollector), nil
+}
@@ -59,6 +59,9 @@ const (
 	// SelectorSyncSetNameLabel is the label that is used to identify a relationship to a given selector syncset object.
 	SelectorSyncSetNameLabel = "hive.openshift.io/selector-syncset-name"
 
+	// ResourceCollectorNameLabel is the label that is used to identify a relationship to a given resource collector object.
+	ResourceCollectorNameLabel = "hive.openshift.io/resource-collector-name"
+
 	// PVCTypeLabel is the label that is used to identify what a PVC is being used for.
 	PVCTypeLabel = "hive.openshift.io/pvc-type"
 
@@ -77,6 +80,12 @@ const (
 	// JobTypeProvision is used as a value of JobTypeLabel that says the Job is specifically running the provisioner.
 	JobTypeProvision = "provision"
 
+	// JobTypePreStopHook is used as a value of JobTypeLabel that says the Job is running the pre-stop hibernation hook.
+	JobTypePreStopHook = "prestop-hook"
+
+	// JobTypePostResumeHook is used as a value of JobTypeLabel that says the Job is running the post-resume hibernation hook.
+	JobTypePostResumeHook = "postresume-hook"
+
 	// DNSZoneTypeLabel is the label that is used to identify what a DNSZone is being used for.
 	DNSZoneTypeLabel = "hive.openshift.io/dnszone-type"
 
@@ -180,6 +189,15 @@ const (
 	// AzureCredentialsName is the name of the Azure credentials file or secret key.
 	AzureCredentialsName = "osServicePrincipal.json"
 
+	// TSIGKeyNameSecretKey is the key of the name of the TSIG key in secrets for RFC 2136 dynamic updates.
+	TSIGKeyNameSecretKey = "tsig-key-name"
+
+	// TSIGSecretSecretKey is the key of the base64 encoded TSIG secret in secrets for RFC 2136 dynamic updates.
+	TSIGSecretSecretKey = "tsig-secret"
+
+	// TSIGAlgorithmSecretKey is the key of the optional TSIG algorithm in secrets for RFC 2136 dynamic updates.
+	TSIGAlgorithmSecretKey = "tsig-algorithm"
+
 	// AzureCredentialsEnvVar is the name of the environment variable pointing to the location
 	// where Azure credentials can be found.
 	AzureCredentialsEnvVar = "AZURE_AUTH_LOCATION"
@@ -362,6 +380,10 @@ const (
 	// ArgoCDNamespaceEnvVar is the name of the environment variable used to specify the ArgoCD namespace
 	ArgoCDNamespaceEnvVar = "HIVE_ARGOCD_NAMESPACE"
 
+	// HibernationSavedReplicasAnnotation is set by the hibernation controller on the MachinePools it scales to zero
+	// when hibernating the workers of a cluster. It holds the replicas and autoscaling settings restored on resume.
+	HibernationSavedReplicasAnnotation = "hive.openshift.io/hibernation-saved-replicas"
+
 	// CreatedByHiveLabel is the label used for artifacts for external systems we integrate with
 	// that were created by Hive. The value for this label should be "true".
 	CreatedByHiveLabel = "hive.openshift.io/created-by"
@@ -43,10 +43,12 @@ import (
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/imageset"
+	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -128,6 +130,7 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		expectations:                            controllerutils.NewExpectations(logger),
 		watchingClusterInstall:                  map[string]struct{}{},
 		validateCredentialsForClusterDeployment: controllerutils.ValidateCredentialsForClusterDeployment,
+		awsClientBuilder:                        awsclient.New,
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
@@ -147,6 +150,12 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		logger.WithError(err).Error("Release Image verification failed to be configured")
 	}
 
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+	}
+	r.managedDomains = managedDomains
+
 	return r
 }
 
@@ -271,6 +280,15 @@ type ReconcileClusterDeployment struct {
 	releaseImageVerifier verify.Interface
 
 	protectedDelete bool
+
+	// managedDomains are the managed domains configured in HiveConfig. They are used to manage DNS with
+	// RFC 2136 dynamic updates for clusters on platforms without a cloud DNS service, and to sign the DNS
+	// zones of clusters with DNSSEC.
+	managedDomains []hivev1.ManageDNSConfig
+
+	// awsClientBuilder is a function pointer to the function that builds the AWS client used to find the VPCs
+	// of the private DNS zones of internal clusters
+	awsClientBuilder func(client.Client, awsclient.Options) (awsclient.Client, error)
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -1427,10 +1445,12 @@ func (r *ReconcileClusterDeployment) setDNSDelayMetric(cd *hivev1.ClusterDeploym
 }
 
 func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*hivev1.DNSZone, error) {
+	rfc2136Config := r.findRFC2136ManagedDomain(cd)
 	switch p := cd.Spec.Platform; {
 	case p.AWS != nil:
 	case p.GCP != nil:
 	case p.Azure != nil:
+	case rfc2136Config != nil:
 	default:
 		cdLog.Error("cluster deployment platform does not support managed DNS")
 		if err := r.setDNSNotReadyCondition(cd, corev1.ConditionTrue, dnsUnsupportedPlatformReason, "Managed DNS is not supported on specified platform", cdLog); err != nil {
@@ -1447,7 +1467,7 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	switch err := r.Get(context.TODO(), dnsZoneNamespacedName, dnsZone); {
 	case apierrors.IsNotFound(err):
 		logger.Info("creating new DNSZone for cluster deployment")
-		return nil, r.createManagedDNSZone(cd, logger)
+		return nil, r.createManagedDNSZone(cd, rfc2136Config, logger)
 	case err != nil:
 		logger.WithError(err).Error("failed to fetch DNS zone")
 		return nil, err
@@ -1515,7 +1535,17 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	return dnsZone, nil
 }
 
-func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+// findRFC2136ManagedDomain returns the RFC 2136 configuration of the managed domain of the base domain of the
+// cluster deployment, if any.
+func (r *ReconcileClusterDeployment) findRFC2136ManagedDomain(cd *hivev1.ClusterDeployment) *hivev1.ManageDNSRFC2136Config {
+	managedDomain := manageddns.FindManagedDomain(r.managedDomains, cd.Spec.BaseDomain)
+	if managedDomain == nil {
+		return nil
+	}
+	return managedDomain.RFC2136
+}
+
+func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDeployment, rfc2136Config *hivev1.ManageDNSRFC2136Config, logger log.FieldLogger) error {
 	dnsZone := &hivev1.DNSZone{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      controllerutils.DNSZoneName(cd.Name),
@@ -1527,6 +1557,8 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 		},
 	}
 
+	managedDomain := manageddns.FindManagedDomain(r.managedDomains, cd.Spec.BaseDomain)
+
 	switch {
 	case cd.Spec.Platform.AWS != nil:
 		additionalTags := make([]hivev1.AWSResourceTag, 0, len(cd.Spec.Platform.AWS.UserTags))
@@ -1543,15 +1575,32 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 			AdditionalTags:        additionalTags,
 			Region:                region,
 		}
+		// Sign the zone with DNSSEC when the managed domain asks for it, using its KMS key for the key signing key.
+		if managedDomain != nil && managedDomain.DNSSEC && managedDomain.AWS != nil {
+			dnsZone.Spec.DNSSEC = true
+			dnsZone.Spec.AWS.DNSSECKMSKeyARN = managedDomain.AWS.DNSSECKMSKeyARN
+		}
 	case cd.Spec.Platform.GCP != nil:
 		dnsZone.Spec.GCP = &hivev1.GCPDNSZoneSpec{
 			CredentialsSecretRef: cd.Spec.Platform.GCP.CredentialsSecretRef,
 		}
+		if managedDomain != nil && managedDomain.DNSSEC {
+			dnsZone.Spec.DNSSEC = true
+		}
 	case cd.Spec.Platform.Azure != nil:
 		dnsZone.Spec.Azure = &hivev1.AzureDNSZoneSpec{
 			CredentialsSecretRef: cd.Spec.Platform.Azure.CredentialsSecretRef,
 			ResourceGroupName:    cd.Spec.Platform.Azure.BaseDomainResourceGroupName,
 		}
+	case rfc2136Config != nil:
+		// The DNSZone controller uses the TSIG key of the managed domain, which stays in the namespace of Hive.
+		dnsZone.Spec.RFC2136 = &hivev1.RFC2136DNSZoneSpec{
+			Server: rfc2136Config.Server,
+		}
+	}
+
+	if err := r.configurePrivateDNSZone(cd, dnsZone, logger); err != nil {
+		return err
 	}
 
 	logger.WithField("derivedObject", dnsZone.Name).Debug("Setting labels on derived object")
@@ -1996,6 +2045,20 @@ func (r *ReconcileClusterDeployment) setSyncSetFailedCondition(cd *hivev1.Cluste
 		message,
 		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
+	// The SyncSetResourcesUnhealthy condition is only set once a syncset with health checks has applied to the cluster.
+	if status, reason, message, ok := syncSetResourcesHealth(clusterSync); ok ||
+		controllerutils.FindClusterDeploymentCondition(conds, hivev1.SyncSetResourcesUnhealthyCondition) != nil {
+		var healthChanged bool
+		conds, healthChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conds,
+			hivev1.SyncSetResourcesUnhealthyCondition,
+			status,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		changed = changed || healthChanged
+	}
 	if !changed {
 		return nil
 	}
@@ -2007,6 +2070,43 @@ func (r *ReconcileClusterDeployment) setSyncSetFailedCondition(cd *hivev1.Cluste
 	return nil
 }
 
+// syncSetResourcesHealth returns the status, reason and message of the SyncSetResourcesUnhealthy condition based on
+// the health of the resources of the syncsets in the ClusterSync. It returns false if none of the syncsets has had
+// the health of its resources assessed.
+func syncSetResourcesHealth(clusterSync *hiveintv1alpha1.ClusterSync) (corev1.ConditionStatus, string, string, bool) {
+	assessed := false
+	var degraded, progressing []string
+	for _, syncStatuses := range []struct {
+		kind     string
+		statuses []hiveintv1alpha1.SyncStatus
+	}{
+		{kind: "SyncSet", statuses: clusterSync.Status.SyncSets},
+		{kind: "SelectorSyncSet", statuses: clusterSync.Status.SelectorSyncSets},
+	} {
+		for _, syncStatus := range syncStatuses.statuses {
+			switch syncStatus.Health {
+			case "":
+				continue
+			case hiveintv1alpha1.DegradedResourceHealth:
+				degraded = append(degraded, fmt.Sprintf("%s %s", syncStatuses.kind, syncStatus.Name))
+			case hiveintv1alpha1.ProgressingResourceHealth:
+				progressing = append(progressing, fmt.Sprintf("%s %s", syncStatuses.kind, syncStatus.Name))
+			}
+			assessed = true
+		}
+	}
+	switch {
+	case len(degraded) > 0:
+		return corev1.ConditionTrue, "SyncSetResourcesDegraded",
+			fmt.Sprintf("Resources are degraded for %s", strings.Join(degraded, ", ")), true
+	case len(progressing) > 0:
+		return corev1.ConditionTrue, "SyncSetResourcesProgressing",
+			fmt.Sprintf("Resources are progressing for %s", strings.Join(progressing, ", ")), true
+	default:
+		return corev1.ConditionFalse, "SyncSetResourcesHealthy", "All SyncSet resources with health checks are healthy", assessed
+	}
+}
+
 // addOwnershipToSecret adds cluster deployment as an additional non-controlling owner to secret
 func (r *ReconcileClusterDeployment) addOwnershipToSecret(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger, name string) error {
 	cdLog = cdLog.WithField("secret", name)
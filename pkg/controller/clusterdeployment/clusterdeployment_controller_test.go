@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -39,8 +41,12 @@ import (
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/apis/hive/v1/baremetal"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
@@ -745,6 +751,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					cd.Spec.ManageDNS = true
 					return cd
 				}(),
+				testInstallConfigSecret(),
 				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
 				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
 			},
@@ -1277,6 +1284,61 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "SyncSetResourcesUnhealthyCondition should be set for degraded resources",
+			existing: []runtime.Object{
+				testClusterDeploymentWithInitializedConditions(testInstalledClusterDeployment(time.Now())),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", adminPassword),
+				&hiveintv1alpha1.ClusterSync{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      testName,
+					},
+					Status: hiveintv1alpha1.ClusterSyncStatus{
+						SyncSets: []hiveintv1alpha1.SyncStatus{
+							{Name: "healthy", Health: hiveintv1alpha1.HealthyResourceHealth},
+							{Name: "not-checked"},
+						},
+						SelectorSyncSets: []hiveintv1alpha1.SyncStatus{
+							{Name: "progressing", Health: hiveintv1alpha1.ProgressingResourceHealth},
+							{Name: "degraded", Health: hiveintv1alpha1.DegradedResourceHealth},
+						},
+					},
+				},
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.SyncSetResourcesUnhealthyCondition)
+				if assert.NotNil(t, cond, "missing SyncSetResourcesUnhealthyCondition status condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "did not get expected state for SyncSetResourcesUnhealthyCondition condition")
+					assert.Equal(t, "SyncSetResourcesDegraded", cond.Reason, "unexpected reason for SyncSetResourcesUnhealthyCondition condition")
+					assert.Equal(t, "Resources are degraded for SelectorSyncSet degraded", cond.Message, "unexpected message for SyncSetResourcesUnhealthyCondition condition")
+				}
+			},
+		},
+		{
+			name: "SyncSetResourcesUnhealthyCondition should not be set without health checks",
+			existing: []runtime.Object{
+				testClusterDeploymentWithInitializedConditions(testInstalledClusterDeployment(time.Now())),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", adminPassword),
+				&hiveintv1alpha1.ClusterSync{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      testName,
+					},
+					Status: hiveintv1alpha1.ClusterSyncStatus{
+						SyncSets: []hiveintv1alpha1.SyncStatus{{Name: "not-checked"}},
+					},
+				},
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.SyncSetResourcesUnhealthyCondition)
+				assert.Nil(t, cond, "unexpected SyncSetResourcesUnhealthyCondition status condition")
+			},
+		},
 		{
 			name: "SyncSet is Paused and ClusterSync object is missing",
 			existing: []runtime.Object{
@@ -3375,10 +3437,15 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 		name                         string
 		existingObjs                 []runtime.Object
 		existingEnvVars              []corev1.EnvVar
+		managedDomains               []hivev1.ManageDNSConfig
 		clusterDeployment            *hivev1.ClusterDeployment
 		expectedErr                  bool
 		expectedDNSZone              *hivev1.DNSZone
 		expectedDNSNotReadyCondition *hivev1.ClusterDeploymentCondition
+		expectedRFC2136DNSZoneSpec   *hivev1.RFC2136DNSZoneSpec
+		expectedAWSDNSSECKMSKeyARN   string
+		awsSubnetVPCs                map[string]string
+		expectedPrivateDNSZoneSpec   *hivev1.DNSZoneSpec
 	}{
 		{
 			name: "unsupported platform",
@@ -3399,6 +3466,171 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 				clusterDeploymentBase(),
 			),
 		},
+		{
+			name: "create zone for RFC 2136 managed domain",
+			existingObjs: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: "tsig-secret"},
+					Data: map[string][]byte{
+						constants.TSIGKeyNameSecretKey: []byte("hive."),
+						constants.TSIGSecretSecretKey:  []byte("c2VjcmV0"),
+					},
+				},
+			},
+			managedDomains: []hivev1.ManageDNSConfig{{
+				Domains: []string{"example.com"},
+				RFC2136: &hivev1.ManageDNSRFC2136Config{
+					Server:        "ns1.example.com",
+					TSIGSecretRef: corev1.LocalObjectReference{Name: "tsig-secret"},
+				},
+			}},
+			clusterDeployment: testclusterdeployment.Build(
+				testclusterdeployment.WithNamespace(testNamespace),
+				testclusterdeployment.WithName(testName),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.BaseDomain = "test.example.com"
+				},
+			),
+			expectedRFC2136DNSZoneSpec: &hivev1.RFC2136DNSZoneSpec{
+				Server: "ns1.example.com",
+			},
+		},
+		{
+			name: "create zone with DNSSEC for managed domain",
+			managedDomains: []hivev1.ManageDNSConfig{{
+				Domains: []string{"example.com"},
+				AWS: &hivev1.ManageDNSAWSConfig{
+					DNSSECKMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/test-key",
+				},
+				DNSSEC: true,
+			}},
+			clusterDeployment: testclusterdeployment.Build(
+				clusterDeploymentBase(),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.BaseDomain = "test.example.com"
+				},
+			),
+			expectedAWSDNSSECKMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/test-key",
+		},
+		{
+			name: "create private zone for internal AWS cluster",
+			existingObjs: []runtime.Object{
+				testPrivateInstallConfigSecret(`
+platform:
+  aws:
+    region: us-east-1
+    subnets:
+    - subnet-a
+    - subnet-b
+    - subnet-c
+`),
+			},
+			managedDomains: []hivev1.ManageDNSConfig{{
+				Domains: []string{"example.com"},
+				AWS: &hivev1.ManageDNSAWSConfig{
+					DNSSECKMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/test-key",
+				},
+				DNSSEC: true,
+			}},
+			clusterDeployment: testclusterdeployment.Build(
+				clusterDeploymentBase(),
+				withInstallConfigSecret(),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.BaseDomain = "test.example.com"
+					cd.Spec.Platform.AWS.Region = "us-east-1"
+				},
+			),
+			awsSubnetVPCs: map[string]string{"subnet-a": "vpc-2", "subnet-b": "vpc-1", "subnet-c": "vpc-2"},
+			expectedPrivateDNSZoneSpec: &hivev1.DNSZoneSpec{
+				Zone:    "test.example.com",
+				Private: true,
+				AWS: &hivev1.AWSDNSZoneSpec{
+					VPCs: []hivev1.AWSDNSZoneVPC{
+						{VPCID: "vpc-1", Region: "us-east-1"},
+						{VPCID: "vpc-2", Region: "us-east-1"},
+					},
+				},
+			},
+		},
+		{
+			name: "create private zone for internal GCP cluster",
+			existingObjs: []runtime.Object{
+				testPrivateInstallConfigSecret(`
+platform:
+  gcp:
+    projectID: test-project
+    region: us-east1
+    network: test-network
+`),
+			},
+			clusterDeployment: testclusterdeployment.Build(
+				testclusterdeployment.WithNamespace(testNamespace),
+				testclusterdeployment.WithName(testName),
+				testclusterdeployment.WithGCPPlatform(&hivev1gcp.Platform{Region: "us-east1"}),
+				withInstallConfigSecret(),
+			),
+			expectedPrivateDNSZoneSpec: &hivev1.DNSZoneSpec{
+				Private: true,
+				GCP: &hivev1.GCPDNSZoneSpec{
+					Networks: []string{"https://www.googleapis.com/compute/v1/projects/test-project/global/networks/test-network"},
+				},
+			},
+		},
+		{
+			name: "create private zone for internal Azure cluster",
+			existingObjs: []runtime.Object{
+				testPrivateInstallConfigSecret(`
+platform:
+  azure:
+    region: eastus
+    networkResourceGroupName: network-rg
+    virtualNetwork: test-vnet
+`),
+			},
+			clusterDeployment: testclusterdeployment.Build(
+				testclusterdeployment.WithNamespace(testNamespace),
+				testclusterdeployment.WithName(testName),
+				testclusterdeployment.WithAzurePlatform(&hivev1azure.Platform{Region: "eastus", BaseDomainResourceGroupName: "dns-rg"}),
+				withInstallConfigSecret(),
+			),
+			expectedPrivateDNSZoneSpec: &hivev1.DNSZoneSpec{
+				Private: true,
+				Azure: &hivev1.AzureDNSZoneSpec{
+					ResourceGroupName: "dns-rg",
+					VirtualNetworks:   []hivev1.AzureDNSZoneVirtualNetwork{{ResourceGroupName: "network-rg", Name: "test-vnet"}},
+				},
+			},
+		},
+		{
+			name: "internal AWS cluster without subnets",
+			existingObjs: []runtime.Object{
+				testPrivateInstallConfigSecret(`
+platform:
+  aws:
+    region: us-east-1
+`),
+			},
+			clusterDeployment: testclusterdeployment.Build(
+				clusterDeploymentBase(),
+				withInstallConfigSecret(),
+			),
+			expectedErr: true,
+			expectedDNSNotReadyCondition: &hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.DNSNotReadyCondition,
+				Status: corev1.ConditionTrue,
+				Reason: dnsPrivateZoneNetworksNotFoundReason,
+			},
+		},
+		{
+			name: "create public zone for external cluster",
+			existingObjs: []runtime.Object{
+				testInstallConfigSecret(),
+			},
+			clusterDeployment: testclusterdeployment.Build(
+				clusterDeploymentBase(),
+				withInstallConfigSecret(),
+			),
+		},
 		{
 			name: "zone already exists and is owned by clusterdeployment",
 			existingObjs: []runtime.Object{
@@ -3476,11 +3708,29 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
 			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			if test.awsSubnetVPCs != nil {
+				mockAWSClient.EXPECT().DescribeSubnets(gomock.Any()).DoAndReturn(
+					func(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+						output := &ec2.DescribeSubnetsOutput{}
+						for _, subnetID := range input.SubnetIds {
+							output.Subnets = append(output.Subnets, &ec2.Subnet{
+								SubnetId: subnetID,
+								VpcId:    aws.String(test.awsSubnetVPCs[*subnetID]),
+							})
+						}
+						return output, nil
+					})
+			}
 			rcd := &ReconcileClusterDeployment{
 				Client:                        fakeClient,
 				scheme:                        scheme.Scheme,
 				logger:                        log.WithField("controller", "clusterDeployment"),
 				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				managedDomains:                test.managedDomains,
+				awsClientBuilder: func(client.Client, awsclient.Options) (awsclient.Client, error) {
+					return mockAWSClient, nil
+				},
 			}
 
 			// act
@@ -3502,6 +3752,37 @@ func TestEnsureManagedDNSZone(t *testing.T) {
 				actualDNSNotReadyCondition.Message = ""                       // zero out so it won't be checked.
 			}
 			assert.Equal(t, test.expectedDNSNotReadyCondition, actualDNSNotReadyCondition, "Expected DNSZone DNSNotReady condition doesn't match returned condition")
+
+			if test.expectedAWSDNSSECKMSKeyARN != "" {
+				createdDNSZone := &hivev1.DNSZone{}
+				err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: controllerutils.DNSZoneName(testName)}, createdDNSZone)
+				if assert.NoError(t, err, "unexpected error getting created DNSZone") {
+					assert.True(t, createdDNSZone.Spec.DNSSEC, "expected DNSSEC to be enabled for created DNSZone")
+					assert.Equal(t, test.expectedAWSDNSSECKMSKeyARN, createdDNSZone.Spec.AWS.DNSSECKMSKeyARN, "unexpected KMS key of created DNSZone")
+				}
+			}
+
+			if test.expectedPrivateDNSZoneSpec != nil {
+				createdDNSZone := &hivev1.DNSZone{}
+				err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: controllerutils.DNSZoneName(testName)}, createdDNSZone)
+				if assert.NoError(t, err, "unexpected error getting created DNSZone") {
+					assert.Equal(t, *test.expectedPrivateDNSZoneSpec, createdDNSZone.Spec, "unexpected spec of created private DNSZone")
+				}
+			}
+
+			if test.expectedRFC2136DNSZoneSpec != nil {
+				createdDNSZone := &hivev1.DNSZone{}
+				err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: controllerutils.DNSZoneName(testName)}, createdDNSZone)
+				if assert.NoError(t, err, "unexpected error getting created DNSZone") {
+					assert.Equal(t, test.expectedRFC2136DNSZoneSpec, createdDNSZone.Spec.RFC2136, "unexpected RFC 2136 spec of created DNSZone")
+				}
+				secrets := &corev1.SecretList{}
+				if assert.NoError(t, fakeClient.List(context.TODO(), secrets, client.InNamespace(testNamespace))) {
+					for _, secret := range secrets.Items {
+						assert.NotContains(t, secret.Data, constants.TSIGSecretSecretKey, "unexpected TSIG key in namespace of cluster deployment")
+					}
+				}
+			}
 		})
 	}
 }
@@ -3550,6 +3831,25 @@ func testCompletedFailedImageSetJob() *batchv1.Job {
 	}
 }
 
+func testPrivateInstallConfigSecret(platform string) *corev1.Secret {
+	secret := testInstallConfigSecret()
+	secret.Data["install-config.yaml"] = []byte(`apiVersion: v1
+metadata:
+  name: testcluster
+baseDomain: example.com
+publish: Internal
+` + platform)
+	return secret
+}
+
+func withInstallConfigSecret() testclusterdeployment.Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Provisioning = &hivev1.Provisioning{
+			InstallConfigSecretRef: &corev1.LocalObjectReference{Name: installConfigSecretName},
+		}
+	}
+}
+
 func dnsZoneBase() testdnszone.Option {
 	return func(dnsZone *hivev1.DNSZone) {
 		dnsZone.Name = controllerutils.DNSZoneName(testName)
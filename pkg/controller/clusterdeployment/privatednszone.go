@@ -0,0 +1,156 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	installConfigSecretKey = "install-config.yaml"
+
+	dnsPrivateZoneNetworksNotFoundReason = "DNSPrivateZoneNetworksNotFound"
+)
+
+// loadInstallConfig returns the install config of the cluster deployment, or nil if the cluster deployment is not
+// provisioned from an install config.
+func (r *ReconcileClusterDeployment) loadInstallConfig(cd *hivev1.ClusterDeployment) (*installertypes.InstallConfig, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return nil, nil
+	}
+	icSecret := &corev1.Secret{}
+	if err := r.Get(
+		context.TODO(),
+		types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name},
+		icSecret,
+	); err != nil {
+		return nil, errors.Wrap(err, "could not get install config secret")
+	}
+	ic := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(icSecret.Data[installConfigSecretKey], ic); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal install config")
+	}
+	return ic, nil
+}
+
+// configurePrivateDNSZone makes the DNS zone of a cluster that is published internally only a private zone associated
+// with the networks of the cluster, so that the hostnames of the cluster are not resolvable publicly. The networks are
+// those of the existing VPC or VNet the cluster is installed into, since the installer requires existing networks for
+// internal clusters.
+func (r *ReconcileClusterDeployment) configurePrivateDNSZone(cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	ic, err := r.loadInstallConfig(cd)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not load install config")
+		return err
+	}
+	if ic == nil || ic.Publish != installertypes.InternalPublishingStrategy {
+		return nil
+	}
+
+	logger.Info("cluster is published internally, using a private DNS zone")
+	// Private zones are not delegated to from the parent domain, and cannot be signed.
+	dnsZone.Spec.Private = true
+	dnsZone.Spec.LinkToParentDomain = false
+	dnsZone.Spec.DNSSEC = false
+
+	var message string
+	switch {
+	case dnsZone.Spec.AWS != nil:
+		dnsZone.Spec.AWS.DNSSECKMSKeyARN = ""
+		if ic.Platform.AWS == nil || len(ic.Platform.AWS.Subnets) == 0 {
+			message = "Private DNS zones require the subnets of the cluster in the install config"
+			break
+		}
+		vpcs, err := r.getAWSSubnetVPCs(cd, ic.Platform.AWS.Subnets)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get VPCs of cluster subnets")
+			return err
+		}
+		dnsZone.Spec.AWS.VPCs = vpcs
+	case dnsZone.Spec.GCP != nil:
+		if ic.Platform.GCP == nil || ic.Platform.GCP.Network == "" {
+			message = "Private DNS zones require the network of the cluster in the install config"
+			break
+		}
+		dnsZone.Spec.GCP.Networks = []string{
+			fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/global/networks/%s", ic.Platform.GCP.ProjectID, ic.Platform.GCP.Network),
+		}
+	case dnsZone.Spec.Azure != nil:
+		if ic.Platform.Azure == nil || ic.Platform.Azure.VirtualNetwork == "" {
+			message = "Private DNS zones require the virtual network of the cluster in the install config"
+			break
+		}
+		dnsZone.Spec.Azure.VirtualNetworks = []hivev1.AzureDNSZoneVirtualNetwork{{
+			ResourceGroupName: ic.Platform.Azure.NetworkResourceGroupName,
+			Name:              ic.Platform.Azure.VirtualNetwork,
+		}}
+	default:
+		message = "Private DNS zones are not supported on specified platform"
+	}
+	if message == "" {
+		return nil
+	}
+
+	logger.Error(message)
+	if err := r.setDNSNotReadyCondition(cd, corev1.ConditionTrue, dnsPrivateZoneNetworksNotFoundReason, message, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update DNSNotReadyCondition")
+		return err
+	}
+	return errors.New(message)
+}
+
+// getAWSSubnetVPCs returns the VPCs of the subnets in the region of the cluster deployment.
+func (r *ReconcileClusterDeployment) getAWSSubnetVPCs(cd *hivev1.ClusterDeployment, subnets []string) ([]hivev1.AWSDNSZoneVPC, error) {
+	awsClient, err := r.awsClientBuilder(r.Client, awsclient.Options{
+		Region: cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.CredentialsSource{
+			Secret: &awsclient.SecretCredentialsSource{
+				Ref:       &cd.Spec.Platform.AWS.CredentialsSecretRef,
+				Namespace: cd.Namespace,
+			},
+			AssumeRole: &awsclient.AssumeRoleCredentialsSource{
+				SecretRef: corev1.SecretReference{
+					Namespace: controllerutils.GetHiveNamespace(),
+					Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
+				},
+				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS client")
+	}
+	resp, err := awsClient.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(subnets)})
+	if err != nil {
+		return nil, err
+	}
+	vpcIDs := sets.NewString()
+	for _, subnet := range resp.Subnets {
+		vpcIDs.Insert(aws.StringValue(subnet.VpcId))
+	}
+	if vpcIDs.Len() == 0 {
+		return nil, errors.New("no VPCs found for the subnets of the cluster")
+	}
+	vpcs := make([]hivev1.AWSDNSZoneVPC, 0, vpcIDs.Len())
+	for _, vpcID := range vpcIDs.List() {
+		vpcs = append(vpcs, hivev1.AWSDNSZoneVPC{VPCID: vpcID, Region: cd.Spec.Platform.AWS.Region})
+	}
+	return vpcs, nil
+}
@@ -0,0 +1,62 @@
+package clustersync
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// isAuditOnly returns true if the resources of the syncset are only compared with the resources in the target
+// cluster, rather than applied.
+func isAuditOnly(syncSet CommonSyncSet) bool {
+	return syncSet.GetSpec().ApplyBehavior == hivev1.AuditSyncSetApplyBehavior
+}
+
+// auditSyncSet compares the resources of the syncset with the resources in the target cluster without changing
+// anything in the target cluster, and returns the resources that have drifted. Secrets and patches are not audited.
+func (r *ReconcileClusterSync) auditSyncSet(
+	syncSet CommonSyncSet,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (
+	driftedResources []hiveintv1alpha1.SyncResourceDrift,
+	requeue bool,
+	returnErr error,
+) {
+	resources, referencesToResources, err := decodeResources(syncSet, logger)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, u := range resources {
+		ref := referencesToResources[i]
+		logger := logger.WithField("resourceIndex", i).
+			WithField("resourceNamespace", ref.Namespace).
+			WithField("resourceName", ref.Name).
+			WithField("resourceAPIVersion", ref.APIVersion).
+			WithField("resourceKind", ref.Kind)
+		bytes, err := json.Marshal(u)
+		if err != nil {
+			logger.WithError(err).Error("error marshalling unstructured object to json bytes")
+			return nil, false, errors.Wrapf(err, "failed to audit resource %d", i)
+		}
+		diff, err := resourceHelper.Diff(bytes)
+		if err != nil {
+			logger.WithError(err).Warn("error auditing resource")
+			return nil, true, errors.Wrapf(err, "failed to audit resource %d", i)
+		}
+		if diff != "" {
+			logger.WithField("diff", diff).Debug("resource has drifted")
+			driftedResources = append(driftedResources, hiveintv1alpha1.SyncResourceDrift{
+				SyncResourceReference: ref,
+				Diff:                  diff,
+			})
+		}
+	}
+	logger.WithField("driftedResources", len(driftedResources)).Info("syncset audited")
+	return driftedResources, false, nil
+}
@@ -2,8 +2,8 @@ package clustersync
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
-	"math/big"
 	"math/rand"
 	"os"
 	"reflect"
@@ -22,16 +22,20 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/yaml"
@@ -55,6 +59,7 @@ const (
 	labelApply             = "apply"
 	labelCreateOrUpdate    = "createOrUpdate"
 	labelCreateOnly        = "createOnly"
+	labelServerSideApply   = "serverSideApply"
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
 	stsName                = "hive-clustersync"
@@ -101,6 +106,21 @@ var (
 			Buckets: []float64{60, 300, 600, 1200, 1800, 2400, 3000, 3600},
 		},
 	)
+
+	metricAssignedClusters = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hive_clustersync_assigned_clusters",
+			Help: "Number of clusters assigned to a replica of the clustersync StatefulSet, labeled by replica ordinal.",
+		},
+		[]string{"replica"},
+	)
+
+	metricHandoffs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clustersync_handoffs_total",
+		Help: "Counter incremented each time a replica of the clustersync StatefulSet takes over a cluster from another replica, labeled by replica ordinal.",
+	},
+		[]string{"replica"},
+	)
 )
 
 func init() {
@@ -109,6 +129,8 @@ func init() {
 	metrics.Registry.MustRegister(metricResourcesApplied)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSetResource)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSets)
+	metrics.Registry.MustRegister(metricAssignedClusters)
+	metrics.Registry.MustRegister(metricHandoffs)
 }
 
 // Add creates a new clustersync Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -142,6 +164,7 @@ func Add(mgr manager.Manager) error {
 	}
 
 	r.ordinalID = int64(ordinalID32)
+	r.podName = podname
 	logger.WithField("ordinalID", r.ordinalID).Debug("ordinalID set")
 
 	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
@@ -160,6 +183,8 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 		}
 	}
 	log.WithField("reapplyInterval", reapplyInterval).Info("Reapply interval set")
+	replicaWeights := loadReplicaWeights(os.Getenv(replicaWeightsEnvKey), logger)
+	log.WithField("replicaWeights", replicaWeights).Info("Replica weights set")
 	c := controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter)
 	return &ReconcileClusterSync{
 		Client:                c,
@@ -169,6 +194,11 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 		remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 			return remoteclient.NewBuilder(c, cd, ControllerName)
 		},
+		kustomizeCache:   newKustomizeCache(),
+		configMapCache:   newConfigMapCache(),
+		configMapReader:  mgr.GetAPIReader(),
+		replicaWeights:   replicaWeights,
+		assignedClusters: newAssignedClusters(),
 	}, nil
 }
 
@@ -206,13 +236,50 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 	// Watch for changes to SelectorSyncSets
 	if err := c.Watch(
 		&source.Kind{Type: &hivev1.SelectorSyncSet{}},
-		handler.EnqueueRequestsFromMapFunc(requestsForSelectorSyncSet(r.Client, r.logger))); err != nil {
+		handler.EnqueueRequestsFromMapFunc(requestsForSelectorSyncSet(r.Client, r.logger)),
+		predicate.Funcs{UpdateFunc: selectorSyncSetUpdateNeedsSync}); err != nil {
+		return err
+	}
+
+	// Watch for changes to the ConfigMaps holding kustomization trees. Only the metadata of the ConfigMaps is watched,
+	// since their content is read when the syncsets referencing them are applied. The resource version in the
+	// watched metadata tells when the content cached for a ConfigMap must be read again.
+	configMapMetadata := &metav1.PartialObjectMetadata{}
+	configMapMetadata.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	if err := c.Watch(
+		&source.Kind{Type: configMapMetadata},
+		handler.EnqueueRequestsFromMapFunc(requestsForKustomizeConfigMap(r.Client, r.logger))); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// selectorSyncSetUpdateNeedsSync returns false for updates of SelectorSyncSets that only change the progress of their
+// rollout, which happens as each cluster applies a new generation, so that those updates do not cause every matching
+// cluster to be synced.
+func selectorSyncSetUpdateNeedsSync(e event.UpdateEvent) bool {
+	oldSSS, ok := e.ObjectOld.(*hivev1.SelectorSyncSet)
+	if !ok {
+		return true
+	}
+	newSSS, ok := e.ObjectNew.(*hivev1.SelectorSyncSet)
+	if !ok {
+		return true
+	}
+	if oldSSS.Generation != newSSS.Generation ||
+		!reflect.DeepEqual(oldSSS.Labels, newSSS.Labels) ||
+		!reflect.DeepEqual(oldSSS.Annotations, newSSS.Annotations) ||
+		!reflect.DeepEqual(oldSSS.DeletionTimestamp, newSSS.DeletionTimestamp) {
+		return true
+	}
+	oldRollout, newRollout := oldSSS.Status.Rollout, newSSS.Status.Rollout
+	if oldRollout == nil || newRollout == nil {
+		return oldRollout != newRollout
+	}
+	return oldRollout.Generation != newRollout.Generation || oldRollout.AdmittedPercent != newRollout.AdmittedPercent
+}
+
 func requestsForSyncSet(o client.Object) []reconcile.Request {
 	ss, ok := o.(*hivev1.SyncSet)
 	if !ok {
@@ -266,7 +333,27 @@ type ReconcileClusterSync struct {
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 
+	// kustomizeCache caches the resources built from the kustomization trees of syncsets
+	kustomizeCache *cache.LRUExpireCache
+
+	// configMapCache caches the content of the ConfigMaps holding the kustomization trees of syncsets
+	configMapCache *cache.LRUExpireCache
+
+	// configMapReader reads the ConfigMaps holding the kustomization trees of syncsets. It reads from the API server
+	// rather than from the cache of the manager, so that the ConfigMaps of the whole cluster are not cached. Only the
+	// metadata of the ConfigMaps is cached, and the content is read again when their resource version changes.
+	configMapReader client.Reader
+
 	ordinalID int64
+
+	// podName is the name of the pod of this replica, recorded as the holder of the leases of the clusters it syncs
+	podName string
+
+	// replicaWeights are the weights of the replicas of the StatefulSet, keyed by ordinal
+	replicaWeights map[int64]int64
+
+	// assignedClusters tracks the clusters assigned to this replica
+	assignedClusters *assignedClusters
 }
 
 func (r *ReconcileClusterSync) getAndCheckClusterSyncStatefulSet(logger log.FieldLogger) (*appsv1.StatefulSet, error) {
@@ -297,24 +384,11 @@ func (r *ReconcileClusterSync) getAndCheckClusterSyncStatefulSet(logger log.Fiel
 
 // isSyncAssignedToMe determines if this instance of the controller is assigned to the resource being sync'd
 func (r *ReconcileClusterSync) isSyncAssignedToMe(sts *appsv1.StatefulSet, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
-	logger.Debug("Getting uid for hashing")
-	var uidAsBigInt big.Int
-
-	// There are a couple of assumptions here:
-	// * the clusterdeployment uid has 4 sections separated by hyphens
-	// * the 4 sections are hex numbers
-	// These assumptions are based on the fact that Kubernetes says UIDs are actually
-	// ISO/IEC 9834-8 UUIDs. If this changes, this code may fail and may need to be updated.
-	// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#uids
-	hexUID := strings.Replace(string(cd.UID), "-", "", 4)
-	logger.Debugf("hexUID: %+v", hexUID)
-	uidAsBigInt.SetString(hexUID, 16)
-
 	logger.Debug("calculating replicas")
 	replicas := int64(*sts.Spec.Replicas)
 
 	logger.Debug("determining who is assigned to sync this cluster")
-	ordinalIDOfAssignee := uidAsBigInt.Mod(&uidAsBigInt, big.NewInt(replicas)).Int64()
+	ordinalIDOfAssignee := assignedReplica(cd.UID, replicas, r.replicaWeights)
 	assignedToMe := ordinalIDOfAssignee == r.ordinalID
 
 	logger.WithFields(log.Fields{
@@ -324,6 +398,8 @@ func (r *ReconcileClusterSync) isSyncAssignedToMe(sts *appsv1.StatefulSet, cd *h
 		"assignedToMe":        assignedToMe,
 	}).Debug("computed values")
 
+	r.recordAssignment(types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, assignedToMe)
+
 	return assignedToMe, nil
 }
 
@@ -343,6 +419,7 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("ClusterDeployment not found")
+			r.recordAssignment(request.NamespacedName, false)
 			return reconcile.Result{}, nil
 		}
 		log.WithError(err).Error("failed to get ClusterDeployment")
@@ -443,10 +520,20 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	// The results of all of the syncsets that apply to the cluster, so that syncsets can wait for their dependencies
+	results := newSyncSetResults()
+	results.add("SyncSet", syncSets, clusterSync.Status.SyncSets)
+	results.add("SelectorSyncSet", selectorSyncSets, clusterSync.Status.SelectorSyncSets)
+	// The syncsets that can never be applied because of their dependencies, so that they fail rather than wait
+	failures := findDependencyFailures(syncSets, selectorSyncSets)
+
 	needToDoFullReapply := needToCreateClusterSync || r.timeUntilFullReapply(lease) <= 0
 	if needToDoFullReapply {
 		logger.Info("need to reapply all syncsets")
 	}
+	// Taking over the cluster from another replica does not require a full reapply, since the time of the last full
+	// reapply is recorded in the lease.
+	handoff := r.takeLease(lease, logger)
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
 	// Apply SyncSets
@@ -457,6 +544,8 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		clusterSync.Status.SyncSets,
 		needToDoFullReapply,
 		false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
+		results,
+		failures,
 		resourceHelper,
 		logger,
 	)
@@ -470,6 +559,8 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		clusterSync.Status.SelectorSyncSets,
 		needToDoFullReapply,
 		clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
+		results,
+		failures,
 		resourceHelper,
 		logger,
 	)
@@ -492,9 +583,11 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
-	if needToDoFullReapply {
-		logger.Info("setting last full apply time")
-		lease.Spec.RenewTime = metav1.NowMicro()
+	if needToDoFullReapply || handoff {
+		if needToDoFullReapply {
+			logger.Info("setting last full apply time")
+			lease.Spec.RenewTime = metav1.NowMicro()
+		}
 		if needToCreateLease {
 			logger.Info("creating lease for ClusterSync")
 			lease.Namespace = cd.Namespace
@@ -516,6 +609,9 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilFullReapply(lease)}
+	if needsHealthCheck(syncStatuses) && result.RequeueAfter > healthCheckInterval {
+		result.RequeueAfter = healthCheckInterval
+	}
 	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
 		result.RequeueAfter = 0
 	}
@@ -529,6 +625,8 @@ func (r *ReconcileClusterSync) applySyncSets(
 	syncStatuses []hiveintv1alpha1.SyncStatus,
 	needToDoFullReapply bool,
 	reportSelectorSyncSetMetrics bool,
+	results syncSetResults,
+	failures dependencyFailures,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
 ) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool) {
@@ -537,6 +635,8 @@ func (r *ReconcileClusterSync) applySyncSets(
 	sort.Slice(syncSets, func(i, j int) bool {
 		return syncSets[i].AsMetaObject().GetName() < syncSets[j].AsMetaObject().GetName()
 	})
+	// Apply the syncsets after the syncsets they depend on.
+	syncSets = orderByDependencies(syncSetType, syncSets)
 
 	for _, syncSet := range syncSets {
 		logger := logger.WithField(syncSetType, syncSet.AsMetaObject().GetName())
@@ -549,6 +649,57 @@ func (r *ReconcileClusterSync) applySyncSets(
 			syncStatuses = syncStatuses[:last]
 		}
 
+		// Only apply the latest generation of a SelectorSyncSet once the cluster is in its rollout
+		if sss, ok := syncSet.(*SelectorSyncSetAsCommon); ok && !controllerutils.IsAdmittedToRollout((*hivev1.SelectorSyncSet)(sss), cd) {
+			if indexOfOldStatus >= 0 {
+				logger.Debug("skipping apply of selectorsyncset since the cluster is not in the rollout of its latest generation")
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+				continue
+			}
+			logger.Debug("waiting for the cluster to be in the rollout of the selectorsyncset")
+			newSyncStatus := hiveintv1alpha1.SyncStatus{
+				Name:               syncSet.AsMetaObject().GetName(),
+				Result:             hiveintv1alpha1.WaitingSyncSetResult,
+				FailureMessage:     fmt.Sprintf("waiting for rollout of generation %d", syncSet.AsMetaObject().GetGeneration()),
+				LastTransitionTime: metav1.Now(),
+			}
+			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+			results[syncSetType][newSyncStatus.Name] = newSyncStatus.Result
+			continue
+		}
+
+		// Build the kustomization of the syncset, and render the templates of the syncset for the cluster
+		renderedSyncSet, renderedHash, err := r.buildKustomization(syncSet, logger)
+		if err != nil {
+			logger.WithError(err).Warn("failed to build syncset kustomization")
+			err = errors.Wrap(err, "failed to build kustomization")
+			requeue = true
+		} else if templatedSyncSet, templatedHash, renderErr := renderSyncSet(renderedSyncSet, cd); renderErr != nil {
+			logger.WithError(renderErr).Warn("failed to render syncset templates")
+			err = errors.Wrap(renderErr, "failed to render templates")
+		} else if isTemplated(syncSet) {
+			renderedSyncSet, renderedHash = templatedSyncSet, templatedHash
+		}
+		if err != nil {
+			// The resources of the syncset are unknown, so keep the resources to delete from the last apply.
+			newSyncStatus := hiveintv1alpha1.SyncStatus{
+				Name:               syncSet.AsMetaObject().GetName(),
+				ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+				RenderedHash:       oldSyncStatus.RenderedHash,
+				ResourcesToDelete:  oldSyncStatus.ResourcesToDelete,
+				Result:             hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage:     err.Error(),
+				LastTransitionTime: oldSyncStatus.LastTransitionTime,
+				FirstSuccessTime:   oldSyncStatus.FirstSuccessTime,
+			}
+			if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+				newSyncStatus.LastTransitionTime = metav1.Now()
+			}
+			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+			results[syncSetType][newSyncStatus.Name] = newSyncStatus.Result
+			continue
+		}
+
 		// Determine if the syncset needs to be applied
 		switch {
 		case needToDoFullReapply:
@@ -556,24 +707,91 @@ func (r *ReconcileClusterSync) applySyncSets(
 		case indexOfOldStatus < 0:
 			logger.Debug("applying syncset because the syncset is new")
 		case oldSyncStatus.Result != hiveintv1alpha1.SuccessSyncSetResult:
-			logger.Debug("applying syncset because the last attempt to apply failed")
+			logger.Debug("applying syncset because the last attempt to apply did not succeed")
 		case oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration():
 			logger.Debug("applying syncset because the syncset generation has changed")
+		case oldSyncStatus.RenderedHash != renderedHash:
+			logger.Debug("applying syncset because the rendered templates or kustomization have changed")
 		default:
 			logger.Debug("skipping apply of syncset since it is up-to-date and it is not time to do a full re-apply")
-			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			newSyncStatus := oldSyncStatus
+			if shouldAssessHealth(syncSet, cd) && oldSyncStatus.Health != hiveintv1alpha1.HealthyResourceHealth {
+				logger.Debug("assessing health of syncset resources again since they were not healthy")
+				_, referencesToResources, _ := decodeResources(renderedSyncSet, logger)
+				newSyncStatus.Health, newSyncStatus.ResourceHealth = assessHealth(referencesToResources, resourceHelper, logger)
+				if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+					newSyncStatus.LastTransitionTime = metav1.Now()
+				}
+			}
+			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+			continue
+		}
+
+		// Fail the syncset if its dependencies can never be applied. It is applied again when the syncsets of the
+		// cluster change.
+		if message, ok := failures[syncSetType][syncSet.AsMetaObject().GetName()]; ok {
+			logger.WithField("reason", message).Warn("dependencies of syncset can never be applied")
+			// Nothing is applied, so keep the resources to delete from the last apply.
+			newSyncStatus := hiveintv1alpha1.SyncStatus{
+				Name:               syncSet.AsMetaObject().GetName(),
+				ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+				RenderedHash:       oldSyncStatus.RenderedHash,
+				ResourcesToDelete:  oldSyncStatus.ResourcesToDelete,
+				Result:             hiveintv1alpha1.FailureSyncSetResult,
+				FailureMessage:     message,
+				LastTransitionTime: oldSyncStatus.LastTransitionTime,
+				FirstSuccessTime:   oldSyncStatus.FirstSuccessTime,
+			}
+			if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+				newSyncStatus.LastTransitionTime = metav1.Now()
+			}
+			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+			results[syncSetType][newSyncStatus.Name] = newSyncStatus.Result
+			continue
+		}
+
+		// Wait for the syncsets that this syncset depends on to be applied
+		if pending := results.pendingDependencies(syncSetType, syncSet); len(pending) > 0 {
+			logger.WithField("dependencies", pending).Info("waiting for dependencies of syncset to be applied")
+			requeue = true
+			// Nothing is applied, so keep the resources to delete from the last apply.
+			newSyncStatus := hiveintv1alpha1.SyncStatus{
+				Name:               syncSet.AsMetaObject().GetName(),
+				ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+				RenderedHash:       oldSyncStatus.RenderedHash,
+				ResourcesToDelete:  oldSyncStatus.ResourcesToDelete,
+				Result:             hiveintv1alpha1.WaitingSyncSetResult,
+				FailureMessage:     waitingForDependencies(pending).Error(),
+				LastTransitionTime: oldSyncStatus.LastTransitionTime,
+				FirstSuccessTime:   oldSyncStatus.FirstSuccessTime,
+			}
+			if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
+				newSyncStatus.LastTransitionTime = metav1.Now()
+			}
+			newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+			results[syncSetType][newSyncStatus.Name] = newSyncStatus.Result
 			continue
 		}
 
-		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
+		// Apply the syncset, or only audit it
+		var resourcesApplied, resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference
+		var driftedResources []hiveintv1alpha1.SyncResourceDrift
+		var syncSetNeedsRequeue bool
+		audit := isAuditOnly(syncSet)
+		if audit {
+			driftedResources, syncSetNeedsRequeue, err = r.auditSyncSet(renderedSyncSet, resourceHelper, logger)
+		} else {
+			resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err = r.applySyncSet(renderedSyncSet, resourceHelper, logger)
+		}
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
 			Name:               syncSet.AsMetaObject().GetName(),
 			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+			RenderedHash:       renderedHash,
 			Result:             hiveintv1alpha1.SuccessSyncSetResult,
+			DriftedResources:   driftedResources,
 		}
 		applyMode := syncSet.GetSpec().ResourceApplyMode
-		if applyMode == hivev1.SyncResourceApplyMode {
+		if applyMode == hivev1.SyncResourceApplyMode && !audit {
 			newSyncStatus.ResourcesToDelete = resourcesApplied
 		}
 		// applyMode defaults to UpsertResourceApplyMode
@@ -583,14 +801,36 @@ func (r *ReconcileClusterSync) applySyncSets(
 		}
 		if err != nil {
 			newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+			var waitErr *waitingError
+			if errors.As(err, &waitErr) {
+				newSyncStatus.Result = hiveintv1alpha1.WaitingSyncSetResult
+			}
+			var conflictErr *applyConflictError
+			if errors.As(err, &conflictErr) {
+				newSyncStatus.Conflicts = conflictErr.conflicts
+			}
 			newSyncStatus.FailureMessage = err.Error()
 		}
 		if syncSetNeedsRequeue {
 			requeue = true
 		}
+		if err == nil && shouldAssessHealth(syncSet, cd) {
+			// The secrets are listed after the resources, and only the health of the resources is assessed.
+			referencesToResources := resourcesInSyncSet[:len(resourcesInSyncSet)-len(syncSet.GetSpec().Secrets)]
+			newSyncStatus.Health, newSyncStatus.ResourceHealth = assessHealth(referencesToResources, resourceHelper, logger)
+		}
 
-		if indexOfOldStatus >= 0 {
+		switch {
+		case indexOfOldStatus < 0:
+		case audit:
+			// Nothing is deleted while auditing, so keep the resources to delete from the last apply.
+			newSyncStatus.ResourcesToDelete = oldSyncStatus.ResourcesToDelete
+			newSyncStatus.LastTransitionTime = oldSyncStatus.LastTransitionTime
+			newSyncStatus.FirstSuccessTime = oldSyncStatus.FirstSuccessTime
+		default:
 			// Delete any resources that were included in the syncset previously but are no longer included now.
+			// The previously applied resources that are still in the syncset remain tracked, even when they were not
+			// applied this time, e.g. because of conflicts with other field managers, so that they are not orphaned.
 			remainingResources, err := deleteFromTargetCluster(
 				oldSyncStatus.ResourcesToDelete,
 				func(r hiveintv1alpha1.SyncResourceReference) bool {
@@ -660,6 +900,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 			return orderResources(newSyncStatus.ResourcesToDelete[i], newSyncStatus.ResourcesToDelete[j])
 		})
 		newSyncStatuses = append(newSyncStatuses, newSyncStatus)
+		results[syncSetType][newSyncStatus.Name] = newSyncStatus.Result
 	}
 
 	// The remaining sync statuses in syncStatuses do not match any syncsets. Any resources to delete in the sync status
@@ -722,27 +963,69 @@ func (r *ReconcileClusterSync) applySyncSet(
 	case hivev1.CreateOnlySyncSetApplyBehavior:
 		applyFn = resourceHelper.Create
 		applyFnMetricsLabel = labelCreateOnly
+	case hivev1.ServerSideApplySyncSetApplyBehavior:
+		force := syncSet.GetSpec().ForceConflicts
+		applyFn = func(obj []byte) (resource.ApplyResult, error) {
+			return resourceHelper.ServerSideApply(obj, force)
+		}
+		applyFnMetricsLabel = labelServerSideApply
 	}
 
-	// Apply Resources
-	for i, resource := range resources {
-		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
-		if returnErr != nil {
-			resourcesApplied = referencesToResources[:i]
+	// Resources with fields owned by other field managers are skipped, so that the rest of the syncset is still
+	// applied, and the conflicts are returned at the end.
+	var conflicts []hiveintv1alpha1.SyncResourceConflict
+	isConflict := func(ref hiveintv1alpha1.SyncResourceReference) bool {
+		conflict := newResourceConflict(ref, returnErr)
+		if conflict == nil {
+			return false
+		}
+		logger.WithField("fields", conflict.Fields).Warn("resource has fields owned by other field managers")
+		conflicts = append(conflicts, *conflict)
+		returnErr, requeue = nil, false
+		return true
+	}
+
+	// Apply Resources. CustomResourceDefinitions and Namespaces are applied first, and the CustomResourceDefinitions
+	// must be established before applying the rest, so that custom resources and namespaced resources can be created
+	// by the same syncset.
+	applyResources := func(indexes []int) bool {
+		for _, i := range indexes {
+			returnErr, requeue = r.applyResource(i, resources[i], referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
+			if returnErr != nil && isConflict(referencesToResources[i]) {
+				continue
+			}
+			if returnErr != nil {
+				return false
+			}
+			resourcesApplied = append(resourcesApplied, referencesToResources[i])
+		}
+		return true
+	}
+	order, numCRDsAndNamespaces := orderResourcesForApply(resources)
+	if !applyResources(order[:numCRDsAndNamespaces]) {
+		return
+	}
+	if len(order) > numCRDsAndNamespaces || len(syncSet.GetSpec().Patches) > 0 {
+		if returnErr = r.waitForCRDs(resourcesApplied, resourceHelper, logger); returnErr != nil {
+			requeue = true
 			return
 		}
 	}
-	resourcesApplied = referencesToResources
+	if !applyResources(order[numCRDsAndNamespaces:]) {
+		return
+	}
 
 	// Apply Secrets
 	for i, secretMapping := range syncSet.GetSpec().Secrets {
 		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger)
+		if returnErr != nil && isConflict(referencesToSecrets[i]) {
+			continue
+		}
 		if returnErr != nil {
-			resourcesApplied = append(resourcesApplied, referencesToSecrets[:i]...)
 			return
 		}
+		resourcesApplied = append(resourcesApplied, referencesToSecrets[i])
 	}
-	resourcesApplied = append(resourcesApplied, referencesToSecrets...)
 
 	// Apply Patches
 	for i, patch := range syncSet.GetSpec().Patches {
@@ -752,6 +1035,10 @@ func (r *ReconcileClusterSync) applySyncSet(
 		}
 	}
 
+	if len(conflicts) > 0 {
+		returnErr, requeue = &applyConflictError{conflicts: conflicts}, true
+		return
+	}
 	logger.Info("syncset applied")
 	return
 }
@@ -812,6 +1099,34 @@ func (r *ReconcileClusterSync) applyResource(
 	return nil, false
 }
 
+// waitForCRDs returns a waitingError if any of the CustomResourceDefinitions among the applied resources is not
+// established yet.
+func (r *ReconcileClusterSync) waitForCRDs(
+	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) error {
+	var notEstablished []string
+	for _, ref := range resourcesApplied {
+		if !isCRD(ref) {
+			continue
+		}
+		crd, err := resourceHelper.Get(ref.APIVersion, ref.Kind, "", ref.Name)
+		if err != nil {
+			logger.WithError(err).WithField("crd", ref.Name).Warn("could not get CustomResourceDefinition")
+			return errors.Wrapf(err, "failed to get CustomResourceDefinition %s", ref.Name)
+		}
+		if !isEstablished(crd) {
+			notEstablished = append(notEstablished, ref.Name)
+		}
+	}
+	if len(notEstablished) > 0 {
+		logger.WithField("crds", notEstablished).Info("waiting for CustomResourceDefinitions to be established")
+		return &waitingError{message: fmt.Sprintf("waiting for CustomResourceDefinitions to be established: %s", strings.Join(notEstablished, ", "))}
+	}
+	return nil
+}
+
 func (r *ReconcileClusterSync) applySecret(
 	syncSet CommonSyncSet,
 	secretIndex int,
@@ -824,13 +1139,74 @@ func (r *ReconcileClusterSync) applySecret(
 	logger = logger.WithField("secretIndex", secretIndex).
 		WithField("secretNamespace", reference.Namespace).
 		WithField("secretName", reference.Name)
+	secret, err, requeue := r.getSourceSecret(syncSet, secretMapping.SourceRef, fmt.Sprintf("secret %d", secretIndex), logger)
+	if err != nil {
+		return err, requeue
+	}
+	data, err := selectSecretKeys(secret, secretMapping.Keys)
+	if err != nil {
+		logger.WithError(err).Warn("cannot select keys of secret")
+		return errors.Wrapf(err, "failed to select keys of secret %d", secretIndex), true
+	}
+	for i, source := range secretMapping.AdditionalSources {
+		description := fmt.Sprintf("additional source %d of secret %d", i, secretIndex)
+		additionalSecret, err, requeue := r.getSourceSecret(syncSet, source.SecretRef, description, logger)
+		if err != nil {
+			return err, requeue
+		}
+		additionalData, err := selectSecretKeys(additionalSecret, source.Keys)
+		if err != nil {
+			logger.WithError(err).WithField("additionalSource", i).Warn("cannot select keys of additional source secret")
+			return errors.Wrapf(err, "failed to select keys of %s", description), true
+		}
+		for _, key := range sets.StringKeySet(additionalData).List() {
+			if _, ok := data[key]; ok {
+				logger.WithField("key", key).Warn("key is provided by more than one source secret")
+				return fmt.Errorf("key %s of secret %d is provided by more than one source", key, secretIndex), false
+			}
+			data[key] = additionalData[key]
+		}
+	}
+	if len(secretMapping.DockerConfigJSON) > 0 {
+		if err := buildDockerConfigJSON(data, secretMapping.DockerConfigJSON); err != nil {
+			logger.WithError(err).Warn("cannot build dockerconfigjson of secret")
+			return errors.Wrapf(err, "failed to build dockerconfigjson of secret %d", secretIndex), true
+		}
+		secret.Type = corev1.SecretTypeDockerConfigJson
+	}
+	secret.Data = data
+	if secretMapping.Type != "" {
+		secret.Type = secretMapping.Type
+	}
+	// Clear out the fields of the metadata which are specific to the cluster to which the secret belongs.
+	secret.ObjectMeta = metav1.ObjectMeta{
+		Namespace:   secretMapping.TargetRef.Namespace,
+		Name:        secretMapping.TargetRef.Name,
+		Annotations: secret.Annotations,
+		Labels:      secret.Labels,
+	}
+	logger.Debug("applying secret")
+	if err := applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger); err != nil {
+		return errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
+	}
+	return nil, false
+}
+
+// getSourceSecret reads a source secret of a secret mapping. The namespace of the source secret defaults to the namespace
+// of the SyncSet, and must be the namespace of the SyncSet if specified. The namespace is required for SelectorSyncSets.
+func (r *ReconcileClusterSync) getSourceSecret(
+	syncSet CommonSyncSet,
+	ref hivev1.SecretReference,
+	description string,
+	logger log.FieldLogger,
+) (secret *corev1.Secret, returnErr error, requeue bool) {
 	syncSetNamespace := syncSet.AsMetaObject().GetNamespace()
-	srcNamespace := secretMapping.SourceRef.Namespace
+	srcNamespace := ref.Namespace
 	if srcNamespace == "" {
 		// The namespace of the source secret is required for SelectorSyncSets.
 		if syncSetNamespace == "" {
 			logger.Warn("namespace must be specified for source secret")
-			return fmt.Errorf("source namespace missing for secret %d", secretIndex), false
+			return nil, fmt.Errorf("source namespace missing for %s", description), false
 		}
 		// Use the namespace of the SyncSet if the namespace of the source secret is omitted.
 		srcNamespace = syncSetNamespace
@@ -838,26 +1214,96 @@ func (r *ReconcileClusterSync) applySecret(
 		// If the namespace of the source secret is specified, then it must match the namespace of the SyncSet.
 		if syncSetNamespace != "" && syncSetNamespace != srcNamespace {
 			logger.Warn("source secret must be in same namespace as SyncSet")
-			return fmt.Errorf("source in wrong namespace for secret %d", secretIndex), false
+			return nil, fmt.Errorf("source in wrong namespace for %s", description), false
 		}
 	}
-	secret := &corev1.Secret{}
-	if err := r.Get(context.Background(), types.NamespacedName{Namespace: srcNamespace, Name: secretMapping.SourceRef.Name}, secret); err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot read secret")
-		return errors.Wrapf(err, "failed to read secret %d", secretIndex), true
+	secret = &corev1.Secret{}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: srcNamespace, Name: ref.Name}, secret); err != nil {
+		logger.WithError(err).WithField("sourceName", ref.Name).Log(controllerutils.LogLevel(err), "cannot read secret")
+		return nil, errors.Wrapf(err, "failed to read %s", description), true
 	}
-	// Clear out the fields of the metadata which are specific to the cluster to which the secret belongs.
-	secret.ObjectMeta = metav1.ObjectMeta{
-		Namespace:   secretMapping.TargetRef.Namespace,
-		Name:        secretMapping.TargetRef.Name,
-		Annotations: secret.Annotations,
-		Labels:      secret.Labels,
+	return secret, nil, false
+}
+
+// selectSecretKeys returns the data of the secret that is copied to the target secret, with the keys selected and
+// renamed by the key mappings. All of the data is returned if there are no key mappings.
+func selectSecretKeys(secret *corev1.Secret, keys []hivev1.SecretKeyMapping) (map[string][]byte, error) {
+	data := make(map[string][]byte, len(secret.Data))
+	if len(keys) == 0 {
+		for key, value := range secret.Data {
+			data[key] = value
+		}
+		return data, nil
 	}
-	logger.Debug("applying secret")
-	if err := applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger); err != nil {
-		return errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
+	for _, km := range keys {
+		value, ok := secret.Data[km.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s/%s", km.Key, secret.Namespace, secret.Name)
+		}
+		targetKey := km.TargetKey
+		if targetKey == "" {
+			targetKey = km.Key
+		}
+		if _, ok := data[targetKey]; ok {
+			return nil, fmt.Errorf("target key %s is mapped more than once", targetKey)
+		}
+		data[targetKey] = value
 	}
-	return nil, false
+	return data, nil
+}
+
+// buildDockerConfigJSON replaces the keys of the data holding the credentials of the registries with a
+// .dockerconfigjson key holding the credentials in the format of a docker config.
+func buildDockerConfigJSON(data map[string][]byte, registries []hivev1.DockerRegistryCredentials) error {
+	type dockerAuth struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	auths := map[string]dockerAuth{}
+	usedKeys := sets.NewString()
+	getValue := func(key string) (string, error) {
+		value, ok := data[key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in source secrets", key)
+		}
+		usedKeys.Insert(key)
+		return string(value), nil
+	}
+	for _, registry := range registries {
+		server := registry.Registry
+		if registry.RegistryKey != "" {
+			var err error
+			if server, err = getValue(registry.RegistryKey); err != nil {
+				return err
+			}
+		}
+		username, err := getValue(registry.UsernameKey)
+		if err != nil {
+			return err
+		}
+		password, err := getValue(registry.PasswordKey)
+		if err != nil {
+			return err
+		}
+		auths[server] = dockerAuth{
+			Username: username,
+			Password: password,
+			Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+		}
+	}
+	if _, ok := data[corev1.DockerConfigJsonKey]; ok && !usedKeys.Has(corev1.DockerConfigJsonKey) {
+		return fmt.Errorf("key %s is provided by a source secret", corev1.DockerConfigJsonKey)
+	}
+	dockerConfig, err := json.Marshal(map[string]interface{}{"auths": auths})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal dockerconfigjson")
+	}
+	for _, key := range usedKeys.List() {
+		delete(data, key)
+	}
+	data[corev1.DockerConfigJsonKey] = dockerConfig
+	return nil
 }
 
 func (r *ReconcileClusterSync) applyPatch(
@@ -1001,8 +1447,8 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 	status := corev1.ConditionFalse
 	reason := "Success"
 	message := "All SyncSets and SelectorSyncSets have been applied to the cluster"
-	failingSyncSets := getFailingSyncSets(clusterSync.Status.SyncSets)
-	failingSelectorSyncSets := getFailingSyncSets(clusterSync.Status.SelectorSyncSets)
+	failingSyncSets := getSyncSetsWithResult(clusterSync.Status.SyncSets, hiveintv1alpha1.FailureSyncSetResult)
+	failingSelectorSyncSets := getSyncSetsWithResult(clusterSync.Status.SelectorSyncSets, hiveintv1alpha1.FailureSyncSetResult)
 	if len(failingSyncSets)+len(failingSelectorSyncSets) != 0 {
 		status = corev1.ConditionTrue
 		reason = "Failure"
@@ -1018,6 +1464,24 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 			verb = "are"
 		}
 		message = fmt.Sprintf("%s %s failing", strings.Join(failureNames, " and "), verb)
+	} else {
+		waitingSyncSets := getSyncSetsWithResult(clusterSync.Status.SyncSets, hiveintv1alpha1.WaitingSyncSetResult)
+		waitingSelectorSyncSets := getSyncSetsWithResult(clusterSync.Status.SelectorSyncSets, hiveintv1alpha1.WaitingSyncSetResult)
+		if len(waitingSyncSets)+len(waitingSelectorSyncSets) != 0 {
+			reason = "Waiting"
+			var waitingNames []string
+			if len(waitingSyncSets) != 0 {
+				waitingNames = append(waitingNames, namesForFailureMessage("SyncSet", waitingSyncSets))
+			}
+			if len(waitingSelectorSyncSets) != 0 {
+				waitingNames = append(waitingNames, namesForFailureMessage("SelectorSyncSet", waitingSelectorSyncSets))
+			}
+			verb := "is"
+			if len(waitingSyncSets)+len(waitingSelectorSyncSets) > 1 {
+				verb = "are"
+			}
+			message = fmt.Sprintf("%s %s waiting to be applied", strings.Join(waitingNames, " and "), verb)
+		}
 	}
 	if len(clusterSync.Status.Conditions) > 0 {
 		cond := clusterSync.Status.Conditions[0]
@@ -1037,14 +1501,14 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 	}}
 }
 
-func getFailingSyncSets(syncStatuses []hiveintv1alpha1.SyncStatus) []string {
-	var failures []string
+func getSyncSetsWithResult(syncStatuses []hiveintv1alpha1.SyncStatus, result hiveintv1alpha1.SyncSetResult) []string {
+	var names []string
 	for _, status := range syncStatuses {
-		if status.Result != hiveintv1alpha1.SuccessSyncSetResult {
-			failures = append(failures, status.Name)
+		if status.Result == result {
+			names = append(names, status.Name)
 		}
 	}
-	return failures
+	return names
 }
 
 func (r *ReconcileClusterSync) setFirstSuccessTime(syncStatuses []hiveintv1alpha1.SyncStatus, cd *hivev1.ClusterDeployment, clusterSync *hiveintv1alpha1.ClusterSync, logger log.FieldLogger) {
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/rest"
@@ -30,6 +31,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	"github.com/openshift/hive/pkg/resource"
@@ -38,6 +40,7 @@ import (
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 	testclusterdeployment "github.com/openshift/hive/pkg/test/clusterdeployment"
 	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testconfigmap "github.com/openshift/hive/pkg/test/configmap"
 	testgeneric "github.com/openshift/hive/pkg/test/generic"
 	testsecret "github.com/openshift/hive/pkg/test/secret"
 	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
@@ -66,6 +69,7 @@ type reconcileTest struct {
 	mockResourceHelper      *resourcemock.MockHelper
 	mockRemoteClientBuilder *remoteclientmock.MockBuilder
 	expectedFailedMessage   string
+	expectedWaitingMessage  string
 
 	// A zero LastTransitionTime indicates that the time should be set to now.
 	// A FirstSuccessTime that points to a zero time indicates that the time should be set to now.
@@ -74,6 +78,7 @@ type reconcileTest struct {
 
 	expectUnchangedLeaseRenewTime bool
 	expectRequeue                 bool
+	expectHealthCheckRequeue      bool
 	expectNoWorkDone              bool
 }
 
@@ -87,7 +92,8 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 	mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
 
 	r := &ReconcileClusterSync{
-		ordinalID:       0,
+		// the replica of the 3 replicas of the test statefulset that is assigned the test cluster
+		ordinalID:       assignedReplica(testCDUID, 3, nil),
 		Client:          c,
 		logger:          logger,
 		reapplyInterval: defaultReapplyInterval,
@@ -97,6 +103,10 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 		remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
 			return mockRemoteClientBuilder
 		},
+		kustomizeCache:   newKustomizeCache(),
+		configMapCache:   newConfigMapCache(),
+		configMapReader:  c,
+		assignedClusters: newAssignedClusters(),
 	}
 
 	return &reconcileTest{
@@ -149,6 +159,8 @@ func (rt *reconcileTest) run(t *testing.T) {
 	assert.True(t, result.Requeue, "expected requeue to be true")
 	if rt.expectRequeue {
 		assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+	} else if rt.expectHealthCheckRequeue {
+		assert.Equal(t, healthCheckInterval, result.RequeueAfter, "unexpected requeue after")
 	} else {
 		var minRequeueAfter, maxRequeueAfter float64
 		if rt.expectUnchangedLeaseRenewTime {
@@ -208,6 +220,9 @@ func (rt *reconcileTest) run(t *testing.T) {
 	expectedConditionMessage := rt.expectedFailedMessage
 	if expectedConditionMessage == "" {
 		expectedConditionStatus = corev1.ConditionFalse
+		expectedConditionMessage = rt.expectedWaitingMessage
+	}
+	if expectedConditionMessage == "" {
 		expectedConditionMessage = "All SyncSets and SelectorSyncSets have been applied to the cluster"
 	}
 	assert.Equal(t, string(expectedConditionStatus), string(syncFailedCond.Status), "unexpected sync failed status")
@@ -444,6 +459,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 	cases := []struct {
 		name                 string
 		ordinalID            int64
+		replicaWeights       map[int64]int64
 		statefulSet          *appsv1.StatefulSet
 		clusterDeployment    *hivev1.ClusterDeployment
 		expectedAssignedToMe bool
@@ -457,7 +473,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800195")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800197")),
 			),
 			expectedAssignedToMe: true,
 			expectedErr:          false,
@@ -507,7 +523,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800197")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800195")),
 			),
 			expectedAssignedToMe: true,
 			expectedErr:          false,
@@ -520,10 +536,51 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800198")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800196")),
+			),
+			expectedErr: false,
+		},
+		{
+			name:           "drained replica",
+			ordinalID:      0,
+			replicaWeights: map[int64]int64{0: 0},
+			statefulSet: teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+				teststatefulset.WithCurrentReplicas(3),
+				teststatefulset.WithReplicas(3),
+			),
+			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800197")),
 			),
 			expectedErr: false,
 		},
+		{
+			name:           "assigned to me - drained replica moved",
+			ordinalID:      1,
+			replicaWeights: map[int64]int64{0: 0},
+			statefulSet: teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+				teststatefulset.WithCurrentReplicas(3),
+				teststatefulset.WithReplicas(3),
+			),
+			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800197")),
+			),
+			expectedAssignedToMe: true,
+			expectedErr:          false,
+		},
+		{
+			name:           "assigned to me - all replicas drained",
+			ordinalID:      0,
+			replicaWeights: map[int64]int64{0: 0, 1: 0, 2: 0},
+			statefulSet: teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+				teststatefulset.WithCurrentReplicas(3),
+				teststatefulset.WithReplicas(3),
+			),
+			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800197")),
+			),
+			expectedAssignedToMe: true,
+			expectedErr:          false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -532,6 +589,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 			defer mockCtrl.Finish()
 			rt := newReconcileTest(t, mockCtrl, scheme)
 			rt.r.ordinalID = tc.ordinalID
+			rt.r.replicaWeights = tc.replicaWeights
 
 			// Act
 			actualAssignedToMe, actualErr := rt.r.isSyncAssignedToMe(tc.statefulSet, tc.clusterDeployment, rt.logger)
@@ -801,6 +859,79 @@ func TestReconcileClusterSync_Reapply(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_LeaseHandoff(t *testing.T) {
+	cases := []struct {
+		name                string
+		holder              string
+		transitions         int32
+		expectedTransitions int32
+	}{
+		{
+			name:                "take over from other replica",
+			holder:              "hive-clustersync-2",
+			transitions:         1,
+			expectedTransitions: 2,
+		},
+		{
+			name: "first holder",
+		},
+		{
+			name:                "already holder",
+			holder:              "hive-clustersync-0",
+			transitions:         1,
+			expectedTransitions: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(testConfigMap("dest-namespace", "dest-name")),
+			)
+			lease := buildSyncLease(time.Now().Add(-time.Hour))
+			lease.Spec.HolderIdentity = tc.holder
+			lease.Spec.LeaseTransitions = tc.transitions
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+						withTransitionInThePast(),
+						withFirstSuccessTimeInThePast(),
+					)),
+				),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				lease,
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			rt.r.podName = "hive-clustersync-0"
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			}
+			// Taking over the cluster does not reapply the syncsets
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+
+			actualLease := &hiveintv1alpha1.ClusterSyncLease{}
+			require.NoError(t, rt.c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testLeaseName}, actualLease), "could not get lease")
+			assert.Equal(t, "hive-clustersync-0", actualLease.Spec.HolderIdentity, "unexpected lease holder")
+			assert.Equal(t, tc.expectedTransitions, actualLease.Spec.LeaseTransitions, "unexpected lease transitions")
+			if tc.holder == rt.r.podName {
+				assert.Nil(t, actualLease.Spec.AcquireTime, "unexpected acquire time")
+			} else {
+				assert.NotNil(t, actualLease.Spec.AcquireTime, "expected acquire time")
+			}
+		})
+	}
+}
+
 func TestReconcileClusterSync_NewSyncSetApplied(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1789,6 +1920,9 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 		{
 			applyBehavior: hivev1.CreateOrUpdateSyncSetApplyBehavior,
 		},
+		{
+			applyBehavior: hivev1.ServerSideApplySyncSetApplyBehavior,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(string(tc.applyBehavior), func(t *testing.T) {
@@ -1842,6 +1976,9 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 			case hivev1.CreateOrUpdateSyncSetApplyBehavior:
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+			case hivev1.ServerSideApplySyncSetApplyBehavior:
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(resourceToApply), false).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(secretToApply), false).Return(resource.CreatedApplyResult, nil)
 			}
 			rt.mockResourceHelper.EXPECT().Patch(
 				types.NamespacedName{Namespace: "patch-namespace", Name: "patch-name"},
@@ -2076,6 +2213,185 @@ func TestReconcileClusterSync_MissingSourceSecret(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_SecretMappingKeys(t *testing.T) {
+	scheme := newScheme()
+	pullSecret := testsecret.FullBuilder(testNamespace, "pull-secret", scheme).Build(
+		testsecret.WithDataKeyValue("auth", []byte("test-auth")),
+		testsecret.WithDataKeyValue("unused", []byte("unused-data")),
+	)
+	credsSecret := testsecret.FullBuilder(testNamespace, "creds", scheme).Build(
+		testsecret.WithDataKeyValue("username", []byte("test-user")),
+		testsecret.WithDataKeyValue("password", []byte("test-password")),
+	)
+	cases := []struct {
+		name                  string
+		secretMapping         hivev1.SecretMapping
+		expectedSecret        *corev1.Secret
+		expectedFailedMessage string
+		expectRequeue         bool
+	}{
+		{
+			name: "select and rename keys",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("pull-secret", "dest-namespace", "dest-name")
+				m.Keys = []hivev1.SecretKeyMapping{{Key: "auth", TargetKey: ".dockerconfigjson"}}
+				m.Type = corev1.SecretTypeDockerConfigJson
+				return m
+			}(),
+			expectedSecret: testsecret.BasicBuilder().GenericOptions(
+				testgeneric.WithNamespace("dest-namespace"),
+				testgeneric.WithName("dest-name"),
+				testgeneric.WithTypeMeta(scheme),
+			).Build(
+				testsecret.WithDataKeyValue(".dockerconfigjson", []byte("test-auth")),
+				testsecret.WithType(corev1.SecretTypeDockerConfigJson),
+			),
+		},
+		{
+			name: "merge additional sources",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("pull-secret", "dest-namespace", "dest-name")
+				m.Keys = []hivev1.SecretKeyMapping{{Key: "auth"}}
+				m.AdditionalSources = []hivev1.SecretSource{{SecretRef: hivev1.SecretReference{Name: "creds"}}}
+				return m
+			}(),
+			expectedSecret: testsecret.BasicBuilder().GenericOptions(
+				testgeneric.WithNamespace("dest-namespace"),
+				testgeneric.WithName("dest-name"),
+				testgeneric.WithTypeMeta(scheme),
+			).Build(
+				testsecret.WithDataKeyValue("auth", []byte("test-auth")),
+				testsecret.WithDataKeyValue("username", []byte("test-user")),
+				testsecret.WithDataKeyValue("password", []byte("test-password")),
+			),
+		},
+		{
+			name: "build dockerconfigjson",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("pull-secret", "dest-namespace", "dest-name")
+				m.Keys = []hivev1.SecretKeyMapping{{Key: "unused"}}
+				m.AdditionalSources = []hivev1.SecretSource{{SecretRef: hivev1.SecretReference{Name: "creds"}}}
+				m.DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{Registry: "quay.io", UsernameKey: "username", PasswordKey: "password"},
+				}
+				return m
+			}(),
+			expectedSecret: testsecret.BasicBuilder().GenericOptions(
+				testgeneric.WithNamespace("dest-namespace"),
+				testgeneric.WithName("dest-name"),
+				testgeneric.WithTypeMeta(scheme),
+			).Build(
+				testsecret.WithDataKeyValue("unused", []byte("unused-data")),
+				testsecret.WithDataKeyValue(".dockerconfigjson", []byte(
+					`{"auths":{"quay.io":{"username":"test-user","password":"test-password","auth":"dGVzdC11c2VyOnRlc3QtcGFzc3dvcmQ="}}}`,
+				)),
+				testsecret.WithType(corev1.SecretTypeDockerConfigJson),
+			),
+		},
+		{
+			name: "build dockerconfigjson with registry from key",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("creds", "dest-namespace", "dest-name")
+				m.AdditionalSources = []hivev1.SecretSource{{
+					SecretRef: hivev1.SecretReference{Name: "pull-secret"},
+					Keys:      []hivev1.SecretKeyMapping{{Key: "auth", TargetKey: "server"}},
+				}}
+				m.DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{RegistryKey: "server", UsernameKey: "username", PasswordKey: "password"},
+				}
+				return m
+			}(),
+			expectedSecret: testsecret.BasicBuilder().GenericOptions(
+				testgeneric.WithNamespace("dest-namespace"),
+				testgeneric.WithName("dest-name"),
+				testgeneric.WithTypeMeta(scheme),
+			).Build(
+				testsecret.WithDataKeyValue(".dockerconfigjson", []byte(
+					`{"auths":{"test-auth":{"username":"test-user","password":"test-password","auth":"dGVzdC11c2VyOnRlc3QtcGFzc3dvcmQ="}}}`,
+				)),
+				testsecret.WithType(corev1.SecretTypeDockerConfigJson),
+			),
+		},
+		{
+			name: "missing dockerconfigjson key",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("pull-secret", "dest-namespace", "dest-name")
+				m.DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{Registry: "quay.io", UsernameKey: "username", PasswordKey: "password"},
+				}
+				return m
+			}(),
+			expectedFailedMessage: "failed to build dockerconfigjson of secret 0: key username not found in source secrets",
+			expectRequeue:         true,
+		},
+		{
+			name: "missing key",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("pull-secret", "dest-namespace", "dest-name")
+				m.Keys = []hivev1.SecretKeyMapping{{Key: "missing"}}
+				return m
+			}(),
+			expectedFailedMessage: "failed to select keys of secret 0: key missing not found in secret " + testNamespace + "/pull-secret",
+			expectRequeue:         true,
+		},
+		{
+			name: "key provided by more than one source",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("pull-secret", "dest-namespace", "dest-name")
+				m.AdditionalSources = []hivev1.SecretSource{{
+					SecretRef: hivev1.SecretReference{Name: "creds"},
+					Keys:      []hivev1.SecretKeyMapping{{Key: "username", TargetKey: "auth"}},
+				}}
+				return m
+			}(),
+			expectedFailedMessage: "key auth of secret 0 is provided by more than one source",
+		},
+		{
+			name: "missing additional source",
+			secretMapping: func() hivev1.SecretMapping {
+				m := testSecretMapping("pull-secret", "dest-namespace", "dest-name")
+				m.AdditionalSources = []hivev1.SecretSource{{SecretRef: hivev1.SecretReference{Name: "missing"}}}
+				return m
+			}(),
+			expectedFailedMessage: `failed to read additional source 0 of secret 0: secrets "missing" not found`,
+			expectRequeue:         true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+					testsyncset.ForClusterDeployments(testCDName),
+					testsyncset.WithGeneration(1),
+					testsyncset.WithSecrets(tc.secretMapping),
+				),
+				pullSecret,
+				credsSecret,
+			)
+			if tc.expectedSecret != nil {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(tc.expectedSecret)).Return(resource.CreatedApplyResult, nil)
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
+			} else {
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withFailureResult(tc.expectedFailedMessage),
+					withNoFirstSuccessTime(),
+				)}
+			}
+			rt.expectRequeue = tc.expectRequeue
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -2369,86 +2685,1282 @@ func TestReconcileClusterSync_UpsertToSyncResourceApplyMode(t *testing.T) {
 	}
 }
 
-func newScheme() *runtime.Scheme {
-	scheme := runtime.NewScheme()
-	hivev1.AddToScheme(scheme)
-	hiveintv1alpha1.AddToScheme(scheme)
-	corev1.AddToScheme(scheme)
-	appsv1.AddToScheme(scheme)
-	return scheme
-}
-
-func cdBuilder(scheme *runtime.Scheme) testcd.Builder {
-	return testcd.FullBuilder(testNamespace, testCDName, scheme).
-		GenericOptions(
-			testgeneric.WithUID(testCDUID),
-		).
-		Options(
-			testcd.Installed(),
-			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
-				Type:   hivev1.UnreachableCondition,
-				Status: corev1.ConditionFalse,
-			}),
-			testcd.InstalledTimestamp(time.Now()),
-		)
-}
-
-func clusterSyncBuilder(scheme *runtime.Scheme) testcs.Builder {
-	return testcs.FullBuilder(testNamespace, testClusterSyncName, scheme).GenericOptions(
-		testgeneric.WithUID(testClusterSyncUID),
-		testgeneric.WithOwnerReference(cdBuilder(scheme).Build()),
-	)
-}
-
-func buildSyncLease(t time.Time) *hiveintv1alpha1.ClusterSyncLease {
-	return &hiveintv1alpha1.ClusterSyncLease{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: testNamespace,
-			Name:      testLeaseName,
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion:         "hiveinternal.openshift.io/v1alpha1",
-				Kind:               "ClusterSync",
-				Name:               testClusterSyncName,
-				UID:                testClusterSyncUID,
-				BlockOwnerDeletion: pointer.BoolPtr(true),
-			}},
-		},
-		Spec: hiveintv1alpha1.ClusterSyncLeaseSpec{
-			RenewTime: metav1.NewMicroTime(t),
-		},
+func TestReconcileClusterSync_TemplatedResources(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	templatedResource := testConfigMap("{{ .Namespace }}", "{{ .ClusterName }}-config")
+	templatedResource.Labels = map[string]string{"env": `{{ index .Labels "env" | upper }}`}
+	templatedResource.Data = map[string]string{
+		"baseDomain": "{{ .BaseDomain }}",
+		"literal":    "not-templated",
 	}
-}
-
-func testConfigMap(namespace, name string) *corev1.ConfigMap {
-	return &corev1.ConfigMap{
-		TypeMeta: metav1.TypeMeta{
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithTemplateMode(hivev1.GoTemplateSyncSetTemplateMode),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResources(templatedResource),
+		testsyncset.WithPatches(hivev1.SyncObjectPatch{
 			APIVersion: "v1",
 			Kind:       "ConfigMap",
+			Namespace:  "dest-namespace",
+			Name:       "{{ .ClusterName }}-patched",
+			PatchType:  "merge",
+			Patch:      `{"data":{"region":"{{ .Region }}"}}`,
+		}),
+	)
+	cd := cdBuilder(scheme).GenericOptions(
+		testgeneric.WithLabel("env", "prod"),
+		testgeneric.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+	).Build(
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterName = "test-cluster"
+			cd.Spec.BaseDomain = "example.com"
 		},
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: namespace,
-			Name:      name,
-		},
-	}
-}
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cd,
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
 
-func testConfigMapRef(namespace, name string) hiveintv1alpha1.SyncResourceReference {
-	return hiveintv1alpha1.SyncResourceReference{
-		APIVersion: "v1",
-		Kind:       "ConfigMap",
-		Namespace:  namespace,
-		Name:       name,
+	renderedResource := testConfigMap(testNamespace, "test-cluster-config")
+	renderedResource.Labels = map[string]string{"env": "PROD"}
+	renderedResource.Data = map[string]string{
+		"baseDomain": "example.com",
+		"literal":    "not-templated",
 	}
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(renderedResource)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Patch(
+		types.NamespacedName{Namespace: "dest-namespace", Name: "test-cluster-patched"},
+		"ConfigMap",
+		"v1",
+		[]byte(`{"data":{"region":"us-east-1"}}`),
+		"merge",
+	).Return(nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withResourcesToDelete(testConfigMapRef(testNamespace, "test-cluster-config")),
+		withRenderedHash(t, syncSet, cd),
+	)}
+	rt.run(t)
 }
 
-func testSecretMapping(srcName, destNamespace, destName string) hivev1.SecretMapping {
-	return hivev1.SecretMapping{
-		SourceRef: hivev1.SecretReference{
-			Name: srcName,
-		},
-		TargetRef: hivev1.SecretReference{
-			Namespace: destNamespace,
-			Name:      destName,
+func TestReconcileClusterSync_TemplateRenderedContentChanged(t *testing.T) {
+	cases := []struct {
+		name        string
+		renderedFor *hivev1.ClusterDeployment
+		expectApply bool
+	}{
+		{
+			name:        "unchanged",
+			renderedFor: cdBuilder(newScheme()).GenericOptions(testgeneric.WithLabel("env", "prod")).Build(),
+		},
+		{
+			name:        "changed",
+			renderedFor: cdBuilder(newScheme()).GenericOptions(testgeneric.WithLabel("env", "dev")).Build(),
+			expectApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			templatedResource := testConfigMap("dest-namespace", `{{ index .Labels "env" }}-config`)
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithTemplateMode(hivev1.GoTemplateSyncSetTemplateMode),
+				testsyncset.WithResources(templatedResource),
+			)
+			cd := cdBuilder(scheme).GenericOptions(testgeneric.WithLabel("env", "prod")).Build()
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cd,
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+						withTransitionInThePast(),
+						withFirstSuccessTimeInThePast(),
+						withRenderedHash(t, syncSet, tc.renderedFor),
+					)),
+				),
+				buildSyncLease(time.Now()),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet)
+			expectedSyncStatus := buildSyncStatus("test-syncset",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withRenderedHash(t, syncSet, cd),
+			)
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(testConfigMap("dest-namespace", "prod-config"))).
+					Return(resource.CreatedApplyResult, nil)
+				expectedSyncStatus.LastTransitionTime = metav1.Time{}
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedSyncStatus}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ErrorRenderingTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(2),
+		testsyncset.WithTemplateMode(hivev1.GoTemplateSyncSetTemplateMode),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "{{ .NoSuchField }}")),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(
+			testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "dest-name")),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			)),
+		),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withObservedGeneration(2),
+		// The previously applied resources are not deleted when the templates cannot be rendered.
+		withResourcesToDelete(testConfigMapRef("dest-namespace", "dest-name")),
+		withFailureResult(`failed to render templates: failed to render resource 0: metadata: name: template: :1:3: executing "" at <.NoSuchField>: can't evaluate field NoSuchField in type *clustersync.templateData`),
+		withFirstSuccessTimeInThePast(),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_Kustomize(t *testing.T) {
+	scheme := newScheme()
+	baseConfigMap := testconfigmap.FullBuilder(testNamespace, "kustomize-base", scheme).Build(
+		testconfigmap.WithDataKeyValue("kustomization.yaml", "resources:\n- configmap.yaml\n"),
+		testconfigmap.WithDataKeyValue("configmap.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: dest-namespace\ndata:\n  foo: bar\n"),
+	)
+	overlayConfigMap := testconfigmap.FullBuilder(testNamespace, "kustomize-overlay", scheme).Build(
+		testconfigmap.WithDataKeyValue("kustomization.yaml", "bases:\n- ../../base\nnamePrefix: prod-\n"),
+	)
+	kustomize := &hivev1.KustomizeSource{
+		Path: "overlays/prod",
+		ConfigMaps: []hivev1.KustomizeConfigMap{
+			{Name: "kustomize-base", Path: "base"},
+			{Name: "kustomize-overlay", Path: "overlays/prod"},
+		},
+	}
+	builtResource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"namespace": "dest-namespace",
+			"name":      "prod-config",
+		},
+		"data": map[string]interface{}{"foo": "bar"},
+	}}
+	kustomizeHash, err := controllerutils.GetChecksumOfObjects("overlays/prod", map[string]string{
+		"/base/kustomization.yaml":          baseConfigMap.Data["kustomization.yaml"],
+		"/base/configmap.yaml":              baseConfigMap.Data["configmap.yaml"],
+		"/overlays/prod/kustomization.yaml": overlayConfigMap.Data["kustomization.yaml"],
+	})
+	require.NoError(t, err, "could not compute hash of kustomization")
+
+	cases := []struct {
+		name                    string
+		existing                []runtime.Object
+		existingSyncSetStatus   *hiveintv1alpha1.SyncStatus
+		expectApply             bool
+		expectDelete            bool
+		expectedFailedMessage   string
+		expectedSyncSetStatuses []hiveintv1alpha1.SyncStatus
+	}{
+		{
+			name:        "built resources applied",
+			existing:    []runtime.Object{baseConfigMap, overlayConfigMap},
+			expectApply: true,
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "prod-config")),
+				func(syncStatus *hiveintv1alpha1.SyncStatus) { syncStatus.RenderedHash = kustomizeHash },
+			)},
+		},
+		{
+			name:     "resources removed from kustomization deleted",
+			existing: []runtime.Object{baseConfigMap, overlayConfigMap},
+			existingSyncSetStatus: func() *hiveintv1alpha1.SyncStatus {
+				s := buildSyncStatus("test-syncset",
+					withResourcesToDelete(
+						testConfigMapRef("dest-namespace", "old-config"),
+						testConfigMapRef("dest-namespace", "prod-config"),
+					),
+					withTransitionInThePast(),
+					withFirstSuccessTimeInThePast(),
+				)
+				s.RenderedHash = "old-hash"
+				return &s
+			}(),
+			expectApply:  true,
+			expectDelete: true,
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "prod-config")),
+				withFirstSuccessTimeInThePast(),
+				func(syncStatus *hiveintv1alpha1.SyncStatus) { syncStatus.RenderedHash = kustomizeHash },
+			)},
+		},
+		{
+			name:                  "missing configmap",
+			existing:              []runtime.Object{baseConfigMap},
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withFailureResult(`failed to build kustomization: failed to read ConfigMap 1: configmaps "kustomize-overlay" not found`),
+				withNoFirstSuccessTime(),
+			)},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			if tc.existingSyncSetStatus != nil {
+				clusterSync.Status.SyncSets = []hiveintv1alpha1.SyncStatus{*tc.existingSyncSetStatus}
+			}
+			existing := append(tc.existing,
+				cdBuilder(scheme).Build(),
+				clusterSync,
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+					testsyncset.ForClusterDeployments(testCDName),
+					testsyncset.WithGeneration(1),
+					testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+					testsyncset.WithKustomize(kustomize),
+				),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(builtResource)).Return(resource.CreatedApplyResult, nil)
+			}
+			if tc.expectDelete {
+				rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "dest-namespace", "old-config").Return(nil)
+			}
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.expectedSyncSetStatuses = tc.expectedSyncSetStatuses
+			rt.expectRequeue = tc.expectedFailedMessage != ""
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_CRDsAndNamespacesAppliedFirst(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	configMap := testConfigMap("dest-namespace", "dest-name")
+	customResource := testCustomResource("dest-namespace", "dest-name")
+	namespace := testNamespaceResource("dest-namespace")
+	crd := testCRD()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(configMap, customResource, namespace, crd),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+	)
+	gomock.InOrder(
+		rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(crd)).Return(resource.CreatedApplyResult, nil),
+		rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(namespace)).Return(resource.CreatedApplyResult, nil),
+		rt.mockResourceHelper.EXPECT().Get("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "foos.example.com").
+			Return(testCRDWithEstablished("True"), nil),
+		rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(configMap)).Return(resource.CreatedApplyResult, nil),
+		rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(customResource)).Return(resource.CreatedApplyResult, nil),
+	)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_WaitForCRDToBeEstablished(t *testing.T) {
+	cases := []struct {
+		name           string
+		crd            *unstructured.Unstructured
+		getErr         error
+		expectedResult syncStatusOption
+	}{
+		{
+			name:           "not established",
+			crd:            testCRDWithEstablished("False"),
+			expectedResult: withWaitingResult("waiting for CustomResourceDefinitions to be established: foos.example.com"),
+		},
+		{
+			name:           "no conditions",
+			crd:            testCRD(),
+			expectedResult: withWaitingResult("waiting for CustomResourceDefinitions to be established: foos.example.com"),
+		},
+		{
+			name:           "error getting CRD",
+			getErr:         errors.New("test get error"),
+			expectedResult: withFailureResult("failed to get CustomResourceDefinition foos.example.com: test get error"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			crd := testCRD()
+			customResource := testCustomResource("dest-namespace", "dest-name")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+				testsyncset.WithResources(customResource, crd),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+			)
+			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(crd)).Return(resource.CreatedApplyResult, nil)
+			rt.mockResourceHelper.EXPECT().Get("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "foos.example.com").
+				Return(tc.crd, tc.getErr)
+			if tc.getErr != nil {
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+			} else {
+				rt.expectedWaitingMessage = "SyncSet test-syncset is waiting to be applied"
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				tc.expectedResult,
+				withResourcesToDelete(hiveintv1alpha1.SyncResourceReference{
+					APIVersion: "apiextensions.k8s.io/v1",
+					Kind:       "CustomResourceDefinition",
+					Name:       "foos.example.com",
+				}),
+				withNoFirstSuccessTime(),
+			)}
+			rt.expectRequeue = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_DependsOn(t *testing.T) {
+	scheme := newScheme()
+	dependencyResource := testConfigMap("dest-namespace", "dependency")
+	dependentResource := testConfigMap("dest-namespace", "dependent")
+	dependentSyncSetBuilder := testsyncset.FullBuilder(testNamespace, "a-dependent", scheme).Options(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(dependentResource),
+	)
+	dependencySyncSet := testsyncset.FullBuilder(testNamespace, "b-dependency", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(dependencyResource),
+	)
+	dependencySelectorSyncSet := testselectorsyncset.FullBuilder("b-dependency", scheme).Build(
+		testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+		testselectorsyncset.WithGeneration(1),
+		testselectorsyncset.WithResources(dependencyResource),
+	)
+	cases := []struct {
+		name                            string
+		existing                        []runtime.Object
+		existingSelectorSyncSetStatuses []hiveintv1alpha1.SyncStatus
+		dependencyApplyErr              error
+		expectDependencyApplied         bool
+		expectDependentApplied          bool
+		expectedFailedMessage           string
+		expectedWaitingMessage          string
+		expectNoRequeue                 bool
+		expectedSyncSetStatuses         []hiveintv1alpha1.SyncStatus
+		expectedSelectorSyncSetStatuses []hiveintv1alpha1.SyncStatus
+	}{
+		{
+			name: "dependency applied first",
+			existing: []runtime.Object{
+				dependentSyncSetBuilder.Build(testsyncset.WithDependsOn(hivev1.SyncSetDependency{Name: "b-dependency"})),
+				dependencySyncSet,
+			},
+			expectDependencyApplied: true,
+			expectDependentApplied:  true,
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("b-dependency"),
+				buildSyncStatus("a-dependent"),
+			},
+		},
+		{
+			name: "dependency failed",
+			existing: []runtime.Object{
+				dependentSyncSetBuilder.Build(testsyncset.WithDependsOn(hivev1.SyncSetDependency{Name: "b-dependency"})),
+				dependencySyncSet,
+			},
+			dependencyApplyErr:      errors.New("test apply error"),
+			expectDependencyApplied: true,
+			expectedFailedMessage:   "SyncSet b-dependency is failing",
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("b-dependency",
+					withFailureResult("failed to apply resource 0: test apply error"),
+					withNoFirstSuccessTime(),
+				),
+				buildSyncStatus("a-dependent",
+					withWaitingResult("waiting for SyncSet b-dependency"),
+					withNoFirstSuccessTime(),
+				),
+			},
+		},
+		{
+			name: "dependency does not apply to cluster",
+			existing: []runtime.Object{
+				dependentSyncSetBuilder.Build(testsyncset.WithDependsOn(hivev1.SyncSetDependency{Name: "other"})),
+			},
+			expectedFailedMessage: "SyncSet a-dependent is failing",
+			expectNoRequeue:       true,
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("a-dependent",
+					withFailureResult("dependency SyncSet other does not exist or does not apply to the cluster"),
+					withNoFirstSuccessTime(),
+				),
+			},
+		},
+		{
+			name: "circular dependencies",
+			existing: []runtime.Object{
+				dependentSyncSetBuilder.Build(testsyncset.WithDependsOn(hivev1.SyncSetDependency{Name: "b-dependency"})),
+				testsyncset.FullBuilder(testNamespace, "b-dependency", scheme).Build(
+					testsyncset.ForClusterDeployments(testCDName),
+					testsyncset.WithGeneration(1),
+					testsyncset.WithResources(dependencyResource),
+					testsyncset.WithDependsOn(hivev1.SyncSetDependency{Name: "a-dependent"}),
+				),
+				testsyncset.FullBuilder(testNamespace, "c-dependent", scheme).Build(
+					testsyncset.ForClusterDeployments(testCDName),
+					testsyncset.WithGeneration(1),
+					testsyncset.WithDependsOn(hivev1.SyncSetDependency{Name: "a-dependent"}),
+				),
+			},
+			expectedFailedMessage: "SyncSets a-dependent, b-dependency, c-dependent are failing",
+			expectNoRequeue:       true,
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("a-dependent",
+					withFailureResult("circular dependency: SyncSet a-dependent -> SyncSet b-dependency -> SyncSet a-dependent"),
+					withNoFirstSuccessTime(),
+				),
+				buildSyncStatus("b-dependency",
+					withFailureResult("circular dependency: SyncSet a-dependent -> SyncSet b-dependency -> SyncSet a-dependent"),
+					withNoFirstSuccessTime(),
+				),
+				buildSyncStatus("c-dependent",
+					withFailureResult("dependency SyncSet a-dependent can never be applied"),
+					withNoFirstSuccessTime(),
+				),
+			},
+		},
+		{
+			name: "selectorsyncset dependency not applied yet",
+			existing: []runtime.Object{
+				dependentSyncSetBuilder.Build(testsyncset.WithDependsOn(
+					hivev1.SyncSetDependency{Kind: "SelectorSyncSet", Name: "b-dependency"},
+				)),
+				dependencySelectorSyncSet,
+			},
+			expectDependencyApplied: true,
+			expectedWaitingMessage:  "SyncSet a-dependent is waiting to be applied",
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("a-dependent",
+					withWaitingResult("waiting for SelectorSyncSet b-dependency"),
+					withNoFirstSuccessTime(),
+				),
+			},
+			expectedSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("b-dependency"),
+			},
+		},
+		{
+			name: "selectorsyncset dependency applied",
+			existing: []runtime.Object{
+				dependentSyncSetBuilder.Build(testsyncset.WithDependsOn(
+					hivev1.SyncSetDependency{Kind: "SelectorSyncSet", Name: "b-dependency"},
+				)),
+				dependencySelectorSyncSet,
+			},
+			existingSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("b-dependency", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			},
+			expectDependentApplied: true,
+			expectedSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("a-dependent"),
+			},
+			expectedSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("b-dependency", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			clusterSync.Status.SelectorSyncSets = tc.existingSelectorSyncSetStatuses
+			existing := append(tc.existing,
+				cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
+				clusterSync,
+				buildSyncLease(time.Now().Add(-1*time.Hour)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			var calls []*gomock.Call
+			if tc.expectDependencyApplied {
+				calls = append(calls, rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(dependencyResource)).
+					Return(resource.CreatedApplyResult, tc.dependencyApplyErr))
+			}
+			if tc.expectDependentApplied {
+				calls = append(calls, rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(dependentResource)).
+					Return(resource.CreatedApplyResult, nil))
+			}
+			gomock.InOrder(calls...)
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.expectedWaitingMessage = tc.expectedWaitingMessage
+			rt.expectedSyncSetStatuses = tc.expectedSyncSetStatuses
+			rt.expectedSelectorSyncSetStatuses = tc.expectedSelectorSyncSetStatuses
+			rt.expectRequeue = !tc.expectNoRequeue && (tc.expectedFailedMessage != "" || tc.expectedWaitingMessage != "")
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_SelectorSyncSetRollout(t *testing.T) {
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	strategy := &hivev1.SelectorSyncSetRolloutStrategy{BatchPercent: 100}
+	cases := []struct {
+		name                            string
+		rollout                         *hivev1.SelectorSyncSetRolloutStatus
+		existingSelectorSyncSetStatuses []hiveintv1alpha1.SyncStatus
+		expectApplied                   bool
+		expectedWaitingMessage          string
+		expectedSelectorSyncSetStatuses []hiveintv1alpha1.SyncStatus
+	}{
+		{
+			name:                   "rollout not started",
+			expectedWaitingMessage: "SelectorSyncSet test-selectorsyncset is waiting to be applied",
+			expectedSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-selectorsyncset",
+					withObservedGeneration(0),
+					withWaitingResult("waiting for rollout of generation 2"),
+					withNoFirstSuccessTime(),
+				),
+			},
+		},
+		{
+			name: "previous generation kept",
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation: 2,
+				Phase:      hivev1.ProgressingSelectorSyncSetRolloutPhase,
+			},
+			existingSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-selectorsyncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			},
+			expectedSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-selectorsyncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			},
+		},
+		{
+			name: "admitted to rollout",
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				Phase:           hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				AdmittedPercent: 100,
+			},
+			existingSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-selectorsyncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+			},
+			expectApplied: true,
+			expectedSelectorSyncSetStatuses: []hiveintv1alpha1.SyncStatus{
+				buildSyncStatus("test-selectorsyncset", withObservedGeneration(2), withFirstSuccessTimeInThePast()),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			clusterSync.Status.SelectorSyncSets = tc.existingSelectorSyncSetStatuses
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
+				clusterSync,
+				buildSyncLease(time.Now().Add(-1*time.Hour)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+					testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+					testselectorsyncset.WithGeneration(2),
+					testselectorsyncset.WithResources(resourceToApply),
+					testselectorsyncset.WithRolloutStrategy(strategy),
+					testselectorsyncset.WithRolloutStatus(tc.rollout),
+				),
+			)
+			if tc.expectApplied {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+			}
+			rt.expectedWaitingMessage = tc.expectedWaitingMessage
+			rt.expectedSelectorSyncSetStatuses = tc.expectedSelectorSyncSetStatuses
+			// The rollout status of the selectorsyncset is watched, so waiting for the rollout does not requeue.
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_HealthCheck(t *testing.T) {
+	deploymentRef := hiveintv1alpha1.SyncResourceReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  "dest-namespace",
+		Name:       "dest-name",
+	}
+	configMapRef := testConfigMapRef("dest-namespace", "dest-name")
+	deploymentWithStatus := func(updatedReplicas, availableReplicas int64) *unstructured.Unstructured {
+		d := testDeployment("dest-namespace", "dest-name")
+		d.Object["status"] = map[string]interface{}{
+			"replicas":          updatedReplicas,
+			"updatedReplicas":   updatedReplicas,
+			"availableReplicas": availableReplicas,
+		}
+		return d
+	}
+	cases := []struct {
+		name                     string
+		healthCheckMode          hivev1.SyncSetHealthCheckMode
+		existingSyncStatus       *hiveintv1alpha1.SyncStatus
+		expectApply              bool
+		deployment               *unstructured.Unstructured
+		getErr                   error
+		expectedSyncStatus       hiveintv1alpha1.SyncStatus
+		expectHealthCheckRequeue bool
+	}{
+		{
+			name:               "health checks disabled",
+			expectApply:        true,
+			expectedSyncStatus: buildSyncStatus("test-syncset"),
+		},
+		{
+			name:            "healthy",
+			healthCheckMode: hivev1.EnabledSyncSetHealthCheckMode,
+			expectApply:     true,
+			deployment:      deploymentWithStatus(1, 1),
+			expectedSyncStatus: buildSyncStatus("test-syncset", withHealth(hiveintv1alpha1.HealthyResourceHealth,
+				hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: deploymentRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+				hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: configMapRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+			)),
+		},
+		{
+			name:            "progressing",
+			healthCheckMode: hivev1.EnabledSyncSetHealthCheckMode,
+			expectApply:     true,
+			deployment:      deploymentWithStatus(1, 0),
+			expectedSyncStatus: buildSyncStatus("test-syncset", withHealth(hiveintv1alpha1.ProgressingResourceHealth,
+				hiveintv1alpha1.SyncResourceHealth{
+					SyncResourceReference: deploymentRef,
+					Health:                hiveintv1alpha1.ProgressingResourceHealth,
+					Message:               "0 of 1 updated replicas are available",
+				},
+				hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: configMapRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+			)),
+			expectHealthCheckRequeue: true,
+		},
+		{
+			name:            "not found",
+			healthCheckMode: hivev1.EnabledSyncSetHealthCheckMode,
+			expectApply:     true,
+			getErr:          apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "dest-name"),
+			expectedSyncStatus: buildSyncStatus("test-syncset", withHealth(hiveintv1alpha1.DegradedResourceHealth,
+				hiveintv1alpha1.SyncResourceHealth{
+					SyncResourceReference: deploymentRef,
+					Health:                hiveintv1alpha1.DegradedResourceHealth,
+					Message:               "resource not found",
+				},
+				hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: configMapRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+			)),
+			expectHealthCheckRequeue: true,
+		},
+		{
+			name:            "progressing resources assessed again without apply",
+			healthCheckMode: hivev1.EnabledSyncSetHealthCheckMode,
+			existingSyncStatus: func() *hiveintv1alpha1.SyncStatus {
+				s := buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast(),
+					withHealth(hiveintv1alpha1.ProgressingResourceHealth,
+						hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: deploymentRef, Health: hiveintv1alpha1.ProgressingResourceHealth},
+						hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: configMapRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+					))
+				return &s
+			}(),
+			deployment: deploymentWithStatus(1, 1),
+			expectedSyncStatus: buildSyncStatus("test-syncset", withFirstSuccessTimeInThePast(), withHealth(hiveintv1alpha1.HealthyResourceHealth,
+				hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: deploymentRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+				hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: configMapRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+			)),
+		},
+		{
+			name:            "healthy resources not assessed again without apply",
+			healthCheckMode: hivev1.EnabledSyncSetHealthCheckMode,
+			existingSyncStatus: func() *hiveintv1alpha1.SyncStatus {
+				s := buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast(),
+					withHealth(hiveintv1alpha1.HealthyResourceHealth,
+						hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: deploymentRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+						hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: configMapRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+					))
+				return &s
+			}(),
+			expectedSyncStatus: buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast(),
+				withHealth(hiveintv1alpha1.HealthyResourceHealth,
+					hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: deploymentRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+					hiveintv1alpha1.SyncResourceHealth{SyncResourceReference: configMapRef, Health: hiveintv1alpha1.HealthyResourceHealth},
+				)),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			deployment := testDeployment("dest-namespace", "dest-name")
+			configMap := testConfigMap("dest-namespace", "dest-name")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithHealthCheckMode(tc.healthCheckMode),
+				testsyncset.WithResources(deployment, configMap),
+			)
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			if tc.existingSyncStatus != nil {
+				clusterSync.Status.SyncSets = []hiveintv1alpha1.SyncStatus{*tc.existingSyncStatus}
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSync,
+				buildSyncLease(time.Now().Add(-1*time.Hour)),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+			)
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(deployment)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(configMap)).Return(resource.CreatedApplyResult, nil)
+			}
+			if tc.deployment != nil || tc.getErr != nil {
+				rt.mockResourceHelper.EXPECT().Get("apps/v1", "Deployment", "dest-namespace", "dest-name").Return(tc.deployment, tc.getErr)
+				rt.mockResourceHelper.EXPECT().Get("v1", "ConfigMap", "dest-namespace", "dest-name").Return(&unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"}}, nil)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncStatus}
+			rt.expectHealthCheckRequeue = tc.expectHealthCheckRequeue
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_Audit(t *testing.T) {
+	cases := []struct {
+		name                     string
+		resourceApplyMode        hivev1.SyncSetResourceApplyMode
+		existingSyncStatus       *hiveintv1alpha1.SyncStatus
+		diffErr                  error
+		expectedSyncSetStatus    hiveintv1alpha1.SyncStatus
+		expectedFailedMessage    string
+		expectUnchangedLeaseTime bool
+		expectRequeue            bool
+	}{
+		{
+			name: "drift recorded",
+			expectedSyncSetStatus: buildSyncStatus("test-syncset",
+				withDriftedResources(hiveintv1alpha1.SyncResourceDrift{
+					SyncResourceReference: testConfigMapRef("dest-namespace", "drifted-resource"),
+					Diff:                  `data.key: desired "value", live "other-value"`,
+				}),
+			),
+		},
+		{
+			name:              "resources are not deleted",
+			resourceApplyMode: hivev1.SyncResourceApplyMode,
+			existingSyncStatus: func() *hiveintv1alpha1.SyncStatus {
+				status := buildSyncStatus("test-syncset",
+					withTransitionInThePast(),
+					withFirstSuccessTimeInThePast(),
+					withResourcesToDelete(testConfigMapRef("dest-namespace", "removed-resource")),
+				)
+				return &status
+			}(),
+			expectedSyncSetStatus: buildSyncStatus("test-syncset",
+				withObservedGeneration(2),
+				withFirstSuccessTimeInThePast(),
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "removed-resource")),
+				withDriftedResources(hiveintv1alpha1.SyncResourceDrift{
+					SyncResourceReference: testConfigMapRef("dest-namespace", "drifted-resource"),
+					Diff:                  `data.key: desired "value", live "other-value"`,
+				}),
+			),
+			expectUnchangedLeaseTime: true,
+		},
+		{
+			name:    "error auditing resource",
+			diffErr: errors.New("diff error"),
+			expectedSyncSetStatus: buildSyncStatus("test-syncset",
+				withFailureResult("failed to audit resource 0: diff error"),
+				withNoFirstSuccessTime(),
+			),
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+			expectRequeue:         true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			driftedResource := testConfigMap("dest-namespace", "drifted-resource")
+			unchangedResource := testConfigMap("dest-namespace", "unchanged-resource")
+			generation := int64(1)
+			if tc.existingSyncStatus != nil {
+				generation = 2
+			}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(generation),
+				testsyncset.WithApplyBehavior(hivev1.AuditSyncSetApplyBehavior),
+				testsyncset.WithApplyMode(tc.resourceApplyMode),
+				testsyncset.WithResources(driftedResource, unchangedResource),
+				testsyncset.WithSecrets(
+					testSecretMapping("test-secret", "secret-namespace", "secret-name"),
+				),
+				testsyncset.WithPatches(hivev1.SyncObjectPatch{
+					APIVersion: "patch-api/v1",
+					Kind:       "PatchKind",
+					Namespace:  "patch-namespace",
+					Name:       "patch-name",
+					PatchType:  "patch-type",
+					Patch:      "test-patch",
+				}),
+			)
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+			}
+			if tc.existingSyncStatus != nil {
+				clusterSync = clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(*tc.existingSyncStatus))
+				existing = append(existing, buildSyncLease(time.Now().Add(-1*time.Hour)))
+			}
+			existing = append(existing, clusterSync)
+			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			// Nothing is applied, patched or deleted.
+			if tc.diffErr != nil {
+				rt.mockResourceHelper.EXPECT().Diff(newDiffMatcher(driftedResource)).Return("", tc.diffErr)
+			} else {
+				rt.mockResourceHelper.EXPECT().Diff(newDiffMatcher(driftedResource)).
+					Return(`data.key: desired "value", live "other-value"`, nil)
+				rt.mockResourceHelper.EXPECT().Diff(newDiffMatcher(unchangedResource)).Return("", nil)
+			}
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncSetStatus}
+			rt.expectUnchangedLeaseRenewTime = tc.expectUnchangedLeaseTime
+			rt.expectRequeue = tc.expectRequeue
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ServerSideApplyConflicts(t *testing.T) {
+	conflictErr := apierrors.NewApplyConflict(
+		[]metav1.StatusCause{
+			{Type: metav1.CauseTypeFieldManagerConflict, Field: ".data.foo", Message: `conflict with "other-manager"`},
+		},
+		`Apply failed with 1 conflict: conflict with "other-manager": .data.foo`,
+	)
+	cases := []struct {
+		name                  string
+		forceConflicts        bool
+		applyErr              error
+		existingSyncStatus    *hiveintv1alpha1.SyncStatus
+		expectedSyncSetStatus hiveintv1alpha1.SyncStatus
+		expectedFailedMessage string
+		expectRequeue         bool
+	}{
+		{
+			name:     "conflicts recorded",
+			applyErr: conflictErr,
+			expectedSyncSetStatus: func() hiveintv1alpha1.SyncStatus {
+				status := buildSyncStatus("test-syncset",
+					withFailureResult("fields are owned by other field managers: ConfigMap dest-namespace/conflicting-resource: .data.foo"),
+					withNoFirstSuccessTime(),
+					withResourcesToDelete(testConfigMapRef("dest-namespace", "other-resource")),
+				)
+				status.Conflicts = []hiveintv1alpha1.SyncResourceConflict{{
+					SyncResourceReference: testConfigMapRef("dest-namespace", "conflicting-resource"),
+					Fields:                []string{".data.foo"},
+					Message:               `Apply failed with 1 conflict: conflict with "other-manager": .data.foo`,
+				}}
+				return status
+			}(),
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+			expectRequeue:         true,
+		},
+		{
+			name:     "conflicting resource applied before is still tracked",
+			applyErr: conflictErr,
+			existingSyncStatus: func() *hiveintv1alpha1.SyncStatus {
+				status := buildSyncStatus("test-syncset",
+					withTransitionInThePast(),
+					withFirstSuccessTimeInThePast(),
+					withResourcesToDelete(
+						testConfigMapRef("dest-namespace", "conflicting-resource"),
+						testConfigMapRef("dest-namespace", "other-resource"),
+					),
+				)
+				return &status
+			}(),
+			expectedSyncSetStatus: func() hiveintv1alpha1.SyncStatus {
+				status := buildSyncStatus("test-syncset",
+					withFailureResult("fields are owned by other field managers: ConfigMap dest-namespace/conflicting-resource: .data.foo"),
+					withFirstSuccessTimeInThePast(),
+					withResourcesToDelete(
+						testConfigMapRef("dest-namespace", "conflicting-resource"),
+						testConfigMapRef("dest-namespace", "other-resource"),
+					),
+				)
+				status.Conflicts = []hiveintv1alpha1.SyncResourceConflict{{
+					SyncResourceReference: testConfigMapRef("dest-namespace", "conflicting-resource"),
+					Fields:                []string{".data.foo"},
+					Message:               `Apply failed with 1 conflict: conflict with "other-manager": .data.foo`,
+				}}
+				return status
+			}(),
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+			expectRequeue:         true,
+		},
+		{
+			name:           "force conflicts",
+			forceConflicts: true,
+			expectedSyncSetStatus: buildSyncStatus("test-syncset",
+				withResourcesToDelete(
+					testConfigMapRef("dest-namespace", "conflicting-resource"),
+					testConfigMapRef("dest-namespace", "other-resource"),
+				),
+			),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			conflictingResource := testConfigMap("dest-namespace", "conflicting-resource")
+			otherResource := testConfigMap("dest-namespace", "other-resource")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithApplyBehavior(hivev1.ServerSideApplySyncSetApplyBehavior),
+				testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+				testsyncset.WithResources(conflictingResource, otherResource),
+			)
+			syncSet.Spec.ForceConflicts = tc.forceConflicts
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			if tc.existingSyncStatus != nil {
+				clusterSync = clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(*tc.existingSyncStatus))
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSync,
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet)
+			applyResult := resource.ConfiguredApplyResult
+			if tc.applyErr != nil {
+				applyResult = ""
+			}
+			rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(conflictingResource), tc.forceConflicts).
+				Return(applyResult, tc.applyErr)
+			// The resources after a conflicting resource are still applied.
+			rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(otherResource), tc.forceConflicts).
+				Return(resource.CreatedApplyResult, nil)
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncSetStatus}
+			rt.expectRequeue = tc.expectRequeue
+			rt.run(t)
+		})
+	}
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	syncSet := func(name string, dependsOn ...string) CommonSyncSet {
+		ss := testsyncset.Build(testsyncset.WithName(name))
+		for _, dep := range dependsOn {
+			ss.Spec.DependsOn = append(ss.Spec.DependsOn, hivev1.SyncSetDependency{Name: dep})
+		}
+		return (*SyncSetAsCommon)(ss)
+	}
+	cases := []struct {
+		name     string
+		syncSets []CommonSyncSet
+		expected []string
+	}{
+		{
+			name:     "no dependencies",
+			syncSets: []CommonSyncSet{syncSet("a"), syncSet("b"), syncSet("c")},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "chain",
+			syncSets: []CommonSyncSet{syncSet("a", "b"), syncSet("b", "c"), syncSet("c")},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			name:     "missing dependency",
+			syncSets: []CommonSyncSet{syncSet("a", "x"), syncSet("b")},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "cycle",
+			syncSets: []CommonSyncSet{syncSet("a", "c"), syncSet("b"), syncSet("c", "a"), syncSet("d", "b")},
+			expected: []string{"b", "d", "a", "c"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []string
+			for _, ss := range orderByDependencies("SyncSet", tc.syncSets) {
+				actual = append(actual, ss.AsMetaObject().GetName())
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFindDependencyFailures(t *testing.T) {
+	syncSet := func(name string, dependsOn ...hivev1.SyncSetDependency) CommonSyncSet {
+		ss := testsyncset.Build(testsyncset.WithName(name))
+		ss.Spec.DependsOn = dependsOn
+		return (*SyncSetAsCommon)(ss)
+	}
+	selectorSyncSet := func(name string, dependsOn ...hivev1.SyncSetDependency) CommonSyncSet {
+		sss := testselectorsyncset.Build(testselectorsyncset.WithName(name))
+		sss.Spec.DependsOn = dependsOn
+		return (*SelectorSyncSetAsCommon)(sss)
+	}
+	dep := func(kind, name string) hivev1.SyncSetDependency {
+		return hivev1.SyncSetDependency{Kind: kind, Name: name}
+	}
+	cases := []struct {
+		name             string
+		syncSets         []CommonSyncSet
+		selectorSyncSets []CommonSyncSet
+		expected         dependencyFailures
+	}{
+		{
+			name:             "no failures",
+			syncSets:         []CommonSyncSet{syncSet("a", dep("", "b")), syncSet("b", dep("SelectorSyncSet", "c"))},
+			selectorSyncSets: []CommonSyncSet{selectorSyncSet("c")},
+			expected:         dependencyFailures{"SyncSet": {}, "SelectorSyncSet": {}},
+		},
+		{
+			name:     "missing dependency",
+			syncSets: []CommonSyncSet{syncSet("a", dep("SelectorSyncSet", "x")), syncSet("b", dep("", "a")), syncSet("c")},
+			expected: dependencyFailures{
+				"SyncSet": {
+					"a": "dependency SelectorSyncSet x does not exist or does not apply to the cluster",
+					"b": "dependency SyncSet a can never be applied",
+				},
+				"SelectorSyncSet": {},
+			},
+		},
+		{
+			name:             "cycle across kinds",
+			syncSets:         []CommonSyncSet{syncSet("a", dep("SelectorSyncSet", "b")), syncSet("c")},
+			selectorSyncSets: []CommonSyncSet{selectorSyncSet("b", dep("SyncSet", "a")), selectorSyncSet("d", dep("", "b"))},
+			expected: dependencyFailures{
+				"SyncSet": {
+					"a": "circular dependency: SyncSet a -> SelectorSyncSet b -> SyncSet a",
+				},
+				"SelectorSyncSet": {
+					"b": "circular dependency: SyncSet a -> SelectorSyncSet b -> SyncSet a",
+					"d": "dependency SelectorSyncSet b can never be applied",
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, findDependencyFailures(tc.syncSets, tc.selectorSyncSets))
+		})
+	}
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+	appsv1.AddToScheme(scheme)
+	return scheme
+}
+
+func cdBuilder(scheme *runtime.Scheme) testcd.Builder {
+	return testcd.FullBuilder(testNamespace, testCDName, scheme).
+		GenericOptions(
+			testgeneric.WithUID(testCDUID),
+		).
+		Options(
+			testcd.Installed(),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionFalse,
+			}),
+			testcd.InstalledTimestamp(time.Now()),
+		)
+}
+
+func clusterSyncBuilder(scheme *runtime.Scheme) testcs.Builder {
+	return testcs.FullBuilder(testNamespace, testClusterSyncName, scheme).GenericOptions(
+		testgeneric.WithUID(testClusterSyncUID),
+		testgeneric.WithOwnerReference(cdBuilder(scheme).Build()),
+	)
+}
+
+func buildSyncLease(t time.Time) *hiveintv1alpha1.ClusterSyncLease {
+	return &hiveintv1alpha1.ClusterSyncLease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testLeaseName,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "hiveinternal.openshift.io/v1alpha1",
+				Kind:               "ClusterSync",
+				Name:               testClusterSyncName,
+				UID:                testClusterSyncUID,
+				BlockOwnerDeletion: pointer.BoolPtr(true),
+			}},
+		},
+		Spec: hiveintv1alpha1.ClusterSyncLeaseSpec{
+			RenewTime: metav1.NewMicroTime(t),
+		},
+	}
+}
+
+func testConfigMap(namespace, name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+}
+
+func testNamespaceResource(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func testCRD() *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName("foos.example.com")
+	return crd
+}
+
+func testCRDWithEstablished(status string) *unstructured.Unstructured {
+	crd := testCRD()
+	unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": status},
+	}, "status", "conditions")
+	return crd
+}
+
+func testDeployment(namespace, name string) *unstructured.Unstructured {
+	d := &unstructured.Unstructured{}
+	d.SetAPIVersion("apps/v1")
+	d.SetKind("Deployment")
+	d.SetNamespace(namespace)
+	d.SetName(name)
+	return d
+}
+
+func testCustomResource(namespace, name string) *unstructured.Unstructured {
+	cr := &unstructured.Unstructured{}
+	cr.SetAPIVersion("example.com/v1")
+	cr.SetKind("Foo")
+	cr.SetNamespace(namespace)
+	cr.SetName(name)
+	return cr
+}
+
+func testConfigMapRef(namespace, name string) hiveintv1alpha1.SyncResourceReference {
+	return hiveintv1alpha1.SyncResourceReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
+
+func testSecretMapping(srcName, destNamespace, destName string) hivev1.SecretMapping {
+	return hivev1.SecretMapping{
+		SourceRef: hivev1.SecretReference{
+			Name: srcName,
+		},
+		TargetRef: hivev1.SecretReference{
+			Namespace: destNamespace,
+			Name:      destName,
 		},
 	}
 }
@@ -2484,6 +3996,19 @@ func newApplyMatcher(resource hivev1.MetaRuntimeObject) gomock.Matcher {
 	return &applyMatcher{resource: u}
 }
 
+// newDiffMatcher matches the resource as it is passed to Diff, which is without the hive managed label.
+func newDiffMatcher(resource hivev1.MetaRuntimeObject) gomock.Matcher {
+	resourceAsJSON, err := json.Marshal(resource)
+	if err != nil {
+		panic(errors.Wrap(err, "could not marshal resource to JSON"))
+	}
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(resourceAsJSON, u); err != nil {
+		panic(errors.Wrap(err, "could not unmarshal as unstructured"))
+	}
+	return &applyMatcher{resource: u}
+}
+
 func (m *applyMatcher) Matches(x interface{}) bool {
 	rawData, ok := x.([]byte)
 	if !ok {
@@ -2578,6 +4103,26 @@ func withFailureResult(message string) syncStatusOption {
 	}
 }
 
+func withWaitingResult(message string) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.Result = hiveintv1alpha1.WaitingSyncSetResult
+		syncStatus.FailureMessage = message
+	}
+}
+
+func withHealth(health hiveintv1alpha1.ResourceHealth, resourceHealth ...hiveintv1alpha1.SyncResourceHealth) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.Health = health
+		syncStatus.ResourceHealth = resourceHealth
+	}
+}
+
+func withDriftedResources(driftedResources ...hiveintv1alpha1.SyncResourceDrift) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.DriftedResources = driftedResources
+	}
+}
+
 func withResourcesToDelete(resourcesToDelete ...hiveintv1alpha1.SyncResourceReference) syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.ResourcesToDelete = resourcesToDelete
@@ -2607,3 +4152,16 @@ func withFirstSuccessTime(firstSuccessTime metav1.Time) syncStatusOption {
 		syncStatus.FirstSuccessTime = &firstSuccessTime
 	}
 }
+
+func withRenderedHash(t *testing.T, syncSet *hivev1.SyncSet, cd *hivev1.ClusterDeployment) syncStatusOption {
+	// Round-trip the syncset through JSON so that its resources are raw, as they are when read from the API.
+	raw, err := json.Marshal(syncSet)
+	require.NoError(t, err, "could not marshal syncset")
+	ss := &hivev1.SyncSet{}
+	require.NoError(t, json.Unmarshal(raw, ss), "could not unmarshal syncset")
+	_, hash, err := renderSyncSet((*SyncSetAsCommon)(ss), cd)
+	require.NoError(t, err, "could not render syncset")
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.RenderedHash = hash
+	}
+}
@@ -1,6 +1,8 @@
 package clustersync
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -48,3 +50,15 @@ func (s *SelectorSyncSetAsCommon) AsMetaObject() metav1.Object {
 func (s *SelectorSyncSetAsCommon) GetSpec() *hivev1.SyncSetCommonSpec {
 	return &s.Spec.SyncSetCommonSpec
 }
+
+// copySyncSet returns a deep copy of the syncset.
+func copySyncSet(syncSet CommonSyncSet) (CommonSyncSet, error) {
+	switch s := syncSet.AsRuntimeObject().DeepCopyObject().(type) {
+	case *hivev1.SyncSet:
+		return (*SyncSetAsCommon)(s), nil
+	case *hivev1.SelectorSyncSet:
+		return (*SelectorSyncSetAsCommon)(s), nil
+	default:
+		return nil, fmt.Errorf("unexpected syncset type %T", s)
+	}
+}
@@ -0,0 +1,45 @@
+package clustersync
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// applyConflictError is returned when resources of a syncset could not be applied with server-side apply because
+// some of their fields are owned by other field managers.
+type applyConflictError struct {
+	conflicts []hiveintv1alpha1.SyncResourceConflict
+}
+
+func (e *applyConflictError) Error() string {
+	conflicts := make([]string, len(e.conflicts))
+	for i, c := range e.conflicts {
+		conflicts[i] = fmt.Sprintf("%s %s: %s", c.Kind, resourceName(c.SyncResourceReference), strings.Join(c.Fields, ", "))
+	}
+	return fmt.Sprintf("fields are owned by other field managers: %s", strings.Join(conflicts, "; "))
+}
+
+// newResourceConflict returns the conflict for the resource if the error applying it is due to fields owned by other
+// field managers, or nil otherwise.
+func newResourceConflict(ref hiveintv1alpha1.SyncResourceReference, err error) *hiveintv1alpha1.SyncResourceConflict {
+	if !resource.IsApplyConflict(err) {
+		return nil
+	}
+	return &hiveintv1alpha1.SyncResourceConflict{
+		SyncResourceReference: ref,
+		Fields:                resource.ApplyConflictFields(err),
+		Message:               errors.Cause(err).Error(),
+	}
+}
+
+func resourceName(ref hiveintv1alpha1.SyncResourceReference) string {
+	if ref.Namespace == "" {
+		return ref.Name
+	}
+	return ref.Namespace + "/" + ref.Name
+}
@@ -0,0 +1,226 @@
+package clustersync
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// healthCheckInterval is how often the health of resources that are not healthy is assessed again.
+const healthCheckInterval = time.Minute
+
+// shouldAssessHealth returns true if the health of the resources of the syncset should be assessed in the cluster.
+// Fake clusters have no workloads to assess, and the resources of syncsets that are only audited are not applied.
+func shouldAssessHealth(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment) bool {
+	return syncSet.GetSpec().HealthCheckMode == hivev1.EnabledSyncSetHealthCheckMode &&
+		!controllerutils.IsFakeCluster(cd) && !isAuditOnly(syncSet)
+}
+
+// assessHealth assesses the health of the given resources in the target cluster. It returns the health of each
+// resource along with the aggregate health, which is the worst health of any of the resources.
+func assessHealth(
+	resources []hiveintv1alpha1.SyncResourceReference,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (hiveintv1alpha1.ResourceHealth, []hiveintv1alpha1.SyncResourceHealth) {
+	aggregate := hiveintv1alpha1.HealthyResourceHealth
+	var resourceHealth []hiveintv1alpha1.SyncResourceHealth
+	for _, ref := range resources {
+		health, message := assessResourceHealth(ref, resourceHelper, logger)
+		resourceHealth = append(resourceHealth, hiveintv1alpha1.SyncResourceHealth{
+			SyncResourceReference: ref,
+			Health:                health,
+			Message:               message,
+		})
+		if healthRank(health) > healthRank(aggregate) {
+			aggregate = health
+		}
+	}
+	return aggregate, resourceHealth
+}
+
+func assessResourceHealth(
+	ref hiveintv1alpha1.SyncResourceReference,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (hiveintv1alpha1.ResourceHealth, string) {
+	logger = logger.WithField("resourceNamespace", ref.Namespace).
+		WithField("resourceName", ref.Name).
+		WithField("resourceAPIVersion", ref.APIVersion).
+		WithField("resourceKind", ref.Kind)
+	obj, err := resourceHelper.Get(ref.APIVersion, ref.Kind, ref.Namespace, ref.Name)
+	switch {
+	case apierrors.IsNotFound(err):
+		return hiveintv1alpha1.DegradedResourceHealth, "resource not found"
+	case err != nil:
+		logger.WithError(err).Warn("could not get resource to assess its health")
+		return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("could not get resource: %v", err)
+	}
+	health, message := resourceHealth(obj)
+	logger.WithField("health", health).Debug("assessed health of resource")
+	return health, message
+}
+
+// resourceHealth returns the health of a resource, based on the rollout of Deployments, DaemonSets and
+// StatefulSets, on the completion of Jobs, and on the conditions of any other resource. Resources without
+// conditions are healthy as long as they exist.
+func resourceHealth(obj *unstructured.Unstructured) (hiveintv1alpha1.ResourceHealth, string) {
+	if hasField(obj, "status", "observedGeneration") && nestedInt64(obj, "status", "observedGeneration") < obj.GetGeneration() {
+		return hiveintv1alpha1.ProgressingResourceHealth, "waiting for the latest generation to be observed"
+	}
+	switch obj.GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		return deploymentHealth(obj)
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		return statefulSetHealth(obj)
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		return daemonSetHealth(obj)
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		return jobHealth(obj)
+	default:
+		return conditionsHealth(obj)
+	}
+}
+
+func deploymentHealth(obj *unstructured.Unstructured) (hiveintv1alpha1.ResourceHealth, string) {
+	if cond := findCondition(obj, "Progressing"); cond != nil && cond["reason"] == "ProgressDeadlineExceeded" {
+		return hiveintv1alpha1.DegradedResourceHealth, fmt.Sprintf("rollout has exceeded its progress deadline: %v", cond["message"])
+	}
+	replicas := int64(1)
+	if hasField(obj, "spec", "replicas") {
+		replicas = nestedInt64(obj, "spec", "replicas")
+	}
+	updated := nestedInt64(obj, "status", "updatedReplicas")
+	available := nestedInt64(obj, "status", "availableReplicas")
+	total := nestedInt64(obj, "status", "replicas")
+	switch {
+	case updated < replicas:
+		return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("%d of %d replicas have been updated", updated, replicas)
+	case total > updated:
+		return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("%d old replicas are pending termination", total-updated)
+	case available < updated:
+		return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("%d of %d updated replicas are available", available, updated)
+	}
+	return hiveintv1alpha1.HealthyResourceHealth, ""
+}
+
+func statefulSetHealth(obj *unstructured.Unstructured) (hiveintv1alpha1.ResourceHealth, string) {
+	replicas := int64(1)
+	if hasField(obj, "spec", "replicas") {
+		replicas = nestedInt64(obj, "spec", "replicas")
+	}
+	ready := nestedInt64(obj, "status", "readyReplicas")
+	if ready < replicas {
+		return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("%d of %d replicas are ready", ready, replicas)
+	}
+	strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type")
+	if strategy == "" || strategy == "RollingUpdate" {
+		current, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+		update, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+		if current != update {
+			updated := nestedInt64(obj, "status", "updatedReplicas")
+			return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("%d of %d replicas have been updated", updated, replicas)
+		}
+	}
+	return hiveintv1alpha1.HealthyResourceHealth, ""
+}
+
+func daemonSetHealth(obj *unstructured.Unstructured) (hiveintv1alpha1.ResourceHealth, string) {
+	desired := nestedInt64(obj, "status", "desiredNumberScheduled")
+	updated := nestedInt64(obj, "status", "updatedNumberScheduled")
+	available := nestedInt64(obj, "status", "numberAvailable")
+	switch {
+	case updated < desired:
+		return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("%d of %d pods have been updated", updated, desired)
+	case available < desired:
+		return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("%d of %d pods are available", available, desired)
+	}
+	return hiveintv1alpha1.HealthyResourceHealth, ""
+}
+
+func jobHealth(obj *unstructured.Unstructured) (hiveintv1alpha1.ResourceHealth, string) {
+	if cond := findCondition(obj, "Failed"); cond != nil && cond["status"] == "True" {
+		return hiveintv1alpha1.DegradedResourceHealth, fmt.Sprintf("job has failed: %v", cond["message"])
+	}
+	if cond := findCondition(obj, "Complete"); cond != nil && cond["status"] == "True" {
+		return hiveintv1alpha1.HealthyResourceHealth, ""
+	}
+	return hiveintv1alpha1.ProgressingResourceHealth, "job has not completed"
+}
+
+func conditionsHealth(obj *unstructured.Unstructured) (hiveintv1alpha1.ResourceHealth, string) {
+	for _, condType := range []string{"Degraded", "Failed"} {
+		if cond := findCondition(obj, condType); cond != nil && cond["status"] == "True" {
+			return hiveintv1alpha1.DegradedResourceHealth, fmt.Sprintf("%s: %v", condType, cond["message"])
+		}
+	}
+	for _, condType := range []string{"Ready", "Available"} {
+		if cond := findCondition(obj, condType); cond != nil {
+			if cond["status"] == "True" {
+				return hiveintv1alpha1.HealthyResourceHealth, ""
+			}
+			return hiveintv1alpha1.ProgressingResourceHealth, fmt.Sprintf("not %s: %v", condType, cond["message"])
+		}
+	}
+	return hiveintv1alpha1.HealthyResourceHealth, ""
+}
+
+func findCondition(obj *unstructured.Unstructured, condType string) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		if cond, ok := c.(map[string]interface{}); ok && cond["type"] == condType {
+			return cond
+		}
+	}
+	return nil
+}
+
+func hasField(obj *unstructured.Unstructured, fields ...string) bool {
+	_, found, _ := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	return found
+}
+
+// nestedInt64 returns the integer value of a field, which may have been decoded from JSON as either an int64 or a
+// float64.
+func nestedInt64(obj *unstructured.Unstructured, fields ...string) int64 {
+	value, _, _ := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	switch v := value.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+func healthRank(health hiveintv1alpha1.ResourceHealth) int {
+	switch health {
+	case hiveintv1alpha1.DegradedResourceHealth:
+		return 2
+	case hiveintv1alpha1.ProgressingResourceHealth:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// needsHealthCheck returns true if any of the sync statuses has resources that are not healthy, in which case their
+// health should be assessed again after healthCheckInterval.
+func needsHealthCheck(syncStatuses []hiveintv1alpha1.SyncStatus) bool {
+	for _, status := range syncStatuses {
+		if status.Health != "" && status.Health != hiveintv1alpha1.HealthyResourceHealth {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package clustersync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+)
+
+func TestResourceHealth(t *testing.T) {
+	cases := []struct {
+		name            string
+		obj             map[string]interface{}
+		expectedHealth  hiveintv1alpha1.ResourceHealth
+		expectedMessage string
+	}{
+		{
+			name: "deployment rolled out",
+			obj: object("apps/v1", "Deployment", 2,
+				map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			),
+			expectedHealth: hiveintv1alpha1.HealthyResourceHealth,
+		},
+		{
+			name: "deployment generation not observed",
+			obj: object("apps/v1", "Deployment", 2,
+				map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"observedGeneration": int64(1), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "waiting for the latest generation to be observed",
+		},
+		{
+			name: "deployment rolling out",
+			obj: object("apps/v1", "Deployment", 1,
+				map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"observedGeneration": int64(1), "replicas": int64(3), "updatedReplicas": int64(1)},
+			),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "1 of 3 replicas have been updated",
+		},
+		{
+			name: "deployment old replicas terminating",
+			obj: object("apps/v1", "Deployment", 1,
+				map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"observedGeneration": int64(1), "replicas": int64(4), "updatedReplicas": int64(3)},
+			),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "1 old replicas are pending termination",
+		},
+		{
+			name: "deployment not available",
+			obj: object("apps/v1", "Deployment", 1,
+				nil,
+				map[string]interface{}{"observedGeneration": int64(1), "replicas": int64(1), "updatedReplicas": int64(1)},
+			),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "0 of 1 updated replicas are available",
+		},
+		{
+			name: "deployment progress deadline exceeded",
+			obj: object("apps/v1", "Deployment", 1,
+				nil,
+				map[string]interface{}{"observedGeneration": int64(1), "conditions": []interface{}{
+					map[string]interface{}{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded", "message": "timed out"},
+				}},
+			),
+			expectedHealth:  hiveintv1alpha1.DegradedResourceHealth,
+			expectedMessage: "rollout has exceeded its progress deadline: timed out",
+		},
+		{
+			name: "statefulset ready",
+			obj: object("apps/v1", "StatefulSet", 1,
+				map[string]interface{}{"replicas": int64(2)},
+				map[string]interface{}{"observedGeneration": int64(1), "readyReplicas": int64(2), "currentRevision": "r1", "updateRevision": "r1"},
+			),
+			expectedHealth: hiveintv1alpha1.HealthyResourceHealth,
+		},
+		{
+			name: "statefulset updating",
+			obj: object("apps/v1", "StatefulSet", 1,
+				map[string]interface{}{"replicas": int64(2)},
+				map[string]interface{}{"observedGeneration": int64(1), "readyReplicas": int64(2), "updatedReplicas": int64(1), "currentRevision": "r1", "updateRevision": "r2"},
+			),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "1 of 2 replicas have been updated",
+		},
+		{
+			name: "statefulset on delete",
+			obj: object("apps/v1", "StatefulSet", 1,
+				map[string]interface{}{"replicas": int64(2), "updateStrategy": map[string]interface{}{"type": "OnDelete"}},
+				map[string]interface{}{"observedGeneration": int64(1), "readyReplicas": int64(2), "currentRevision": "r1", "updateRevision": "r2"},
+			),
+			expectedHealth: hiveintv1alpha1.HealthyResourceHealth,
+		},
+		{
+			name: "daemonset rolled out",
+			obj: object("apps/v1", "DaemonSet", 1,
+				nil,
+				map[string]interface{}{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(3), "numberAvailable": int64(3)},
+			),
+			expectedHealth: hiveintv1alpha1.HealthyResourceHealth,
+		},
+		{
+			name: "daemonset not available",
+			obj: object("apps/v1", "DaemonSet", 1,
+				nil,
+				map[string]interface{}{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(3), "numberAvailable": int64(2)},
+			),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "2 of 3 pods are available",
+		},
+		{
+			name:            "job running",
+			obj:             object("batch/v1", "Job", 1, nil, map[string]interface{}{"active": int64(1)}),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "job has not completed",
+		},
+		{
+			name: "job complete",
+			obj: object("batch/v1", "Job", 1, nil, map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Complete", "status": "True"},
+			}}),
+			expectedHealth: hiveintv1alpha1.HealthyResourceHealth,
+		},
+		{
+			name: "job failed",
+			obj: object("batch/v1", "Job", 1, nil, map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Failed", "status": "True", "message": "backoff limit exceeded"},
+			}}),
+			expectedHealth:  hiveintv1alpha1.DegradedResourceHealth,
+			expectedMessage: "job has failed: backoff limit exceeded",
+		},
+		{
+			name: "ready condition true",
+			obj: object("example.com/v1", "Foo", 1, nil, map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+			}}),
+			expectedHealth: hiveintv1alpha1.HealthyResourceHealth,
+		},
+		{
+			name: "available condition false",
+			obj: object("example.com/v1", "Foo", 1, nil, map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False", "message": "starting"},
+			}}),
+			expectedHealth:  hiveintv1alpha1.ProgressingResourceHealth,
+			expectedMessage: "not Available: starting",
+		},
+		{
+			name: "degraded condition true",
+			obj: object("example.com/v1", "Foo", 1, nil, map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Degraded", "status": "True", "message": "broken"},
+			}}),
+			expectedHealth:  hiveintv1alpha1.DegradedResourceHealth,
+			expectedMessage: "Degraded: broken",
+		},
+		{
+			name:           "no status",
+			obj:            object("v1", "ConfigMap", 0, nil, nil),
+			expectedHealth: hiveintv1alpha1.HealthyResourceHealth,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			health, message := resourceHealth(&unstructured.Unstructured{Object: tc.obj})
+			assert.Equal(t, tc.expectedHealth, health, "unexpected health")
+			assert.Equal(t, tc.expectedMessage, message, "unexpected message")
+		})
+	}
+}
+
+func object(apiVersion, kind string, generation int64, spec, status map[string]interface{}) map[string]interface{} {
+	obj := map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":       "test",
+			"generation": generation,
+		},
+	}
+	if spec != nil {
+		obj["spec"] = spec
+	}
+	if status != nil {
+		obj["status"] = status
+	}
+	return obj
+}
@@ -0,0 +1,293 @@
+package clustersync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/kustomize"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/kustomize/pkg/constants"
+	"sigs.k8s.io/kustomize/pkg/fs"
+	"sigs.k8s.io/kustomize/pkg/git"
+	kustomizetypes "sigs.k8s.io/kustomize/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// kustomizeCacheSize is the maximum number of kustomization builds that are cached.
+	kustomizeCacheSize = 100
+
+	// kustomizeCacheTTL is how long a kustomization build is cached after it was last built.
+	kustomizeCacheTTL = time.Hour
+
+	// configMapCacheSize is the maximum number of ConfigMaps holding kustomization trees whose content is cached.
+	configMapCacheSize = 500
+
+	// configMapCacheTTL is how long the content of a ConfigMap is cached after it was last read.
+	configMapCacheTTL = time.Hour
+)
+
+// newKustomizeCache returns a cache of the resources built from kustomization trees, keyed by the hash of the content
+// of the trees. A tree shared by the syncsets of many clusters is then only built once.
+func newKustomizeCache() *cache.LRUExpireCache {
+	return cache.NewLRUExpireCache(kustomizeCacheSize)
+}
+
+// configMapCacheEntry is the content of a ConfigMap holding a kustomization tree at a given resource version.
+type configMapCacheEntry struct {
+	resourceVersion string
+	files           map[string]string
+}
+
+// newConfigMapCache returns a cache of the content of the ConfigMaps holding kustomization trees, keyed by the
+// namespace and name of the ConfigMaps. The content of a ConfigMap is only read again when the resource version in
+// the metadata watched by the controller no longer matches the cached one.
+func newConfigMapCache() *cache.LRUExpireCache {
+	return cache.NewLRUExpireCache(configMapCacheSize)
+}
+
+// buildKustomization returns a copy of the syncset with the resources built from its kustomization tree appended to its
+// Resources, along with the hash of the content of the tree. Syncsets without a kustomization are returned as is.
+func (r *ReconcileClusterSync) buildKustomization(syncSet CommonSyncSet, logger log.FieldLogger) (CommonSyncSet, string, error) {
+	source := syncSet.GetSpec().Kustomize
+	if source == nil {
+		return syncSet, "", nil
+	}
+	files, err := r.getKustomizationFiles(syncSet, source, logger)
+	if err != nil {
+		return nil, "", err
+	}
+	hash, err := controllerutils.GetChecksumOfObjects(source.Path, files)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to compute hash of kustomization")
+	}
+
+	var resources []runtime.RawExtension
+	if cached, ok := r.kustomizeCache.Get(hash); ok {
+		logger.WithField("hash", hash).Debug("using cached kustomization build")
+		resources = cached.([]runtime.RawExtension)
+	} else {
+		logger.WithField("hash", hash).Info("building kustomization")
+		resources, err = runKustomizeBuild(source.Path, files)
+		if err != nil {
+			return nil, "", err
+		}
+		r.kustomizeCache.Add(hash, resources, kustomizeCacheTTL)
+	}
+
+	built, err := copySyncSet(syncSet)
+	if err != nil {
+		return nil, "", err
+	}
+	spec := built.GetSpec()
+	spec.Resources = append(spec.Resources, resources...)
+	return built, hash, nil
+}
+
+// kustomizeSourceReferences returns whether the kustomization tree of the syncset includes the ConfigMap with the given
+// namespace and name.
+func kustomizeSourceReferences(syncSet CommonSyncSet, namespace, name string) bool {
+	source := syncSet.GetSpec().Kustomize
+	if source == nil {
+		return false
+	}
+	syncSetNamespace := syncSet.AsMetaObject().GetNamespace()
+	for _, ref := range source.ConfigMaps {
+		refNamespace := ref.Namespace
+		if refNamespace == "" {
+			refNamespace = syncSetNamespace
+		}
+		if refNamespace == namespace && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// requestsForKustomizeConfigMap returns a function mapping a ConfigMap to the ClusterDeployments of the SyncSets and
+// SelectorSyncSets whose kustomization trees include the ConfigMap, so that changes to the tree are synced promptly.
+func requestsForKustomizeConfigMap(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	selectorSyncSetRequests := requestsForSelectorSyncSet(c, logger)
+	return func(o client.Object) []reconcile.Request {
+		logger := logger.WithField("configMap", fmt.Sprintf("%s/%s", o.GetNamespace(), o.GetName()))
+		var requests []reconcile.Request
+		syncSets := &hivev1.SyncSetList{}
+		if err := c.List(context.Background(), syncSets, client.InNamespace(o.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SyncSets")
+			return nil
+		}
+		for i := range syncSets.Items {
+			ss := &syncSets.Items[i]
+			if kustomizeSourceReferences((*SyncSetAsCommon)(ss), o.GetNamespace(), o.GetName()) {
+				requests = append(requests, requestsForSyncSet(ss)...)
+			}
+		}
+		selectorSyncSets := &hivev1.SelectorSyncSetList{}
+		if err := c.List(context.Background(), selectorSyncSets); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SelectorSyncSets")
+			return nil
+		}
+		for i := range selectorSyncSets.Items {
+			sss := &selectorSyncSets.Items[i]
+			if kustomizeSourceReferences((*SelectorSyncSetAsCommon)(sss), o.GetNamespace(), o.GetName()) {
+				requests = append(requests, selectorSyncSetRequests(sss)...)
+			}
+		}
+		return requests
+	}
+}
+
+// getKustomizationFiles returns the content of the files of the kustomization tree keyed by their absolute path in the
+// tree.
+func (r *ReconcileClusterSync) getKustomizationFiles(
+	syncSet CommonSyncSet,
+	source *hivev1.KustomizeSource,
+	logger log.FieldLogger,
+) (map[string]string, error) {
+	syncSetNamespace := syncSet.AsMetaObject().GetNamespace()
+	files := map[string]string{}
+	for i, ref := range source.ConfigMaps {
+		namespace := ref.Namespace
+		if namespace == "" {
+			// The namespace of the ConfigMap is required for SelectorSyncSets.
+			if syncSetNamespace == "" {
+				return nil, fmt.Errorf("namespace missing for ConfigMap %d", i)
+			}
+			// Use the namespace of the SyncSet if the namespace of the ConfigMap is omitted.
+			namespace = syncSetNamespace
+		} else if syncSetNamespace != "" && syncSetNamespace != namespace {
+			return nil, fmt.Errorf("ConfigMap %d must be in the same namespace as the SyncSet", i)
+		}
+		cmFiles, err := r.getConfigMapFiles(types.NamespacedName{Namespace: namespace, Name: ref.Name}, logger)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read ConfigMap %d", i)
+		}
+		dir := path.Join("/", ref.Path)
+		for key, data := range cmFiles {
+			files[path.Join(dir, key)] = data
+		}
+	}
+	return files, nil
+}
+
+// getConfigMapFiles returns the content of the ConfigMap keyed by the names of its files. The content is read from
+// the API server only when the resource version of the ConfigMap changed since it was last read.
+func (r *ReconcileClusterSync) getConfigMapFiles(key types.NamespacedName, logger log.FieldLogger) (map[string]string, error) {
+	logger = logger.WithField("configMap", key.String())
+	metadata := &metav1.PartialObjectMetadata{}
+	metadata.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	if err := r.Get(context.Background(), key, metadata); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot get ConfigMap metadata")
+		return nil, err
+	}
+	if cached, ok := r.configMapCache.Get(key); ok {
+		if entry := cached.(*configMapCacheEntry); entry.resourceVersion == metadata.ResourceVersion {
+			return entry.files, nil
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.configMapReader.Get(context.Background(), key, cm); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot read ConfigMap")
+		return nil, err
+	}
+	files := make(map[string]string, len(cm.Data)+len(cm.BinaryData))
+	for name, data := range cm.Data {
+		files[name] = data
+	}
+	for name, data := range cm.BinaryData {
+		files[name] = string(data)
+	}
+	logger.WithField("resourceVersion", cm.ResourceVersion).Debug("read ConfigMap")
+	r.configMapCache.Add(key, &configMapCacheEntry{resourceVersion: cm.ResourceVersion, files: files}, configMapCacheTTL)
+	return files, nil
+}
+
+// runKustomizeBuild builds the kustomization at the given path of the tree of files, and returns the built resources.
+// The tree is built in memory. Remote bases are not supported, since they would need to be cloned.
+func runKustomizeBuild(kustomizationPath string, files map[string]string) ([]runtime.RawExtension, error) {
+	fSys := fs.MakeFakeFS()
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if err := checkNoRemoteBases(p, files[p]); err != nil {
+			return nil, err
+		}
+		if err := fSys.WriteFile(p, []byte(files[p])); err != nil {
+			return nil, errors.Wrapf(err, "failed to write %s", p)
+		}
+	}
+
+	out := &bytes.Buffer{}
+	if err := kustomize.RunKustomizeBuild(out, fSys, path.Join("/", kustomizationPath)); err != nil {
+		return nil, err
+	}
+
+	var resources []runtime.RawExtension
+	decoder := utilyaml.NewYAMLOrJSONDecoder(out, 4096)
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "failed to decode built resources")
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode built resource")
+		}
+		resources = append(resources, runtime.RawExtension{Raw: raw})
+	}
+	return resources, nil
+}
+
+// checkNoRemoteBases returns an error if the file is a kustomization with a base that is a git repository.
+func checkNoRemoteBases(filePath, content string) error {
+	isKustomization := false
+	for _, name := range constants.KustomizationFileNames {
+		if path.Base(filePath) == name {
+			isKustomization = true
+			break
+		}
+	}
+	if !isKustomization {
+		return nil
+	}
+	k := &kustomizetypes.Kustomization{}
+	if err := yaml.Unmarshal([]byte(content), k); err != nil {
+		return errors.Wrapf(err, "failed to decode %s", filePath)
+	}
+	for _, base := range k.Bases {
+		if _, err := git.NewRepoSpecFromUrl(base); err == nil {
+			return fmt.Errorf("remote base %s of %s is not supported", base, filePath)
+		}
+	}
+	return nil
+}
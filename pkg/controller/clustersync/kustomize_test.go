@@ -0,0 +1,179 @@
+package clustersync
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+func TestRunKustomizeBuild(t *testing.T) {
+	cases := []struct {
+		name              string
+		path              string
+		files             map[string]string
+		expectedResources []string
+		expectedError     string
+	}{
+		{
+			name: "multiple resources",
+			files: map[string]string{
+				"/kustomization.yaml": "resources:\n- resources.yaml\nnamespace: dest-namespace\n",
+				"/resources.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n",
+			},
+			expectedResources: []string{
+				`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a","namespace":"dest-namespace"}}`,
+				`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"b","namespace":"dest-namespace"}}`,
+			},
+		},
+		{
+			name: "overlay",
+			path: "overlay",
+			files: map[string]string{
+				"/base/kustomization.yaml":    "resources:\n- configmap.yaml\n",
+				"/base/configmap.yaml":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n",
+				"/overlay/kustomization.yaml": "bases:\n- ../base\ncommonLabels:\n  env: prod\n",
+			},
+			expectedResources: []string{
+				`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"labels":{"env":"prod"},"name":"a"}}`,
+			},
+		},
+		{
+			name: "remote base",
+			files: map[string]string{
+				"/kustomization.yaml": "bases:\n- github.com/example/repo//base?ref=v1\n",
+			},
+			expectedError: "remote base github.com/example/repo//base?ref=v1 of /kustomization.yaml is not supported",
+		},
+		{
+			name: "missing kustomization",
+			files: map[string]string{
+				"/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n",
+			},
+			expectedError: "unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory '/'",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resources, err := runKustomizeBuild(tc.path, tc.files)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError, "unexpected error")
+				return
+			}
+			if assert.NoError(t, err, "unexpected error") {
+				actual := make([]string, len(resources))
+				for i, r := range resources {
+					actual[i] = string(r.Raw)
+				}
+				assert.Equal(t, tc.expectedResources, actual, "unexpected resources")
+			}
+		})
+	}
+}
+
+func TestRequestsForKustomizeConfigMap(t *testing.T) {
+	scheme := newScheme()
+	kustomizeSource := func(namespace, name string) *hivev1.KustomizeSource {
+		return &hivev1.KustomizeSource{ConfigMaps: []hivev1.KustomizeConfigMap{{Namespace: namespace, Name: name}}}
+	}
+	existing := []runtime.Object{
+		testcd.FullBuilder(testNamespace, "cd-1", scheme).Build(testcd.WithLabel("kustomize", "true")),
+		testcd.FullBuilder("other-namespace", "cd-2", scheme).Build(testcd.WithLabel("kustomize", "true")),
+		testcd.FullBuilder(testNamespace, "cd-3", scheme).Build(),
+		testsyncset.FullBuilder(testNamespace, "implicit-namespace", scheme).Build(
+			testsyncset.ForClusterDeployments("cd-3"),
+			testsyncset.WithKustomize(kustomizeSource("", "tree")),
+		),
+		testsyncset.FullBuilder(testNamespace, "other-configmap", scheme).Build(
+			testsyncset.ForClusterDeployments("cd-4"),
+			testsyncset.WithKustomize(kustomizeSource("", "other-tree")),
+		),
+		testsyncset.FullBuilder("other-namespace", "other-namespace", scheme).Build(
+			testsyncset.ForClusterDeployments("cd-5"),
+			testsyncset.WithKustomize(kustomizeSource("", "tree")),
+		),
+		testsyncset.FullBuilder(testNamespace, "no-kustomize", scheme).Build(
+			testsyncset.ForClusterDeployments("cd-6"),
+		),
+		testselectorsyncset.FullBuilder("selector", scheme).Build(
+			testselectorsyncset.WithLabelSelector("kustomize", "true"),
+			testselectorsyncset.WithKustomize(kustomizeSource(testNamespace, "tree")),
+		),
+		testselectorsyncset.FullBuilder("other-selector", scheme).Build(
+			testselectorsyncset.WithLabelSelector("other", "true"),
+			testselectorsyncset.WithKustomize(kustomizeSource("other-namespace", "tree")),
+		),
+	}
+	c := fake.NewFakeClientWithScheme(scheme, existing...)
+	configMap := &metav1.PartialObjectMetadata{}
+	configMap.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	configMap.Namespace = testNamespace
+	configMap.Name = "tree"
+
+	requests := requestsForKustomizeConfigMap(c, log.StandardLogger())(configMap)
+
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "cd-3"}},
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "cd-1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "other-namespace", Name: "cd-2"}},
+	}, requests, "unexpected requests for ConfigMap")
+}
+
+// countingReader counts the reads of the wrapped reader
+type countingReader struct {
+	client.Reader
+	reads int
+}
+
+func (r *countingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	r.reads++
+	return r.Reader.Get(ctx, key, obj)
+}
+
+func TestGetKustomizationFilesCachesConfigMaps(t *testing.T) {
+	scheme := newScheme()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "tree"},
+		Data:       map[string]string{"kustomization.yaml": "resources:\n- a.yaml\n"},
+	}
+	c := fake.NewFakeClientWithScheme(scheme, configMap)
+	reader := &countingReader{Reader: c}
+	r := &ReconcileClusterSync{
+		Client:          c,
+		configMapCache:  newConfigMapCache(),
+		configMapReader: reader,
+	}
+	syncSet := (*SyncSetAsCommon)(testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build())
+	source := &hivev1.KustomizeSource{ConfigMaps: []hivev1.KustomizeConfigMap{{Name: "tree", Path: "overlay"}}}
+
+	for i := 0; i < 2; i++ {
+		files, err := r.getKustomizationFiles(syncSet, source, log.StandardLogger())
+		require.NoError(t, err, "unexpected error getting kustomization files")
+		assert.Equal(t, map[string]string{"/overlay/kustomization.yaml": "resources:\n- a.yaml\n"}, files, "unexpected files")
+	}
+	assert.Equal(t, 1, reader.reads, "expected the ConfigMap to be read once while unchanged")
+
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(configMap), configMap))
+	configMap.Data["kustomization.yaml"] = "resources:\n- b.yaml\n"
+	require.NoError(t, c.Update(context.TODO(), configMap))
+
+	files, err := r.getKustomizationFiles(syncSet, source, log.StandardLogger())
+	require.NoError(t, err, "unexpected error getting kustomization files")
+	assert.Equal(t, map[string]string{"/overlay/kustomization.yaml": "resources:\n- b.yaml\n"}, files, "unexpected files after update")
+	assert.Equal(t, 2, reader.reads, "expected the ConfigMap to be read again after it changed")
+}
@@ -0,0 +1,271 @@
+package clustersync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+)
+
+const (
+	crdGroup = "apiextensions.k8s.io"
+	crdKind  = "CustomResourceDefinition"
+)
+
+// waitingError is returned when a syncset cannot be applied yet, as opposed to having failed to apply.
+type waitingError struct {
+	message string
+}
+
+func (e *waitingError) Error() string {
+	return e.message
+}
+
+// syncSetResults holds the latest result of each syncset that applies to the cluster, keyed by kind and name.
+type syncSetResults map[string]map[string]hiveintv1alpha1.SyncSetResult
+
+func newSyncSetResults() syncSetResults {
+	return syncSetResults{"SyncSet": {}, "SelectorSyncSet": {}}
+}
+
+// add records the results of the syncsets of the given kind from their sync statuses. Syncsets without a sync
+// status have not been applied yet, so they have an empty result.
+func (r syncSetResults) add(syncSetType string, syncSets []CommonSyncSet, syncStatuses []hiveintv1alpha1.SyncStatus) {
+	for _, syncSet := range syncSets {
+		status, _ := getOldSyncStatus(syncSet, syncStatuses)
+		r[syncSetType][syncSet.AsMetaObject().GetName()] = status.Result
+	}
+}
+
+// pendingDependencies returns the dependencies of the syncset that have not been applied successfully to the cluster.
+func (r syncSetResults) pendingDependencies(syncSetType string, syncSet CommonSyncSet) []string {
+	var pending []string
+	for _, dep := range syncSet.GetSpec().DependsOn {
+		kind := dependencyKind(syncSetType, dep)
+		if r[kind][dep.Name] != hiveintv1alpha1.SuccessSyncSetResult {
+			pending = append(pending, fmt.Sprintf("%s %s", kind, dep.Name))
+		}
+	}
+	return pending
+}
+
+func dependencyKind(syncSetType string, dep hivev1.SyncSetDependency) string {
+	if dep.Kind == "" {
+		return syncSetType
+	}
+	return dep.Kind
+}
+
+func waitingForDependencies(pending []string) error {
+	return &waitingError{message: fmt.Sprintf("waiting for %s", strings.Join(pending, ", "))}
+}
+
+// dependencyFailures holds the reason why each syncset that can never be applied to the cluster, because of its
+// dependencies, is failing, keyed by kind and name.
+type dependencyFailures map[string]map[string]string
+
+// syncSetRef is the kind and name of a syncset in the dependency graph of the syncsets of a cluster.
+type syncSetRef struct {
+	kind string
+	name string
+}
+
+func (r syncSetRef) String() string {
+	return fmt.Sprintf("%s %s", r.kind, r.name)
+}
+
+// findDependencyFailures returns the syncsets whose dependencies can never be satisfied for the cluster: syncsets
+// depending on a syncset that does not exist or does not apply to the cluster, syncsets with circular dependencies,
+// and syncsets depending on any of those. Such syncsets would otherwise wait for their dependencies forever.
+func findDependencyFailures(syncSets, selectorSyncSets []CommonSyncSet) dependencyFailures {
+	deps := map[syncSetRef][]syncSetRef{}
+	var refs []syncSetRef
+	addSyncSets := func(syncSetType string, syncSets []CommonSyncSet) {
+		for _, syncSet := range syncSets {
+			ref := syncSetRef{kind: syncSetType, name: syncSet.AsMetaObject().GetName()}
+			refs = append(refs, ref)
+			deps[ref] = nil
+			for _, dep := range syncSet.GetSpec().DependsOn {
+				deps[ref] = append(deps[ref], syncSetRef{kind: dependencyKind(syncSetType, dep), name: dep.Name})
+			}
+		}
+	}
+	addSyncSets("SyncSet", syncSets)
+	addSyncSets("SelectorSyncSet", selectorSyncSets)
+	// Visit the SyncSets before the SelectorSyncSets and each kind by name, so that the reported cycles are stable.
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].kind != refs[j].kind {
+			return refs[i].kind > refs[j].kind
+		}
+		return refs[i].name < refs[j].name
+	})
+
+	failures := map[syncSetRef]string{}
+	for _, ref := range refs {
+		for _, dep := range deps[ref] {
+			if _, ok := deps[dep]; !ok {
+				failures[ref] = fmt.Sprintf("dependency %s does not exist or does not apply to the cluster", dep)
+				break
+			}
+		}
+	}
+
+	// Find the cycles with a depth-first search, keeping the path from the syncset the search started from.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[syncSetRef]int{}
+	var path []syncSetRef
+	var visit func(ref syncSetRef)
+	visit = func(ref syncSetRef) {
+		state[ref] = visiting
+		path = append(path, ref)
+		for _, dep := range deps[ref] {
+			if _, ok := deps[dep]; !ok {
+				continue
+			}
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				start := len(path) - 1
+				for path[start] != dep {
+					start--
+				}
+				cycle := make([]string, 0, len(path)-start+1)
+				for _, member := range path[start:] {
+					cycle = append(cycle, member.String())
+				}
+				cycle = append(cycle, dep.String())
+				for _, member := range path[start:] {
+					if _, failed := failures[member]; !failed {
+						failures[member] = fmt.Sprintf("circular dependency: %s", strings.Join(cycle, " -> "))
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[ref] = visited
+	}
+	for _, ref := range refs {
+		if state[ref] == unvisited {
+			visit(ref)
+		}
+	}
+
+	// Syncsets depending on a syncset that can never be applied can never be applied either.
+	for changed := true; changed; {
+		changed = false
+		for _, ref := range refs {
+			if _, failed := failures[ref]; failed {
+				continue
+			}
+			for _, dep := range deps[ref] {
+				if _, failed := failures[dep]; failed {
+					failures[ref] = fmt.Sprintf("dependency %s can never be applied", dep)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	result := dependencyFailures{"SyncSet": {}, "SelectorSyncSet": {}}
+	for ref, message := range failures {
+		result[ref.kind][ref.name] = message
+	}
+	return result
+}
+
+// orderByDependencies orders the syncsets so that syncsets are applied after the syncsets of the same kind that
+// they depend on. The syncsets must already be sorted by name, which is kept as the order among syncsets that do
+// not depend on each other. Syncsets with circular dependencies are left in name order after all others, and are
+// reported as failing, see findDependencyFailures.
+func orderByDependencies(syncSetType string, syncSets []CommonSyncSet) []CommonSyncSet {
+	names := make(map[string]bool, len(syncSets))
+	for _, syncSet := range syncSets {
+		names[syncSet.AsMetaObject().GetName()] = true
+	}
+	ordered := make([]CommonSyncSet, 0, len(syncSets))
+	placed := make(map[string]bool, len(syncSets))
+	remaining := syncSets
+	for len(remaining) > 0 {
+		var next []CommonSyncSet
+		for _, syncSet := range remaining {
+			ready := true
+			for _, dep := range syncSet.GetSpec().DependsOn {
+				if dependencyKind(syncSetType, dep) == syncSetType && names[dep.Name] && !placed[dep.Name] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, syncSet)
+				placed[syncSet.AsMetaObject().GetName()] = true
+			} else {
+				next = append(next, syncSet)
+			}
+		}
+		if len(next) == len(remaining) {
+			// No progress was made, so the remaining syncsets have circular dependencies.
+			ordered = append(ordered, next...)
+			break
+		}
+		remaining = next
+	}
+	return ordered
+}
+
+// orderResourcesForApply returns the indexes of the resources in the order in which they should be applied:
+// CustomResourceDefinitions first, then Namespaces, then everything else. The order within each group is the
+// order of the resources in the syncset. The number of CustomResourceDefinitions and Namespaces is also returned.
+func orderResourcesForApply(resources []*unstructured.Unstructured) (order []int, numCRDsAndNamespaces int) {
+	rank := func(u *unstructured.Unstructured) int {
+		gvk := u.GroupVersionKind()
+		switch {
+		case gvk.Group == crdGroup && gvk.Kind == crdKind:
+			return 0
+		case gvk.Group == "" && gvk.Kind == "Namespace":
+			return 1
+		default:
+			return 2
+		}
+	}
+	order = make([]int, len(resources))
+	for i := range resources {
+		order[i] = i
+		if rank(resources[i]) < 2 {
+			numCRDsAndNamespaces++
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rank(resources[order[i]]) < rank(resources[order[j]])
+	})
+	return
+}
+
+func isCRD(ref hiveintv1alpha1.SyncResourceReference) bool {
+	return schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).GroupKind() == schema.GroupKind{Group: crdGroup, Kind: crdKind}
+}
+
+// isEstablished returns true if the CustomResourceDefinition has an Established condition that is True.
+func isEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == "Established" && cond["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
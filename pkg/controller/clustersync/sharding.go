@@ -0,0 +1,159 @@
+package clustersync
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+)
+
+const (
+	// replicaWeightsEnvKey is the environment variable holding the weights of the replicas of the clustersync
+	// StatefulSet, as a comma-separated list of ordinal=weight pairs.
+	replicaWeightsEnvKey = "CLUSTERSYNC_REPLICA_WEIGHTS"
+
+	// defaultReplicaWeight is the weight of the replicas that are not given one.
+	defaultReplicaWeight = 100
+)
+
+// parseReplicaWeights parses the weights of the replicas of the clustersync StatefulSet from the value of the
+// CLUSTERSYNC_REPLICA_WEIGHTS environment variable.
+func parseReplicaWeights(value string) (map[int64]int64, error) {
+	weights := map[int64]int64{}
+	if value == "" {
+		return weights, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid replica weight %q", pair)
+		}
+		ordinal, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ordinal of replica weight %q", pair)
+		}
+		if ordinal < 0 {
+			return nil, fmt.Errorf("invalid ordinal of replica weight %q", pair)
+		}
+		weight, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight of replica weight %q", pair)
+		}
+		weights[ordinal] = weight
+	}
+	return weights, nil
+}
+
+// loadReplicaWeights returns the weights of the replicas of the clustersync StatefulSet parsed from the value of the
+// CLUSTERSYNC_REPLICA_WEIGHTS environment variable. Invalid weights are logged and ignored, so that all of the replicas
+// get the default weight rather than the controller failing to start.
+func loadReplicaWeights(value string, logger log.FieldLogger) map[int64]int64 {
+	weights, err := parseReplicaWeights(value)
+	if err != nil {
+		logger.WithError(err).WithField("replicaWeights", value).Errorf("unable to parse %s, using equal weights", replicaWeightsEnvKey)
+		return map[int64]int64{}
+	}
+	return weights
+}
+
+// assignedReplica returns the ordinal of the replica that syncs the cluster. Clusters are assigned with weighted
+// rendezvous hashing: each replica scores the cluster, and the replica with the highest score gets it. Adding or
+// removing a replica, or changing its weight, therefore only moves the clusters that it wins or loses, rather than
+// reshuffling all the clusters. Replicas with a weight of 0 are not assigned any cluster, unless all replicas have a
+// weight of 0.
+func assignedReplica(uid types.UID, replicas int64, weights map[int64]int64) int64 {
+	assignee, bestScore := int64(0), math.Inf(-1)
+	allDrained := true
+	for ordinal := int64(0); ordinal < replicas; ordinal++ {
+		if replicaWeight(ordinal, weights) > 0 {
+			allDrained = false
+			break
+		}
+	}
+	for ordinal := int64(0); ordinal < replicas; ordinal++ {
+		weight := replicaWeight(ordinal, weights)
+		if allDrained {
+			weight = defaultReplicaWeight
+		}
+		if weight == 0 {
+			continue
+		}
+		if score := rendezvousScore(uid, ordinal, weight); score > bestScore {
+			assignee, bestScore = ordinal, score
+		}
+	}
+	return assignee
+}
+
+func replicaWeight(ordinal int64, weights map[int64]int64) int64 {
+	if weight, ok := weights[ordinal]; ok {
+		return weight
+	}
+	return defaultReplicaWeight
+}
+
+// rendezvousScore returns the score of the replica for the cluster. The hash of the pair is mapped to a uniform value u
+// in (0, 1), and the score is -weight/ln(u), so that the probability of the replica having the highest score is
+// proportional to its weight.
+func rendezvousScore(uid types.UID, ordinal, weight int64) float64 {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", uid, ordinal)))
+	u := (float64(binary.BigEndian.Uint64(sum[:])>>11) + 0.5) / (1 << 53)
+	return -float64(weight) / math.Log(u)
+}
+
+// takeLease records this replica as the holder of the lease of the cluster. It returns true if the holder changed, in
+// which case the lease needs to be saved.
+func (r *ReconcileClusterSync) takeLease(lease *hiveintv1alpha1.ClusterSyncLease, logger log.FieldLogger) bool {
+	if lease.Spec.HolderIdentity == r.podName {
+		return false
+	}
+	if previousHolder := lease.Spec.HolderIdentity; previousHolder != "" {
+		logger.WithField("previousHolder", previousHolder).Info("taking over cluster from another replica")
+		lease.Spec.LeaseTransitions++
+		metricHandoffs.WithLabelValues(strconv.FormatInt(r.ordinalID, 10)).Inc()
+	}
+	now := metav1.NowMicro()
+	lease.Spec.HolderIdentity = r.podName
+	lease.Spec.AcquireTime = &now
+	return true
+}
+
+// recordAssignment records whether the cluster is assigned to this replica, and reports the number of clusters
+// assigned to it.
+func (r *ReconcileClusterSync) recordAssignment(cluster types.NamespacedName, assigned bool) {
+	count := r.assignedClusters.set(cluster, assigned)
+	metricAssignedClusters.WithLabelValues(strconv.FormatInt(r.ordinalID, 10)).Set(float64(count))
+}
+
+// assignedClusters tracks the clusters assigned to a replica, to report its load.
+type assignedClusters struct {
+	mutex    sync.Mutex
+	clusters map[types.NamespacedName]bool
+}
+
+func newAssignedClusters() *assignedClusters {
+	return &assignedClusters{clusters: map[types.NamespacedName]bool{}}
+}
+
+// set records whether the cluster is assigned to the replica, and returns the number of clusters assigned to it.
+func (a *assignedClusters) set(cluster types.NamespacedName, assigned bool) int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if assigned {
+		a.clusters[cluster] = true
+	} else {
+		delete(a.clusters, cluster)
+	}
+	return len(a.clusters)
+}
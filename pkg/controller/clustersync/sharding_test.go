@@ -0,0 +1,112 @@
+package clustersync
+
+import (
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseReplicaWeights(t *testing.T) {
+	cases := []struct {
+		name            string
+		value           string
+		expectedWeights map[int64]int64
+		expectedErr     bool
+	}{
+		{
+			name:            "empty",
+			expectedWeights: map[int64]int64{},
+		},
+		{
+			name:            "weights",
+			value:           "0=100,1=50,3=0",
+			expectedWeights: map[int64]int64{0: 100, 1: 50, 3: 0},
+		},
+		{
+			name:        "missing weight",
+			value:       "0=100,1",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid ordinal",
+			value:       "a=100",
+			expectedErr: true,
+		},
+		{
+			name:        "negative ordinal",
+			value:       "-1=100",
+			expectedErr: true,
+		},
+		{
+			name:        "negative weight",
+			value:       "0=-1",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			weights, err := parseReplicaWeights(tc.value)
+			if tc.expectedErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedWeights, weights, "unexpected weights")
+		})
+	}
+}
+
+func TestLoadReplicaWeights(t *testing.T) {
+	logger := log.WithField("controller", ControllerName)
+	assert.Equal(t, map[int64]int64{0: 100, 1: 50}, loadReplicaWeights("0=100,1=50", logger), "unexpected weights")
+	assert.Equal(t, map[int64]int64{}, loadReplicaWeights("0=100,1=-50", logger), "expected equal weights for invalid value")
+}
+
+func TestAssignedReplica(t *testing.T) {
+	const clusters = 3000
+	uids := make([]types.UID, clusters)
+	for i := range uids {
+		uids[i] = types.UID(fmt.Sprintf("cluster-%d", i))
+	}
+	assign := func(replicas int64, weights map[int64]int64) ([]int64, map[int64]int) {
+		assignees := make([]int64, clusters)
+		counts := map[int64]int{}
+		for i, uid := range uids {
+			assignees[i] = assignedReplica(uid, replicas, weights)
+			counts[assignees[i]]++
+		}
+		return assignees, counts
+	}
+
+	three, counts := assign(3, nil)
+	for ordinal := int64(0); ordinal < 3; ordinal++ {
+		assert.InDelta(t, clusters/3, counts[ordinal], clusters/3*0.1, "unbalanced replica %d", ordinal)
+	}
+
+	// Scaling up only moves clusters to the new replica
+	four, counts := assign(4, nil)
+	for i := range uids {
+		if four[i] != three[i] {
+			assert.Equal(t, int64(3), four[i], "cluster %d moved between existing replicas", i)
+		}
+	}
+	assert.InDelta(t, clusters/4, counts[3], clusters/4*0.1, "unexpected number of clusters moved to the new replica")
+
+	// Lowering the weight of a replica only moves clusters away from it
+	weighted, counts := assign(3, map[int64]int64{0: 50})
+	for i := range uids {
+		if weighted[i] != three[i] {
+			assert.Equal(t, int64(0), three[i], "cluster %d moved from a replica whose weight did not change", i)
+		}
+	}
+	assert.InDelta(t, clusters/5, counts[0], clusters/5*0.1, "unexpected number of clusters on the weighted replica")
+
+	// Draining a replica moves all its clusters away
+	_, counts = assign(3, map[int64]int64{0: 0})
+	assert.Zero(t, counts[0], "unexpected clusters on the drained replica")
+}
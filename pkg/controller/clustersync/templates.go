@@ -0,0 +1,191 @@
+package clustersync
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// templateData is the data available to the templates of a SyncSet for a target cluster.
+type templateData struct {
+	Name        string
+	Namespace   string
+	ClusterName string
+	BaseDomain  string
+	InfraID     string
+	ClusterID   string
+	Region      string
+	Platform    string
+	Labels      map[string]string
+	Annotations map[string]string
+	Spec        map[string]interface{}
+	Status      map[string]interface{}
+}
+
+// templateFuncs is the set of functions available to the templates of a SyncSet. Only functions whose
+// output depends on nothing but their input are allowed, so that rendering is deterministic.
+var templateFuncs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"quote":      func(s string) string { return fmt.Sprintf("%q", s) },
+	"b64enc":     func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec": func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		return string(b), err
+	},
+	"default": func(def string, value interface{}) interface{} {
+		if value == nil || value == "" {
+			return def
+		}
+		return value
+	},
+	"required": func(msg string, value interface{}) (interface{}, error) {
+		if value == nil || value == "" {
+			return nil, errors.New(msg)
+		}
+		return value, nil
+	},
+	"toJSON": func(value interface{}) (string, error) {
+		b, err := json.Marshal(value)
+		return string(b), err
+	},
+}
+
+func isTemplated(syncSet CommonSyncSet) bool {
+	return syncSet.GetSpec().TemplateMode == hivev1.GoTemplateSyncSetTemplateMode
+}
+
+// renderSyncSet returns a copy of the syncset with its Resources and Patches rendered for the given ClusterDeployment
+// along with the hash of the rendered content. Syncsets that do not use templates are returned as is.
+func renderSyncSet(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment) (CommonSyncSet, string, error) {
+	if !isTemplated(syncSet) {
+		return syncSet, "", nil
+	}
+	data, err := newTemplateData(cd)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rendered, err := copySyncSet(syncSet)
+	if err != nil {
+		return nil, "", err
+	}
+
+	spec := rendered.GetSpec()
+	for i, resource := range spec.Resources {
+		var obj interface{}
+		if err := json.Unmarshal(resource.Raw, &obj); err != nil {
+			return nil, "", errors.Wrapf(err, "failed to decode resource %d", i)
+		}
+		obj, err := renderValue(obj, data)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "failed to render resource %d", i)
+		}
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "failed to encode resource %d", i)
+		}
+		spec.Resources[i] = runtime.RawExtension{Raw: raw}
+	}
+	for i := range spec.Patches {
+		patch := &spec.Patches[i]
+		for _, field := range []*string{&patch.Name, &patch.Namespace, &patch.Patch} {
+			if *field, err = renderString(*field, data); err != nil {
+				return nil, "", errors.Wrapf(err, "failed to render patch %d", i)
+			}
+		}
+	}
+
+	hash, err := controllerutils.GetChecksumOfObjects(spec.Resources, spec.Patches)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to compute hash of rendered syncset")
+	}
+	return rendered, hash, nil
+}
+
+func newTemplateData(cd *hivev1.ClusterDeployment) (*templateData, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert ClusterDeployment for templates")
+	}
+	data := &templateData{
+		Name:        cd.Name,
+		Namespace:   cd.Namespace,
+		ClusterName: cd.Spec.ClusterName,
+		BaseDomain:  cd.Spec.BaseDomain,
+		Region:      cd.Labels[hivev1.HiveClusterRegionLabel],
+		Platform:    cd.Labels[hivev1.HiveClusterPlatformLabel],
+		Labels:      cd.Labels,
+		Annotations: cd.Annotations,
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		data.InfraID = cd.Spec.ClusterMetadata.InfraID
+		data.ClusterID = cd.Spec.ClusterMetadata.ClusterID
+	}
+	if spec, ok := obj["spec"].(map[string]interface{}); ok {
+		data.Spec = spec
+	}
+	if status, ok := obj["status"].(map[string]interface{}); ok {
+		data.Status = status
+	}
+	return data, nil
+}
+
+// renderValue renders all of the strings in a decoded JSON value. Map keys are left as is.
+func renderValue(value interface{}, data *templateData) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return renderString(v, data)
+	case map[string]interface{}:
+		for key, elem := range v {
+			rendered, err := renderValue(elem, data)
+			if err != nil {
+				return nil, errors.Wrap(err, key)
+			}
+			v[key] = rendered
+		}
+	case []interface{}:
+		for i, elem := range v {
+			rendered, err := renderValue(elem, data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "[%d]", i)
+			}
+			v[i] = rendered
+		}
+	}
+	return value, nil
+}
+
+func renderString(s string, data *templateData) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	tmpl, err := template.New("").Option("missingkey=error").Funcs(templateFuncs).Parse(s)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
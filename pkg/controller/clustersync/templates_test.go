@@ -0,0 +1,90 @@
+package clustersync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderString(t *testing.T) {
+	data := &templateData{
+		ClusterName: "test-cluster",
+		InfraID:     "test-cluster-abcde",
+		Labels:      map[string]string{"env": "prod"},
+		Spec:        map[string]interface{}{"platform": map[string]interface{}{"aws": map[string]interface{}{"region": "us-east-1"}}},
+	}
+	cases := []struct {
+		name          string
+		template      string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "no template",
+			template: "plain value",
+			expected: "plain value",
+		},
+		{
+			name:     "field",
+			template: "{{ .ClusterName }}-config",
+			expected: "test-cluster-config",
+		},
+		{
+			name:     "label",
+			template: `{{ index .Labels "env" }}`,
+			expected: "prod",
+		},
+		{
+			name:     "missing label with default",
+			template: `{{ index .Labels "team" | default "none" }}`,
+			expected: "none",
+		},
+		{
+			name:     "spec",
+			template: "{{ .Spec.platform.aws.region }}",
+			expected: "us-east-1",
+		},
+		{
+			name:     "string functions",
+			template: `{{ .InfraID | trimPrefix "test-" | replace "-" "_" | upper }}`,
+			expected: "CLUSTER_ABCDE",
+		},
+		{
+			name:     "base64",
+			template: `{{ .ClusterName | b64enc | b64dec }}`,
+			expected: "test-cluster",
+		},
+		{
+			name:     "toJSON",
+			template: "{{ toJSON .Labels }}",
+			expected: `{"env":"prod"}`,
+		},
+		{
+			name:          "missing spec field",
+			template:      "{{ .Spec.baseDomain }}",
+			expectedError: `template: :1:8: executing "" at <.Spec.baseDomain>: map has no entry for key "baseDomain"`,
+		},
+		{
+			name:          "required",
+			template:      `{{ index .Labels "team" | required "team label is required" }}`,
+			expectedError: `template: :1:26: executing "" at <required "team label is required">: error calling required: team label is required`,
+		},
+		{
+			name:          "function not allowed",
+			template:      `{{ now }}`,
+			expectedError: `template: :1: function "now" not defined`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := renderString(tc.template, data)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, actual)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package dnsendpoint
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -176,7 +177,8 @@ func (r *ReconcileDNSEndpoint) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	if !instance.Spec.LinkToParentDomain {
+	// Private zones cannot be resolved from the public parent domain, so they are never delegated to.
+	if !instance.Spec.LinkToParentDomain || instance.Spec.Private {
 		return reconcile.Result{}, nil
 	}
 
@@ -261,6 +263,10 @@ func (r *ReconcileDNSEndpoint) Reconcile(ctx context.Context, request reconcile.
 		nsTool.scraper.RemoveEndpoint(fullDomain)
 	}
 
+	if err := syncDSRecords(r.Client, dnsLog, nsTool.queryClient, rootDomain, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	parentLinkCreated := false
 	if !isDeleted && len(desiredNameServers) > 0 {
 		parentLinkCreated = true
@@ -301,6 +307,11 @@ func createNameServerQuery(c client.Client, logger log.FieldLogger, managedDomai
 		logger.Infof("using azure creds for managed domain stored in %q secret", secretName)
 		return nameserver.NewAzureQuery(c, secretName, managedDomain.Azure.ResourceGroupName)
 	}
+	if managedDomain.RFC2136 != nil {
+		secretName := managedDomain.RFC2136.TSIGSecretRef.Name
+		logger.Infof("using TSIG key for managed domain stored in %q secret", secretName)
+		return nameserver.NewRFC2136Query(c, secretName, managedDomain.RFC2136.Server)
+	}
 	logger.Error("unsupported cloud for managing DNS")
 	return nil
 }
@@ -322,6 +333,67 @@ func updateParentLinkCreatedCondition(c client.Client, logger log.FieldLogger, d
 	return updateCondition(c, logger, dnsZone, hivev1.ParentLinkCreatedCondition, status, reason, message)
 }
 
+// syncDSRecords publishes the DS records of the active DNSSEC key signing keys of the zone in the root domain, and
+// deletes them when the zone is deleted or no longer signed.
+func syncDSRecords(c client.Client, logger log.FieldLogger, queryClient nameserver.Query, rootDomain string, dnsZone *hivev1.DNSZone) error {
+	fullDomain := dnsZone.Spec.Zone
+	desiredDSRecords := sets.NewString()
+	if dnsZone.DeletionTimestamp == nil && dnsZone.Spec.DNSSEC && dnsZone.Status.DNSSEC != nil && dnsZone.Status.DNSSEC.Signing {
+		for _, key := range dnsZone.Status.DNSSEC.KeySigningKeys {
+			if key.Active && key.DSRecord != "" {
+				desiredDSRecords.Insert(nameserver.CanonicalDSValue(key.DSRecord))
+			}
+		}
+	}
+	dsRecordsCondition := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.DSRecordsCreatedCondition)
+	if len(desiredDSRecords) == 0 && (dsRecordsCondition == nil || dsRecordsCondition.Status != corev1.ConditionTrue) {
+		// Zones that have never been signed do not need to query the root domain.
+		return nil
+	}
+
+	currentDSRecords, err := queryClient.GetDS(rootDomain, fullDomain)
+	if err != nil {
+		logger.WithError(err).Error("error getting DS records")
+		return err
+	}
+	switch {
+	case currentDSRecords.Equal(desiredDSRecords):
+		logger.Debug("DS records are up to date")
+	case len(desiredDSRecords) > 0:
+		logger.Info("creating DS records")
+		if err := queryClient.CreateDS(rootDomain, fullDomain, desiredDSRecords); err != nil {
+			logger.WithError(err).Error("error creating DS records")
+			return err
+		}
+	default:
+		logger.Info("deleting DS records")
+		if err := queryClient.DeleteDS(rootDomain, fullDomain); err != nil {
+			logger.WithError(err).Error("error deleting DS records")
+			return err
+		}
+	}
+
+	_, err = updateDSRecordsCreatedCondition(c, logger, dnsZone, desiredDSRecords)
+	return err
+}
+
+func updateDSRecordsCreatedCondition(c client.Client, logger log.FieldLogger, dnsZone *hivev1.DNSZone, dsRecords sets.String) (bool, error) {
+	var status corev1.ConditionStatus
+	var reason string
+	var message string
+	if len(dsRecords) > 0 {
+		status = corev1.ConditionTrue
+		reason = "DSRecordsCreated"
+		message = fmt.Sprintf("DS records created in parent domain: %s", strings.Join(dsRecords.List(), ", "))
+	} else {
+		status = corev1.ConditionFalse
+		reason = "DSRecordsNotCreated"
+		message = "DS records have not been created in parent domain"
+	}
+
+	return updateCondition(c, logger, dnsZone, hivev1.DSRecordsCreatedCondition, status, reason, message)
+}
+
 func updateDomainNotManagedCondition(c client.Client, logger log.FieldLogger, dnsZone *hivev1.DNSZone, missing bool) (bool, error) {
 	var status corev1.ConditionStatus
 	var reason string
@@ -256,6 +256,26 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "private zone",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testDNSZone()
+				z.Spec.Private = true
+				return z
+			}(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{},
+			},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{},
+			},
+			expectedConditions: []conditionExpectations{
+				{
+					conditionType: hivev1.ParentLinkCreatedCondition,
+					status:        corev1.ConditionFalse,
+				},
+			},
+		},
 		{
 			name: "deleted with no finalizer",
 			dnsZone: func() *hivev1.DNSZone {
@@ -296,6 +316,187 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				rootDomain: nameServersMap{},
 			},
 		},
+		{
+			name:    "new DS records",
+			dnsZone: testSignedDNSZone(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			configureQuery: func(mockQuery *mock.MockQuery) {
+				mockQuery.EXPECT().GetDS(rootDomain, dnsName).Return(nil, nil)
+				mockQuery.EXPECT().CreateDS(rootDomain, dnsName, sets.NewString("12345 13 2 ABCDEF")).Return(nil)
+			},
+			expectedConditions: []conditionExpectations{
+				{
+					conditionType: hivev1.DSRecordsCreatedCondition,
+					status:        corev1.ConditionTrue,
+				},
+			},
+		},
+		{
+			name:    "up-to-date DS records",
+			dnsZone: testSignedDNSZone(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			configureQuery: func(mockQuery *mock.MockQuery) {
+				mockQuery.EXPECT().GetDS(rootDomain, dnsName).Return(sets.NewString("12345 13 2 ABCDEF"), nil)
+			},
+			expectedConditions: []conditionExpectations{
+				{
+					conditionType: hivev1.DSRecordsCreatedCondition,
+					status:        corev1.ConditionTrue,
+				},
+			},
+		},
+		{
+			name: "DS records not yet available",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testSignedDNSZone()
+				z.Status.DNSSEC.KeySigningKeys[0].Active = false
+				return z
+			}(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			expectedConditions: []conditionExpectations{
+				{
+					conditionType: hivev1.DSRecordsCreatedCondition,
+					status:        corev1.ConditionFalse,
+				},
+			},
+		},
+		{
+			name: "DNSSEC disabled",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testSignedDNSZone()
+				z.Spec.DNSSEC = false
+				z.Status.Conditions = []hivev1.DNSZoneCondition{{
+					Type:   hivev1.DSRecordsCreatedCondition,
+					Status: corev1.ConditionTrue,
+				}}
+				return z
+			}(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			configureQuery: func(mockQuery *mock.MockQuery) {
+				mockQuery.EXPECT().GetDS(rootDomain, dnsName).Return(sets.NewString("12345 13 2 ABCDEF"), nil)
+				mockQuery.EXPECT().DeleteDS(rootDomain, dnsName).Return(nil)
+			},
+			expectedConditions: []conditionExpectations{
+				{
+					conditionType: hivev1.DSRecordsCreatedCondition,
+					status:        corev1.ConditionFalse,
+				},
+			},
+		},
+		{
+			name: "delete DS records",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testSignedDNSZone()
+				now := metav1.Now()
+				z.DeletionTimestamp = &now
+				z.Status.Conditions = []hivev1.DNSZoneCondition{{
+					Type:   hivev1.DSRecordsCreatedCondition,
+					Status: corev1.ConditionTrue,
+				}}
+				return z
+			}(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			configureQuery: func(mockQuery *mock.MockQuery) {
+				mockQuery.EXPECT().Delete(rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
+				mockQuery.EXPECT().GetDS(rootDomain, dnsName).Return(sets.NewString("12345 13 2 ABCDEF"), nil)
+				mockQuery.EXPECT().DeleteDS(rootDomain, dnsName).Return(nil)
+			},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{},
+			},
+		},
+		{
+			name:    "create DS records error",
+			dnsZone: testSignedDNSZone(),
+			nameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			expectedNameServers: rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			},
+			configureQuery: func(mockQuery *mock.MockQuery) {
+				mockQuery.EXPECT().GetDS(rootDomain, dnsName).Return(nil, nil)
+				mockQuery.EXPECT().CreateDS(rootDomain, dnsName, sets.NewString("12345 13 2 ABCDEF")).Return(errors.New("create error"))
+			},
+			expectErr: true,
+		},
 		{
 			name:    "missing domain client condition",
 			dnsZone: testDNSZone(),
@@ -639,6 +840,21 @@ func testDNSZone() *hivev1.DNSZone {
 	}
 }
 
+func testSignedDNSZone() *hivev1.DNSZone {
+	z := testDNSZone()
+	z.Spec.DNSSEC = true
+	z.Status.DNSSEC = &hivev1.DNSSECStatus{
+		Signing: true,
+		KeySigningKeys: []hivev1.DNSSECKeySigningKey{{
+			Name:     "test-key",
+			KeyTag:   12345,
+			Active:   true,
+			DSRecord: "12345 13 2 abcdef",
+		}},
+	}
+	return z
+}
+
 func testDeletedDNSZone() *hivev1.DNSZone {
 	e := testDNSZone()
 	now := metav1.Now()
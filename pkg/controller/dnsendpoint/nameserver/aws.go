@@ -3,6 +3,7 @@ package nameserver
 import (
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/pkg/errors"
@@ -109,6 +110,79 @@ func (q *awsQuery) Delete(rootDomain string, domain string, values sets.String)
 	)
 }
 
+// GetDS implements Query.GetDS.
+func (q *awsQuery) GetDS(rootDomain string, domain string) (sets.String, error) {
+	awsClient, err := q.getAWSClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get AWS client")
+	}
+	zoneID, err := q.queryZoneID(awsClient, rootDomain)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying zone ID")
+	}
+	if zoneID == nil {
+		return nil, nil
+	}
+	recordSet, err := q.queryRecordSet(awsClient, *zoneID, domain, route53.RRTypeDs)
+	if err != nil || recordSet == nil {
+		return nil, errors.Wrap(err, "error querying DS records")
+	}
+	values := sets.NewString()
+	for _, record := range recordSet.ResourceRecords {
+		values.Insert(CanonicalDSValue(*record.Value))
+	}
+	return values, nil
+}
+
+// CreateDS implements Query.CreateDS.
+func (q *awsQuery) CreateDS(rootDomain string, domain string, values sets.String) error {
+	awsClient, err := q.getAWSClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get AWS client")
+	}
+	zoneID, err := q.queryZoneID(awsClient, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone ID")
+	}
+	if zoneID == nil {
+		return errors.New("no public hosted zone found for domain")
+	}
+	return errors.Wrap(
+		q.changeRecords(awsClient, *zoneID, domain, route53.RRTypeDs, values, route53.ChangeActionUpsert),
+		"error creating the DS records",
+	)
+}
+
+// DeleteDS implements Query.DeleteDS.
+func (q *awsQuery) DeleteDS(rootDomain string, domain string) error {
+	awsClient, err := q.getAWSClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get AWS client")
+	}
+	zoneID, err := q.queryZoneID(awsClient, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone ID")
+	}
+	if zoneID == nil {
+		return nil
+	}
+	recordSet, err := q.queryRecordSet(awsClient, *zoneID, domain, route53.RRTypeDs)
+	if err != nil || recordSet == nil {
+		return errors.Wrap(err, "error querying the current DS records")
+	}
+	// Route53 only deletes record sets that match the current values.
+	_, err = awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: zoneID,
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: recordSet,
+			}},
+		},
+	})
+	return errors.Wrap(err, "error deleting the DS records")
+}
+
 // queryZoneID queries AWS for the public hosted zone for the specified domain.
 func (q *awsQuery) queryZoneID(awsClient awsclient.Client, domain string) (*string, error) {
 	maxItems := "5"
@@ -176,8 +250,21 @@ func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID str
 
 // queryNameServer queries AWS for the name servers in the specified hosted zone for the specified domain.
 func (q *awsQuery) queryNameServer(awsClient awsclient.Client, hostedZoneID string, domain string) (sets.String, error) {
+	recordSet, err := q.queryRecordSet(awsClient, hostedZoneID, domain, route53.RRTypeNs)
+	if err != nil || recordSet == nil {
+		return nil, err
+	}
+	values := sets.NewString()
+	for _, record := range recordSet.ResourceRecords {
+		values.Insert(*record.Value)
+	}
+	return values, nil
+}
+
+// queryRecordSet queries AWS for the record set of the specified type in the specified hosted zone for the
+// specified domain.
+func (q *awsQuery) queryRecordSet(awsClient awsclient.Client, hostedZoneID string, domain string, recordType string) (*route53.ResourceRecordSet, error) {
 	maxItems := "1"
-	recordType := route53.RRTypeNs
 	listOutput, err := awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
 		HostedZoneId:    &hostedZoneID,
 		MaxItems:        &maxItems,
@@ -197,19 +284,19 @@ func (q *awsQuery) queryNameServer(awsClient awsclient.Client, hostedZoneID stri
 	if controllerutils.Undotted(*recordSet.Name) != domain {
 		return nil, nil
 	}
-	if recordSet.Type == nil || *recordSet.Type != route53.RRTypeNs {
+	if recordSet.Type == nil || *recordSet.Type != recordType {
 		return nil, nil
 	}
-	values := sets.NewString()
-	for _, record := range recordSet.ResourceRecords {
-		values.Insert(*record.Value)
-	}
-	return values, nil
+	return recordSet, nil
 }
 
 // changeNameServers changes the name servers for the specified domain in the specified hosted zone.
 func (q *awsQuery) changeNameServers(awsClient awsclient.Client, hostedZoneID string, domain string, values sets.String, action string) error {
-	recordType := route53.RRTypeNs
+	return q.changeRecords(awsClient, hostedZoneID, domain, route53.RRTypeNs, values, action)
+}
+
+// changeRecords changes the records of the specified type for the specified domain in the specified hosted zone.
+func (q *awsQuery) changeRecords(awsClient awsclient.Client, hostedZoneID string, domain string, recordType string, values sets.String, action string) error {
 	ttl := int64(60)
 	records := make([]*route53.ResourceRecord, 0, len(values))
 	for v := range values {
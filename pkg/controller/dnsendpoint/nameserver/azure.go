@@ -83,6 +83,21 @@ func (q *azureQuery) Delete(rootDomain string, domain string, values sets.String
 	)
 }
 
+// GetDS implements Query.GetDS. Azure DNS does not support DS records.
+func (q *azureQuery) GetDS(rootDomain string, domain string) (sets.String, error) {
+	return nil, nil
+}
+
+// CreateDS implements Query.CreateDS. Azure DNS does not support DS records.
+func (q *azureQuery) CreateDS(rootDomain string, domain string, values sets.String) error {
+	return errors.New("DS records are not supported by Azure DNS")
+}
+
+// DeleteDS implements Query.DeleteDS. Azure DNS does not support DS records.
+func (q *azureQuery) DeleteDS(rootDomain string, domain string) error {
+	return nil
+}
+
 // deleteNameServers deletes the name servers for the specified domain in the specified managed zone.
 func (q *azureQuery) deleteNameServers(azureClient azureclient.Client, rootDomain string, domain string) error {
 	ctx, cancel := contextWithTimeout(context.TODO())
@@ -120,6 +120,79 @@ func (q *gcpQuery) Delete(rootDomain string, domain string, values sets.String)
 	)
 }
 
+// GetDS implements Query.GetDS.
+func (q *gcpQuery) GetDS(rootDomain string, domain string) (sets.String, error) {
+	gcpClient, err := q.getGCPClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get GCP client")
+	}
+	zoneName, err := q.queryZoneName(gcpClient, rootDomain)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying zone name")
+	}
+	if zoneName == "" {
+		return nil, nil
+	}
+	recordSet, err := q.queryDS(gcpClient, zoneName, domain)
+	if err != nil || recordSet == nil {
+		return nil, errors.Wrap(err, "error querying DS records")
+	}
+	values := sets.NewString()
+	for _, v := range recordSet.Rrdatas {
+		values.Insert(CanonicalDSValue(v))
+	}
+	return values, nil
+}
+
+// CreateDS implements Query.CreateDS.
+func (q *gcpQuery) CreateDS(rootDomain string, domain string, values sets.String) error {
+	gcpClient, err := q.getGCPClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get GCP client")
+	}
+	zoneName, err := q.queryZoneName(gcpClient, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone name")
+	}
+	if zoneName == "" {
+		return errors.New("no public managed zone found for domain")
+	}
+	currentRecordSet, err := q.queryDS(gcpClient, zoneName, domain)
+	if err != nil {
+		return errors.Wrap(err, "error querying the current DS records")
+	}
+	// The current DS records, if any, are replaced in the same change.
+	return errors.Wrap(
+		gcpClient.UpdateResourceRecordSet(zoneName, &dns.ResourceRecordSet{
+			Name:    controllerutils.Dotted(domain),
+			Rrdatas: values.List(),
+			Ttl:     int64(60),
+			Type:    "DS",
+		}, currentRecordSet),
+		"error creating the DS records",
+	)
+}
+
+// DeleteDS implements Query.DeleteDS.
+func (q *gcpQuery) DeleteDS(rootDomain string, domain string) error {
+	gcpClient, err := q.getGCPClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get GCP client")
+	}
+	zoneName, err := q.queryZoneName(gcpClient, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone name")
+	}
+	if zoneName == "" {
+		return nil
+	}
+	recordSet, err := q.queryDS(gcpClient, zoneName, domain)
+	if err != nil || recordSet == nil {
+		return errors.Wrap(err, "error querying the current DS records")
+	}
+	return errors.Wrap(gcpClient.DeleteResourceRecordSet(zoneName, recordSet), "error deleting the DS records")
+}
+
 // queryZoneName queries GCP for the public managed zone for the specified domain.
 func (q *gcpQuery) queryZoneName(gcpClient gcpclient.Client, domain string) (string, error) {
 	listOpts := gcpclient.ListManagedZonesOptions{
@@ -192,6 +265,25 @@ func (q *gcpQuery) queryNameServer(gcpClient gcpclient.Client, managedZone strin
 	return values, nil
 }
 
+// queryDS queries GCP for the DS record set for the specified domain in the specified managed zone.
+func (q *gcpQuery) queryDS(gcpClient gcpclient.Client, managedZone string, domain string) (*dns.ResourceRecordSet, error) {
+	listOutput, err := gcpClient.ListResourceRecordSets(
+		managedZone,
+		gcpclient.ListResourceRecordSetsOptions{
+			MaxResults: 1,
+			Name:       controllerutils.Dotted(domain),
+			Type:       "DS",
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(listOutput.Rrsets) == 0 {
+		return nil, nil
+	}
+	return listOutput.Rrsets[0], nil
+}
+
 // createNameServers creates the name servers for the specified domain in the specified managed zone.
 func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone string, domain string, values sets.String) error {
 
@@ -75,3 +75,46 @@ func (mr *MockQueryMockRecorder) Delete(rootDomain, domain, values interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuery)(nil).Delete), rootDomain, domain, values)
 }
+
+// GetDS mocks base method
+func (m *MockQuery) GetDS(rootDomain, domain string) (sets.String, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDS", rootDomain, domain)
+	ret0, _ := ret[0].(sets.String)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDS indicates an expected call of GetDS
+func (mr *MockQueryMockRecorder) GetDS(rootDomain, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDS", reflect.TypeOf((*MockQuery)(nil).GetDS), rootDomain, domain)
+}
+
+// CreateDS mocks base method
+func (m *MockQuery) CreateDS(rootDomain, domain string, values sets.String) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDS", rootDomain, domain, values)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDS indicates an expected call of CreateDS
+func (mr *MockQueryMockRecorder) CreateDS(rootDomain, domain, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDS", reflect.TypeOf((*MockQuery)(nil).CreateDS), rootDomain, domain, values)
+}
+
+// DeleteDS mocks base method
+func (m *MockQuery) DeleteDS(rootDomain, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDS", rootDomain, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDS indicates an expected call of DeleteDS
+func (mr *MockQueryMockRecorder) DeleteDS(rootDomain, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDS", reflect.TypeOf((*MockQuery)(nil).DeleteDS), rootDomain, domain)
+}
@@ -1,6 +1,8 @@
 package nameserver
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -19,4 +21,20 @@ type Query interface {
 	// If there are other name servers for the specified domain server, those will be
 	// deleted as well.
 	Delete(rootDomain string, domain string, values sets.String) error
+
+	// GetDS gets the DS records for the specified domain under the specified root domain.
+	GetDS(rootDomain string, domain string) (sets.String, error)
+
+	// CreateDS creates the DS records for the specified domain under the specified root domain,
+	// replacing any existing DS records for the domain.
+	CreateDS(rootDomain string, domain string, values sets.String) error
+
+	// DeleteDS deletes the DS records for the specified domain under the specified root domain.
+	DeleteDS(rootDomain string, domain string) error
+}
+
+// CanonicalDSValue returns the DS record value in a canonical form so that values from different dns providers
+// can be compared, with single spaces between the fields and an upper case digest.
+func CanonicalDSValue(value string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(value), " "))
 }
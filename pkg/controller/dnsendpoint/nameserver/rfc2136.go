@@ -0,0 +1,210 @@
+package nameserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/rfc2136client"
+)
+
+const rfc2136NameServerTTL = 60
+
+// NewRFC2136Query creates a new name server query for a name server that accepts RFC 2136 dynamic updates.
+func NewRFC2136Query(c client.Client, tsigSecretName string, server string) Query {
+	return &rfc2136Query{
+		getRFC2136Client: func() (rfc2136client.Client, error) {
+			tsigSecret := &corev1.Secret{}
+			if err := c.Get(
+				context.Background(),
+				client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: tsigSecretName},
+				tsigSecret,
+			); err != nil {
+				return nil, errors.Wrap(err, "could not get the TSIG secret")
+			}
+			rfc2136Client, err := rfc2136client.NewClientFromSecret(server, tsigSecret)
+			return rfc2136Client, errors.Wrap(err, "error creating RFC 2136 client")
+		},
+	}
+}
+
+type rfc2136Query struct {
+	getRFC2136Client func() (rfc2136client.Client, error)
+}
+
+var _ Query = (*rfc2136Query)(nil)
+
+// Get implements Query.Get.
+func (q *rfc2136Query) Get(rootDomain string) (map[string]sets.String, error) {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get RFC 2136 client")
+	}
+	zone, err := q.queryZone(rfc2136Client, rootDomain)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying zone")
+	}
+	if zone == "" {
+		return nil, nil
+	}
+	currentNameServers, err := q.queryNameServers(rfc2136Client, zone, rootDomain)
+	return currentNameServers, errors.Wrap(err, "error querying name servers")
+}
+
+// Create implements Query.Create.
+func (q *rfc2136Query) Create(rootDomain string, domain string, values sets.String) error {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return errors.Wrap(err, "failed to get RFC 2136 client")
+	}
+	zone, err := q.queryZone(rfc2136Client, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone")
+	}
+	if zone == "" {
+		return errors.New("no zone found for domain on the name server")
+	}
+	// Replace the current name servers of the domain, if any, in a single update.
+	nsRecord := &dns.NS{Hdr: dns.RR_Header{Name: controllerutils.Dotted(domain), Rrtype: dns.TypeNS, Class: dns.ClassINET}}
+	var nsRecords []dns.RR
+	for _, v := range values.List() {
+		nsRecords = append(nsRecords, &dns.NS{
+			Hdr: dns.RR_Header{Name: controllerutils.Dotted(domain), Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: rfc2136NameServerTTL},
+			Ns:  controllerutils.Dotted(v),
+		})
+	}
+	return errors.Wrap(
+		rfc2136Client.UpdateZone(zone, []dns.RR{nsRecord}, nsRecords),
+		"error creating the name server",
+	)
+}
+
+// Delete implements Query.Delete.
+func (q *rfc2136Query) Delete(rootDomain string, domain string, values sets.String) error {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return errors.Wrap(err, "failed to get RFC 2136 client")
+	}
+	zone, err := q.queryZone(rfc2136Client, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone")
+	}
+	if zone == "" {
+		return errors.New("no zone found for domain on the name server")
+	}
+	// Deleting the whole record set also deletes name servers that differ from the specified values, and is a
+	// no-op when there are no name servers for the domain.
+	nsRecord := &dns.NS{Hdr: dns.RR_Header{Name: controllerutils.Dotted(domain), Rrtype: dns.TypeNS, Class: dns.ClassINET}}
+	return errors.Wrap(
+		rfc2136Client.UpdateZone(zone, []dns.RR{nsRecord}, nil),
+		"error deleting the name server",
+	)
+}
+
+// GetDS implements Query.GetDS.
+func (q *rfc2136Query) GetDS(rootDomain string, domain string) (sets.String, error) {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get RFC 2136 client")
+	}
+	records, err := rfc2136Client.GetRecords(controllerutils.Dotted(domain), dns.TypeDS)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying DS records")
+	}
+	values := sets.NewString()
+	for _, rr := range records {
+		if ds, ok := rr.(*dns.DS); ok {
+			values.Insert(CanonicalDSValue(strings.TrimPrefix(ds.String(), ds.Hdr.String())))
+		}
+	}
+	return values, nil
+}
+
+// CreateDS implements Query.CreateDS.
+func (q *rfc2136Query) CreateDS(rootDomain string, domain string, values sets.String) error {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return errors.Wrap(err, "failed to get RFC 2136 client")
+	}
+	zone, err := q.queryZone(rfc2136Client, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone")
+	}
+	if zone == "" {
+		return errors.New("no zone found for domain on the name server")
+	}
+	var dsRecords []dns.RR
+	for _, v := range values.List() {
+		rr, err := dns.NewRR(fmt.Sprintf("%s %d IN DS %s", controllerutils.Dotted(domain), rfc2136NameServerTTL, v))
+		if err != nil || rr == nil {
+			return errors.Errorf("invalid DS record %q", v)
+		}
+		dsRecords = append(dsRecords, rr)
+	}
+	// Replace the current DS records of the domain, if any, in a single update.
+	dsRecord := &dns.DS{Hdr: dns.RR_Header{Name: controllerutils.Dotted(domain), Rrtype: dns.TypeDS, Class: dns.ClassINET}}
+	return errors.Wrap(
+		rfc2136Client.UpdateZone(zone, []dns.RR{dsRecord}, dsRecords),
+		"error creating the DS records",
+	)
+}
+
+// DeleteDS implements Query.DeleteDS.
+func (q *rfc2136Query) DeleteDS(rootDomain string, domain string) error {
+	rfc2136Client, err := q.getRFC2136Client()
+	if err != nil {
+		return errors.Wrap(err, "failed to get RFC 2136 client")
+	}
+	zone, err := q.queryZone(rfc2136Client, rootDomain)
+	if err != nil {
+		return errors.Wrap(err, "error querying zone")
+	}
+	if zone == "" {
+		return errors.New("no zone found for domain on the name server")
+	}
+	dsRecord := &dns.DS{Hdr: dns.RR_Header{Name: controllerutils.Dotted(domain), Rrtype: dns.TypeDS, Class: dns.ClassINET}}
+	return errors.Wrap(
+		rfc2136Client.UpdateZone(zone, []dns.RR{dsRecord}, nil),
+		"error deleting the DS records",
+	)
+}
+
+// queryZone queries the name server for the zone that contains the specified domain.
+func (q *rfc2136Query) queryZone(rfc2136Client rfc2136client.Client, domain string) (string, error) {
+	soa, err := rfc2136Client.GetZone(domain)
+	if err != nil || soa == nil {
+		return "", err
+	}
+	return soa.Hdr.Name, nil
+}
+
+// queryNameServers queries the name server for the name servers of the subdomains of the specified domain in the
+// specified zone.
+func (q *rfc2136Query) queryNameServers(rfc2136Client rfc2136client.Client, zone string, domain string) (map[string]sets.String, error) {
+	records, err := rfc2136Client.TransferZone(zone)
+	if err != nil {
+		return nil, err
+	}
+	nameServers := map[string]sets.String{}
+	dottedDomain := controllerutils.Dotted(domain)
+	for _, rr := range records {
+		ns, ok := rr.(*dns.NS)
+		if !ok || dns.CountLabel(ns.Hdr.Name) <= dns.CountLabel(dottedDomain) || !dns.IsSubDomain(dottedDomain, ns.Hdr.Name) {
+			continue
+		}
+		name := controllerutils.Undotted(ns.Hdr.Name)
+		if nameServers[name] == nil {
+			nameServers[name] = sets.NewString()
+		}
+		nameServers[name].Insert(controllerutils.Undotted(ns.Ns))
+	}
+	return nameServers, nil
+}
@@ -0,0 +1,210 @@
+package nameserver
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/hive/pkg/rfc2136client"
+	"github.com/openshift/hive/pkg/test/dnsserver"
+)
+
+func TestRFC2136Get(t *testing.T) {
+	cases := []struct {
+		name                string
+		zones               []string
+		records             []string
+		rootDomain          string
+		expectedNameServers map[string]sets.String
+	}{
+		{
+			name:       "no zone for domain",
+			zones:      []string{"other-domain"},
+			rootDomain: "test-domain",
+		},
+		{
+			name:       "no name server records",
+			zones:      []string{"test-domain"},
+			records:    []string{"test-subdomain.test-domain. 60 IN A 192.0.2.1"},
+			rootDomain: "test-domain",
+		},
+		{
+			name:  "name servers for multiple domains",
+			zones: []string{"test-domain"},
+			records: []string{
+				"test-subdomain-1.test-domain. 60 IN NS test-ns-1.",
+				"test-subdomain-1.test-domain. 60 IN NS test-ns-2.",
+				"test-subdomain-2.test-domain. 60 IN NS test-ns-3.",
+			},
+			rootDomain: "test-domain",
+			expectedNameServers: map[string]sets.String{
+				"test-subdomain-1.test-domain": sets.NewString("test-ns-1", "test-ns-2"),
+				"test-subdomain-2.test-domain": sets.NewString("test-ns-3"),
+			},
+		},
+		{
+			name:  "root domain in enclosing zone",
+			zones: []string{"test-domain"},
+			records: []string{
+				"test-subdomain.test-root.test-domain. 60 IN NS test-ns-1.",
+				"other-subdomain.test-domain. 60 IN NS test-ns-2.",
+			},
+			rootDomain: "test-root.test-domain",
+			expectedNameServers: map[string]sets.String{
+				"test-subdomain.test-root.test-domain": sets.NewString("test-ns-1"),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := dnsserver.Start(t, tc.zones...)
+			server.AddRecords(t, tc.records...)
+			query := testRFC2136Query(server)
+			nameServers, err := query.Get(tc.rootDomain)
+			require.NoError(t, err, "unexpected error getting name servers")
+			if len(tc.expectedNameServers) == 0 {
+				assert.Empty(t, nameServers, "expected no name servers")
+			} else {
+				assert.Equal(t, tc.expectedNameServers, nameServers, "unexpected name servers")
+			}
+		})
+	}
+}
+
+func TestRFC2136Create(t *testing.T) {
+	cases := []struct {
+		name            string
+		zones           []string
+		records         []string
+		expectErr       bool
+		expectedRecords []string
+	}{
+		{
+			name:      "no zone for domain",
+			zones:     []string{"other-domain"},
+			expectErr: true,
+		},
+		{
+			name:  "new name servers",
+			zones: []string{"test-domain"},
+			expectedRecords: []string{
+				"test-subdomain.test-domain.\t60\tIN\tNS\ttest-ns-1.",
+				"test-subdomain.test-domain.\t60\tIN\tNS\ttest-ns-2.",
+			},
+		},
+		{
+			name:  "replace name servers",
+			zones: []string{"test-domain"},
+			records: []string{
+				"test-subdomain.test-domain. 60 IN NS test-ns-1.",
+				"test-subdomain.test-domain. 60 IN NS old-ns.",
+			},
+			expectedRecords: []string{
+				"test-subdomain.test-domain.\t60\tIN\tNS\ttest-ns-1.",
+				"test-subdomain.test-domain.\t60\tIN\tNS\ttest-ns-2.",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := dnsserver.Start(t, tc.zones...)
+			server.AddRecords(t, tc.records...)
+			query := testRFC2136Query(server)
+			err := query.Create("test-domain", "test-subdomain.test-domain", sets.NewString("test-ns-1", "test-ns-2"))
+			if tc.expectErr {
+				assert.Error(t, err, "expected error creating name servers")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating name servers")
+			assert.Equal(t, tc.expectedRecords, server.Records("test-subdomain.test-domain", dns.TypeNS), "unexpected name server records")
+		})
+	}
+}
+
+func TestRFC2136Delete(t *testing.T) {
+	cases := []struct {
+		name      string
+		zones     []string
+		records   []string
+		values    sets.String
+		expectErr bool
+	}{
+		{
+			name:      "no zone for domain",
+			zones:     []string{"other-domain"},
+			expectErr: true,
+		},
+		{
+			name:  "no name servers",
+			zones: []string{"test-domain"},
+		},
+		{
+			name:  "matching values",
+			zones: []string{"test-domain"},
+			records: []string{
+				"test-subdomain.test-domain. 60 IN NS test-ns-1.",
+			},
+			values: sets.NewString("test-ns-1"),
+		},
+		{
+			name:  "stale values",
+			zones: []string{"test-domain"},
+			records: []string{
+				"test-subdomain.test-domain. 60 IN NS test-ns-1.",
+				"test-subdomain.test-domain. 60 IN NS test-ns-2.",
+			},
+			values: sets.NewString("test-ns-1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := dnsserver.Start(t, tc.zones...)
+			server.AddRecords(t, tc.records...)
+			server.AddRecords(t, "other-subdomain."+tc.zones[0]+". 60 IN NS other-ns.")
+			query := testRFC2136Query(server)
+			err := query.Delete("test-domain", "test-subdomain.test-domain", tc.values)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error deleting name servers")
+				return
+			}
+			require.NoError(t, err, "unexpected error deleting name servers")
+			assert.Empty(t, server.Records("test-subdomain.test-domain", dns.TypeNS), "expected name server records to be deleted")
+			assert.Len(t, server.Records("other-subdomain.test-domain", dns.TypeNS), 1, "expected other name server records to be kept")
+		})
+	}
+}
+
+func TestRFC2136DS(t *testing.T) {
+	server := dnsserver.Start(t, "test-domain")
+	server.AddRecords(t,
+		"test-subdomain.test-domain. 60 IN NS test-ns-1.",
+		"test-subdomain.test-domain. 60 IN DS 1 13 2 0123",
+	)
+	query := testRFC2136Query(server)
+
+	err := query.CreateDS("test-domain", "test-subdomain.test-domain", sets.NewString("12345 13 2 ABCDEF", "23456 13 2 abcdef"))
+	require.NoError(t, err, "unexpected error creating DS records")
+	values, err := query.GetDS("test-domain", "test-subdomain.test-domain")
+	require.NoError(t, err, "unexpected error getting DS records")
+	assert.Equal(t, sets.NewString("12345 13 2 ABCDEF", "23456 13 2 ABCDEF"), values, "unexpected DS records")
+
+	err = query.DeleteDS("test-domain", "test-subdomain.test-domain")
+	require.NoError(t, err, "unexpected error deleting DS records")
+	assert.Empty(t, server.Records("test-subdomain.test-domain", dns.TypeDS), "expected DS records to be deleted")
+	assert.Len(t, server.Records("test-subdomain.test-domain", dns.TypeNS), 1, "expected name server records to be kept")
+	values, err = query.GetDS("test-domain", "test-subdomain.test-domain")
+	require.NoError(t, err, "unexpected error getting DS records")
+	assert.Empty(t, values, "expected no DS records")
+}
+
+func testRFC2136Query(server *dnsserver.Server) *rfc2136Query {
+	return &rfc2136Query{
+		getRFC2136Client: func() (rfc2136client.Client, error) {
+			return rfc2136client.NewClient(server.Addr, dnsserver.KeyName, dnsserver.Secret, "")
+		},
+	}
+}
@@ -0,0 +1,477 @@
+package dnsrecord
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/dnszone"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/manageddns"
+)
+
+const (
+	ControllerName = hivev1.DNSRecordControllerName
+
+	// defaultTTL is the TTL of the records when the DNSRecord does not specify one.
+	defaultTTL = 60
+
+	// resyncDuration is the time between two syncs of the records with the dns provider.
+	resyncDuration = 2 * time.Hour
+
+	recordsSyncedReason   = "RecordsSynced"
+	invalidRecordReason   = "InvalidRecord"
+	dnsZoneNotFoundReason = "DNSZoneNotFound"
+	dnsZoneNotReadyReason = "DNSZoneNotReady"
+	recordConflictReason  = "RecordConflict"
+	noConflictReason      = "NoConflict"
+	dnsErrorReason        = "DNSError"
+)
+
+// Add creates a new DNSRecord controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileDNSRecord {
+	logger := log.WithField("controller", ControllerName)
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+	}
+	return &ReconcileDNSRecord{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+		logger: logger,
+		actuatorBuilder: func(c client.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) (dnszone.Actuator, error) {
+			return dnszone.NewActuator(c, dnsZone, managedDomains, logger)
+		},
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileDNSRecord, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("dnsrecord-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new dnsrecord controller")
+		return err
+	}
+
+	// Watch for changes to DNSRecords
+	if err := c.Watch(&source.Kind{Type: &hivev1.DNSRecord{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching dns records")
+		return err
+	}
+
+	// Watch for changes to DNSZones
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.DNSZone{}},
+		handler.EnqueueRequestsFromMapFunc(requestsForDNSZone(r.Client, r.logger)),
+	); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching dns zones")
+		return err
+	}
+	return nil
+}
+
+func requestsForDNSZone(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		dnsZone, ok := o.(*hivev1.DNSZone)
+		if !ok {
+			return nil
+		}
+		dnsRecords := &hivev1.DNSRecordList{}
+		if err := c.List(context.Background(), dnsRecords, client.InNamespace(dnsZone.Namespace)); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list DNSRecords of DNSZone")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, dnsRecord := range dnsRecords.Items {
+			owned := dnsRecord.Status.OwnedRecordSet
+			if dnsRecord.Spec.DNSZoneRef.Name == dnsZone.Name || (owned != nil && owned.DNSZone == dnsZone.Name) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: dnsRecord.Namespace, Name: dnsRecord.Name},
+				})
+			}
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileDNSRecord{}
+
+// ReconcileDNSRecord reconciles a DNSRecord object with the records of its DNSZone in the dns provider.
+type ReconcileDNSRecord struct {
+	client.Client
+	scheme *runtime.Scheme
+	logger log.FieldLogger
+
+	// actuatorBuilder is a function pointer to the function that builds the actuator for the dns provider of a
+	// DNSZone
+	actuatorBuilder func(c client.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) (dnszone.Actuator, error)
+}
+
+// Reconcile creates, updates and deletes the records of the DNSRecord in the dns provider of its DNSZone.
+func (r *ReconcileDNSRecord) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "dnsRecord", request.NamespacedName)
+	logger.Info("reconciling dns record")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	dnsRecord := &hivev1.DNSRecord{}
+	if err := r.Get(context.TODO(), request.NamespacedName, dnsRecord); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("dns record not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("Error getting dns record")
+		return reconcile.Result{}, err
+	}
+
+	if dnsRecord.DeletionTimestamp != nil {
+		return r.reconcileDeletedDNSRecord(dnsRecord, logger)
+	}
+
+	if !controllerutils.HasFinalizer(dnsRecord, hivev1.FinalizerDNSRecord) {
+		logger.Info("DNSRecord does not have a finalizer. Adding one.")
+		controllerutils.AddFinalizer(dnsRecord, hivev1.FinalizerDNSRecord)
+		if err := r.Update(context.TODO(), dnsRecord); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to add finalizer to DNSRecord")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if sync, delta := shouldSync(dnsRecord); !sync {
+		logger.WithField("delta", delta).Debug("Sync not needed")
+		return reconcile.Result{RequeueAfter: resyncDuration - delta}, nil
+	}
+
+	originalStatus := dnsRecord.Status.DeepCopy()
+	result, err := r.syncRecords(dnsRecord, logger)
+	if !reflect.DeepEqual(*originalStatus, dnsRecord.Status) {
+		if err := r.Status().Update(context.TODO(), dnsRecord); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update dns record status")
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Encountered error while attempting to sync records")
+	}
+	return result, err
+}
+
+// shouldSync returns true when the records must be sync'd with the dns provider, or else the time since the last
+// sync.
+func shouldSync(dnsRecord *hivev1.DNSRecord) (bool, time.Duration) {
+	if dnsRecord.Status.LastSyncTimestamp == nil || dnsRecord.Status.LastSyncGeneration != dnsRecord.Generation {
+		return true, 0
+	}
+	if cond := controllerutils.FindDNSRecordCondition(dnsRecord.Status.Conditions, hivev1.DNSRecordReadyCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+		return true, 0
+	}
+	delta := time.Since(dnsRecord.Status.LastSyncTimestamp.Time)
+	return delta >= resyncDuration, delta
+}
+
+// syncRecords makes the records in the dns provider match the DNSRecord, and sets the conditions of the DNSRecord.
+func (r *ReconcileDNSRecord) syncRecords(dnsRecord *hivev1.DNSRecord, logger log.FieldLogger) (reconcile.Result, error) {
+	ttl := dnsRecord.Spec.TTL
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+	recordSet, err := dnszone.NewRecordSet(dnsRecord.Spec.Name, dnsRecord.Spec.Type, ttl, dnsRecord.Spec.Values)
+	if err != nil {
+		logger.WithError(err).Warn("invalid dns record")
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, invalidRecordReason, err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	dnsZone := &hivev1.DNSZone{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: dnsRecord.Namespace, Name: dnsRecord.Spec.DNSZoneRef.Name}, dnsZone); {
+	case apierrors.IsNotFound(err):
+		logger.Info("dns zone not found")
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsZoneNotFoundReason, fmt.Sprintf("DNSZone %s not found", dnsRecord.Spec.DNSZoneRef.Name))
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("Error getting dns zone")
+		return reconcile.Result{}, err
+	}
+	if dnsZone.DeletionTimestamp != nil {
+		logger.Info("dns zone is being deleted")
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsZoneNotReadyReason, "DNSZone is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	zone := controllerutils.Dotted(dnsZone.Spec.Zone)
+	if !dns.IsSubDomain(zone, recordSet.Name) {
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, invalidRecordReason, fmt.Sprintf("%s is not in zone %s", recordSet.Name, zone))
+		return reconcile.Result{}, nil
+	}
+	if recordSet.Type == hivev1.DNSRecordTypeCNAME && dns.CountLabel(recordSet.Name) == dns.CountLabel(zone) {
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, invalidRecordReason, "CNAME records are not allowed at the apex of the zone")
+		return reconcile.Result{}, nil
+	}
+
+	// Release the record set owned for a previous zone, name or type before claiming the new one.
+	if owned := dnsRecord.Status.OwnedRecordSet; owned != nil &&
+		(owned.DNSZone != dnsZone.Name || owned.Name != recordSet.Name || owned.Type != recordSet.Type) {
+		if err := r.deleteOwnedRecordSet(dnsRecord, logger); err != nil {
+			setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsErrorReason, controllerutils.ErrorScrub(err))
+			return reconcile.Result{}, err
+		}
+	}
+
+	actuator, err := r.getActuator(dnsZone, logger)
+	if err != nil {
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsErrorReason, controllerutils.ErrorScrub(err))
+		return reconcile.Result{}, err
+	}
+	if actuator == nil {
+		logger.Info("dns zone does not exist in the dns provider yet")
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsZoneNotReadyReason, "DNSZone does not exist in the DNS provider")
+		return reconcile.Result{}, nil
+	}
+
+	currentRecordSet, err := actuator.GetRecordSet(recordSet.Name, recordSet.Type)
+	if err != nil {
+		setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsErrorReason, controllerutils.ErrorScrub(err))
+		return reconcile.Result{}, err
+	}
+
+	if dnsRecord.Status.OwnedRecordSet == nil {
+		conflict, err := r.findConflict(dnsRecord, dnsZone, recordSet, currentRecordSet)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if conflict != "" {
+			logger.WithField("conflict", conflict).Warn("record set cannot be owned by the dns record")
+			setConflictCondition(dnsRecord, corev1.ConditionTrue, recordConflictReason, conflict)
+			setReadyCondition(dnsRecord, corev1.ConditionFalse, recordConflictReason, conflict)
+			return reconcile.Result{RequeueAfter: resyncDuration}, nil
+		}
+
+		// Claim the record set before creating it, so that it is never created without an owner.
+		logger.Info("claiming record set")
+		dnsRecord.Status.OwnedRecordSet = &hivev1.DNSRecordSetReference{
+			DNSZone: dnsZone.Name,
+			Name:    recordSet.Name,
+			Type:    recordSet.Type,
+		}
+		if err := r.Status().Update(context.TODO(), dnsRecord); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to claim record set")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !recordSet.Equal(currentRecordSet) {
+		logger.Info("updating records in the dns provider")
+		if err := actuator.UpsertRecordSet(recordSet); err != nil {
+			setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsErrorReason, controllerutils.ErrorScrub(err))
+			return reconcile.Result{}, err
+		}
+	}
+
+	setConflictCondition(dnsRecord, corev1.ConditionFalse, noConflictReason, "Record set is owned by the DNSRecord")
+	setReadyCondition(dnsRecord, corev1.ConditionTrue, recordsSyncedReason, "Records are in sync with the DNS provider")
+	now := metav1.Now()
+	dnsRecord.Status.LastSyncTimestamp = &now
+	dnsRecord.Status.LastSyncGeneration = dnsRecord.Generation
+	return reconcile.Result{RequeueAfter: resyncDuration}, nil
+}
+
+// findConflict returns why the record set of the DNSRecord cannot be owned by the DNSRecord, or an empty string when
+// it can.
+func (r *ReconcileDNSRecord) findConflict(dnsRecord *hivev1.DNSRecord, dnsZone *hivev1.DNSZone, recordSet, currentRecordSet *dnszone.RecordSet) (string, error) {
+	if cdName := dnsZone.Labels[constants.ClusterDeploymentNameLabel]; cdName != "" {
+		cd := &hivev1.ClusterDeployment{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: dnsZone.Namespace, Name: cdName}, cd); {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			r.logger.WithError(err).Error("Error getting cluster deployment")
+			return "", err
+		default:
+			for _, installerName := range installerRecordNames(cd) {
+				if installerName == recordSet.Name {
+					return fmt.Sprintf("%s is managed by the installer of ClusterDeployment %s", installerName, cd.Name), nil
+				}
+			}
+		}
+	}
+
+	dnsRecords := &hivev1.DNSRecordList{}
+	if err := r.List(context.TODO(), dnsRecords, client.InNamespace(dnsRecord.Namespace)); err != nil {
+		r.logger.WithError(err).Log(controllerutils.LogLevel(err), "Error listing dns records")
+		return "", err
+	}
+	for _, other := range dnsRecords.Items {
+		owned := other.Status.OwnedRecordSet
+		if other.Name != dnsRecord.Name && owned != nil &&
+			owned.DNSZone == dnsZone.Name && owned.Name == recordSet.Name && owned.Type == recordSet.Type {
+			return fmt.Sprintf("record set is owned by DNSRecord %s", other.Name), nil
+		}
+	}
+
+	if currentRecordSet != nil {
+		return "record set already exists in the DNS provider", nil
+	}
+	return "", nil
+}
+
+// installerRecordNames returns the names of the records that the installer creates for the cluster.
+func installerRecordNames(cd *hivev1.ClusterDeployment) []string {
+	clusterDomain := strings.ToLower(controllerutils.Dotted(fmt.Sprintf("%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain)))
+	return []string{
+		"api." + clusterDomain,
+		"api-int." + clusterDomain,
+		"*.apps." + clusterDomain,
+	}
+}
+
+// reconcileDeletedDNSRecord deletes the record set owned by the DNSRecord from the dns provider, then removes the
+// finalizer of the DNSRecord.
+func (r *ReconcileDNSRecord) reconcileDeletedDNSRecord(dnsRecord *hivev1.DNSRecord, logger log.FieldLogger) (reconcile.Result, error) {
+	if !controllerutils.HasFinalizer(dnsRecord, hivev1.FinalizerDNSRecord) {
+		logger.Debug("DNSRecord resource has been deleted")
+		return reconcile.Result{}, nil
+	}
+
+	if dnsRecord.Status.OwnedRecordSet != nil {
+		if err := r.deleteOwnedRecordSet(dnsRecord, logger); err != nil {
+			// When the namespace is being deleted, the credentials of the dns provider may already be gone, so there
+			// is no way to ever delete the records.
+			ns := &corev1.Namespace{}
+			if nsErr := r.Get(context.TODO(), types.NamespacedName{Name: dnsRecord.Namespace}, ns); nsErr != nil || ns.DeletionTimestamp == nil {
+				changed := setReadyCondition(dnsRecord, corev1.ConditionFalse, dnsErrorReason, controllerutils.ErrorScrub(err))
+				if changed {
+					if err := r.Status().Update(context.TODO(), dnsRecord); err != nil {
+						logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update dns record status")
+					}
+				}
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to delete owned record set")
+				return reconcile.Result{}, err
+			}
+			logger.WithError(err).Warn("detected a namespace deleted before dnsrecord could be cleaned up, giving up and removing finalizer")
+		}
+	}
+
+	logger.Info("Removing DNSRecord finalizer")
+	controllerutils.DeleteFinalizer(dnsRecord, hivev1.FinalizerDNSRecord)
+	if err := r.Update(context.TODO(), dnsRecord); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to remove DNSRecord finalizer")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// deleteOwnedRecordSet deletes the record set owned by the DNSRecord from the dns provider, and releases it.
+func (r *ReconcileDNSRecord) deleteOwnedRecordSet(dnsRecord *hivev1.DNSRecord, logger log.FieldLogger) error {
+	owned := dnsRecord.Status.OwnedRecordSet
+	logger = logger.WithFields(log.Fields{"dnsZone": owned.DNSZone, "name": owned.Name, "type": owned.Type})
+
+	dnsZone := &hivev1.DNSZone{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: dnsRecord.Namespace, Name: owned.DNSZone}, dnsZone); {
+	case apierrors.IsNotFound(err):
+		logger.Info("dns zone of the owned record set not found, nothing to delete")
+	case err != nil:
+		logger.WithError(err).Error("Error getting dns zone")
+		return err
+	default:
+		actuator, err := r.getActuator(dnsZone, logger)
+		if err != nil {
+			return err
+		}
+		if actuator != nil {
+			logger.Info("deleting owned record set")
+			if err := actuator.DeleteRecordSet(owned.Name, owned.Type); err != nil {
+				return err
+			}
+		}
+	}
+
+	logger.Info("releasing owned record set")
+	dnsRecord.Status.OwnedRecordSet = nil
+	if err := r.Status().Update(context.TODO(), dnsRecord); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to release owned record set")
+		return err
+	}
+	return nil
+}
+
+// getActuator returns the actuator for the dns provider of the DNSZone, or nil when the zone does not exist in the
+// dns provider.
+func (r *ReconcileDNSRecord) getActuator(dnsZone *hivev1.DNSZone, logger log.FieldLogger) (dnszone.Actuator, error) {
+	actuator, err := r.actuatorBuilder(r.Client, dnsZone.DeepCopy(), logger)
+	if err != nil {
+		logger.WithError(err).Error("error instantiating actuator")
+		return nil, err
+	}
+	if err := actuator.Refresh(); err != nil {
+		logger.WithError(err).Error("Failed to retrieve hosted zone")
+		return nil, err
+	}
+	exists, err := actuator.Exists()
+	if err != nil || !exists {
+		return nil, err
+	}
+	return actuator, nil
+}
+
+func setReadyCondition(dnsRecord *hivev1.DNSRecord, status corev1.ConditionStatus, reason, message string) bool {
+	var changed bool
+	dnsRecord.Status.Conditions, changed = controllerutils.SetDNSRecordConditionWithChangeCheck(
+		dnsRecord.Status.Conditions,
+		hivev1.DNSRecordReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	return changed
+}
+
+func setConflictCondition(dnsRecord *hivev1.DNSRecord, status corev1.ConditionStatus, reason, message string) bool {
+	var changed bool
+	dnsRecord.Status.Conditions, changed = controllerutils.SetDNSRecordConditionWithChangeCheck(
+		dnsRecord.Status.Conditions,
+		hivev1.DNSRecordConflictCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	return changed
+}
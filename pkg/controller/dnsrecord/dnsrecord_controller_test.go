@@ -0,0 +1,376 @@
+package dnsrecord
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakekubeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/dnszone"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testNamespace   = "test-namespace"
+	testDNSZoneName = "test-zone"
+	testRecordName  = "test-record"
+)
+
+func TestReconcileDNSRecord(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name                    string
+		dnsRecord               *hivev1.DNSRecord
+		existing                []runtime.Object
+		noDNSZone               bool
+		zoneNotInProvider       bool
+		providerRecordSets      []*dnszone.RecordSet
+		providerErr             error
+		expectErr               bool
+		expectedReadyStatus     corev1.ConditionStatus
+		expectedReadyReason     string
+		expectedConflict        bool
+		expectedOwned           *hivev1.DNSRecordSetReference
+		expectedProviderRecords []*dnszone.RecordSet
+		expectFinalizer         bool
+	}{
+		{
+			name:                "create records",
+			dnsRecord:           testDNSRecord(),
+			expectedReadyStatus: corev1.ConditionTrue,
+			expectedReadyReason: recordsSyncedReason,
+			expectedOwned:       testOwnedRecordSet("www.example.com."),
+			expectedProviderRecords: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.1"),
+			},
+			expectFinalizer: true,
+		},
+		{
+			name: "update owned records",
+			dnsRecord: func() *hivev1.DNSRecord {
+				r := testDNSRecord()
+				r.Spec.TTL = 300
+				r.Status.OwnedRecordSet = testOwnedRecordSet("www.example.com.")
+				return r
+			}(),
+			providerRecordSets: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.9"),
+			},
+			expectedReadyStatus: corev1.ConditionTrue,
+			expectedReadyReason: recordsSyncedReason,
+			expectedOwned:       testOwnedRecordSet("www.example.com."),
+			expectedProviderRecords: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 300, "192.0.2.1"),
+			},
+			expectFinalizer: true,
+		},
+		{
+			name: "rename owned records",
+			dnsRecord: func() *hivev1.DNSRecord {
+				r := testDNSRecord()
+				r.Status.OwnedRecordSet = testOwnedRecordSet("old.example.com.")
+				return r
+			}(),
+			providerRecordSets: []*dnszone.RecordSet{
+				testRecordSet("old.example.com", 60, "192.0.2.1"),
+			},
+			expectedReadyStatus: corev1.ConditionTrue,
+			expectedReadyReason: recordsSyncedReason,
+			expectedOwned:       testOwnedRecordSet("www.example.com."),
+			expectedProviderRecords: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.1"),
+			},
+			expectFinalizer: true,
+		},
+		{
+			name:      "conflict with records in the dns provider",
+			dnsRecord: testDNSRecord(),
+			providerRecordSets: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.9"),
+			},
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: recordConflictReason,
+			expectedConflict:    true,
+			expectedProviderRecords: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.9"),
+			},
+			expectFinalizer: true,
+		},
+		{
+			name: "conflict with installer records",
+			dnsRecord: func() *hivev1.DNSRecord {
+				r := testDNSRecord()
+				r.Spec.Name = "api.mycluster.example.com"
+				return r
+			}(),
+			existing: []runtime.Object{
+				&hivev1.ClusterDeployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "mycluster"},
+					Spec: hivev1.ClusterDeploymentSpec{
+						ClusterName: "mycluster",
+						BaseDomain:  "example.com",
+					},
+				},
+			},
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: recordConflictReason,
+			expectedConflict:    true,
+			expectFinalizer:     true,
+		},
+		{
+			name:      "conflict with another dns record",
+			dnsRecord: testDNSRecord(),
+			existing: []runtime.Object{
+				func() *hivev1.DNSRecord {
+					r := testDNSRecord()
+					r.Name = "other-record"
+					r.Status.OwnedRecordSet = testOwnedRecordSet("www.example.com.")
+					return r
+				}(),
+			},
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: recordConflictReason,
+			expectedConflict:    true,
+			expectFinalizer:     true,
+		},
+		{
+			name: "record outside of the zone",
+			dnsRecord: func() *hivev1.DNSRecord {
+				r := testDNSRecord()
+				r.Spec.Name = "www.other.com"
+				return r
+			}(),
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: invalidRecordReason,
+			expectFinalizer:     true,
+		},
+		{
+			name: "invalid value",
+			dnsRecord: func() *hivev1.DNSRecord {
+				r := testDNSRecord()
+				r.Spec.Values = []string{"not-an-address"}
+				return r
+			}(),
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: invalidRecordReason,
+			expectFinalizer:     true,
+		},
+		{
+			name:                "dns zone not found",
+			dnsRecord:           testDNSRecord(),
+			noDNSZone:           true,
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: dnsZoneNotFoundReason,
+			expectFinalizer:     true,
+		},
+		{
+			name:                "zone not in the dns provider",
+			dnsRecord:           testDNSRecord(),
+			zoneNotInProvider:   true,
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: dnsZoneNotReadyReason,
+			expectFinalizer:     true,
+		},
+		{
+			name:                "dns provider error",
+			dnsRecord:           testDNSRecord(),
+			providerErr:         errors.New("provider error"),
+			expectErr:           true,
+			expectedReadyStatus: corev1.ConditionFalse,
+			expectedReadyReason: dnsErrorReason,
+			expectFinalizer:     true,
+		},
+		{
+			name: "delete owned records",
+			dnsRecord: func() *hivev1.DNSRecord {
+				r := testDNSRecord()
+				now := metav1.Now()
+				r.DeletionTimestamp = &now
+				r.Finalizers = []string{hivev1.FinalizerDNSRecord}
+				r.Status.OwnedRecordSet = testOwnedRecordSet("www.example.com.")
+				return r
+			}(),
+			providerRecordSets: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.1"),
+				testRecordSet("other.example.com", 60, "192.0.2.2"),
+			},
+			expectedProviderRecords: []*dnszone.RecordSet{
+				testRecordSet("other.example.com", 60, "192.0.2.2"),
+			},
+		},
+		{
+			name: "delete without owned records",
+			dnsRecord: func() *hivev1.DNSRecord {
+				r := testDNSRecord()
+				now := metav1.Now()
+				r.DeletionTimestamp = &now
+				r.Finalizers = []string{hivev1.FinalizerDNSRecord}
+				return r
+			}(),
+			providerRecordSets: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.9"),
+			},
+			expectedProviderRecords: []*dnszone.RecordSet{
+				testRecordSet("www.example.com", 60, "192.0.2.9"),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := append(tc.existing, tc.dnsRecord)
+			if !tc.noDNSZone {
+				existing = append(existing, testDNSZone())
+			}
+			c := fakekubeclient.NewClientBuilder().WithRuntimeObjects(existing...).Build()
+			actuator := &fakeActuator{
+				exists:     !tc.zoneNotInProvider,
+				recordSets: map[string]*dnszone.RecordSet{},
+				err:        tc.providerErr,
+			}
+			for _, rs := range tc.providerRecordSets {
+				actuator.recordSets[recordSetKey(rs.Name, rs.Type)] = rs
+			}
+			r := &ReconcileDNSRecord{
+				Client: c,
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", ControllerName),
+				actuatorBuilder: func(client.Client, *hivev1.DNSZone, log.FieldLogger) (dnszone.Actuator, error) {
+					return actuator, nil
+				},
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testRecordName},
+			})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			var providerRecords []*dnszone.RecordSet
+			for _, rs := range actuator.recordSets {
+				providerRecords = append(providerRecords, rs)
+			}
+			assert.ElementsMatch(t, tc.expectedProviderRecords, providerRecords, "unexpected records in the dns provider")
+
+			dnsRecord := &hivev1.DNSRecord{}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testRecordName}, dnsRecord)
+			if apierrors.IsNotFound(err) {
+				assert.False(t, tc.expectFinalizer, "expected DNSRecord to exist")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting DNSRecord")
+			assert.Equal(t, tc.expectFinalizer, controllerutils.HasFinalizer(dnsRecord, hivev1.FinalizerDNSRecord), "unexpected finalizer")
+			assert.Equal(t, tc.expectedOwned, dnsRecord.Status.OwnedRecordSet, "unexpected owned record set")
+			if tc.expectedReadyStatus != "" {
+				cond := controllerutils.FindDNSRecordCondition(dnsRecord.Status.Conditions, hivev1.DNSRecordReadyCondition)
+				if assert.NotNil(t, cond, "missing ready condition") {
+					assert.Equal(t, tc.expectedReadyStatus, cond.Status, "unexpected ready condition status")
+					assert.Equal(t, tc.expectedReadyReason, cond.Reason, "unexpected ready condition reason")
+				}
+			}
+			cond := controllerutils.FindDNSRecordCondition(dnsRecord.Status.Conditions, hivev1.DNSRecordConflictCondition)
+			assert.Equal(t, tc.expectedConflict, cond != nil && cond.Status == corev1.ConditionTrue, "unexpected conflict condition")
+		})
+	}
+}
+
+func testDNSZone() *hivev1.DNSZone {
+	return &hivev1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testDNSZoneName,
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: "mycluster",
+			},
+		},
+		Spec: hivev1.DNSZoneSpec{
+			Zone: "example.com",
+		},
+	}
+}
+
+func testDNSRecord() *hivev1.DNSRecord {
+	return &hivev1.DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testRecordName,
+		},
+		Spec: hivev1.DNSRecordSpec{
+			DNSZoneRef: corev1.LocalObjectReference{Name: testDNSZoneName},
+			Name:       "www.example.com",
+			Type:       hivev1.DNSRecordTypeA,
+			Values:     []string{"192.0.2.1"},
+		},
+	}
+}
+
+func testOwnedRecordSet(name string) *hivev1.DNSRecordSetReference {
+	return &hivev1.DNSRecordSetReference{
+		DNSZone: testDNSZoneName,
+		Name:    name,
+		Type:    hivev1.DNSRecordTypeA,
+	}
+}
+
+func testRecordSet(name string, ttl int64, values ...string) *dnszone.RecordSet {
+	rs, err := dnszone.NewRecordSet(name, hivev1.DNSRecordTypeA, ttl, values)
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+func recordSetKey(name string, recordType hivev1.DNSRecordType) string {
+	return controllerutils.Dotted(name) + "/" + string(recordType)
+}
+
+// fakeActuator is an actuator that keeps the record sets of the zone in memory.
+type fakeActuator struct {
+	exists     bool
+	recordSets map[string]*dnszone.RecordSet
+	err        error
+}
+
+var _ dnszone.Actuator = (*fakeActuator)(nil)
+
+func (a *fakeActuator) Create() error                     { return nil }
+func (a *fakeActuator) Delete() error                     { return nil }
+func (a *fakeActuator) Exists() (bool, error)             { return a.exists, nil }
+func (a *fakeActuator) UpdateMetadata() error             { return nil }
+func (a *fakeActuator) GetNameServers() ([]string, error) { return nil, nil }
+func (a *fakeActuator) Refresh() error                    { return a.err }
+func (a *fakeActuator) SetConditionsForError(error) bool  { return false }
+func (a *fakeActuator) SyncDNSSEC(bool) error             { return nil }
+
+func (a *fakeActuator) GetRecordSet(name string, recordType hivev1.DNSRecordType) (*dnszone.RecordSet, error) {
+	return a.recordSets[recordSetKey(name, recordType)], nil
+}
+
+func (a *fakeActuator) UpsertRecordSet(recordSet *dnszone.RecordSet) error {
+	a.recordSets[recordSetKey(recordSet.Name, recordSet.Type)] = recordSet
+	return nil
+}
+
+func (a *fakeActuator) DeleteRecordSet(name string, recordType hivev1.DNSRecordType) error {
+	delete(a.recordSets, recordSetKey(name, recordType))
+	return nil
+}
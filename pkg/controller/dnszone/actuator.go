@@ -1,5 +1,9 @@
 package dnszone
 
+import (
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
 // Actuator interface is the interface that is used to add dns provider support to the dnszone controller.
 type Actuator interface {
 	// Create tells the actuator to make a zone in the dns provider.
@@ -23,6 +27,19 @@ type Actuator interface {
 	// Refresh will update the DNSZone object's platform-specific status fields.
 	Refresh() error
 
+	// GetRecordSet returns the record set of the zone with the given name and type, or nil if there is none.
+	GetRecordSet(name string, recordType hivev1.DNSRecordType) (*RecordSet, error)
+
+	// UpsertRecordSet creates the record set in the zone, or replaces the record set with the same name and type.
+	UpsertRecordSet(recordSet *RecordSet) error
+
+	// DeleteRecordSet removes the record set with the given name and type from the zone, if it exists.
+	DeleteRecordSet(name string, recordType hivev1.DNSRecordType) error
+
+	// SyncDNSSEC enables or disables DNSSEC signing of the zone in the dns provider.
+	// The DNSSEC status of the DNSZone will be populated with the key signing keys of the zone.
+	SyncDNSSEC(enabled bool) error
+
 	// SetConditionsForError sets conditions on the dnszone given a specific error
 	SetConditionsForError(err error) bool
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
@@ -24,6 +25,13 @@ import (
 
 const (
 	hiveDNSZoneAWSTag = "hive.openshift.io/dnszone"
+
+	// awsKeySigningKeyName is the name of the key signing key that hive creates for hosted zones signed with DNSSEC.
+	awsKeySigningKeyName = "hive"
+
+	awsDNSSECStatusSigning   = "SIGNING"
+	awsKeySigningKeyActive   = "ACTIVE"
+	awsKeySigningKeyInactive = "INACTIVE"
 )
 
 // Ensure AWSActuator implements the Actuator interface. This will fail at compile time when false.
@@ -43,6 +51,9 @@ type AWSActuator struct {
 	// currentTags are the list of tags associated with the currentHostedZone
 	currentHostedZoneTags []*route53.Tag
 
+	// hostedZoneVPCs are the VPCs associated with a private hosted zone
+	hostedZoneVPCs []*route53.VPC
+
 	// The DNSZone that represents the desired state.
 	dnsZone *hivev1.DNSZone
 }
@@ -85,8 +96,11 @@ func (a *AWSActuator) UpdateMetadata() error {
 		return errors.New("hostedZone is unpopulated")
 	}
 
-	// For now, tags are the only things we can sync with existing zones.
-	return a.syncTags()
+	// For now, tags and the VPCs of private zones are the only things we can sync with existing zones.
+	if err := a.syncTags(); err != nil {
+		return err
+	}
+	return a.syncVPCs()
 }
 
 // syncTags determines if there are changes that need to happen to match tags in the spec
@@ -168,6 +182,52 @@ func (a *AWSActuator) syncTags() error {
 	return nil
 }
 
+// syncVPCs associates the VPCs of the DNSZone with a private hosted zone, and disassociates the other VPCs.
+// The VPCs are associated first, as the last VPC of a private hosted zone cannot be disassociated.
+func (a *AWSActuator) syncVPCs() error {
+	if !a.dnsZone.Spec.Private {
+		return nil
+	}
+
+	logger := a.logger.WithField("id", a.hostedZone.Id)
+	current := sets.NewString()
+	for _, vpc := range a.hostedZoneVPCs {
+		current.Insert(aws.StringValue(vpc.VPCId))
+	}
+	expected := sets.NewString()
+	for _, vpc := range a.dnsZone.Spec.AWS.VPCs {
+		expected.Insert(vpc.VPCID)
+		if current.Has(vpc.VPCID) {
+			continue
+		}
+		logger.WithField("vpc", vpc.VPCID).Info("Associating VPC with hosted zone")
+		if _, err := a.awsClient.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			HostedZoneId: a.hostedZone.Id,
+			VPC: &route53.VPC{
+				VPCId:     aws.String(vpc.VPCID),
+				VPCRegion: aws.String(vpc.Region),
+			},
+		}); err != nil {
+			logger.WithError(err).WithField("vpc", vpc.VPCID).Error("Cannot associate VPC with hosted zone")
+			return err
+		}
+	}
+	for _, vpc := range a.hostedZoneVPCs {
+		if expected.Has(aws.StringValue(vpc.VPCId)) {
+			continue
+		}
+		logger.WithField("vpc", aws.StringValue(vpc.VPCId)).Info("Disassociating VPC from hosted zone")
+		if _, err := a.awsClient.DisassociateVPCFromHostedZone(&route53.DisassociateVPCFromHostedZoneInput{
+			HostedZoneId: a.hostedZone.Id,
+			VPC:          vpc,
+		}); err != nil {
+			logger.WithError(err).WithField("vpc", aws.StringValue(vpc.VPCId)).Error("Cannot disassociate VPC from hosted zone")
+			return err
+		}
+	}
+	return nil
+}
+
 // modifyStatus updates the DnsZone's status with AWS specific information.
 func (a *AWSActuator) modifyStatus() error {
 	if a.hostedZone == nil {
@@ -232,6 +292,7 @@ func (a *AWSActuator) Refresh() error {
 		}
 		logger.Debug("Found hosted zone")
 		a.hostedZone = resp.HostedZone
+		a.hostedZoneVPCs = resp.VPCs
 
 		// Update dnsZone status now that we have the zoneID
 		if err := a.modifyStatus(); err != nil {
@@ -331,14 +392,27 @@ func (a *AWSActuator) Create() error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
 	logger.Info("Creating route53 hostedzone")
 	var hostedZone *route53.HostedZone
-	resp, err := a.awsClient.CreateHostedZone(&route53.CreateHostedZoneInput{
+	input := &route53.CreateHostedZoneInput{
 		Name: aws.String(a.dnsZone.Spec.Zone),
 		// We use the UID of the HostedZone resource as the caller reference so that if
 		// we fail to update the status of the HostedZone with the ID of the recently
 		// created zone, we don't attempt to recreate it. Same if communication fails on
 		// the response from AWS.
 		CallerReference: aws.String(string(a.dnsZone.UID)),
-	})
+	}
+	if a.dnsZone.Spec.Private {
+		// A private hosted zone is created with one of its VPCs, the others are associated once it exists.
+		if len(a.dnsZone.Spec.AWS.VPCs) == 0 {
+			return errors.New("private hosted zones require at least one VPC")
+		}
+		vpc := a.dnsZone.Spec.AWS.VPCs[0]
+		input.HostedZoneConfig = &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}
+		input.VPC = &route53.VPC{
+			VPCId:     aws.String(vpc.VPCID),
+			VPCRegion: aws.String(vpc.Region),
+		}
+	}
+	resp, err := a.awsClient.CreateHostedZone(input)
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeHostedZoneAlreadyExists {
 			// If the zone was already created, we need to find its ID
@@ -382,6 +456,20 @@ func (a *AWSActuator) Create() error {
 		return err
 	}
 
+	if a.dnsZone.Spec.Private {
+		logger.Debug("Syncing zone VPCs")
+		resp, err := a.awsClient.GetHostedZone(&route53.GetHostedZoneInput{Id: hostedZone.Id})
+		if err != nil {
+			logger.WithError(err).Error("Cannot get hosted zone")
+			return err
+		}
+		a.hostedZoneVPCs = resp.VPCs
+		if err := a.syncVPCs(); err != nil {
+			logger.WithError(err).Error("Failed to associate VPCs with newly created zone")
+			return err
+		}
+	}
+
 	return err
 }
 
@@ -539,6 +627,223 @@ func (a *AWSActuator) Exists() (bool, error) {
 	return a.hostedZone != nil, nil
 }
 
+// GetRecordSet returns the route53 recordset with the given name and type. Alias recordsets are returned without
+// values.
+func (a *AWSActuator) GetRecordSet(name string, recordType hivev1.DNSRecordType) (*RecordSet, error) {
+	recordSet, err := a.getAWSRecordSet(name, recordType)
+	if err != nil || recordSet == nil {
+		return nil, err
+	}
+	if recordSet.AliasTarget != nil {
+		return &RecordSet{Name: canonicalRecordName(name), Type: recordType}, nil
+	}
+	values := make([]string, len(recordSet.ResourceRecords))
+	for i, record := range recordSet.ResourceRecords {
+		values[i] = aws.StringValue(record.Value)
+	}
+	return newRecordSetFromZoneFileValues(name, string(recordType), aws.Int64Value(recordSet.TTL), values)
+}
+
+// UpsertRecordSet creates or replaces the route53 recordset.
+func (a *AWSActuator) UpsertRecordSet(recordSet *RecordSet) error {
+	if a.hostedZone == nil {
+		return errors.New("hostedZone is unpopulated")
+	}
+	values, err := recordSet.zoneFileValues()
+	if err != nil {
+		return err
+	}
+	records := make([]*route53.ResourceRecord, len(values))
+	for i, value := range values {
+		records[i] = &route53.ResourceRecord{Value: aws.String(value)}
+	}
+
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id)).WithField("name", recordSet.Name).WithField("type", recordSet.Type)
+	logger.Info("Upserting route53 recordset")
+	_, err = a.awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: a.hostedZone.Id,
+		ChangeBatch: &route53.ChangeBatch{Changes: []*route53.Change{{
+			Action: aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name:            aws.String(recordSet.Name),
+				Type:            aws.String(string(recordSet.Type)),
+				TTL:             aws.Int64(recordSet.TTL),
+				ResourceRecords: records,
+			},
+		}}},
+	})
+	if err != nil {
+		logger.WithError(err).Error("Cannot upsert recordset")
+	}
+	return err
+}
+
+// DeleteRecordSet deletes the route53 recordset with the given name and type.
+func (a *AWSActuator) DeleteRecordSet(name string, recordType hivev1.DNSRecordType) error {
+	recordSet, err := a.getAWSRecordSet(name, recordType)
+	if err != nil || recordSet == nil {
+		return err
+	}
+
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id)).WithField("name", name).WithField("type", recordType)
+	logger.Info("Deleting route53 recordset")
+	// Route53 only deletes recordsets that match the current values and TTL.
+	_, err = a.awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: a.hostedZone.Id,
+		ChangeBatch: &route53.ChangeBatch{Changes: []*route53.Change{{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: recordSet,
+		}}},
+	})
+	if err != nil {
+		logger.WithError(err).Error("Cannot delete recordset")
+	}
+	return err
+}
+
+func (a *AWSActuator) getAWSRecordSet(name string, recordType hivev1.DNSRecordType) (*route53.ResourceRecordSet, error) {
+	if a.hostedZone == nil {
+		return nil, errors.New("hostedZone is unpopulated")
+	}
+
+	name = canonicalRecordName(name)
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id)).WithField("name", name).WithField("type", recordType)
+	logger.Debug("Listing hosted zone recordsets")
+	resp, err := a.awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    a.hostedZone.Id,
+		StartRecordName: aws.String(name),
+		StartRecordType: aws.String(string(recordType)),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error listing recordsets for zone")
+		return nil, err
+	}
+	for _, recordSet := range resp.ResourceRecordSets {
+		// Route53 returns the asterisk of wildcard names in octal escape form.
+		recordName := strings.ToLower(strings.Replace(aws.StringValue(recordSet.Name), `\052`, "*", 1))
+		if recordName == name && aws.StringValue(recordSet.Type) == string(recordType) {
+			return recordSet, nil
+		}
+	}
+	return nil, nil
+}
+
+// SyncDNSSEC enables or disables DNSSEC signing of the hosted zone. Route53 signs the zone with a key signing key
+// that is backed by the customer managed KMS key of the DNSZone.
+func (a *AWSActuator) SyncDNSSEC(enabled bool) error {
+	if a.hostedZone == nil {
+		return errors.New("hostedZone is unpopulated")
+	}
+
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id))
+	logger.Debug("Fetching DNSSEC status of hosted zone")
+	dnssec, err := a.awsClient.GetDNSSEC(&route53.GetDNSSECInput{HostedZoneId: a.hostedZone.Id})
+	if err != nil {
+		logger.WithError(err).Error("Cannot get DNSSEC status of hosted zone")
+		return err
+	}
+	var ksk *route53.KeySigningKey
+	for _, key := range dnssec.KeySigningKeys {
+		if aws.StringValue(key.Name) == awsKeySigningKeyName {
+			ksk = key
+		}
+	}
+	signing := dnssec.Status != nil && aws.StringValue(dnssec.Status.ServeSignature) == awsDNSSECStatusSigning
+
+	if !enabled {
+		if signing {
+			logger.Info("Disabling DNSSEC signing of hosted zone")
+			if _, err := a.awsClient.DisableHostedZoneDNSSEC(&route53.DisableHostedZoneDNSSECInput{HostedZoneId: a.hostedZone.Id}); err != nil {
+				logger.WithError(err).Error("Cannot disable DNSSEC signing of hosted zone")
+				return err
+			}
+		}
+		if ksk != nil {
+			if aws.StringValue(ksk.Status) == awsKeySigningKeyActive {
+				logger.Info("Deactivating key signing key")
+				if _, err := a.awsClient.DeactivateKeySigningKey(&route53.DeactivateKeySigningKeyInput{
+					HostedZoneId: a.hostedZone.Id,
+					Name:         ksk.Name,
+				}); err != nil {
+					logger.WithError(err).Error("Cannot deactivate key signing key")
+					return err
+				}
+			}
+			logger.Info("Deleting key signing key")
+			if _, err := a.awsClient.DeleteKeySigningKey(&route53.DeleteKeySigningKeyInput{
+				HostedZoneId: a.hostedZone.Id,
+				Name:         ksk.Name,
+			}); err != nil {
+				logger.WithError(err).Error("Cannot delete key signing key")
+				return err
+			}
+		}
+		a.dnsZone.Status.DNSSEC = nil
+		return nil
+	}
+
+	changed := false
+	switch {
+	case ksk == nil:
+		kmsKeyARN := a.dnsZone.Spec.AWS.DNSSECKMSKeyARN
+		if kmsKeyARN == "" {
+			return errors.New("a KMS key is required for the key signing key of the hosted zone")
+		}
+		logger.WithField("kmsKey", kmsKeyARN).Info("Creating key signing key")
+		if _, err := a.awsClient.CreateKeySigningKey(&route53.CreateKeySigningKeyInput{
+			CallerReference:         aws.String(fmt.Sprintf("%s-%d", a.dnsZone.UID, a.dnsZone.Generation)),
+			HostedZoneId:            a.hostedZone.Id,
+			KeyManagementServiceArn: aws.String(kmsKeyARN),
+			Name:                    aws.String(awsKeySigningKeyName),
+			Status:                  aws.String(awsKeySigningKeyActive),
+		}); err != nil {
+			logger.WithError(err).Error("Cannot create key signing key")
+			return err
+		}
+		changed = true
+	case aws.StringValue(ksk.Status) == awsKeySigningKeyInactive:
+		logger.Info("Activating key signing key")
+		if _, err := a.awsClient.ActivateKeySigningKey(&route53.ActivateKeySigningKeyInput{
+			HostedZoneId: a.hostedZone.Id,
+			Name:         ksk.Name,
+		}); err != nil {
+			logger.WithError(err).Error("Cannot activate key signing key")
+			return err
+		}
+		changed = true
+	}
+	if !signing {
+		logger.Info("Enabling DNSSEC signing of hosted zone")
+		if _, err := a.awsClient.EnableHostedZoneDNSSEC(&route53.EnableHostedZoneDNSSECInput{HostedZoneId: a.hostedZone.Id}); err != nil {
+			logger.WithError(err).Error("Cannot enable DNSSEC signing of hosted zone")
+			return err
+		}
+		changed = true
+	}
+	if changed {
+		logger.Debug("Fetching updated DNSSEC status of hosted zone")
+		if dnssec, err = a.awsClient.GetDNSSEC(&route53.GetDNSSECInput{HostedZoneId: a.hostedZone.Id}); err != nil {
+			logger.WithError(err).Error("Cannot get DNSSEC status of hosted zone")
+			return err
+		}
+	}
+
+	status := &hivev1.DNSSECStatus{
+		Signing: dnssec.Status != nil && aws.StringValue(dnssec.Status.ServeSignature) == awsDNSSECStatusSigning,
+	}
+	for _, key := range dnssec.KeySigningKeys {
+		status.KeySigningKeys = append(status.KeySigningKeys, hivev1.DNSSECKeySigningKey{
+			Name:     aws.StringValue(key.Name),
+			KeyTag:   aws.Int64Value(key.KeyTag),
+			Active:   aws.StringValue(key.Status) == awsKeySigningKeyActive,
+			DSRecord: aws.StringValue(key.DSRecord),
+		})
+	}
+	a.dnsZone.Status.DNSSEC = status
+	return nil
+}
+
 func (a *AWSActuator) setInsufficientCredentialsConditionToFalse() bool {
 	accessDeniedConds, accessDeniedCondsChanged := controllerutils.SetDNSZoneConditionWithChangeCheck(
 		a.dnsZone.Status.Conditions,
@@ -140,6 +140,22 @@ func mockExistingAWSTags(expect *mock.MockClientMockRecorder) {
 	}, nil).Times(1)
 }
 
+func mockAWSGetDNSSEC(expect *mock.MockClientMockRecorder, signing bool, kskStatus string) {
+	output := &route53.GetDNSSECOutput{Status: &route53.DNSSECStatus{ServeSignature: aws.String("NOT_SIGNING")}}
+	if signing {
+		output.Status.ServeSignature = aws.String(awsDNSSECStatusSigning)
+	}
+	if kskStatus != "" {
+		output.KeySigningKeys = []*route53.KeySigningKey{{
+			Name:     aws.String(awsKeySigningKeyName),
+			KeyTag:   aws.Int64(12345),
+			Status:   aws.String(kskStatus),
+			DSRecord: aws.String("12345 13 2 ABCDEF"),
+		}}
+	}
+	expect.GetDNSSEC(gomock.Any()).Return(output, nil).Times(1)
+}
+
 func mockSyncAWSTags(expect *mock.MockClientMockRecorder) {
 	expect.ChangeTagsForResource(gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil).AnyTimes()
 }
@@ -180,6 +196,27 @@ func mockDeleteAWSZone(expect *mock.MockClientMockRecorder) {
 	expect.DeleteHostedZone(gomock.Any()).Return(nil, nil).Times(1)
 }
 
+func mockAWSGetPrivateZone(expect *mock.MockClientMockRecorder, vpcIDs ...string) {
+	resp := &route53.GetHostedZoneOutput{
+		HostedZone: &route53.HostedZone{
+			Id:     aws.String("1234"),
+			Name:   aws.String("blah.example.com."),
+			Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)},
+		},
+	}
+	for _, vpcID := range vpcIDs {
+		resp.VPCs = append(resp.VPCs, &route53.VPC{VPCId: aws.String(vpcID), VPCRegion: aws.String("us-east-1")})
+	}
+	expect.GetHostedZone(gomock.Any()).Return(resp, nil).Times(1)
+}
+
+func mockAssociateAWSVPC(expect *mock.MockClientMockRecorder, vpcID string) {
+	expect.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+		HostedZoneId: aws.String("1234"),
+		VPC:          &route53.VPC{VPCId: aws.String(vpcID), VPCRegion: aws.String("us-east-1")},
+	}).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil).Times(1)
+}
+
 func mockGetResourcePages(expect *mock.MockClientMockRecorder) {
 	expect.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).Do(func(i *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
 		getResourcesOutput := &resourcegroupstaggingapi.GetResourcesOutput{
@@ -192,3 +229,103 @@ func mockGetResourcePages(expect *mock.MockClientMockRecorder) {
 		f(getResourcesOutput, true)
 	})
 }
+
+// TestAWSRecordSets tests that the AWS actuator gets, upserts and deletes route53 recordsets.
+func TestAWSRecordSets(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	a := &AWSActuator{
+		logger:     log.WithField("controller", ControllerName),
+		awsClient:  mocks.mockAWSClient,
+		dnsZone:    validDNSZone(),
+		hostedZone: &route53.HostedZone{Id: aws.String("1234"), Name: aws.String("blah.example.com.")},
+	}
+	txtRecordSet := &route53.ResourceRecordSet{
+		Name:            aws.String(`\052.blah.example.com.`),
+		Type:            aws.String("TXT"),
+		TTL:             aws.Int64(60),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(`"some text"`)}},
+	}
+	mocks.mockAWSClient.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("1234"),
+		StartRecordName: aws.String("*.blah.example.com."),
+		StartRecordType: aws.String("TXT"),
+		MaxItems:        aws.String("1"),
+	}).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53.ResourceRecordSet{txtRecordSet},
+	}, nil).Times(2)
+	mocks.mockAWSClient.EXPECT().ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53.ResourceRecordSet{{
+			Name:        aws.String("api.blah.example.com."),
+			Type:        aws.String("A"),
+			AliasTarget: &route53.AliasTarget{DNSName: aws.String("lb.example.com.")},
+		}},
+	}, nil).Times(1)
+
+	rs, err := a.GetRecordSet("*.blah.example.com", hivev1.DNSRecordTypeTXT)
+	if assert.NoError(t, err, "unexpected error getting recordset") {
+		assert.Equal(t, &RecordSet{Name: "*.blah.example.com.", Type: hivev1.DNSRecordTypeTXT, TTL: 60, Values: []string{"some text"}}, rs, "unexpected recordset")
+	}
+
+	rs, err = a.GetRecordSet("api.blah.example.com", hivev1.DNSRecordTypeA)
+	if assert.NoError(t, err, "unexpected error getting alias recordset") {
+		assert.Equal(t, &RecordSet{Name: "api.blah.example.com.", Type: hivev1.DNSRecordTypeA}, rs, "expected alias recordset without values")
+	}
+
+	desired, err := NewRecordSet("*.blah.example.com", hivev1.DNSRecordTypeTXT, 300, []string{"new text"})
+	assert.NoError(t, err, "unexpected error creating recordset")
+	mocks.mockAWSClient.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("1234"),
+		ChangeBatch: &route53.ChangeBatch{Changes: []*route53.Change{{
+			Action: aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name:            aws.String("*.blah.example.com."),
+				Type:            aws.String("TXT"),
+				TTL:             aws.Int64(300),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(`"new text"`)}},
+			},
+		}}},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Times(1)
+	assert.NoError(t, a.UpsertRecordSet(desired), "unexpected error upserting recordset")
+
+	// Deleting a recordset must match its current values.
+	mocks.mockAWSClient.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("1234"),
+		ChangeBatch: &route53.ChangeBatch{Changes: []*route53.Change{{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: txtRecordSet,
+		}}},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Times(1)
+	assert.NoError(t, a.DeleteRecordSet("*.blah.example.com", hivev1.DNSRecordTypeTXT), "unexpected error deleting recordset")
+}
+
+// TestAWSPrivateZoneVPCs tests that the AWS actuator associates the VPCs of a private zone, and disassociates the
+// VPCs that are no longer in the DNSZone.
+func TestAWSPrivateZoneVPCs(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	defer mocks.mockCtrl.Finish()
+	a := &AWSActuator{
+		logger:     log.WithField("controller", ControllerName),
+		awsClient:  mocks.mockAWSClient,
+		dnsZone:    validPrivateDNSZone(),
+		hostedZone: &route53.HostedZone{Id: aws.String("1234"), Name: aws.String("blah.example.com.")},
+		hostedZoneVPCs: []*route53.VPC{
+			{VPCId: aws.String("vpc-1"), VPCRegion: aws.String("us-east-1")},
+			{VPCId: aws.String("vpc-3"), VPCRegion: aws.String("us-west-2")},
+		},
+	}
+	gomock.InOrder(
+		mocks.mockAWSClient.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			HostedZoneId: aws.String("1234"),
+			VPC:          &route53.VPC{VPCId: aws.String("vpc-2"), VPCRegion: aws.String("us-east-1")},
+		}).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil).Times(1),
+		mocks.mockAWSClient.EXPECT().DisassociateVPCFromHostedZone(&route53.DisassociateVPCFromHostedZoneInput{
+			HostedZoneId: aws.String("1234"),
+			VPC:          &route53.VPC{VPCId: aws.String("vpc-3"), VPCRegion: aws.String("us-west-2")},
+		}).Return(&route53.DisassociateVPCFromHostedZoneOutput{}, nil).Times(1),
+	)
+	assert.NoError(t, a.syncVPCs(), "unexpected error syncing VPCs")
+
+	// A private hosted zone cannot be created without a VPC.
+	a.dnsZone.Spec.AWS.VPCs = nil
+	assert.Error(t, a.Create(), "expected error creating private hosted zone without VPCs")
+}
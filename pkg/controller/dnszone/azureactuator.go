@@ -3,10 +3,14 @@ package dnszone
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest/to"
+	miekgdns "github.com/miekg/dns"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
@@ -144,6 +148,220 @@ func (a *AzureActuator) Exists() (bool, error) {
 	return a.managedZone != nil, nil
 }
 
+// SyncDNSSEC implements the SyncDNSSEC call of the actuator interface
+func (a *AzureActuator) SyncDNSSEC(enabled bool) error {
+	if enabled {
+		return errors.New("DNSSEC is not supported for Azure DNS")
+	}
+	return nil
+}
+
+// GetRecordSet implements the GetRecordSet call of the actuator interface
+func (a *AzureActuator) GetRecordSet(name string, recordType hivev1.DNSRecordType) (*RecordSet, error) {
+	if a.managedZone == nil {
+		return nil, errors.New("managedZone is unpopulated")
+	}
+
+	relativeName := a.relativeRecordName(name)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", relativeName).WithField("type", recordType)
+	logger.Debug("Fetching recordset")
+	recordSet, err := a.azureClient.GetRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone, relativeName, dns.RecordType(recordType))
+	if err != nil {
+		if recordSet.Response.Response != nil && recordSet.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		logger.WithError(err).Error("Cannot get recordset")
+		return nil, err
+	}
+	return azureRecordSetToRecordSet(canonicalRecordName(name), recordType, recordSet)
+}
+
+// UpsertRecordSet implements the UpsertRecordSet call of the actuator interface
+func (a *AzureActuator) UpsertRecordSet(recordSet *RecordSet) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+	azureRecordSet, err := recordSetToAzureRecordSet(recordSet)
+	if err != nil {
+		return err
+	}
+
+	relativeName := a.relativeRecordName(recordSet.Name)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", relativeName).WithField("type", recordSet.Type)
+	logger.Info("Upserting recordset")
+	if _, err := a.azureClient.CreateOrUpdateRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone, relativeName, dns.RecordType(recordSet.Type), azureRecordSet); err != nil {
+		logger.WithError(err).Error("Cannot upsert recordset")
+		return err
+	}
+	return nil
+}
+
+// DeleteRecordSet implements the DeleteRecordSet call of the actuator interface
+func (a *AzureActuator) DeleteRecordSet(name string, recordType hivev1.DNSRecordType) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+
+	relativeName := a.relativeRecordName(name)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", relativeName).WithField("type", recordType)
+	logger.Info("Deleting recordset")
+	// Deleting a recordset that does not exist succeeds.
+	if err := a.azureClient.DeleteRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone, relativeName, dns.RecordType(recordType)); err != nil {
+		logger.WithError(err).Error("Cannot delete recordset")
+		return err
+	}
+	return nil
+}
+
+// relativeRecordName returns the name of the recordset relative to the zone, as used by Azure DNS.
+func (a *AzureActuator) relativeRecordName(name string) string {
+	return azureRelativeRecordName(a.dnsZone.Spec.Zone, name)
+}
+
+// azureRelativeRecordName returns the name of the recordset relative to the zone, as used by Azure DNS and
+// Azure Private DNS.
+func azureRelativeRecordName(zone, name string) string {
+	name = canonicalRecordName(name)
+	zone = canonicalRecordName(zone)
+	if name == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+zone)
+}
+
+// azureRecordSetToRecordSet converts the records of an Azure DNS recordset.
+func azureRecordSetToRecordSet(name string, recordType hivev1.DNSRecordType, recordSet dns.RecordSet) (*RecordSet, error) {
+	props := recordSet.RecordSetProperties
+	if props == nil {
+		return nil, errors.New("recordset has no properties")
+	}
+	hdr := miekgdns.RR_Header{
+		Name:   name,
+		Rrtype: miekgdns.StringToType[string(recordType)],
+		Class:  miekgdns.ClassINET,
+		Ttl:    uint32(to.Int64(props.TTL)),
+	}
+	var rrs []miekgdns.RR
+	switch recordType {
+	case hivev1.DNSRecordTypeA:
+		if props.ARecords == nil {
+			break
+		}
+		for _, r := range *props.ARecords {
+			rrs = append(rrs, &miekgdns.A{Hdr: hdr, A: net.ParseIP(to.String(r.Ipv4Address))})
+		}
+	case hivev1.DNSRecordTypeAAAA:
+		if props.AaaaRecords == nil {
+			break
+		}
+		for _, r := range *props.AaaaRecords {
+			rrs = append(rrs, &miekgdns.AAAA{Hdr: hdr, AAAA: net.ParseIP(to.String(r.Ipv6Address))})
+		}
+	case hivev1.DNSRecordTypeCAA:
+		if props.CaaRecords == nil {
+			break
+		}
+		for _, r := range *props.CaaRecords {
+			rrs = append(rrs, &miekgdns.CAA{Hdr: hdr, Flag: uint8(to.Int32(r.Flags)), Tag: to.String(r.Tag), Value: to.String(r.Value)})
+		}
+	case hivev1.DNSRecordTypeCNAME:
+		if props.CnameRecord != nil {
+			rrs = append(rrs, &miekgdns.CNAME{Hdr: hdr, Target: miekgdns.Fqdn(to.String(props.CnameRecord.Cname))})
+		}
+	case hivev1.DNSRecordTypeMX:
+		if props.MxRecords == nil {
+			break
+		}
+		for _, r := range *props.MxRecords {
+			rrs = append(rrs, &miekgdns.MX{Hdr: hdr, Preference: uint16(to.Int32(r.Preference)), Mx: miekgdns.Fqdn(to.String(r.Exchange))})
+		}
+	case hivev1.DNSRecordTypeSRV:
+		if props.SrvRecords == nil {
+			break
+		}
+		for _, r := range *props.SrvRecords {
+			rrs = append(rrs, &miekgdns.SRV{
+				Hdr:      hdr,
+				Priority: uint16(to.Int32(r.Priority)),
+				Weight:   uint16(to.Int32(r.Weight)),
+				Port:     uint16(to.Int32(r.Port)),
+				Target:   miekgdns.Fqdn(to.String(r.Target)),
+			})
+		}
+	case hivev1.DNSRecordTypeTXT:
+		if props.TxtRecords == nil {
+			break
+		}
+		for _, r := range *props.TxtRecords {
+			rrs = append(rrs, &miekgdns.TXT{Hdr: hdr, Txt: to.StringSlice(r.Value)})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+	if len(rrs) == 0 {
+		// A recordset without records, e.g. an alias recordset, still occupies the name and type.
+		return &RecordSet{Name: name, Type: recordType, TTL: int64(hdr.Ttl)}, nil
+	}
+	return newRecordSetFromRRs(rrs), nil
+}
+
+// recordSetToAzureRecordSet converts the records of a record set to an Azure DNS recordset.
+func recordSetToAzureRecordSet(recordSet *RecordSet) (dns.RecordSet, error) {
+	rrs, err := recordSet.RRs()
+	if err != nil {
+		return dns.RecordSet{}, err
+	}
+	props := &dns.RecordSetProperties{TTL: to.Int64Ptr(recordSet.TTL)}
+	var (
+		aRecords    []dns.ARecord
+		aaaaRecords []dns.AaaaRecord
+		caaRecords  []dns.CaaRecord
+		mxRecords   []dns.MxRecord
+		srvRecords  []dns.SrvRecord
+		txtRecords  []dns.TxtRecord
+	)
+	for _, rr := range rrs {
+		switch rr := rr.(type) {
+		case *miekgdns.A:
+			aRecords = append(aRecords, dns.ARecord{Ipv4Address: to.StringPtr(rr.A.String())})
+		case *miekgdns.AAAA:
+			aaaaRecords = append(aaaaRecords, dns.AaaaRecord{Ipv6Address: to.StringPtr(rr.AAAA.String())})
+		case *miekgdns.CAA:
+			caaRecords = append(caaRecords, dns.CaaRecord{Flags: to.Int32Ptr(int32(rr.Flag)), Tag: to.StringPtr(rr.Tag), Value: to.StringPtr(rr.Value)})
+		case *miekgdns.CNAME:
+			props.CnameRecord = &dns.CnameRecord{Cname: to.StringPtr(rr.Target)}
+		case *miekgdns.MX:
+			mxRecords = append(mxRecords, dns.MxRecord{Preference: to.Int32Ptr(int32(rr.Preference)), Exchange: to.StringPtr(rr.Mx)})
+		case *miekgdns.SRV:
+			srvRecords = append(srvRecords, dns.SrvRecord{
+				Priority: to.Int32Ptr(int32(rr.Priority)),
+				Weight:   to.Int32Ptr(int32(rr.Weight)),
+				Port:     to.Int32Ptr(int32(rr.Port)),
+				Target:   to.StringPtr(rr.Target),
+			})
+		case *miekgdns.TXT:
+			txtRecords = append(txtRecords, dns.TxtRecord{Value: to.StringSlicePtr(rr.Txt)})
+		default:
+			return dns.RecordSet{}, fmt.Errorf("unsupported record type %q", recordSet.Type)
+		}
+	}
+	switch {
+	case len(aRecords) > 0:
+		props.ARecords = &aRecords
+	case len(aaaaRecords) > 0:
+		props.AaaaRecords = &aaaaRecords
+	case len(caaRecords) > 0:
+		props.CaaRecords = &caaRecords
+	case len(mxRecords) > 0:
+		props.MxRecords = &mxRecords
+	case len(srvRecords) > 0:
+		props.SrvRecords = &srvRecords
+	case len(txtRecords) > 0:
+		props.TxtRecords = &txtRecords
+	}
+	return dns.RecordSet{RecordSetProperties: props}, nil
+}
+
 // GetNameServers implements the GetNameServers call of the actuator interface
 func (a *AzureActuator) GetNameServers() ([]string, error) {
 	if a.managedZone == nil {
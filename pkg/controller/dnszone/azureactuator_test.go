@@ -3,6 +3,7 @@ package dnszone
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
@@ -11,6 +12,7 @@ import (
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -93,3 +95,39 @@ func mockDeleteAzureZone(mockCtrl *gomock.Controller, expect *mock.MockClientMoc
 	expect.ListRecordSetsByZone(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(recordSetPage, nil)
 	expect.DeleteZone(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 }
+
+// TestAzureRecordSetConversion tests that record sets are converted to Azure DNS recordsets and back.
+func TestAzureRecordSetConversion(t *testing.T) {
+	cases := []struct {
+		name       string
+		recordType hivev1.DNSRecordType
+		values     []string
+	}{
+		{name: "A", recordType: hivev1.DNSRecordTypeA, values: []string{"192.0.2.1", "192.0.2.2"}},
+		{name: "AAAA", recordType: hivev1.DNSRecordTypeAAAA, values: []string{"2001:db8::1"}},
+		{name: "CAA", recordType: hivev1.DNSRecordTypeCAA, values: []string{`0 issue "letsencrypt.org"`}},
+		{name: "CNAME", recordType: hivev1.DNSRecordTypeCNAME, values: []string{"router.example.com."}},
+		{name: "MX", recordType: hivev1.DNSRecordTypeMX, values: []string{"10 mail1.example.com.", "20 mail2.example.com."}},
+		{name: "SRV", recordType: hivev1.DNSRecordTypeSRV, values: []string{"10 5 443 svc.example.com."}},
+		{name: "TXT", recordType: hivev1.DNSRecordTypeTXT, values: []string{"some text", strings.Repeat("a", 300)}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := NewRecordSet("www.blah.example.com", tc.recordType, 300, tc.values)
+			require.NoError(t, err, "unexpected error creating record set")
+			azureRecordSet, err := recordSetToAzureRecordSet(rs)
+			require.NoError(t, err, "unexpected error converting to Azure recordset")
+			assert.Equal(t, int64(300), to.Int64(azureRecordSet.TTL), "unexpected TTL")
+			converted, err := azureRecordSetToRecordSet(rs.Name, rs.Type, azureRecordSet)
+			require.NoError(t, err, "unexpected error converting from Azure recordset")
+			assert.Equal(t, rs, converted, "expected record set to survive conversion")
+		})
+	}
+}
+
+// TestAzureRelativeRecordName tests that record names are made relative to the zone.
+func TestAzureRelativeRecordName(t *testing.T) {
+	a := &AzureActuator{dnsZone: validAzureDNSZone()}
+	assert.Equal(t, "@", a.relativeRecordName("blah.example.com."), "unexpected name for the apex of the zone")
+	assert.Equal(t, "*.apps", a.relativeRecordName("*.apps.Blah.example.com"), "unexpected name for a wildcard")
+}
@@ -0,0 +1,491 @@
+package dnszone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	miekgdns "github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// AzurePrivateActuator attempts to make the current state of an Azure Private DNS zone reflect the given desired state.
+type AzurePrivateActuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// azureClient is a utility for making it easy for controllers to interface with Azure
+	azureClient azureclient.Client
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// privateZone is the Azure Private DNS zone object.
+	privateZone *privatedns.PrivateZone
+
+	// virtualNetworkLinks are the links of the private zone to virtual networks.
+	virtualNetworkLinks []privatedns.VirtualNetworkLink
+}
+
+// NewAzurePrivateActuator creates a new AzurePrivateActuator object. A new AzurePrivateActuator is expected to be created for each controller sync.
+func NewAzurePrivateActuator(
+	logger log.FieldLogger,
+	secret *corev1.Secret,
+	dnsZone *hivev1.DNSZone,
+	azureClientBuilder azureClientBuilderType,
+) (*AzurePrivateActuator, error) {
+	azureClient, err := azureClientBuilder(secret)
+	if err != nil {
+		logger.WithError(err).Error("Error creating AzureClient")
+		return nil, err
+	}
+
+	azurePrivateActuator := &AzurePrivateActuator{
+		logger:      logger,
+		azureClient: azureClient,
+		dnsZone:     dnsZone,
+	}
+
+	return azurePrivateActuator, nil
+}
+
+// Ensure AzurePrivateActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &AzurePrivateActuator{}
+
+// Create implements the Create call of the actuator interface
+func (a *AzurePrivateActuator) Create() error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Creating private zone")
+
+	privateZone, err := a.azureClient.CreateOrUpdatePrivateZone(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone)
+	if err != nil {
+		logger.WithError(err).Error("Error creating private zone")
+		return err
+	}
+
+	logger.Debug("Private zone successfully created")
+	a.privateZone = &privateZone
+	a.virtualNetworkLinks = nil
+	return a.syncVirtualNetworkLinks()
+}
+
+// Delete implements the Delete call of the actuator interface
+func (a *AzurePrivateActuator) Delete() error {
+	if a.privateZone == nil {
+		return errors.New("privateZone is unpopulated")
+	}
+
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+
+	logger.Info("Deleting recordsets in private zone")
+	if err := DeleteAzurePrivateRecordSets(a.azureClient, a.dnsZone, logger); err != nil {
+		return err
+	}
+
+	// Private zones cannot be deleted while they are linked to virtual networks.
+	for _, link := range a.virtualNetworkLinks {
+		logger.WithField("link", to.String(link.Name)).Info("Deleting virtual network link")
+		if err := a.azureClient.DeleteVirtualNetworkLink(context.TODO(), resourceGroupName, a.dnsZone.Spec.Zone, to.String(link.Name)); err != nil {
+			logger.WithError(err).Error("Cannot delete virtual network link")
+			return err
+		}
+	}
+
+	logger.Info("Deleting private zone")
+	err := a.azureClient.DeletePrivateZone(context.TODO(), resourceGroupName, a.dnsZone.Spec.Zone)
+	if err != nil {
+		log.WithError(err).Error("Cannot delete private zone")
+	}
+
+	return err
+}
+
+// DeleteAzurePrivateRecordSets will remove all non-essential records from the private DNSZone provided.
+func DeleteAzurePrivateRecordSets(azureClient azureclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	resourceGroupName := dnsZone.Spec.Azure.ResourceGroupName
+	zoneName := dnsZone.Spec.Zone
+	recordSetsPage, err := azureClient.ListPrivateRecordSetsByZone(context.Background(), resourceGroupName, zoneName)
+	if err != nil {
+		return err
+	}
+	for recordSetsPage.NotDone() {
+		for _, recordSet := range recordSetsPage.Values() {
+			if recordSet.Name == nil || recordSet.Type == nil {
+				logger.Warn("found recordset with missing name or type")
+				continue
+			}
+			name := *recordSet.Name
+			// The type comes in as, for example, "Microsoft.Network/privateDnsZones/A". We need just the last part
+			// of that, in this case "A".
+			typeParts := strings.Split(*recordSet.Type, "/")
+			recordType := privatedns.RecordType(typeParts[len(typeParts)-1])
+			// Ignore the recordset that is created with the private zone and that cannot be deleted
+			if name == "@" && recordType == privatedns.SOA {
+				continue
+			}
+			logger.WithField("name", name).WithField("type", recordType).Info("deleting recordset")
+			if err := azureClient.DeletePrivateRecordSet(context.Background(), resourceGroupName, zoneName, name, recordType); err != nil {
+				return err
+			}
+		}
+		if err := recordSetsPage.NextWithContext(context.Background()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *AzurePrivateActuator) Exists() (bool, error) {
+	return a.privateZone != nil, nil
+}
+
+// SyncDNSSEC implements the SyncDNSSEC call of the actuator interface
+func (a *AzurePrivateActuator) SyncDNSSEC(enabled bool) error {
+	if enabled {
+		return errors.New("DNSSEC is not supported for Azure Private DNS")
+	}
+	return nil
+}
+
+// GetRecordSet implements the GetRecordSet call of the actuator interface
+func (a *AzurePrivateActuator) GetRecordSet(name string, recordType hivev1.DNSRecordType) (*RecordSet, error) {
+	if a.privateZone == nil {
+		return nil, errors.New("privateZone is unpopulated")
+	}
+
+	relativeName := azureRelativeRecordName(a.dnsZone.Spec.Zone, name)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", relativeName).WithField("type", recordType)
+	logger.Debug("Fetching recordset")
+	recordSet, err := a.azureClient.GetPrivateRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone, relativeName, privatedns.RecordType(recordType))
+	if err != nil {
+		if recordSet.Response.Response != nil && recordSet.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		logger.WithError(err).Error("Cannot get recordset")
+		return nil, err
+	}
+	return azurePrivateRecordSetToRecordSet(canonicalRecordName(name), recordType, recordSet)
+}
+
+// UpsertRecordSet implements the UpsertRecordSet call of the actuator interface
+func (a *AzurePrivateActuator) UpsertRecordSet(recordSet *RecordSet) error {
+	if a.privateZone == nil {
+		return errors.New("privateZone is unpopulated")
+	}
+	azureRecordSet, err := recordSetToAzurePrivateRecordSet(recordSet)
+	if err != nil {
+		return err
+	}
+
+	relativeName := azureRelativeRecordName(a.dnsZone.Spec.Zone, recordSet.Name)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", relativeName).WithField("type", recordSet.Type)
+	logger.Info("Upserting recordset")
+	if _, err := a.azureClient.CreateOrUpdatePrivateRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone, relativeName, privatedns.RecordType(recordSet.Type), azureRecordSet); err != nil {
+		logger.WithError(err).Error("Cannot upsert recordset")
+		return err
+	}
+	return nil
+}
+
+// DeleteRecordSet implements the DeleteRecordSet call of the actuator interface
+func (a *AzurePrivateActuator) DeleteRecordSet(name string, recordType hivev1.DNSRecordType) error {
+	if a.privateZone == nil {
+		return errors.New("privateZone is unpopulated")
+	}
+
+	relativeName := azureRelativeRecordName(a.dnsZone.Spec.Zone, name)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", relativeName).WithField("type", recordType)
+	logger.Info("Deleting recordset")
+	// Deleting a recordset that does not exist succeeds.
+	if err := a.azureClient.DeletePrivateRecordSet(context.TODO(), a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone, relativeName, privatedns.RecordType(recordType)); err != nil {
+		logger.WithError(err).Error("Cannot delete recordset")
+		return err
+	}
+	return nil
+}
+
+// azurePrivateRecordSetToRecordSet converts the records of an Azure Private DNS recordset.
+func azurePrivateRecordSetToRecordSet(name string, recordType hivev1.DNSRecordType, recordSet privatedns.RecordSet) (*RecordSet, error) {
+	props := recordSet.RecordSetProperties
+	if props == nil {
+		return nil, errors.New("recordset has no properties")
+	}
+	hdr := miekgdns.RR_Header{
+		Name:   name,
+		Rrtype: miekgdns.StringToType[string(recordType)],
+		Class:  miekgdns.ClassINET,
+		Ttl:    uint32(to.Int64(props.TTL)),
+	}
+	var rrs []miekgdns.RR
+	switch recordType {
+	case hivev1.DNSRecordTypeA:
+		if props.ARecords == nil {
+			break
+		}
+		for _, r := range *props.ARecords {
+			rrs = append(rrs, &miekgdns.A{Hdr: hdr, A: net.ParseIP(to.String(r.Ipv4Address))})
+		}
+	case hivev1.DNSRecordTypeAAAA:
+		if props.AaaaRecords == nil {
+			break
+		}
+		for _, r := range *props.AaaaRecords {
+			rrs = append(rrs, &miekgdns.AAAA{Hdr: hdr, AAAA: net.ParseIP(to.String(r.Ipv6Address))})
+		}
+	case hivev1.DNSRecordTypeCNAME:
+		if props.CnameRecord != nil {
+			rrs = append(rrs, &miekgdns.CNAME{Hdr: hdr, Target: miekgdns.Fqdn(to.String(props.CnameRecord.Cname))})
+		}
+	case hivev1.DNSRecordTypeMX:
+		if props.MxRecords == nil {
+			break
+		}
+		for _, r := range *props.MxRecords {
+			rrs = append(rrs, &miekgdns.MX{Hdr: hdr, Preference: uint16(to.Int32(r.Preference)), Mx: miekgdns.Fqdn(to.String(r.Exchange))})
+		}
+	case hivev1.DNSRecordTypeSRV:
+		if props.SrvRecords == nil {
+			break
+		}
+		for _, r := range *props.SrvRecords {
+			rrs = append(rrs, &miekgdns.SRV{
+				Hdr:      hdr,
+				Priority: uint16(to.Int32(r.Priority)),
+				Weight:   uint16(to.Int32(r.Weight)),
+				Port:     uint16(to.Int32(r.Port)),
+				Target:   miekgdns.Fqdn(to.String(r.Target)),
+			})
+		}
+	case hivev1.DNSRecordTypeTXT:
+		if props.TxtRecords == nil {
+			break
+		}
+		for _, r := range *props.TxtRecords {
+			rrs = append(rrs, &miekgdns.TXT{Hdr: hdr, Txt: to.StringSlice(r.Value)})
+		}
+	default:
+		return nil, fmt.Errorf("record type %q is not supported by Azure Private DNS", recordType)
+	}
+	if len(rrs) == 0 {
+		return &RecordSet{Name: name, Type: recordType, TTL: int64(hdr.Ttl)}, nil
+	}
+	return newRecordSetFromRRs(rrs), nil
+}
+
+// recordSetToAzurePrivateRecordSet converts the records of a record set to an Azure Private DNS recordset.
+func recordSetToAzurePrivateRecordSet(recordSet *RecordSet) (privatedns.RecordSet, error) {
+	rrs, err := recordSet.RRs()
+	if err != nil {
+		return privatedns.RecordSet{}, err
+	}
+	props := &privatedns.RecordSetProperties{TTL: to.Int64Ptr(recordSet.TTL)}
+	var (
+		aRecords    []privatedns.ARecord
+		aaaaRecords []privatedns.AaaaRecord
+		mxRecords   []privatedns.MxRecord
+		srvRecords  []privatedns.SrvRecord
+		txtRecords  []privatedns.TxtRecord
+	)
+	for _, rr := range rrs {
+		switch rr := rr.(type) {
+		case *miekgdns.A:
+			aRecords = append(aRecords, privatedns.ARecord{Ipv4Address: to.StringPtr(rr.A.String())})
+		case *miekgdns.AAAA:
+			aaaaRecords = append(aaaaRecords, privatedns.AaaaRecord{Ipv6Address: to.StringPtr(rr.AAAA.String())})
+		case *miekgdns.CNAME:
+			props.CnameRecord = &privatedns.CnameRecord{Cname: to.StringPtr(rr.Target)}
+		case *miekgdns.MX:
+			mxRecords = append(mxRecords, privatedns.MxRecord{Preference: to.Int32Ptr(int32(rr.Preference)), Exchange: to.StringPtr(rr.Mx)})
+		case *miekgdns.SRV:
+			srvRecords = append(srvRecords, privatedns.SrvRecord{
+				Priority: to.Int32Ptr(int32(rr.Priority)),
+				Weight:   to.Int32Ptr(int32(rr.Weight)),
+				Port:     to.Int32Ptr(int32(rr.Port)),
+				Target:   to.StringPtr(rr.Target),
+			})
+		case *miekgdns.TXT:
+			txtRecords = append(txtRecords, privatedns.TxtRecord{Value: to.StringSlicePtr(rr.Txt)})
+		default:
+			return privatedns.RecordSet{}, fmt.Errorf("record type %q is not supported by Azure Private DNS", recordSet.Type)
+		}
+	}
+	switch {
+	case len(aRecords) > 0:
+		props.ARecords = &aRecords
+	case len(aaaaRecords) > 0:
+		props.AaaaRecords = &aaaaRecords
+	case len(mxRecords) > 0:
+		props.MxRecords = &mxRecords
+	case len(srvRecords) > 0:
+		props.SrvRecords = &srvRecords
+	case len(txtRecords) > 0:
+		props.TxtRecords = &txtRecords
+	}
+	return privatedns.RecordSet{RecordSetProperties: props}, nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *AzurePrivateActuator) GetNameServers() ([]string, error) {
+	if a.privateZone == nil {
+		return nil, errors.New("privateZone is unpopulated")
+	}
+
+	// Private zones are resolved by the Azure-provided name servers of their virtual networks.
+	return nil, nil
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *AzurePrivateActuator) Refresh() error {
+	zoneName := a.dnsZone.Spec.Zone
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+
+	// Fetch the private zone
+	logger := a.logger.WithField("zone", zoneName)
+	logger.Debug("Fetching private zone by zone name")
+	resp, err := a.azureClient.GetPrivateZone(context.TODO(), resourceGroupName, zoneName)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			logger.Debug("Zone not found, clearing out the cached object")
+			a.privateZone = nil
+			a.virtualNetworkLinks = nil
+			return nil
+		}
+
+		logger.WithError(err).Error("Cannot get private zone")
+		return err
+	}
+
+	logger.Debug("Found private zone")
+	a.privateZone = &resp
+
+	logger.Debug("Listing virtual network links of private zone")
+	linksPage, err := a.azureClient.ListVirtualNetworkLinks(context.TODO(), resourceGroupName, zoneName)
+	if err != nil {
+		logger.WithError(err).Error("Cannot list virtual network links of private zone")
+		return err
+	}
+	a.virtualNetworkLinks = nil
+	for linksPage.NotDone() {
+		a.virtualNetworkLinks = append(a.virtualNetworkLinks, linksPage.Values()...)
+		if err := linksPage.NextWithContext(context.TODO()); err != nil {
+			logger.WithError(err).Error("Cannot list virtual network links of private zone")
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *AzurePrivateActuator) UpdateMetadata() error {
+	if a.privateZone == nil {
+		return errors.New("privateZone is unpopulated")
+	}
+
+	// The virtual network links are the only things we can sync with existing zones.
+	return a.syncVirtualNetworkLinks()
+}
+
+// syncVirtualNetworkLinks links the private zone to the virtual networks of the DNSZone, and removes the links to
+// other virtual networks.
+func (a *AzurePrivateActuator) syncVirtualNetworkLinks() error {
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+
+	linked := map[string]bool{}
+	for _, link := range a.virtualNetworkLinks {
+		if key, ok := virtualNetworkLinkKey(link); ok {
+			linked[key] = true
+		}
+	}
+	expected := map[string]bool{}
+	for _, vnet := range a.dnsZone.Spec.Azure.VirtualNetworks {
+		key := virtualNetworkKey(vnet.ResourceGroupName, vnet.Name)
+		expected[key] = true
+		if linked[key] {
+			continue
+		}
+		linkName := azureVirtualNetworkLinkName(vnet)
+		logger.WithField("link", linkName).Info("Linking private zone to virtual network")
+		if err := a.azureClient.CreateOrUpdateVirtualNetworkLink(context.TODO(), resourceGroupName, a.dnsZone.Spec.Zone, linkName, vnet.ResourceGroupName, vnet.Name); err != nil {
+			logger.WithError(err).WithField("link", linkName).Error("Cannot link private zone to virtual network")
+			return err
+		}
+	}
+	for _, link := range a.virtualNetworkLinks {
+		if key, ok := virtualNetworkLinkKey(link); ok && expected[key] {
+			continue
+		}
+		logger.WithField("link", to.String(link.Name)).Info("Deleting virtual network link")
+		if err := a.azureClient.DeleteVirtualNetworkLink(context.TODO(), resourceGroupName, a.dnsZone.Spec.Zone, to.String(link.Name)); err != nil {
+			logger.WithError(err).WithField("link", to.String(link.Name)).Error("Cannot delete virtual network link")
+			return err
+		}
+	}
+	return nil
+}
+
+// azureVirtualNetworkLinkName returns the name of the link of a private zone to the virtual network.
+func azureVirtualNetworkLinkName(vnet hivev1.AzureDNSZoneVirtualNetwork) string {
+	return fmt.Sprintf("%s-%s", vnet.ResourceGroupName, vnet.Name)
+}
+
+// virtualNetworkLinkKey returns the key of the virtual network of a link, if the link has a valid virtual network.
+func virtualNetworkLinkKey(link privatedns.VirtualNetworkLink) (string, bool) {
+	if link.VirtualNetworkLinkProperties == nil || link.VirtualNetwork == nil {
+		return "", false
+	}
+	resource, err := azure.ParseResourceID(to.String(link.VirtualNetwork.ID))
+	if err != nil {
+		return "", false
+	}
+	return virtualNetworkKey(resource.ResourceGroup, resource.ResourceName), true
+}
+
+// virtualNetworkKey identifies a virtual network. Azure resource group names are case insensitive.
+func virtualNetworkKey(resourceGroupName, name string) string {
+	return strings.ToLower(resourceGroupName) + "/" + name
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *AzurePrivateActuator) SetConditionsForError(err error) bool {
+	// other conditions not implemented for Azure yet, so set generic condition
+	var cloudErrorsConds []hivev1.DNSZoneCondition
+	var cloudErrorsCondsChanged bool
+	if err == nil {
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionFalse,
+			dnsNoErrorReason,
+			"No cloud errors occurred",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	} else {
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionTrue,
+			dnsCloudErrorReason,
+			controllerutils.ErrorScrub(err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if cloudErrorsCondsChanged {
+		a.dnsZone.Status.Conditions = cloudErrorsConds
+	}
+	return cloudErrorsCondsChanged
+}
@@ -0,0 +1,98 @@
+package dnszone
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// TestAzurePrivateRecordSetConversion tests that record sets are converted to Azure Private DNS recordsets and back.
+func TestAzurePrivateRecordSetConversion(t *testing.T) {
+	cases := []struct {
+		name       string
+		recordType hivev1.DNSRecordType
+		values     []string
+		expectErr  bool
+	}{
+		{name: "A", recordType: hivev1.DNSRecordTypeA, values: []string{"192.0.2.1", "192.0.2.2"}},
+		{name: "AAAA", recordType: hivev1.DNSRecordTypeAAAA, values: []string{"2001:db8::1"}},
+		{name: "CNAME", recordType: hivev1.DNSRecordTypeCNAME, values: []string{"router.example.com."}},
+		{name: "MX", recordType: hivev1.DNSRecordTypeMX, values: []string{"10 mail1.example.com.", "20 mail2.example.com."}},
+		{name: "SRV", recordType: hivev1.DNSRecordTypeSRV, values: []string{"10 5 443 svc.example.com."}},
+		{name: "TXT", recordType: hivev1.DNSRecordTypeTXT, values: []string{"some text", strings.Repeat("a", 300)}},
+		{name: "CAA", recordType: hivev1.DNSRecordTypeCAA, values: []string{`0 issue "letsencrypt.org"`}, expectErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := NewRecordSet("www.blah.example.com", tc.recordType, 300, tc.values)
+			require.NoError(t, err, "unexpected error creating record set")
+			azureRecordSet, err := recordSetToAzurePrivateRecordSet(rs)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error converting to Azure Private DNS recordset")
+				return
+			}
+			require.NoError(t, err, "unexpected error converting to Azure Private DNS recordset")
+			assert.Equal(t, int64(300), to.Int64(azureRecordSet.TTL), "unexpected TTL")
+			converted, err := azurePrivateRecordSetToRecordSet(rs.Name, rs.Type, azureRecordSet)
+			require.NoError(t, err, "unexpected error converting from Azure Private DNS recordset")
+			assert.Equal(t, rs, converted, "expected record set to survive conversion")
+		})
+	}
+}
+
+// TestAzurePrivateZoneVirtualNetworkLinks tests that the private zone is linked to the virtual networks of the
+// DNSZone, and that links to other virtual networks are deleted.
+func TestAzurePrivateZoneVirtualNetworkLinks(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	defer mocks.mockCtrl.Finish()
+	dnsZone := validAzureDNSZone()
+	dnsZone.Spec.Private = true
+	dnsZone.Spec.Azure.VirtualNetworks = []hivev1.AzureDNSZoneVirtualNetwork{
+		{ResourceGroupName: "network-rg", Name: "vnet-1"},
+		{ResourceGroupName: "network-rg", Name: "vnet-2"},
+	}
+	a := &AzurePrivateActuator{
+		logger:      log.WithField("controller", ControllerName),
+		azureClient: mocks.mockAzureClient,
+		dnsZone:     dnsZone,
+		privateZone: &privatedns.PrivateZone{Name: to.StringPtr("blah.example.com")},
+		virtualNetworkLinks: []privatedns.VirtualNetworkLink{
+			{
+				Name: to.StringPtr("network-rg-vnet-1"),
+				VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+					VirtualNetwork: &privatedns.SubResource{
+						// Resource group names are case insensitive.
+						ID: to.StringPtr("/subscriptions/sub/resourceGroups/NETWORK-RG/providers/Microsoft.Network/virtualNetworks/vnet-1"),
+					},
+				},
+			},
+			{
+				Name: to.StringPtr("old-rg-vnet-3"),
+				VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+					VirtualNetwork: &privatedns.SubResource{
+						ID: to.StringPtr("/subscriptions/sub/resourceGroups/old-rg/providers/Microsoft.Network/virtualNetworks/vnet-3"),
+					},
+				},
+			},
+		},
+	}
+	gomock.InOrder(
+		mocks.mockAzureClient.EXPECT().
+			CreateOrUpdateVirtualNetworkLink(gomock.Any(), "default", "blah.example.com", "network-rg-vnet-2", "network-rg", "vnet-2").
+			Return(nil).Times(1),
+		mocks.mockAzureClient.EXPECT().
+			DeleteVirtualNetworkLink(gomock.Any(), "default", "blah.example.com", "old-rg-vnet-3").
+			Return(nil).Times(1),
+	)
+	assert.NoError(t, a.UpdateMetadata(), "unexpected error syncing virtual network links")
+
+	assert.Error(t, a.SyncDNSSEC(true), "expected error enabling DNSSEC for private zone")
+}
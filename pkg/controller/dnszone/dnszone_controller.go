@@ -20,6 +20,8 @@ import (
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/manageddns"
+	"github.com/openshift/hive/pkg/rfc2136client"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,18 +40,20 @@ import (
 )
 
 const (
-	ControllerName                  = hivev1.DNSZoneControllerName
-	zoneResyncDuration              = 2 * time.Hour
-	domainAvailabilityCheckInterval = 30 * time.Second
-	dnsClientTimeout                = 30 * time.Second
-	resolverConfigFile              = "/etc/resolv.conf"
-	zoneCheckDNSServersEnvVar       = "ZONE_CHECK_DNS_SERVERS"
-	accessDeniedReason              = "AccessDenied"
-	accessGrantedReason             = "AccessGranted"
-	authenticationFailedReason      = "AuthenticationFailed"
-	authenticationSucceededReason   = "AuthenticationSucceeded"
-	dnsCloudErrorReason             = "CloudError"
-	dnsNoErrorReason                = "NoError"
+	ControllerName                   = hivev1.DNSZoneControllerName
+	zoneResyncDuration               = 2 * time.Hour
+	domainAvailabilityCheckInterval  = 30 * time.Second
+	dnssecStatusCheckInterval        = 30 * time.Second
+	dnsClientTimeout                 = 30 * time.Second
+	dnsRecordDeletionRequeueDuration = 10 * time.Second
+	resolverConfigFile               = "/etc/resolv.conf"
+	zoneCheckDNSServersEnvVar        = "ZONE_CHECK_DNS_SERVERS"
+	accessDeniedReason               = "AccessDenied"
+	accessGrantedReason              = "AccessGranted"
+	authenticationFailedReason       = "AuthenticationFailed"
+	authenticationSucceededReason    = "AuthenticationSucceeded"
+	dnsCloudErrorReason              = "CloudError"
+	dnsNoErrorReason                 = "NoError"
 )
 
 var (
@@ -79,11 +83,17 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileDNSZone {
+	logger := log.WithField("controller", ControllerName)
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+	}
 	return &ReconcileDNSZone{
-		Client:    controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		scheme:    mgr.GetScheme(),
-		logger:    log.WithField("controller", ControllerName),
-		soaLookup: lookupSOARecord,
+		Client:         controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:         mgr.GetScheme(),
+		logger:         logger,
+		soaLookup:      lookupSOARecord,
+		managedDomains: managedDomains,
 	}
 }
 
@@ -115,9 +125,24 @@ func add(mgr manager.Manager, r *ReconcileDNSZone, concurrentReconciles int, rat
 		return err
 	}
 
+	// Watch for changes to DNSRecord so that deleted DNSZones can proceed once their DNSRecords are gone
+	if err := c.Watch(&source.Kind{Type: &hivev1.DNSRecord{}}, handler.EnqueueRequestsFromMapFunc(dnsRecordWatchHandler)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func dnsRecordWatchHandler(o client.Object) []reconcile.Request {
+	dnsRecord, ok := o.(*hivev1.DNSRecord)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Namespace: dnsRecord.Namespace, Name: dnsRecord.Spec.DNSZoneRef.Name},
+	}}
+}
+
 var _ reconcile.Reconciler = &ReconcileDNSZone{}
 
 // ReconcileDNSZone reconciles a DNSZone object
@@ -129,6 +154,10 @@ type ReconcileDNSZone struct {
 
 	// soaLookup is a function that looks up a zone's SOA record
 	soaLookup func(string, log.FieldLogger) (bool, error)
+
+	// managedDomains are the managed domains configured in HiveConfig. The TSIG keys of RFC 2136 managed domains
+	// are used for the zones that do not reference a TSIG key of their own.
+	managedDomains []hivev1.ManageDNSConfig
 }
 
 // Reconcile reads that state of the cluster for a DNSZone object and makes changes based on the state read
@@ -185,7 +214,7 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, nil
 	}
 
-	actuator, err := r.getActuator(desiredState, dnsLog)
+	actuator, err := NewActuator(r.Client, desiredState, r.managedDomains, dnsLog)
 	if err != nil {
 		// Handle an edge case here where if the DNSZone has been deleted, it has its finalizer, the actuator couldn't be
 		// created (presumably because creds secret is absent), and our namespace is terminated, we know we've entered a bad state
@@ -272,8 +301,24 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 
 	if dnsZone.DeletionTimestamp != nil {
 		if zoneFound {
+			// The DNSRecords of the zone must delete their records before the zone goes away.
+			dnsRecordsDeleted, err := r.deleteDNSRecords(dnsZone)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if !dnsRecordsDeleted {
+				r.logger.Info("DNSZone resource is deleted, waiting for the DNSRecords of the zone to be deleted")
+				return reconcile.Result{RequeueAfter: dnsRecordDeletionRequeueDuration}, nil
+			}
+			if dnsZone.Status.DNSSEC != nil {
+				// Route53 does not delete hosted zones that are signed with DNSSEC.
+				r.logger.Debug("DNSZone resource is deleted, disabling DNSSEC signing of hosted zone")
+				if err := actuator.SyncDNSSEC(false); err != nil {
+					return reconcile.Result{}, err
+				}
+			}
 			r.logger.Debug("DNSZone resource is deleted, deleting hosted zone")
-			err := actuator.Delete()
+			err = actuator.Delete()
 			if err != nil {
 				return reconcile.Result{}, err
 			}
@@ -315,15 +360,26 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 		}
 	}
 
+	if enabled := dnssecEnabled(dnsZone); enabled || dnsZone.Status.DNSSEC != nil {
+		if err := actuator.SyncDNSSEC(enabled); err != nil {
+			r.logger.WithError(err).Error("Failed to sync DNSSEC signing of hosted zone")
+			return reconcile.Result{}, err
+		}
+	}
+
 	nameServers, err := actuator.GetNameServers()
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to get hosted zone name servers")
 		return reconcile.Result{}, err
 	}
 
-	isZoneSOAAvailable, err := r.soaLookup(dnsZone.Spec.Zone, r.logger)
-	if err != nil {
-		r.logger.WithError(err).Error("error looking up SOA record for zone")
+	// Private zones cannot be resolved from here, they are available as soon as they exist in the dns provider.
+	isZoneSOAAvailable := true
+	if !dnsZone.Spec.Private {
+		isZoneSOAAvailable, err = r.soaLookup(dnsZone.Spec.Zone, r.logger)
+		if err != nil {
+			r.logger.WithError(err).Error("error looking up SOA record for zone")
+		}
 	}
 
 	reconcileResult := reconcile.Result{}
@@ -331,10 +387,40 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 		r.logger.Info("SOA record for DNS zone not available")
 		reconcileResult.RequeueAfter = domainAvailabilityCheckInterval
 	}
+	if dnssecPending(dnsZone) {
+		r.logger.Info("DNSSEC signing of hosted zone not yet enabled")
+		reconcileResult.RequeueAfter = dnssecStatusCheckInterval
+	}
 
 	return reconcileResult, r.updateStatus(nameServers, isZoneSOAAvailable, dnsZone)
 }
 
+// deleteDNSRecords deletes the DNSRecords that reference the DNSZone. Returns true when there are no DNSRecords left.
+func (r *ReconcileDNSZone) deleteDNSRecords(dnsZone *hivev1.DNSZone) (bool, error) {
+	dnsRecords := &hivev1.DNSRecordList{}
+	if err := r.List(context.TODO(), dnsRecords, client.InNamespace(dnsZone.Namespace)); err != nil {
+		r.logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to list DNSRecords")
+		return false, err
+	}
+	allDeleted := true
+	for i := range dnsRecords.Items {
+		dnsRecord := &dnsRecords.Items[i]
+		if dnsRecord.Spec.DNSZoneRef.Name != dnsZone.Name {
+			continue
+		}
+		allDeleted = false
+		if dnsRecord.DeletionTimestamp != nil {
+			continue
+		}
+		r.logger.WithField("dnsRecord", dnsRecord.Name).Info("Deleting DNSRecord of deleted DNSZone")
+		if err := r.Delete(context.TODO(), dnsRecord); err != nil && !apierrors.IsNotFound(err) {
+			r.logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to delete DNSRecord")
+			return false, err
+		}
+	}
+	return allDeleted, nil
+}
+
 func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 	if desiredState.DeletionTimestamp != nil && !controllerutils.HasFinalizer(desiredState, hivev1.FinalizerDNSZone) {
 		return false, 0 // No finalizer means our cleanup has been completed. There's nothing left to do.
@@ -359,7 +445,15 @@ func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 		} // If waiting to link to parent, sync now to check domain
 	}
 
+	if !dnssecEnabled(desiredState) && desiredState.Status.DNSSEC != nil {
+		return true, 0 // The DS records have been removed from the parent domain, disable DNSSEC signing now.
+	}
+
 	delta := time.Now().Sub(desiredState.Status.LastSyncTimestamp.Time)
+	if dnssecPending(desiredState) && delta >= dnssecStatusCheckInterval {
+		// Waiting for the dns provider to sign the zone, sync now to check the keys.
+		return true, delta
+	}
 	if delta >= zoneResyncDuration {
 		// We haven't sync'd in over zoneResyncDuration time, sync now.
 		return true, delta
@@ -369,7 +463,37 @@ func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 	return false, delta
 }
 
-func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.FieldLogger) (Actuator, error) {
+// dnssecEnabled returns true if the zone should be signed with DNSSEC. A zone stays signed until the DS records of
+// its keys have been removed from the parent domain, as resolvers would otherwise fail to validate the zone.
+func dnssecEnabled(dnsZone *hivev1.DNSZone) bool {
+	if dnsZone.Spec.DNSSEC {
+		return true
+	}
+	dsRecordsCondition := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.DSRecordsCreatedCondition)
+	return dsRecordsCondition != nil && dsRecordsCondition.Status == corev1.ConditionTrue
+}
+
+// dnssecPending returns true while the zone should be signed with DNSSEC but the dns provider has not yet signed it
+// with an active key signing key.
+func dnssecPending(dnsZone *hivev1.DNSZone) bool {
+	if !dnsZone.Spec.DNSSEC {
+		return false
+	}
+	status := dnsZone.Status.DNSSEC
+	if status == nil || !status.Signing {
+		return true
+	}
+	for _, key := range status.KeySigningKeys {
+		if key.Active && key.DSRecord != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// NewActuator creates the actuator for the dns provider of the DNSZone. The managed domains provide the TSIG keys of
+// RFC 2136 zones that do not reference one.
+func NewActuator(c client.Client, dnsZone *hivev1.DNSZone, managedDomains []hivev1.ManageDNSConfig, dnsLog log.FieldLogger) (Actuator, error) {
 	if dnsZone.Spec.AWS != nil {
 		credentials := awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
@@ -385,12 +509,12 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 			},
 		}
 
-		return NewAWSActuator(dnsLog, r.Client, credentials, dnsZone, awsclient.New)
+		return NewAWSActuator(dnsLog, c, credentials, dnsZone, awsclient.New)
 	}
 
 	if dnsZone.Spec.GCP != nil {
 		secret := &corev1.Secret{}
-		err := r.Get(context.TODO(),
+		err := c.Get(context.TODO(),
 			types.NamespacedName{
 				Name:      dnsZone.Spec.GCP.CredentialsSecretRef.Name,
 				Namespace: dnsZone.Namespace,
@@ -405,7 +529,7 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 
 	if dnsZone.Spec.Azure != nil {
 		secret := &corev1.Secret{}
-		err := r.Get(context.TODO(),
+		err := c.Get(context.TODO(),
 			types.NamespacedName{
 				Name:      dnsZone.Spec.Azure.CredentialsSecretRef.Name,
 				Namespace: dnsZone.Namespace,
@@ -415,9 +539,37 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 			return nil, err
 		}
 
+		if dnsZone.Spec.Private {
+			return NewAzurePrivateActuator(dnsLog, secret, dnsZone, azureclient.NewClientFromSecret)
+		}
 		return NewAzureActuator(dnsLog, secret, dnsZone, azureclient.NewClientFromSecret)
 	}
 
+	if dnsZone.Spec.RFC2136 != nil {
+		secretKey := types.NamespacedName{
+			Name:      dnsZone.Spec.RFC2136.TSIGSecretRef.Name,
+			Namespace: dnsZone.Namespace,
+		}
+		if secretKey.Name == "" {
+			// The TSIG key of the managed domain is read from the namespace of Hive rather than copied to the
+			// namespace of the zone.
+			managedDomain := manageddns.FindManagedDomain(managedDomains, dnsZone.Spec.Zone)
+			if managedDomain == nil || managedDomain.RFC2136 == nil {
+				return nil, errors.New("zone references no TSIG key and is not in an RFC 2136 managed domain")
+			}
+			secretKey = types.NamespacedName{
+				Name:      managedDomain.RFC2136.TSIGSecretRef.Name,
+				Namespace: controllerutils.GetHiveNamespace(),
+			}
+		}
+		secret := &corev1.Secret{}
+		if err := c.Get(context.TODO(), secretKey, secret); err != nil {
+			return nil, err
+		}
+
+		return NewRFC2136Actuator(dnsLog, secret, dnsZone, rfc2136client.NewClientFromSecret)
+	}
+
 	return nil, errors.New("unable to determine which actuator to use")
 }
 
@@ -437,6 +589,9 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 		availableStatus = corev1.ConditionTrue
 		availableReason = "ZoneAvailable"
 		availableMessage = "DNS SOA record for zone is reachable"
+		if dnsZone.Spec.Private {
+			availableMessage = "Private DNS zone is created"
+		}
 	} else {
 		availableStatus = corev1.ConditionFalse
 		availableReason = "ZoneUnavailable"
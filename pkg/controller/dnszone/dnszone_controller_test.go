@@ -5,24 +5,34 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/golang/mock/gomock"
+	"github.com/miekg/dns"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	googledns "google.golang.org/api/dns/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	fakekubeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/awsclient/mock"
 	awsmock "github.com/openshift/hive/pkg/awsclient/mock"
 	azuremock "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpmock "github.com/openshift/hive/pkg/gcpclient/mock"
+	"github.com/openshift/hive/pkg/test/dnsserver"
 	testdnszone "github.com/openshift/hive/pkg/test/dnszone"
 	testgeneric "github.com/openshift/hive/pkg/test/generic"
 )
@@ -167,6 +177,155 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
 			},
 		},
+		{
+			name:    "Create private hosted zone",
+			dnsZone: validPrivateDNSZoneWithoutID(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneDoesntExist(expect, validPrivateDNSZoneWithoutID())
+				expect.CreateHostedZone(gomock.Any()).
+					Do(func(input *route53.CreateHostedZoneInput) {
+						assert.True(t, aws.BoolValue(input.HostedZoneConfig.PrivateZone), "expected private hosted zone")
+						assert.Equal(t, "vpc-1", aws.StringValue(input.VPC.VPCId), "unexpected VPC of created hosted zone")
+					}).
+					Return(&route53.CreateHostedZoneOutput{
+						HostedZone: &route53.HostedZone{Id: aws.String("1234"), Name: aws.String("blah.example.com.")},
+					}, nil).Times(1)
+				mockNoExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				mockAWSGetPrivateZone(expect, "vpc-1")
+				mockAssociateAWSVPC(expect, "vpc-2")
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, "1234", aws.StringValue(zone.Status.AWS.ZoneID), "unexpected zone ID")
+			},
+		},
+		{
+			name:    "Existing private zone, available without SOA lookup",
+			dnsZone: validPrivateDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSGetPrivateZone(expect, "vpc-1", "vpc-2")
+				mockExistingAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+				if assert.NotNil(t, condition, "zone available condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "private zone should be available")
+				}
+			},
+		},
+		{
+			name:    "Existing zone, enable DNSSEC",
+			dnsZone: validDNSZoneWithDNSSEC(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithDNSSEC())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, false, "")
+				expect.CreateKeySigningKey(gomock.Any()).
+					Do(func(input *route53.CreateKeySigningKeyInput) {
+						assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/1234abcd", aws.StringValue(input.KeyManagementServiceArn), "unexpected KMS key")
+						assert.Equal(t, awsKeySigningKeyActive, aws.StringValue(input.Status), "unexpected key signing key status")
+					}).
+					Return(&route53.CreateKeySigningKeyOutput{}, nil).Times(1)
+				expect.EnableHostedZoneDNSSEC(gomock.Any()).Return(&route53.EnableHostedZoneDNSSECOutput{}, nil).Times(1)
+				mockAWSGetDNSSEC(expect, true, awsKeySigningKeyActive)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, validDNSZoneSignedWithDNSSEC().Status.DNSSEC, zone.Status.DNSSEC, "unexpected DNSSEC status")
+			},
+		},
+		{
+			name:    "Existing zone, activate key signing key",
+			dnsZone: validDNSZoneWithDNSSEC(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithDNSSEC())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, true, awsKeySigningKeyInactive)
+				expect.ActivateKeySigningKey(gomock.Any()).Return(&route53.ActivateKeySigningKeyOutput{}, nil).Times(1)
+				mockAWSGetDNSSEC(expect, true, awsKeySigningKeyActive)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, validDNSZoneSignedWithDNSSEC().Status.DNSSEC, zone.Status.DNSSEC, "unexpected DNSSEC status")
+			},
+		},
+		{
+			name: "Existing zone, enable DNSSEC without KMS key",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneWithDNSSEC()
+				dz.Spec.AWS.DNSSECKMSKeyARN = ""
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithDNSSEC())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, false, "")
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Existing zone, disable DNSSEC",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneSignedWithDNSSEC()
+				dz.Spec.DNSSEC = false
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithDNSSEC())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, true, awsKeySigningKeyActive)
+				expect.DisableHostedZoneDNSSEC(gomock.Any()).Return(&route53.DisableHostedZoneDNSSECOutput{}, nil).Times(1)
+				expect.DeactivateKeySigningKey(gomock.Any()).Return(&route53.DeactivateKeySigningKeyOutput{}, nil).Times(1)
+				expect.DeleteKeySigningKey(gomock.Any()).Return(&route53.DeleteKeySigningKeyOutput{}, nil).Times(1)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Nil(t, zone.Status.DNSSEC, "unexpected DNSSEC status")
+			},
+		},
+		{
+			name: "Existing zone, keep DNSSEC while DS records are in parent domain",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneSignedWithDNSSEC()
+				dz.Spec.DNSSEC = false
+				dz.Status.Conditions = []hivev1.DNSZoneCondition{{
+					Type:   hivev1.DSRecordsCreatedCondition,
+					Status: corev1.ConditionTrue,
+				}}
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithDNSSEC())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, true, awsKeySigningKeyActive)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, validDNSZoneSignedWithDNSSEC().Status.DNSSEC, zone.Status.DNSSEC, "unexpected DNSSEC status")
+			},
+		},
+		{
+			name: "Delete hosted zone signed with DNSSEC",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneSignedWithDNSSEC()
+				dz.DeletionTimestamp = kubeTimeNow
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithDNSSEC())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, true, awsKeySigningKeyActive)
+				expect.DisableHostedZoneDNSSEC(gomock.Any()).Return(&route53.DisableHostedZoneDNSSECOutput{}, nil).Times(1)
+				expect.DeactivateKeySigningKey(gomock.Any()).Return(&route53.DeactivateKeySigningKeyOutput{}, nil).Times(1)
+				expect.DeleteKeySigningKey(gomock.Any()).Return(&route53.DeleteKeySigningKeyOutput{}, nil).Times(1)
+				mockDeleteAWSZone(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -304,6 +463,68 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
+		{
+			name:    "Existing zone, enable DNSSEC",
+			dnsZone: validDNSZoneWithDNSSEC(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+				expect.PatchManagedZone("hive-blah-example-com", &googledns.ManagedZone{
+					DnssecConfig: &googledns.ManagedZoneDnsSecConfig{State: "on"},
+				}).Return(nil).Times(1)
+				expect.ListDNSKeys("hive-blah-example-com").Return([]*googledns.DnsKey{
+					{
+						Id:        "1",
+						Type:      "keySigning",
+						Algorithm: "ecdsap256sha256",
+						KeyTag:    12345,
+						IsActive:  true,
+						Digests: []*googledns.DnsKeyDigest{
+							{Type: "sha1", Digest: "0123"},
+							{Type: "sha256", Digest: "abcdef"},
+						},
+					},
+					{
+						Id:        "2",
+						Type:      "zoneSigning",
+						Algorithm: "ecdsap256sha256",
+						KeyTag:    23456,
+						IsActive:  true,
+					},
+				}, nil).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, &hivev1.DNSSECStatus{
+					KeySigningKeys: []hivev1.DNSSECKeySigningKey{{
+						Name:     "1",
+						KeyTag:   12345,
+						Active:   true,
+						DSRecord: "12345 13 2 ABCDEF",
+					}},
+				}, zone.Status.DNSSEC, "unexpected DNSSEC status")
+			},
+		},
+		{
+			name: "Existing zone, disable DNSSEC",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneSignedWithDNSSEC()
+				dz.Spec.DNSSEC = false
+				return dz
+			}(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				expect.GetManagedZone(gomock.Any()).Return(&googledns.ManagedZone{
+					DnsName:      "blah.example.com",
+					Name:         "hive-blah-example-com",
+					NameServers:  []string{"ns1.example.com", "ns2.example.com"},
+					DnssecConfig: &googledns.ManagedZoneDnsSecConfig{State: "on"},
+				}, nil).Times(1)
+				expect.PatchManagedZone("hive-blah-example-com", &googledns.ManagedZone{
+					DnssecConfig: &googledns.ManagedZoneDnsSecConfig{State: "off"},
+				}).Return(nil).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Nil(t, zone.Status.DNSSEC, "unexpected DNSSEC status")
+			},
+		},
 		{
 			name:            "Existing zone, link to parent, reachable SOA",
 			dnsZone:         validDNSZoneWithLinkToParent(),
@@ -1090,3 +1311,70 @@ func testInvalidSignatureExceptionError() error {
 		fmt.Errorf("The request signature we calculated does not match the signature you provided. Check your AWS Secret Access Key and signing method. Consult the service documentation for details"))
 	return invalidSignatureErr
 }
+
+// TestReconcileDNSZoneDeletionWithDNSRecords tests that a deleted DNSZone deletes its DNSRecords, and waits for them
+// to be gone before deleting the zone.
+func TestReconcileDNSZoneDeletionWithDNSRecords(t *testing.T) {
+	server := dnsserver.Start(t, "blah.example.com")
+	server.AddRecords(t, "www.blah.example.com. 60 IN A 192.0.2.1")
+
+	dnsZone := validDNSZoneBeingDeleted()
+	dnsZone.Spec.AWS = nil
+	dnsZone.Spec.RFC2136 = &hivev1.RFC2136DNSZoneSpec{
+		Server:        server.Addr,
+		TSIGSecretRef: corev1.LocalObjectReference{Name: "tsig-secret"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: dnsZone.Namespace, Name: "tsig-secret"},
+		Data: map[string][]byte{
+			constants.TSIGKeyNameSecretKey: []byte(dnsserver.KeyName),
+			constants.TSIGSecretSecretKey:  []byte(dnsserver.Secret),
+		},
+	}
+	newDNSRecord := func(name string) *hivev1.DNSRecord {
+		return &hivev1.DNSRecord{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  dnsZone.Namespace,
+				Name:       name,
+				Finalizers: []string{hivev1.FinalizerDNSRecord},
+			},
+			Spec: hivev1.DNSRecordSpec{
+				DNSZoneRef: corev1.LocalObjectReference{Name: dnsZone.Name},
+				Name:       name + ".blah.example.com",
+				Type:       hivev1.DNSRecordTypeA,
+				Values:     []string{"192.0.2.1"},
+			},
+		}
+	}
+	dnsRecord := newDNSRecord("www")
+	deletedDNSRecord := newDNSRecord("deleted")
+	now := metav1.Now()
+	deletedDNSRecord.DeletionTimestamp = &now
+	c := fakekubeclient.NewClientBuilder().WithRuntimeObjects(dnsZone, secret, dnsRecord, deletedDNSRecord).Build()
+	r := &ReconcileDNSZone{
+		Client: c,
+		scheme: scheme.Scheme,
+		logger: log.WithField("controller", ControllerName),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dnsZone.Namespace, Name: dnsZone.Name}}
+
+	result, err := r.Reconcile(context.TODO(), request)
+	require.NoError(t, err, "unexpected error from reconcile")
+	assert.NotZero(t, result.RequeueAfter, "expected requeue while waiting for DNSRecords")
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: dnsRecord.Namespace, Name: dnsRecord.Name}, &hivev1.DNSRecord{})
+	assert.True(t, apierrors.IsNotFound(err), "expected DNSRecord to be deleted")
+	assert.Len(t, server.Records("www.blah.example.com", dns.TypeA), 1, "expected records to be kept until the DNSRecords are gone")
+	zone := &hivev1.DNSZone{}
+	require.NoError(t, c.Get(context.TODO(), request.NamespacedName, zone), "unexpected error getting DNSZone")
+	assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "expected DNSZone finalizer to be kept")
+
+	// The DNSRecord controller removes the DNSRecord once its records are deleted.
+	require.NoError(t, c.Delete(context.TODO(), deletedDNSRecord), "unexpected error deleting DNSRecord")
+
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err, "unexpected error from reconcile")
+	assert.Empty(t, server.Records("www.blah.example.com", dns.TypeA), "expected records of the zone to be deleted")
+	zone = &hivev1.DNSZone{}
+	require.NoError(t, c.Get(context.TODO(), request.NamespacedName, zone), "unexpected error getting DNSZone")
+	assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "expected DNSZone finalizer to be removed")
+}
@@ -1,9 +1,11 @@
 package dnszone
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
+	miekgdns "github.com/miekg/dns"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/pkg/errors"
@@ -12,12 +14,20 @@ import (
 
 	dns "google.golang.org/api/dns/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
 	zoneNotEmptyReason = "containerNotEmpty"
+
+	gcpDNSSECStateOn      = "on"
+	gcpDNSSECStateOff     = "off"
+	gcpKeySigningKeyType  = "keySigning"
+	gcpDSRecordDigestType = "sha256"
+
+	gcpVisibilityPrivate = "private"
 )
 
 // GCPActuator attempts to make the current state reflect the given desired state.
@@ -70,13 +80,16 @@ func (a *GCPActuator) Create() error {
 	logger.Info("Creating managed zone")
 
 	zone := a.dnsZone.Spec.Zone
-	managedZone, err := a.gcpClient.CreateManagedZone(
-		&dns.ManagedZone{
-			Name:        generateManagedZoneName(zone),
-			Description: managedByHiveDescription,
-			DnsName:     controllerutils.Dotted(zone),
-		},
-	)
+	desiredZone := &dns.ManagedZone{
+		Name:        generateManagedZoneName(zone),
+		Description: managedByHiveDescription,
+		DnsName:     controllerutils.Dotted(zone),
+	}
+	if a.dnsZone.Spec.Private {
+		desiredZone.Visibility = gcpVisibilityPrivate
+		desiredZone.PrivateVisibilityConfig = a.privateVisibilityConfig()
+	}
+	managedZone, err := a.gcpClient.CreateManagedZone(desiredZone)
 
 	if err != nil {
 		logger.WithError(err).Error("Error creating managed zone")
@@ -163,12 +176,193 @@ func (a *GCPActuator) Exists() (bool, error) {
 	return a.managedZone != nil, nil
 }
 
+// GetRecordSet implements the GetRecordSet call of the actuator interface
+func (a *GCPActuator) GetRecordSet(name string, recordType hivev1.DNSRecordType) (*RecordSet, error) {
+	recordSet, err := a.getGCPRecordSet(name, recordType)
+	if err != nil || recordSet == nil {
+		return nil, err
+	}
+	return newRecordSetFromZoneFileValues(recordSet.Name, recordSet.Type, recordSet.Ttl, recordSet.Rrdatas)
+}
+
+// UpsertRecordSet implements the UpsertRecordSet call of the actuator interface
+func (a *GCPActuator) UpsertRecordSet(recordSet *RecordSet) error {
+	currentRecordSet, err := a.getGCPRecordSet(recordSet.Name, recordSet.Type)
+	if err != nil {
+		return err
+	}
+	values, err := recordSet.zoneFileValues()
+	if err != nil {
+		return err
+	}
+
+	logger := a.logger.WithField("zoneName", a.managedZone.Name).WithField("name", recordSet.Name).WithField("type", recordSet.Type)
+	logger.Info("Upserting recordset")
+	// The current recordset, if any, is replaced in the same change.
+	err = a.gcpClient.UpdateResourceRecordSet(a.managedZone.Name, &dns.ResourceRecordSet{
+		Name:    recordSet.Name,
+		Type:    string(recordSet.Type),
+		Ttl:     recordSet.TTL,
+		Rrdatas: values,
+	}, currentRecordSet)
+	if err != nil {
+		logger.WithError(err).Error("Cannot upsert recordset")
+	}
+	return err
+}
+
+// DeleteRecordSet implements the DeleteRecordSet call of the actuator interface
+func (a *GCPActuator) DeleteRecordSet(name string, recordType hivev1.DNSRecordType) error {
+	recordSet, err := a.getGCPRecordSet(name, recordType)
+	if err != nil || recordSet == nil {
+		return err
+	}
+
+	logger := a.logger.WithField("zoneName", a.managedZone.Name).WithField("name", recordSet.Name).WithField("type", recordSet.Type)
+	logger.Info("Deleting recordset")
+	if err := a.gcpClient.DeleteResourceRecordSet(a.managedZone.Name, recordSet); err != nil {
+		logger.WithError(err).Error("Cannot delete recordset")
+		return err
+	}
+	return nil
+}
+
+func (a *GCPActuator) getGCPRecordSet(name string, recordType hivev1.DNSRecordType) (*dns.ResourceRecordSet, error) {
+	if a.managedZone == nil {
+		return nil, errors.New("managedZone is unpopulated")
+	}
+
+	name = canonicalRecordName(name)
+	logger := a.logger.WithField("zoneName", a.managedZone.Name).WithField("name", name).WithField("type", recordType)
+	logger.Debug("Listing managed zone recordsets")
+	resp, err := a.gcpClient.ListResourceRecordSets(a.managedZone.Name, gcpclient.ListResourceRecordSetsOptions{
+		Name: name,
+		Type: string(recordType),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error listing recordsets for zone")
+		return nil, err
+	}
+	for _, recordSet := range resp.Rrsets {
+		if strings.EqualFold(recordSet.Name, name) && recordSet.Type == string(recordType) {
+			return recordSet, nil
+		}
+	}
+	return nil, nil
+}
+
+// SyncDNSSEC implements the SyncDNSSEC call of the actuator interface
+func (a *GCPActuator) SyncDNSSEC(enabled bool) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zoneName", a.managedZone.Name)
+	state := gcpDNSSECStateOff
+	if a.managedZone.DnssecConfig != nil && a.managedZone.DnssecConfig.State != "" {
+		state = a.managedZone.DnssecConfig.State
+	}
+	desiredState := gcpDNSSECStateOff
+	if enabled {
+		desiredState = gcpDNSSECStateOn
+	}
+	if state != desiredState {
+		logger.WithField("state", desiredState).Info("Updating DNSSEC state of managed zone")
+		if err := a.gcpClient.PatchManagedZone(a.managedZone.Name, &dns.ManagedZone{
+			DnssecConfig: &dns.ManagedZoneDnsSecConfig{State: desiredState},
+		}); err != nil {
+			logger.WithError(err).Error("Cannot update DNSSEC state of managed zone")
+			return err
+		}
+	}
+	if !enabled {
+		a.dnsZone.Status.DNSSEC = nil
+		return nil
+	}
+
+	logger.Debug("Listing DNSSEC keys of managed zone")
+	keys, err := a.gcpClient.ListDNSKeys(a.managedZone.Name)
+	if err != nil {
+		logger.WithError(err).Error("Cannot list DNSSEC keys of managed zone")
+		return err
+	}
+	// The zone is signed once the state change has been applied by Cloud DNS.
+	status := &hivev1.DNSSECStatus{Signing: state == gcpDNSSECStateOn}
+	for _, key := range keys {
+		if key.Type != gcpKeySigningKeyType {
+			continue
+		}
+		status.KeySigningKeys = append(status.KeySigningKeys, hivev1.DNSSECKeySigningKey{
+			Name:     key.Id,
+			KeyTag:   key.KeyTag,
+			Active:   key.IsActive,
+			DSRecord: gcpDSRecord(key),
+		})
+	}
+	a.dnsZone.Status.DNSSEC = status
+	return nil
+}
+
+// gcpDSRecord returns the value of the DS record with the SHA-256 digest of the key signing key, or an empty string
+// if Cloud DNS did not compute that digest.
+func gcpDSRecord(key *dns.DnsKey) string {
+	algorithm, ok := miekgdns.StringToAlgorithm[strings.ToUpper(key.Algorithm)]
+	if !ok {
+		return ""
+	}
+	for _, digest := range key.Digests {
+		if digest.Type == gcpDSRecordDigestType {
+			return fmt.Sprintf("%d %d %d %s", key.KeyTag, algorithm, miekgdns.SHA256, strings.ToUpper(digest.Digest))
+		}
+	}
+	return ""
+}
+
 // UpdateMetadata implements the UpdateMetadata call of the actuator interface
 func (a *GCPActuator) UpdateMetadata() error {
-	// Nothing to do here since GCP CloudDNS doesn't support tags.
+	// GCP CloudDNS doesn't support tags, so the networks of private zones are the only things we can sync
+	// with existing zones.
+	if !a.dnsZone.Spec.Private {
+		return nil
+	}
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+
+	current := sets.NewString()
+	if config := a.managedZone.PrivateVisibilityConfig; config != nil {
+		for _, network := range config.Networks {
+			current.Insert(network.NetworkUrl)
+		}
+	}
+	if current.Equal(sets.NewString(a.dnsZone.Spec.GCP.Networks...)) {
+		return nil
+	}
+
+	logger := a.logger.WithField("zoneName", a.managedZone.Name)
+	logger.WithField("networks", a.dnsZone.Spec.GCP.Networks).Info("Updating networks of private managed zone")
+	if err := a.gcpClient.PatchManagedZone(a.managedZone.Name, &dns.ManagedZone{
+		PrivateVisibilityConfig: a.privateVisibilityConfig(),
+	}); err != nil {
+		logger.WithError(err).Error("Cannot update networks of private managed zone")
+		return err
+	}
 	return nil
 }
 
+// privateVisibilityConfig returns the networks that a private managed zone is visible to.
+func (a *GCPActuator) privateVisibilityConfig() *dns.ManagedZonePrivateVisibilityConfig {
+	config := &dns.ManagedZonePrivateVisibilityConfig{
+		Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{},
+		// Send the empty list of networks to remove all the networks of the zone.
+		ForceSendFields: []string{"Networks"},
+	}
+	for _, network := range a.dnsZone.Spec.GCP.Networks {
+		config.Networks = append(config.Networks, &dns.ManagedZonePrivateVisibilityConfigNetwork{NetworkUrl: network})
+	}
+	return config
+}
+
 // modifyStatus updates the DnsZone's status with GCP specific information.
 func (a *GCPActuator) modifyStatus() error {
 	if a.managedZone == nil {
@@ -79,3 +79,43 @@ func mockDeleteGCPZone(expect *mock.MockClientMockRecorder) {
 	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{}, nil)
 	expect.DeleteManagedZone(gomock.Any()).Return(nil).Times(1)
 }
+
+// TestGCPPrivateZoneNetworks tests that private managed zones are created with, and updated to, the networks of the
+// DNSZone.
+func TestGCPPrivateZoneNetworks(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	defer mocks.mockCtrl.Finish()
+	network := "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/test-network"
+	dnsZone := validDNSZone()
+	dnsZone.Spec.AWS = nil
+	dnsZone.Spec.GCP = &hivev1.GCPDNSZoneSpec{Networks: []string{network}}
+	dnsZone.Spec.Private = true
+	a := &GCPActuator{
+		logger:    log.WithField("controller", ControllerName),
+		gcpClient: mocks.mockGCPClient,
+		dnsZone:   dnsZone,
+	}
+
+	mocks.mockGCPClient.EXPECT().CreateManagedZone(gomock.Any()).
+		Do(func(zone *dns.ManagedZone) {
+			assert.Equal(t, gcpVisibilityPrivate, zone.Visibility, "unexpected visibility of created zone")
+			if assert.NotNil(t, zone.PrivateVisibilityConfig, "expected private visibility config") {
+				assert.Equal(t, network, zone.PrivateVisibilityConfig.Networks[0].NetworkUrl, "unexpected network of created zone")
+			}
+		}).
+		Return(&dns.ManagedZone{Name: "hive-blah-example-com", DnsName: "blah.example.com."}, nil).Times(1)
+	assert.NoError(t, a.Create(), "unexpected error creating private zone")
+
+	a.managedZone.PrivateVisibilityConfig = &dns.ManagedZonePrivateVisibilityConfig{
+		Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{{NetworkUrl: network}},
+	}
+	assert.NoError(t, a.UpdateMetadata(), "unexpected error syncing unchanged networks")
+
+	a.dnsZone.Spec.GCP.Networks = nil
+	mocks.mockGCPClient.EXPECT().PatchManagedZone("hive-blah-example-com", gomock.Any()).
+		Do(func(_ string, patch *dns.ManagedZone) {
+			assert.Empty(t, patch.PrivateVisibilityConfig.Networks, "expected networks to be removed")
+		}).
+		Return(nil).Times(1)
+	assert.NoError(t, a.UpdateMetadata(), "unexpected error syncing removed networks")
+}
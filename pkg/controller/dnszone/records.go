@@ -0,0 +1,190 @@
+package dnszone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// maxTXTStringLength is the maximum length of a character string of a TXT record.
+const maxTXTStringLength = 255
+
+// RecordSet is a set of records of a zone with the same name and type.
+type RecordSet struct {
+	// Name is the fully qualified domain name of the records, lower case and with a trailing dot.
+	Name string
+
+	// Type is the type of the records.
+	Type hivev1.DNSRecordType
+
+	// TTL is the time to live of the records in seconds.
+	TTL int64
+
+	// Values are the sorted values of the records, in the format of the values of DNSRecords.
+	Values []string
+}
+
+// NewRecordSet creates a record set with the given values, in the format of the values of DNSRecords. The values
+// are validated and normalized so that record sets can be compared with the record sets in the dns provider.
+func NewRecordSet(name string, recordType hivev1.DNSRecordType, ttl int64, values []string) (*RecordSet, error) {
+	if _, ok := dns.IsDomainName(name); !ok {
+		return nil, fmt.Errorf("invalid record name %q", name)
+	}
+	if _, ok := dns.StringToType[string(recordType)]; !ok {
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+	if recordType == hivev1.DNSRecordTypeCNAME && len(values) != 1 {
+		return nil, errors.New("CNAME records must have a single value")
+	}
+	rs := &RecordSet{
+		Name: canonicalRecordName(name),
+		Type: recordType,
+		TTL:  ttl,
+	}
+	var rrs []dns.RR
+	for _, value := range values {
+		rr, err := rs.newRR(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s value %q", recordType, value)
+		}
+		rrs = append(rrs, rr)
+	}
+	rs.setValues(rrs)
+	return rs, nil
+}
+
+// newRecordSetFromRRs creates a record set from records of the same name and type.
+func newRecordSetFromRRs(rrs []dns.RR) *RecordSet {
+	if len(rrs) == 0 {
+		return nil
+	}
+	hdr := rrs[0].Header()
+	rs := &RecordSet{
+		Name: canonicalRecordName(hdr.Name),
+		Type: hivev1.DNSRecordType(dns.TypeToString[hdr.Rrtype]),
+		TTL:  int64(hdr.Ttl),
+	}
+	rs.setValues(rrs)
+	return rs
+}
+
+// newRecordSetFromZoneFileValues creates a record set from values in the zone file format, e.g. with quoted TXT
+// values, as used by the Route53 and Cloud DNS APIs.
+func newRecordSetFromZoneFileValues(name string, recordType string, ttl int64, values []string) (*RecordSet, error) {
+	var rrs []dns.RR
+	for _, value := range values {
+		rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", canonicalRecordName(name), ttl, recordType, value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s value %q", recordType, value)
+		}
+		if rr == nil {
+			return nil, fmt.Errorf("empty %s value", recordType)
+		}
+		rrs = append(rrs, rr)
+	}
+	return newRecordSetFromRRs(rrs), nil
+}
+
+// Equal returns true if the record sets have the same name, type, TTL and values.
+func (rs *RecordSet) Equal(other *RecordSet) bool {
+	if rs == nil || other == nil {
+		return rs == other
+	}
+	if rs.Name != other.Name || rs.Type != other.Type || rs.TTL != other.TTL || len(rs.Values) != len(other.Values) {
+		return false
+	}
+	for i := range rs.Values {
+		if rs.Values[i] != other.Values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RRs returns the records of the record set.
+func (rs *RecordSet) RRs() ([]dns.RR, error) {
+	var rrs []dns.RR
+	for _, value := range rs.Values {
+		rr, err := rs.newRR(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s value %q", rs.Type, value)
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, nil
+}
+
+// zoneFileValues returns the values of the records of the record set in the zone file format.
+func (rs *RecordSet) zoneFileValues() ([]string, error) {
+	rrs, err := rs.RRs()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(rrs))
+	for i, rr := range rrs {
+		values[i] = rdata(rr)
+	}
+	return values, nil
+}
+
+func (rs *RecordSet) newRR(value string) (dns.RR, error) {
+	if rs.Type == hivev1.DNSRecordTypeTXT {
+		return &dns.TXT{
+			Hdr: dns.RR_Header{Name: rs.Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: uint32(rs.TTL)},
+			Txt: splitTXT(value),
+		}, nil
+	}
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", rs.Name, rs.TTL, rs.Type, value))
+	if err != nil {
+		return nil, err
+	}
+	if rr == nil {
+		return nil, errors.New("empty value")
+	}
+	return rr, nil
+}
+
+func (rs *RecordSet) setValues(rrs []dns.RR) {
+	values := map[string]bool{}
+	rs.Values = nil
+	for _, rr := range rrs {
+		value := recordValue(rr)
+		if !values[value] {
+			values[value] = true
+			rs.Values = append(rs.Values, value)
+		}
+	}
+	sort.Strings(rs.Values)
+}
+
+// recordValue returns the value of the record in the format of the values of DNSRecords.
+func recordValue(rr dns.RR) string {
+	if txt, ok := rr.(*dns.TXT); ok {
+		return strings.Join(txt.Txt, "")
+	}
+	return rdata(rr)
+}
+
+// rdata returns the data of the record in the zone file format.
+func rdata(rr dns.RR) string {
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// splitTXT splits the text of a TXT record into character strings of the maximum length.
+func splitTXT(text string) []string {
+	var txt []string
+	for len(text) > maxTXTStringLength {
+		txt = append(txt, text[:maxTXTStringLength])
+		text = text[maxTXTStringLength:]
+	}
+	return append(txt, text)
+}
+
+func canonicalRecordName(name string) string {
+	return strings.ToLower(dns.Fqdn(name))
+}
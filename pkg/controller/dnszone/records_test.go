@@ -0,0 +1,121 @@
+package dnszone
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestNewRecordSet(t *testing.T) {
+	longText := strings.Repeat("a", 300)
+	cases := []struct {
+		name           string
+		recordName     string
+		recordType     hivev1.DNSRecordType
+		values         []string
+		expectErr      bool
+		expectedName   string
+		expectedValues []string
+		expectedZone   []string
+	}{
+		{
+			name:           "A records",
+			recordName:     "WWW.Example.com",
+			recordType:     hivev1.DNSRecordTypeA,
+			values:         []string{"192.0.2.2", "192.0.2.1", "192.0.2.2"},
+			expectedName:   "www.example.com.",
+			expectedValues: []string{"192.0.2.1", "192.0.2.2"},
+			expectedZone:   []string{"192.0.2.1", "192.0.2.2"},
+		},
+		{
+			name:           "AAAA records",
+			recordName:     "www.example.com",
+			recordType:     hivev1.DNSRecordTypeAAAA,
+			values:         []string{"2001:DB8:0:0::1"},
+			expectedName:   "www.example.com.",
+			expectedValues: []string{"2001:db8::1"},
+			expectedZone:   []string{"2001:db8::1"},
+		},
+		{
+			name:           "MX records",
+			recordName:     "example.com.",
+			recordType:     hivev1.DNSRecordTypeMX,
+			values:         []string{"10 mail.example.com"},
+			expectedName:   "example.com.",
+			expectedValues: []string{"10 mail.example.com."},
+			expectedZone:   []string{"10 mail.example.com."},
+		},
+		{
+			name:           "CAA records",
+			recordName:     "example.com",
+			recordType:     hivev1.DNSRecordTypeCAA,
+			values:         []string{`0 issue "letsencrypt.org"`},
+			expectedName:   "example.com.",
+			expectedValues: []string{`0 issue "letsencrypt.org"`},
+			expectedZone:   []string{`0 issue "letsencrypt.org"`},
+		},
+		{
+			name:           "TXT records",
+			recordName:     "_acme-challenge.example.com",
+			recordType:     hivev1.DNSRecordTypeTXT,
+			values:         []string{"with spaces", longText},
+			expectedName:   "_acme-challenge.example.com.",
+			expectedValues: []string{longText, "with spaces"},
+			expectedZone:   []string{`"` + longText[:255] + `" "` + longText[255:] + `"`, `"with spaces"`},
+		},
+		{
+			name:           "wildcard name",
+			recordName:     "*.apps.example.com",
+			recordType:     hivev1.DNSRecordTypeCNAME,
+			values:         []string{"router.example.com"},
+			expectedName:   "*.apps.example.com.",
+			expectedValues: []string{"router.example.com."},
+			expectedZone:   []string{"router.example.com."},
+		},
+		{
+			name:       "multiple CNAME values",
+			recordName: "www.example.com",
+			recordType: hivev1.DNSRecordTypeCNAME,
+			values:     []string{"a.example.com", "b.example.com"},
+			expectErr:  true,
+		},
+		{
+			name:       "invalid value",
+			recordName: "www.example.com",
+			recordType: hivev1.DNSRecordTypeA,
+			values:     []string{"not-an-address"},
+			expectErr:  true,
+		},
+		{
+			name:       "unsupported type",
+			recordName: "www.example.com",
+			recordType: hivev1.DNSRecordType("BOGUS"),
+			values:     []string{"192.0.2.1"},
+			expectErr:  true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := NewRecordSet(tc.recordName, tc.recordType, 60, tc.values)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error creating record set")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating record set")
+			assert.Equal(t, tc.expectedName, rs.Name, "unexpected name")
+			assert.Equal(t, tc.expectedValues, rs.Values, "unexpected values")
+			zoneFileValues, err := rs.zoneFileValues()
+			require.NoError(t, err, "unexpected error getting zone file values")
+			assert.Equal(t, tc.expectedZone, zoneFileValues, "unexpected zone file values")
+
+			// The record set read back from the zone file values of a dns provider must be equal.
+			fromZone, err := newRecordSetFromZoneFileValues(rs.Name, string(rs.Type), rs.TTL, zoneFileValues)
+			require.NoError(t, err, "unexpected error reading zone file values")
+			assert.True(t, rs.Equal(fromZone), "expected record sets to be equal")
+		})
+	}
+}
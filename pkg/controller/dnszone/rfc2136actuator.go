@@ -0,0 +1,286 @@
+package dnszone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/rfc2136client"
+)
+
+const (
+	zoneNotConfiguredReason = "ZoneNotConfigured"
+	nameServerErrorReason   = "NameServerError"
+)
+
+// errZoneNotConfigured is returned when creating a zone that is not configured on the name server, as RFC 2136
+// dynamic updates cannot create zones.
+var errZoneNotConfigured = errors.New("zone is not configured on the name server, RFC 2136 dynamic updates cannot create zones")
+
+// RFC2136Actuator attempts to make the current state reflect the given desired state with RFC 2136 dynamic
+// updates. The zone must be configured on the name server, the actuator manages its records.
+type RFC2136Actuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// client sends queries, zone transfers and dynamic updates to the name server of the zone
+	client rfc2136client.Client
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// soa is the SOA record of the zone on the name server.
+	soa *dns.SOA
+}
+
+type rfc2136ClientBuilderType func(server string, secret *corev1.Secret) (rfc2136client.Client, error)
+
+// NewRFC2136Actuator creates a new RFC2136Actuator object. A new RFC2136Actuator is expected to be created for each controller sync.
+func NewRFC2136Actuator(
+	logger log.FieldLogger,
+	secret *corev1.Secret,
+	dnsZone *hivev1.DNSZone,
+	rfc2136ClientBuilder rfc2136ClientBuilderType,
+) (*RFC2136Actuator, error) {
+	client, err := rfc2136ClientBuilder(dnsZone.Spec.RFC2136.Server, secret)
+	if err != nil {
+		logger.WithError(err).Error("Error creating RFC 2136 client")
+		return nil, err
+	}
+
+	return &RFC2136Actuator{
+		logger:  logger.WithField("server", dnsZone.Spec.RFC2136.Server),
+		client:  client,
+		dnsZone: dnsZone,
+	}, nil
+}
+
+// Ensure RFC2136Actuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &RFC2136Actuator{}
+
+// Create implements the Create call of the actuator interface
+func (a *RFC2136Actuator) Create() error {
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).Error("zone is not configured on the name server")
+	return errZoneNotConfigured
+}
+
+// Delete implements the Delete call of the actuator interface. The records of the zone are deleted, except for
+// the SOA and NS records of the apex of the zone, but the zone itself stays configured on the name server.
+func (a *RFC2136Actuator) Delete() error {
+	zone := controllerutils.Dotted(a.dnsZone.Spec.Zone)
+	logger := a.logger.WithField("zone", zone)
+
+	records, err := a.client.TransferZone(zone)
+	if err != nil {
+		logger.WithError(err).Error("Cannot list the records of the zone")
+		return err
+	}
+	var recordsToDelete []dns.RR
+	for _, rr := range records {
+		// Ignore the records of the apex of the zone that cannot be deleted
+		if n, t := rr.Header().Name, rr.Header().Rrtype; strings.EqualFold(n, zone) && (t == dns.TypeNS || t == dns.TypeSOA) {
+			continue
+		}
+		logger.WithField("name", rr.Header().Name).WithField("type", dns.TypeToString[rr.Header().Rrtype]).Info("record set for deletion")
+		recordsToDelete = append(recordsToDelete, rr)
+	}
+	if len(recordsToDelete) == 0 {
+		return nil
+	}
+	logger.WithField("count", len(recordsToDelete)).Info("deleting records")
+	if err := a.client.UpdateZone(zone, recordsToDelete, nil); err != nil {
+		logger.WithError(err).Error("Cannot delete the records of the zone")
+		return err
+	}
+	return nil
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *RFC2136Actuator) Exists() (bool, error) {
+	return a.soa != nil, nil
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *RFC2136Actuator) UpdateMetadata() error {
+	// Nothing to do here since DNS zones have no metadata.
+	return nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *RFC2136Actuator) GetNameServers() ([]string, error) {
+	if a.soa == nil {
+		return nil, errors.New("zone SOA record is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	records, err := a.client.GetRecords(a.soa.Hdr.Name, dns.TypeNS)
+	if err != nil {
+		logger.WithError(err).Error("Cannot get the name servers of the zone")
+		return nil, err
+	}
+	var result []string
+	for _, rr := range records {
+		if ns, ok := rr.(*dns.NS); ok {
+			result = append(result, controllerutils.Undotted(ns.Ns))
+		}
+	}
+	sort.Strings(result)
+	logger.WithField("nameservers", result).Debug("found zone name servers")
+	return result, nil
+}
+
+// SyncDNSSEC implements the SyncDNSSEC call of the actuator interface
+func (a *RFC2136Actuator) SyncDNSSEC(enabled bool) error {
+	if enabled {
+		return errors.New("DNSSEC is not supported for RFC 2136 zones")
+	}
+	return nil
+}
+
+// GetRecordSet implements the GetRecordSet call of the actuator interface
+func (a *RFC2136Actuator) GetRecordSet(name string, recordType hivev1.DNSRecordType) (*RecordSet, error) {
+	if a.soa == nil {
+		return nil, errors.New("zone SOA record is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.soa.Hdr.Name).WithField("name", name).WithField("type", recordType)
+	records, err := a.client.GetRecords(canonicalRecordName(name), dns.StringToType[string(recordType)])
+	if err != nil {
+		logger.WithError(err).Error("Cannot get the records")
+		return nil, err
+	}
+	return newRecordSetFromRRs(records), nil
+}
+
+// UpsertRecordSet implements the UpsertRecordSet call of the actuator interface. The current records are replaced
+// in a single dynamic update.
+func (a *RFC2136Actuator) UpsertRecordSet(recordSet *RecordSet) error {
+	if a.soa == nil {
+		return errors.New("zone SOA record is unpopulated")
+	}
+	records, err := recordSet.RRs()
+	if err != nil {
+		return err
+	}
+
+	logger := a.logger.WithField("zone", a.soa.Hdr.Name).WithField("name", recordSet.Name).WithField("type", recordSet.Type)
+	logger.Info("Upserting records")
+	if err := a.client.UpdateZone(a.soa.Hdr.Name, []dns.RR{rrsetHeader(recordSet.Name, recordSet.Type)}, records); err != nil {
+		logger.WithError(err).Error("Cannot upsert the records")
+		return err
+	}
+	return nil
+}
+
+// DeleteRecordSet implements the DeleteRecordSet call of the actuator interface
+func (a *RFC2136Actuator) DeleteRecordSet(name string, recordType hivev1.DNSRecordType) error {
+	if a.soa == nil {
+		return errors.New("zone SOA record is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.soa.Hdr.Name).WithField("name", name).WithField("type", recordType)
+	logger.Info("Deleting records")
+	// Deleting a record set that does not exist is a no-op.
+	if err := a.client.UpdateZone(a.soa.Hdr.Name, []dns.RR{rrsetHeader(name, recordType)}, nil); err != nil {
+		logger.WithError(err).Error("Cannot delete the records")
+		return err
+	}
+	return nil
+}
+
+// rrsetHeader returns a record without data that identifies the record set with the given name and type.
+func rrsetHeader(name string, recordType hivev1.DNSRecordType) dns.RR {
+	return &dns.ANY{Hdr: dns.RR_Header{Name: canonicalRecordName(name), Rrtype: dns.StringToType[string(recordType)], Class: dns.ClassINET}}
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *RFC2136Actuator) Refresh() error {
+	zone := controllerutils.Dotted(a.dnsZone.Spec.Zone)
+	logger := a.logger.WithField("zone", zone)
+	logger.Debug("Fetching SOA record of the zone")
+	soa, err := a.client.GetZone(zone)
+	if err != nil {
+		logger.WithError(err).Error("Cannot get the SOA record of the zone")
+		return err
+	}
+	// The name server may serve the name from an enclosing zone rather than from a zone of its own.
+	if soa == nil || !strings.EqualFold(soa.Hdr.Name, zone) {
+		logger.Debug("Zone not found on the name server, clearing out the cached SOA record")
+		a.soa = nil
+		return nil
+	}
+
+	logger.Debug("Found zone")
+	a.soa = soa
+	return nil
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *RFC2136Actuator) SetConditionsForError(err error) bool {
+	conds := a.dnsZone.Status.Conditions
+	var authenticationFailureCondsChanged, dnsErrorsCondsChanged bool
+	switch {
+	case err == nil:
+		conds, authenticationFailureCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			conds,
+			hivev1.AuthenticationFailureCondition,
+			corev1.ConditionFalse,
+			authenticationSucceededReason,
+			"credentials authenticated",
+			controllerutils.UpdateConditionNever,
+		)
+		conds, dnsErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			conds,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionFalse,
+			dnsNoErrorReason,
+			"No errors occurred",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	case rfc2136client.IsAuthenticationError(err):
+		conds, authenticationFailureCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			conds,
+			hivev1.AuthenticationFailureCondition,
+			corev1.ConditionTrue,
+			authenticationFailedReason,
+			controllerutils.ErrorScrub(err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	case errors.Cause(err) == errZoneNotConfigured:
+		// Hive cannot configure the zone on the name server, so make it clear what needs to be done.
+		message := fmt.Sprintf(
+			"Zone %s is not configured on the name server %s. RFC 2136 dynamic updates cannot create zones, the zone must be configured on the name server.",
+			a.dnsZone.Spec.Zone,
+			a.dnsZone.Spec.RFC2136.Server,
+		)
+		conds, dnsErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			conds,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionTrue,
+			zoneNotConfiguredReason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	default:
+		conds, dnsErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			conds,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionTrue,
+			nameServerErrorReason,
+			controllerutils.ErrorScrub(err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if authenticationFailureCondsChanged || dnsErrorsCondsChanged {
+		a.dnsZone.Status.Conditions = conds
+	}
+	return authenticationFailureCondsChanged || dnsErrorsCondsChanged
+}
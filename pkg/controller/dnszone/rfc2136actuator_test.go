@@ -0,0 +1,228 @@
+package dnszone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakekubeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/rfc2136client"
+	"github.com/openshift/hive/pkg/test/dnsserver"
+)
+
+// TestReconcileDNSZoneForRFC2136 tests that the DNSZone controller manages zones with RFC 2136 dynamic updates
+// against an in-process name server.
+func TestReconcileDNSZoneForRFC2136(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name                    string
+		serverZones             []string
+		serverRecords           []string
+		deleted                 bool
+		tsigSecret              string
+		managedDomainKey        bool
+		expectErr               bool
+		expectedNameServers     []string
+		expectedConditionType   hivev1.DNSZoneConditionType
+		expectedConditionReason string
+		expectFinalizer         bool
+	}{
+		{
+			name:        "zone configured on the name server",
+			serverZones: []string{"example.com", "blah.example.com"},
+			serverRecords: []string{
+				"blah.example.com. 60 IN NS ns2.example.com.",
+			},
+			expectedNameServers: []string{"ns1.blah.example.com", "ns2.example.com"},
+			expectFinalizer:     true,
+		},
+		{
+			name:        "TSIG key of the managed domain",
+			serverZones: []string{"example.com", "blah.example.com"},
+			serverRecords: []string{
+				"blah.example.com. 60 IN NS ns2.example.com.",
+			},
+			managedDomainKey:    true,
+			expectedNameServers: []string{"ns1.blah.example.com", "ns2.example.com"},
+			expectFinalizer:     true,
+		},
+		{
+			name:                    "zone not configured on the name server",
+			serverZones:             []string{"example.com"},
+			expectErr:               true,
+			expectedConditionType:   hivev1.GenericDNSErrorsCondition,
+			expectedConditionReason: zoneNotConfiguredReason,
+			expectFinalizer:         true,
+		},
+		{
+			name:        "zone delegated to another name server",
+			serverZones: []string{"example.com"},
+			serverRecords: []string{
+				"blah.example.com. 60 IN NS ns.elsewhere.com.",
+			},
+			expectErr:               true,
+			expectedConditionType:   hivev1.GenericDNSErrorsCondition,
+			expectedConditionReason: zoneNotConfiguredReason,
+			expectFinalizer:         true,
+		},
+		{
+			name:                    "invalid TSIG key",
+			serverZones:             []string{"blah.example.com"},
+			tsigSecret:              "d3Jvbmctc2VjcmV0",
+			expectErr:               true,
+			expectedConditionType:   hivev1.AuthenticationFailureCondition,
+			expectedConditionReason: authenticationFailedReason,
+			expectFinalizer:         true,
+		},
+		{
+			name:        "delete zone",
+			serverZones: []string{"example.com", "blah.example.com"},
+			serverRecords: []string{
+				"api.blah.example.com. 60 IN A 192.0.2.10",
+				"*.apps.blah.example.com. 60 IN A 192.0.2.11",
+				"blah.example.com. 60 IN TXT \"owner=hive\"",
+			},
+			deleted: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := dnsserver.Start(t, tc.serverZones...)
+			server.AddRecords(t, tc.serverRecords...)
+
+			dnsZone := validDNSZone()
+			dnsZone.Spec.AWS = nil
+			dnsZone.Spec.RFC2136 = &hivev1.RFC2136DNSZoneSpec{
+				Server:        server.Addr,
+				TSIGSecretRef: corev1.LocalObjectReference{Name: "tsig-secret"},
+			}
+			var managedDomains []hivev1.ManageDNSConfig
+			secretNamespace := dnsZone.Namespace
+			if tc.managedDomainKey {
+				// The zone uses the TSIG key of the managed domain, which is only in the namespace of Hive.
+				dnsZone.Spec.RFC2136.TSIGSecretRef = corev1.LocalObjectReference{}
+				managedDomains = []hivev1.ManageDNSConfig{{
+					Domains: []string{"example.com"},
+					RFC2136: &hivev1.ManageDNSRFC2136Config{
+						Server:        server.Addr,
+						TSIGSecretRef: corev1.LocalObjectReference{Name: "tsig-secret"},
+					},
+				}}
+				secretNamespace = constants.DefaultHiveNamespace
+			}
+			dnsZone.Status = hivev1.DNSZoneStatus{}
+			if tc.deleted {
+				now := metav1.Now()
+				dnsZone.DeletionTimestamp = &now
+			}
+			tsigSecret := tc.tsigSecret
+			if tsigSecret == "" {
+				tsigSecret = dnsserver.Secret
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: secretNamespace, Name: "tsig-secret"},
+				Data: map[string][]byte{
+					constants.TSIGKeyNameSecretKey: []byte(dnsserver.KeyName),
+					constants.TSIGSecretSecretKey:  []byte(tsigSecret),
+				},
+			}
+			c := fakekubeclient.NewClientBuilder().WithRuntimeObjects(dnsZone, secret).Build()
+			r := &ReconcileDNSZone{
+				Client: c,
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", ControllerName),
+				soaLookup: func(string, log.FieldLogger) (bool, error) {
+					return true, nil
+				},
+				managedDomains: managedDomains,
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: dnsZone.Namespace, Name: dnsZone.Name},
+			})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			if tc.deleted {
+				assert.Equal(t, []string{"blah.example.com.\t60\tIN\tNS\tns1.blah.example.com."}, server.Records("blah.example.com", dns.TypeNS), "expected name servers of the zone to be kept")
+				assert.Empty(t, server.Records("blah.example.com", dns.TypeTXT), "expected TXT record to be deleted")
+				assert.Empty(t, server.Records("api.blah.example.com", dns.TypeANY), "expected api record to be deleted")
+				assert.Empty(t, server.Records("*.apps.blah.example.com", dns.TypeANY), "expected apps record to be deleted")
+			}
+
+			zone := &hivev1.DNSZone{}
+			err = c.Get(context.TODO(), types.NamespacedName{Namespace: dnsZone.Namespace, Name: dnsZone.Name}, zone)
+			require.NoError(t, err, "unexpected error getting DNSZone")
+			assert.Equal(t, tc.expectFinalizer, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "unexpected finalizer")
+			assert.Equal(t, tc.expectedNameServers, zone.Status.NameServers, "unexpected name servers")
+			if tc.expectedConditionType != "" {
+				cond := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, tc.expectedConditionType)
+				if assert.NotNil(t, cond, "missing condition") {
+					assert.Equal(t, tc.expectedConditionReason, cond.Reason, "unexpected condition reason")
+					if tc.expectedConditionReason == zoneNotConfiguredReason {
+						assert.Contains(t, cond.Message, server.Addr, "expected the name server in the condition message")
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestRFC2136RecordSets tests that the RFC 2136 actuator gets, upserts and deletes record sets on the name server.
+func TestRFC2136RecordSets(t *testing.T) {
+	server := dnsserver.Start(t, "blah.example.com")
+	server.AddRecords(t,
+		"www.blah.example.com. 60 IN A 192.0.2.1",
+		"www.blah.example.com. 60 IN TXT \"other\"",
+	)
+	client, err := rfc2136client.NewClient(server.Addr, dnsserver.KeyName, dnsserver.Secret, "")
+	require.NoError(t, err, "unexpected error creating client")
+	dnsZone := validDNSZone()
+	a := &RFC2136Actuator{
+		logger:  log.WithField("controller", ControllerName),
+		client:  client,
+		dnsZone: dnsZone,
+	}
+	require.NoError(t, a.Refresh(), "unexpected error refreshing zone")
+
+	rs, err := a.GetRecordSet("www.blah.example.com", hivev1.DNSRecordTypeA)
+	require.NoError(t, err, "unexpected error getting record set")
+	expected, err := NewRecordSet("www.blah.example.com", hivev1.DNSRecordTypeA, 60, []string{"192.0.2.1"})
+	require.NoError(t, err, "unexpected error creating record set")
+	assert.True(t, expected.Equal(rs), "unexpected record set %v", rs)
+
+	rs, err = a.GetRecordSet("other.blah.example.com", hivev1.DNSRecordTypeA)
+	require.NoError(t, err, "unexpected error getting missing record set")
+	assert.Nil(t, rs, "expected no record set")
+
+	desired, err := NewRecordSet("www.blah.example.com", hivev1.DNSRecordTypeA, 300, []string{"192.0.2.2", "192.0.2.3"})
+	require.NoError(t, err, "unexpected error creating record set")
+	require.NoError(t, a.UpsertRecordSet(desired), "unexpected error upserting record set")
+	assert.Equal(t, []string{
+		"www.blah.example.com.\t300\tIN\tA\t192.0.2.2",
+		"www.blah.example.com.\t300\tIN\tA\t192.0.2.3",
+	}, server.Records("www.blah.example.com", dns.TypeA), "unexpected records after upsert")
+
+	require.NoError(t, a.DeleteRecordSet("www.blah.example.com", hivev1.DNSRecordTypeA), "unexpected error deleting record set")
+	assert.Empty(t, server.Records("www.blah.example.com", dns.TypeA), "expected records to be deleted")
+	assert.Len(t, server.Records("www.blah.example.com", dns.TypeTXT), 1, "expected records of other types to be kept")
+	assert.NoError(t, a.DeleteRecordSet("www.blah.example.com", hivev1.DNSRecordTypeA), "expected deleting a missing record set to succeed")
+}
@@ -159,6 +159,43 @@ var (
 		return zone
 	}
 
+	validDNSZoneWithDNSSEC = func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Spec.DNSSEC = true
+		zone.Spec.AWS.DNSSECKMSKeyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd"
+		return zone
+	}
+
+	validDNSZoneSignedWithDNSSEC = func() *hivev1.DNSZone {
+		zone := validDNSZoneWithDNSSEC()
+		zone.Status.DNSSEC = &hivev1.DNSSECStatus{
+			Signing: true,
+			KeySigningKeys: []hivev1.DNSSECKeySigningKey{{
+				Name:     "hive",
+				KeyTag:   12345,
+				Active:   true,
+				DSRecord: "12345 13 2 ABCDEF",
+			}},
+		}
+		return zone
+	}
+
+	validPrivateDNSZone = func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Spec.Private = true
+		zone.Spec.AWS.VPCs = []hivev1.AWSDNSZoneVPC{
+			{VPCID: "vpc-1", Region: "us-east-1"},
+			{VPCID: "vpc-2", Region: "us-east-1"},
+		}
+		return zone
+	}
+
+	validPrivateDNSZoneWithoutID = func() *hivev1.DNSZone {
+		zone := validPrivateDNSZone()
+		zone.Status.AWS = nil
+		return zone
+	}
+
 	validAzureDNSZoneBeingDeleted = func() *hivev1.DNSZone {
 		// Take a copy of the default validAzureDNSZone object
 		zone := validAzureDNSZone()
@@ -166,11 +166,24 @@ func getClusterInstanceIDs(cd *hivev1.ClusterDeployment, c awsclient.Client, sta
 	result := []*string{}
 	for _, r := range out.Reservations {
 		for _, i := range r.Instances {
-			if states.Has(aws.StringValue(i.State.Name)) {
-				result = append(result, i.InstanceId)
+			if !states.Has(aws.StringValue(i.State.Name)) {
+				continue
 			}
+			if workersOnly(cd) && isControlPlaneMachine(cd, awsInstanceName(i)) {
+				continue
+			}
+			result = append(result, i.InstanceId)
 		}
 	}
 	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing instances")
 	return result, nil
 }
+
+func awsInstanceName(instance *ec2.Instance) string {
+	for _, tag := range instance.Tags {
+		if aws.StringValue(tag.Key) == "Name" {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
@@ -118,6 +118,31 @@ func TestStopAndStartMachines(t *testing.T) {
 	}
 }
 
+func TestStopWorkerMachines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	awsClient := mockawsclient.NewMockClient(ctrl)
+	instances := []*ec2.Instance{}
+	for _, name := range []string{"abcd1234-master-0", "abcd1234-master-1", "abcd1234-worker-us-east-1a-x7k2p", "abcd1234-worker-us-east-1b-m2v8q"} {
+		instances = append(instances, &ec2.Instance{
+			InstanceId: aws.String(name),
+			State:      &ec2.InstanceState{Name: aws.String("running")},
+			Tags:       []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+		})
+	}
+	awsClient.EXPECT().DescribeInstances(gomock.Any()).Times(1).Return(
+		&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: instances}}}, nil)
+	awsClient.EXPECT().StopInstances(gomock.Any()).Do(
+		func(input *ec2.StopInstancesInput) {
+			actual := sets.NewString(aws.StringValueSlice(input.InstanceIds)...)
+			assert.Equal(t, []string{"abcd1234-worker-us-east-1a-x7k2p", "abcd1234-worker-us-east-1b-m2v8q"}, actual.List())
+		}).Return(nil, nil)
+
+	cd := testClusterDeployment()
+	cd.Status.HibernationMode = hivev1.WorkersHibernatingClusterPowerState
+	err := testAWSActuator(awsClient).StopMachines(cd, nil, log.New())
+	assert.Nil(t, err)
+}
+
 func TestMachinesStoppedAndRunning(t *testing.T) {
 	tests := []struct {
 		name        string
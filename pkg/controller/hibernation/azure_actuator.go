@@ -142,7 +142,12 @@ func listAzureMachines(cd *hivev1.ClusterDeployment, azureClient azureclient.Cli
 	}
 	var result []compute.VirtualMachine
 	for page.NotDone() {
-		result = append(result, filterByResourceGroupAndState(page.Values(), clusterDeploymentResourceGroup(cd), states, logger)...)
+		for _, vm := range filterByResourceGroupAndState(page.Values(), clusterDeploymentResourceGroup(cd), states, logger) {
+			if workersOnly(cd) && isControlPlaneMachine(cd, to.String(vm.Name)) {
+				continue
+			}
+			result = append(result, vm)
+		}
 		if err = page.Next(); err != nil {
 			return nil, err
 		}
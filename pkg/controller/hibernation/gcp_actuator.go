@@ -150,9 +150,13 @@ func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeplo
 	}, func(list *compute.InstanceAggregatedList) error {
 		for _, scopedList := range list.Items {
 			for _, instance := range scopedList.Instances {
-				if statuses.Has(instance.Status) {
-					instances = append(instances, instance)
+				if !statuses.Has(instance.Status) {
+					continue
 				}
+				if workersOnly(cd) && isControlPlaneMachine(cd, instance.Name) {
+					continue
+				}
+				instances = append(instances, instance)
 			}
 		}
 		return nil
@@ -3,6 +3,8 @@ package hibernation
 //go:generate mockgen -source=./hibernation_actuator.go -destination=./mock/hibernation_actuator_generated.go -package=mock
 
 import (
+	"strings"
+
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,6 +18,7 @@ type HibernationActuator interface {
 	// CanHandle returns true if the actuator can handle a particular ClusterDeployment
 	CanHandle(cd *hivev1.ClusterDeployment) bool
 	// StopMachines will start machines belonging to the given ClusterDeployment
+	// Actuators must limit themselves to worker machines when workersOnly returns true.
 	StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
 	// StartMachines will select machines belonging to the given ClusterDeployment
 	StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
@@ -26,3 +29,15 @@ type HibernationActuator interface {
 	// ClusterDeployment are in a stopped state.
 	MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error)
 }
+
+// workersOnly returns true if hibernation of the given ClusterDeployment is limited to its worker
+// machines. Actuators must then leave the control plane machines untouched.
+func workersOnly(cd *hivev1.ClusterDeployment) bool {
+	return cd.Status.HibernationMode == hivev1.WorkersHibernatingClusterPowerState
+}
+
+// isControlPlaneMachine returns true if the cloud instance with the given name is one of the
+// control plane machines of the given ClusterDeployment.
+func isControlPlaneMachine(cd *hivev1.ClusterDeployment, name string) bool {
+	return strings.HasPrefix(name, cd.Spec.ClusterMetadata.InfraID+"-master-")
+}
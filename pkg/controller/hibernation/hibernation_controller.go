@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -97,6 +98,10 @@ type hibernationReconciler struct {
 	csrUtil csrHelper
 
 	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// powerStateRequeueInterval is the longest time after which a cluster is reconciled again, so that the time
+	// spent in its current power state keeps being accumulated. Clusters are not requeued for it when zero.
+	powerStateRequeueInterval time.Duration
 }
 
 // NewReconciler returns a new Reconciler
@@ -106,6 +111,8 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hi
 		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger:  logger,
 		csrUtil: &csrUtility{},
+
+		powerStateRequeueInterval: powerStateDurationsUpdateInterval,
 	}
 	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
@@ -131,6 +138,16 @@ func AddToManager(mgr manager.Manager, r *hibernationReconciler, concurrentRecon
 		log.WithField("controller", ControllerName).WithError(err).Log(controllerutils.LogLevel(err), "Error setting up a watch on ClusterDeployment")
 		return err
 	}
+
+	// Watch for changes to the hibernation hook Jobs owned by ClusterDeployments
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterDeployment{},
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Log(controllerutils.LogLevel(err), "Error setting up a watch on Jobs")
+		return err
+	}
 	return nil
 }
 
@@ -181,12 +198,27 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return r.setHibernatingCondition(cd, hivev1.HibernatingHibernationReason, "Skipping hibernation for fake cluster", corev1.ConditionFalse, cdLog)
 	}
 
+	if err := r.updatePowerStateDurations(cd, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+	defer func() {
+		// Reconcile at least every powerStateRequeueInterval so that the time spent in the current
+		// power state keeps being accumulated.
+		interval := r.powerStateRequeueInterval
+		requeueNow := result.Requeue && result.RequeueAfter <= 0
+		if interval > 0 && returnErr == nil && !requeueNow && (result.RequeueAfter <= 0 || result.RequeueAfter > interval) {
+			result.RequeueAfter = interval
+			result.Requeue = true
+		}
+	}()
+
 	// set hibernating condition to false for unsupported clouds
 	if supported, msg := r.hibernationSupported(cd); !supported {
 		return r.setHibernatingCondition(cd, hivev1.UnsupportedHibernationReason, msg, corev1.ConditionFalse, cdLog)
 	}
 
-	shouldHibernate := cd.Spec.PowerState == hivev1.HibernatingClusterPowerState
+	shouldHibernate := cd.Spec.PowerState == hivev1.HibernatingClusterPowerState ||
+		cd.Spec.PowerState == hivev1.WorkersHibernatingClusterPowerState
 	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
 
 	// Signal a problem if we should be hibernating or have requested hibernate after and the
@@ -216,7 +248,7 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 	}
 
 	// Check if HibernateAfter is set, and if the cluster has been in running state for longer than this duration, put it to sleep.
-	if cd.Spec.HibernateAfter != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState {
+	if cd.Spec.HibernateAfter != nil && !shouldHibernate {
 		hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 		runningSince := cd.Status.InstalledTimestamp.Time
 		hibLog := cdLog.WithFields(log.Fields{
@@ -268,6 +300,10 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 
 	if !shouldHibernate {
 		if hibernatingCondition.Status == corev1.ConditionUnknown || hibernatingCondition.Status == corev1.ConditionFalse {
+			switch hibernatingCondition.Reason {
+			case hivev1.PreStopHookRunningHibernationReason, hivev1.PreStopHookFailedHibernationReason:
+				return r.cancelHibernation(cd, cdLog)
+			}
 			return reconcile.Result{}, nil
 		}
 		switch hibernatingCondition.Reason {
@@ -275,6 +311,8 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 			return r.startMachines(cd, cdLog)
 		case hivev1.ResumingHibernationReason:
 			return r.checkClusterResumed(cd, cdLog)
+		case hivev1.PostResumeHookRunningHibernationReason, hivev1.PostResumeHookFailedHibernationReason:
+			return r.finishResume(cd, cdLog)
 		}
 		return reconcile.Result{}, nil
 	}
@@ -282,9 +320,22 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 	if (hibernatingCondition.Status == corev1.ConditionUnknown || hibernatingCondition.Status == corev1.ConditionFalse &&
 		hibernatingCondition.Reason != hivev1.UnsupportedHibernationReason) ||
 		hibernatingCondition.Reason == hivev1.ResumingHibernationReason ||
-		(cd.Spec.PowerState == hivev1.HibernatingClusterPowerState && hibernatingCondition.Reason == hivev1.FailedToStartHibernationReason) {
+		hibernatingCondition.Reason == hivev1.PostResumeHookRunningHibernationReason ||
+		hibernatingCondition.Reason == hivev1.PostResumeHookFailedHibernationReason ||
+		hibernatingCondition.Reason == hivev1.FailedToStartHibernationReason {
 		return r.stopMachines(cd, cdLog)
 	}
+	if hibernatingCondition.Status == corev1.ConditionTrue && activeHibernationMode(cd) != cd.Spec.PowerState {
+		switch cd.Spec.PowerState {
+		case hivev1.HibernatingClusterPowerState:
+			// Only the workers are hibernating, stop the control plane machines as well.
+			return r.stopMachines(cd, cdLog)
+		case hivev1.WorkersHibernatingClusterPowerState:
+			// The whole cluster is hibernating, resume it. The workers are stopped again as soon
+			// as the cluster is resuming.
+			return r.startMachines(cd, cdLog)
+		}
+	}
 	if hibernatingCondition.Reason == hivev1.StoppingHibernationReason {
 		return r.checkClusterStopped(cd, false, cdLog)
 	}
@@ -298,7 +349,14 @@ func (r *hibernationReconciler) startMachines(cd *hivev1.ClusterDeployment, logg
 		return reconcile.Result{}, nil
 	}
 	logger.Info("Resuming cluster")
-	if err := actuator.StartMachines(cd, r.Client, logger); err != nil {
+	if err := r.deleteHookJob(cd, constants.JobTypePreStopHook, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	err := actuator.StartMachines(cd, r.Client, logger)
+	if err == nil {
+		err = r.restoreMachinePools(cd, logger)
+	}
+	if err != nil {
 		msg := fmt.Sprintf("Failed to start machines: %v", err)
 		result, condErr := r.setHibernatingCondition(cd, hivev1.FailedToStartHibernationReason, msg, corev1.ConditionTrue, logger)
 		if condErr != nil {
@@ -307,6 +365,9 @@ func (r *hibernationReconciler) startMachines(cd *hivev1.ClusterDeployment, logg
 		// Return the error starting machines so we get requeue + backoff
 		return result, err
 	}
+	if workersOnly(cd) {
+		return r.setHibernatingCondition(cd, hivev1.ResumingHibernationReason, "Starting cluster worker machines", corev1.ConditionTrue, logger)
+	}
 	return r.setHibernatingCondition(cd, hivev1.ResumingHibernationReason, "Starting cluster machines", corev1.ConditionTrue, logger)
 }
 
@@ -316,11 +377,48 @@ func (r *hibernationReconciler) stopMachines(cd *hivev1.ClusterDeployment, logge
 		logger.Warning("No compatible actuator found to start cluster machines")
 		return reconcile.Result{}, nil
 	}
-	logger.Info("Stopping cluster")
+	if hooks := cd.Spec.HibernationHooks; hooks != nil && hooks.PreStop != nil {
+		state, err := r.runHook(cd, hooks.PreStop, constants.JobTypePreStopHook, logger)
+		switch {
+		case state == hookUnknown:
+			return reconcile.Result{}, err
+		case state == hookRunning:
+			return r.setHibernatingCondition(cd, hivev1.PreStopHookRunningHibernationReason, "Waiting for the pre-stop hook to complete", corev1.ConditionFalse, logger)
+		case state == hookFailed && hookFailureIgnored(hooks.PreStop):
+			logger.WithError(err).Warn("pre-stop hook failed, ignoring the failure")
+		case state == hookFailed:
+			result, condErr := r.setHibernatingCondition(cd, hivev1.PreStopHookFailedHibernationReason, hookFailedMessage(cd, constants.JobTypePreStopHook, err), corev1.ConditionFalse, logger)
+			return hookFailedResult(result, err), condErr
+		}
+	}
+	if err := r.deleteHookJob(cd, constants.JobTypePostResumeHook, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	if cd.Status.HibernationMode != cd.Spec.PowerState {
+		// Record the hibernation mode before stopping anything so that the actuators and later
+		// reconciles know which machines are affected.
+		cd.Status.HibernationMode = cd.Spec.PowerState
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to update hibernation mode")
+			return reconcile.Result{}, errors.Wrap(err, "failed to update hibernation mode")
+		}
+	}
+	if workersOnly(cd) {
+		logger.Info("Stopping cluster workers")
+		if err := r.scaleDownMachinePools(cd, logger); err != nil {
+			msg := fmt.Sprintf("Failed to scale down machine pools: %v", err)
+			return r.setHibernatingCondition(cd, hivev1.FailedToStopHibernationReason, msg, corev1.ConditionFalse, logger)
+		}
+	} else {
+		logger.Info("Stopping cluster")
+	}
 	if err := actuator.StopMachines(cd, r.Client, logger); err != nil {
 		msg := fmt.Sprintf("Failed to stop machines: %v", err)
 		return r.setHibernatingCondition(cd, hivev1.FailedToStopHibernationReason, msg, corev1.ConditionFalse, logger)
 	}
+	if workersOnly(cd) {
+		return r.setHibernatingCondition(cd, hivev1.StoppingHibernationReason, "Stopping cluster worker machines", corev1.ConditionTrue, logger)
+	}
 	return r.setHibernatingCondition(cd, hivev1.StoppingHibernationReason, "Stopping cluster machines", corev1.ConditionTrue, logger)
 }
 
@@ -338,6 +436,10 @@ func (r *hibernationReconciler) checkClusterStopped(cd *hivev1.ClusterDeployment
 	if !stopped {
 		return reconcile.Result{RequeueAfter: stateCheckInterval}, nil
 	}
+	if workersOnly(cd) {
+		logger.Info("Cluster workers have stopped and the cluster is in hibernating state")
+		return r.setHibernatingCondition(cd, hivev1.HibernatingHibernationReason, "Cluster workers are stopped, control plane is running", corev1.ConditionTrue, logger)
+	}
 	logger.Info("Cluster has stopped and is in hibernating state")
 	return r.setHibernatingCondition(cd, hivev1.HibernatingHibernationReason, "Cluster is stopped", corev1.ConditionTrue, logger)
 }
@@ -375,10 +477,40 @@ func (r *hibernationReconciler) checkClusterResumed(cd *hivev1.ClusterDeployment
 		logger.Info("Nodes are not ready, checking for CSRs to approve")
 		return r.checkCSRs(cd, remoteClient, logger)
 	}
+	return r.finishResume(cd, logger)
+}
+
+// finishResume runs the post-resume hook, if any, and marks the cluster as running once it has completed.
+func (r *hibernationReconciler) finishResume(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	if hooks := cd.Spec.HibernationHooks; hooks != nil && hooks.PostResume != nil {
+		state, err := r.runHook(cd, hooks.PostResume, constants.JobTypePostResumeHook, logger)
+		switch {
+		case state == hookUnknown:
+			return reconcile.Result{}, err
+		case state == hookRunning:
+			return r.setHibernatingCondition(cd, hivev1.PostResumeHookRunningHibernationReason, "Waiting for the post-resume hook to complete", corev1.ConditionTrue, logger)
+		case state == hookFailed && hookFailureIgnored(hooks.PostResume):
+			logger.WithError(err).Warn("post-resume hook failed, ignoring the failure")
+		case state == hookFailed:
+			result, condErr := r.setHibernatingCondition(cd, hivev1.PostResumeHookFailedHibernationReason, hookFailedMessage(cd, constants.JobTypePostResumeHook, err), corev1.ConditionTrue, logger)
+			return hookFailedResult(result, err), condErr
+		}
+	}
 	logger.Info("Cluster has started and is in Running state")
+	cd.Status.HibernationMode = ""
 	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "All machines are started and nodes are ready", corev1.ConditionFalse, logger)
 }
 
+// cancelHibernation cleans up the pre-stop hook when the cluster is asked to run again before its machines were stopped.
+func (r *hibernationReconciler) cancelHibernation(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.Info("Hibernation cancelled before machines were stopped")
+	if err := r.deleteHookJob(cd, constants.JobTypePreStopHook, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	cd.Status.HibernationMode = ""
+	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "Hibernation cancelled before machines were stopped", corev1.ConditionFalse, logger)
+}
+
 func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeployment, reason, message string, status corev1.ConditionStatus, logger log.FieldLogger) (result reconcile.Result, returnErr error) {
 	changed := false
 	cd.Status.Conditions, changed = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
@@ -515,6 +647,15 @@ func (r *hibernationReconciler) checkCSRs(cd *hivev1.ClusterDeployment, remoteCl
 	return reconcile.Result{RequeueAfter: csrCheckInterval}, nil
 }
 
+// activeHibernationMode returns the kind of hibernation last acted upon for the given ClusterDeployment.
+// Clusters hibernated before the hibernation mode was recorded were fully hibernated.
+func activeHibernationMode(cd *hivev1.ClusterDeployment) hivev1.ClusterPowerState {
+	if cd.Status.HibernationMode == "" {
+		return hivev1.HibernatingClusterPowerState
+	}
+	return cd.Status.HibernationMode
+}
+
 func isNodeReady(node *corev1.Node) bool {
 	for _, c := range node.Status.Conditions {
 		if c.Type == corev1.NodeReady {
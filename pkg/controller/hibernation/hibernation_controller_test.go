@@ -14,10 +14,12 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	certsv1beta1 "k8s.io/api/certificates/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -422,12 +424,427 @@ func TestReconcile(t *testing.T) {
 
 }
 
+func TestHibernateWorkers(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	batchv1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+	)
+	o := clusterDeploymentOptions{}
+	cs := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(time.Now().Add(-10 * time.Hour)),
+	).Build()
+	pool := func(opts ...func(*hivev1.MachinePool)) *hivev1.MachinePool {
+		mp := &hivev1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: cdName + "-worker"},
+			Spec: hivev1.MachinePoolSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName},
+				Name:                 "worker",
+				Replicas:             pointer.Int64Ptr(3),
+			},
+		}
+		for _, o := range opts {
+			o(mp)
+		}
+		return mp
+	}
+	scaledDown := func(mp *hivev1.MachinePool) {
+		mp.Annotations = map[string]string{constants.HibernationSavedReplicasAnnotation: `{"replicas":3}`}
+		mp.Spec.Replicas = pointer.Int64Ptr(0)
+	}
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		mp                 *hivev1.MachinePool
+		setupActuator      func(actuator *mock.MockHibernationActuator)
+		expectedReason     string
+		expectedMode       hivev1.ClusterPowerState
+		expectedReplicas   int64
+		expectSavedReplica bool
+	}{
+		{
+			name: "start hibernating workers",
+			cd:   cdBuilder.Options(o.shouldHibernateWorkers).Build(),
+			mp:   pool(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					Do(func(cd *hivev1.ClusterDeployment, _ client.Client, _ log.FieldLogger) {
+						assert.True(t, workersOnly(cd), "expected actuator to stop workers only")
+					}).Return(nil)
+			},
+			expectedReason:     hivev1.StoppingHibernationReason,
+			expectedMode:       hivev1.WorkersHibernatingClusterPowerState,
+			expectedReplicas:   0,
+			expectSavedReplica: true,
+		},
+		{
+			name: "workers stopped",
+			cd:   cdBuilder.Options(o.shouldHibernateWorkers, o.stopping, o.workersMode).Build(),
+			mp:   pool(scaledDown),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			expectedReason:     hivev1.HibernatingHibernationReason,
+			expectedMode:       hivev1.WorkersHibernatingClusterPowerState,
+			expectedReplicas:   0,
+			expectSavedReplica: true,
+		},
+		{
+			name: "resume workers",
+			cd:   cdBuilder.Options(o.shouldRun, o.hibernating, o.workersMode).Build(),
+			mp:   pool(scaledDown),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			expectedReason:   hivev1.ResumingHibernationReason,
+			expectedMode:     hivev1.WorkersHibernatingClusterPowerState,
+			expectedReplicas: 3,
+		},
+		{
+			name: "switch from workers to full hibernation",
+			cd:   cdBuilder.Options(o.shouldHibernate, o.hibernating, o.workersMode).Build(),
+			mp:   pool(scaledDown),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					Do(func(cd *hivev1.ClusterDeployment, _ client.Client, _ log.FieldLogger) {
+						assert.False(t, workersOnly(cd), "expected actuator to stop all machines")
+					}).Return(nil)
+			},
+			expectedReason:     hivev1.StoppingHibernationReason,
+			expectedMode:       hivev1.HibernatingClusterPowerState,
+			expectedReplicas:   0,
+			expectSavedReplica: true,
+		},
+		{
+			name: "switch from full to workers hibernation",
+			cd:   cdBuilder.Options(o.shouldHibernateWorkers, o.hibernating).Build(),
+			mp:   pool(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			expectedReason:   hivev1.ResumingHibernationReason,
+			expectedReplicas: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			if test.setupActuator != nil {
+				test.setupActuator(mockActuator)
+			}
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, cs, test.mp)
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return remoteclientmock.NewMockBuilder(ctrl)
+				},
+				csrUtil: mock.NewMockcsrHelper(ctrl),
+			}
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "expected no error from reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd))
+			cond := getHibernatingCondition(cd)
+			require.NotNil(t, cond)
+			assert.Equal(t, test.expectedReason, cond.Reason, "unexpected hibernating reason")
+			assert.Equal(t, test.expectedMode, cd.Status.HibernationMode, "unexpected hibernation mode")
+
+			mp := &hivev1.MachinePool{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: test.mp.Name}, mp))
+			require.NotNil(t, mp.Spec.Replicas)
+			assert.Equal(t, test.expectedReplicas, *mp.Spec.Replicas, "unexpected machine pool replicas")
+			_, saved := mp.Annotations[constants.HibernationSavedReplicasAnnotation]
+			assert.Equal(t, test.expectSavedReplica, saved, "unexpected saved replicas annotation")
+		})
+	}
+}
+
+func TestHibernationHooks(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	batchv1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	o := clusterDeploymentOptions{}
+	withHooks := func(policy hivev1.HibernationHookFailurePolicy) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.HibernationHooks = &hivev1.HibernationHooks{
+				PreStop: &hivev1.HibernationHook{
+					PodTemplateRef: corev1.LocalObjectReference{Name: "pre-stop"},
+					FailurePolicy:  policy,
+				},
+				PostResume: &hivev1.HibernationHook{
+					PodTemplateRef: corev1.LocalObjectReference{Name: "post-resume"},
+					FailurePolicy:  policy,
+				},
+			}
+		}
+	}
+	withClusterMetadata := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+			InfraID:                  "test-infra-id",
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "admin-kubeconfig"},
+		}
+	}
+	withCondition := func(reason string, status corev1.ConditionStatus) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Reason: reason,
+				Status: status,
+			})
+		}
+	}
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+		withClusterMetadata,
+	)
+	cs := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(time.Now().Add(-10 * time.Hour)),
+	).Build()
+	podTemplate := func(name string) *corev1.PodTemplate {
+		return &corev1.PodTemplate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "hook", Image: "hook-image"}},
+				},
+			},
+		}
+	}
+	hookJob := func(jobType string, jobCondition batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      cdName + "-" + jobType,
+				Labels: map[string]string{
+					constants.ClusterDeploymentNameLabel: cdName,
+					constants.JobTypeLabel:               jobType,
+				},
+			},
+		}
+		if jobCondition != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: jobCondition, Status: corev1.ConditionTrue}}
+		}
+		return job
+	}
+
+	tests := []struct {
+		name            string
+		cd              *hivev1.ClusterDeployment
+		existing        []runtime.Object
+		setupActuator   func(actuator *mock.MockHibernationActuator)
+		expectedReason  string
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+		expectedRequeue time.Duration
+		validateJobs    func(t *testing.T, c client.Client)
+	}{
+		{
+			name:           "pre-stop hook started",
+			cd:             cdBuilder.Build(o.shouldHibernate, withHooks("")),
+			existing:       []runtime.Object{podTemplate("pre-stop")},
+			expectedReason: hivev1.PreStopHookRunningHibernationReason,
+			expectedStatus: corev1.ConditionFalse,
+			validateJobs: func(t *testing.T, c client.Client) {
+				job := &batchv1.Job{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName + "-prestop-hook"}, job))
+				assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+				require.Len(t, job.Spec.Template.Spec.Containers, 1)
+				container := job.Spec.Template.Spec.Containers[0]
+				assert.Contains(t, container.Env, corev1.EnvVar{Name: "KUBECONFIG", Value: "/etc/hibernation-hook/kubeconfig"})
+				require.Len(t, container.VolumeMounts, 1)
+				assert.Equal(t, "kubeconfig", container.VolumeMounts[0].Name)
+			},
+		},
+		{
+			name:           "pre-stop hook running",
+			cd:             cdBuilder.Build(o.shouldHibernate, withHooks(""), withCondition(hivev1.PreStopHookRunningHibernationReason, corev1.ConditionFalse)),
+			existing:       []runtime.Object{podTemplate("pre-stop"), hookJob("prestop-hook", "")},
+			expectedReason: hivev1.PreStopHookRunningHibernationReason,
+			expectedStatus: corev1.ConditionFalse,
+		},
+		{
+			name:     "pre-stop hook completed",
+			cd:       cdBuilder.Build(o.shouldHibernate, withHooks(""), withCondition(hivev1.PreStopHookRunningHibernationReason, corev1.ConditionFalse)),
+			existing: []runtime.Object{podTemplate("pre-stop"), hookJob("prestop-hook", batchv1.JobComplete), hookJob("postresume-hook", batchv1.JobComplete)},
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			expectedReason: hivev1.StoppingHibernationReason,
+			expectedStatus: corev1.ConditionTrue,
+			validateJobs: func(t *testing.T, c client.Client) {
+				job := &batchv1.Job{}
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName + "-postresume-hook"}, job)
+				assert.True(t, apierrors.IsNotFound(err), "expected post-resume hook job to be deleted")
+			},
+		},
+		{
+			name:           "pre-stop hook failed",
+			cd:             cdBuilder.Build(o.shouldHibernate, withHooks(hivev1.FailHibernationHookFailurePolicy), withCondition(hivev1.PreStopHookRunningHibernationReason, corev1.ConditionFalse)),
+			existing:       []runtime.Object{podTemplate("pre-stop"), hookJob("prestop-hook", batchv1.JobFailed)},
+			expectedReason: hivev1.PreStopHookFailedHibernationReason,
+			expectedStatus: corev1.ConditionFalse,
+		},
+		{
+			name:     "pre-stop hook failed, failure ignored",
+			cd:       cdBuilder.Build(o.shouldHibernate, withHooks(hivev1.IgnoreHibernationHookFailurePolicy), withCondition(hivev1.PreStopHookRunningHibernationReason, corev1.ConditionFalse)),
+			existing: []runtime.Object{podTemplate("pre-stop"), hookJob("prestop-hook", batchv1.JobFailed)},
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			expectedReason: hivev1.StoppingHibernationReason,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:            "pre-stop hook pod template missing",
+			cd:              cdBuilder.Build(o.shouldHibernate, withHooks(hivev1.FailHibernationHookFailurePolicy)),
+			expectedReason:  hivev1.PreStopHookFailedHibernationReason,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "failed to get pod template pre-stop",
+			expectedRequeue: hookStartRetryInterval,
+		},
+		{
+			name: "pre-stop hook pod template missing, failure ignored",
+			cd:   cdBuilder.Build(o.shouldHibernate, withHooks(hivev1.IgnoreHibernationHookFailurePolicy)),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			expectedReason: hivev1.StoppingHibernationReason,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:           "hibernation cancelled while pre-stop hook running",
+			cd:             cdBuilder.Build(o.shouldRun, withHooks(""), withCondition(hivev1.PreStopHookRunningHibernationReason, corev1.ConditionFalse)),
+			existing:       []runtime.Object{podTemplate("pre-stop"), hookJob("prestop-hook", "")},
+			expectedReason: hivev1.RunningHibernationReason,
+			expectedStatus: corev1.ConditionFalse,
+			validateJobs: func(t *testing.T, c client.Client) {
+				job := &batchv1.Job{}
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName + "-prestop-hook"}, job)
+				assert.True(t, apierrors.IsNotFound(err), "expected pre-stop hook job to be deleted")
+			},
+		},
+		{
+			name:           "hibernation cancelled after hooks were removed",
+			cd:             cdBuilder.Build(o.shouldRun, withCondition(hivev1.PreStopHookRunningHibernationReason, corev1.ConditionFalse)),
+			existing:       []runtime.Object{hookJob("prestop-hook", "")},
+			expectedReason: hivev1.RunningHibernationReason,
+			expectedStatus: corev1.ConditionFalse,
+			validateJobs: func(t *testing.T, c client.Client) {
+				job := &batchv1.Job{}
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName + "-prestop-hook"}, job)
+				assert.True(t, apierrors.IsNotFound(err), "expected pre-stop hook job to be deleted")
+			},
+		},
+		{
+			name:           "post-resume hook running",
+			cd:             cdBuilder.Build(o.shouldRun, withHooks(""), withCondition(hivev1.PostResumeHookRunningHibernationReason, corev1.ConditionTrue)),
+			existing:       []runtime.Object{podTemplate("post-resume"), hookJob("postresume-hook", "")},
+			expectedReason: hivev1.PostResumeHookRunningHibernationReason,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:           "post-resume hook completed",
+			cd:             cdBuilder.Build(o.shouldRun, withHooks(""), withCondition(hivev1.PostResumeHookRunningHibernationReason, corev1.ConditionTrue)),
+			existing:       []runtime.Object{podTemplate("post-resume"), hookJob("postresume-hook", batchv1.JobComplete)},
+			expectedReason: hivev1.RunningHibernationReason,
+			expectedStatus: corev1.ConditionFalse,
+		},
+		{
+			name:           "post-resume hook failed",
+			cd:             cdBuilder.Build(o.shouldRun, withHooks(""), withCondition(hivev1.PostResumeHookRunningHibernationReason, corev1.ConditionTrue)),
+			existing:       []runtime.Object{podTemplate("post-resume"), hookJob("postresume-hook", batchv1.JobFailed)},
+			expectedReason: hivev1.PostResumeHookFailedHibernationReason,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:            "post-resume hook pod template missing",
+			cd:              cdBuilder.Build(o.shouldRun, withHooks(hivev1.FailHibernationHookFailurePolicy), withCondition(hivev1.PostResumeHookRunningHibernationReason, corev1.ConditionTrue)),
+			expectedReason:  hivev1.PostResumeHookFailedHibernationReason,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedMessage: "failed to get pod template post-resume",
+			expectedRequeue: hookStartRetryInterval,
+		},
+		{
+			name:           "post-resume hook pod template missing, failure ignored",
+			cd:             cdBuilder.Build(o.shouldRun, withHooks(hivev1.IgnoreHibernationHookFailurePolicy), withCondition(hivev1.PostResumeHookRunningHibernationReason, corev1.ConditionTrue)),
+			expectedReason: hivev1.RunningHibernationReason,
+			expectedStatus: corev1.ConditionFalse,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			if test.setupActuator != nil {
+				test.setupActuator(mockActuator)
+			}
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewFakeClientWithScheme(scheme, append(test.existing, test.cd, cs)...)
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return remoteclientmock.NewMockBuilder(ctrl)
+				},
+				csrUtil: mock.NewMockcsrHelper(ctrl),
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "expected no error from reconcile")
+			assert.Equal(t, test.expectedRequeue, result.RequeueAfter, "unexpected requeue after")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd))
+			cond := getHibernatingCondition(cd)
+			require.NotNil(t, cond)
+			assert.Equal(t, test.expectedReason, cond.Reason, "unexpected hibernating reason")
+			assert.Equal(t, test.expectedStatus, cond.Status, "unexpected hibernating status")
+			assert.Contains(t, cond.Message, test.expectedMessage, "unexpected hibernating message")
+			if test.validateJobs != nil {
+				test.validateJobs(t, c)
+			}
+		})
+	}
+}
+
 func TestHibernateAfter(t *testing.T) {
 	logger := log.New()
 	logger.SetLevel(log.DebugLevel)
 
 	scheme := runtime.NewScheme()
 	corev1.AddToScheme(scheme)
+	batchv1.AddToScheme(scheme)
 	hivev1.AddToScheme(scheme)
 	hiveintv1alpha1.AddToScheme(scheme)
 
@@ -446,6 +863,8 @@ func TestHibernateAfter(t *testing.T) {
 		cd            *hivev1.ClusterDeployment
 		cs            *hiveintv1alpha1.ClusterSync
 
+		powerStateRequeueInterval time.Duration
+
 		expectError             bool
 		expectRequeueAfter      time.Duration
 		expectedPowerState      hivev1.ClusterPowerState
@@ -489,6 +908,25 @@ func TestHibernateAfter(t *testing.T) {
 			expectRequeueAfter: 2 * time.Hour,
 			expectedPowerState: "",
 		},
+		{
+			name: "cluster not yet due for hibernate requeued to track power state durations",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(12*time.Hour),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                        csBuilder.Build(),
+			powerStateRequeueInterval: powerStateDurationsUpdateInterval,
+			expectRequeueAfter:        powerStateDurationsUpdateInterval,
+			expectedPowerState:        "",
+		},
+		{
+			name: "cluster without hibernate after requeued to track power state durations",
+			cd: cdBuilder.Build(
+				testcd.InstalledTimestamp(time.Now().Add(-10 * time.Hour))),
+			cs:                        csBuilder.Build(),
+			powerStateRequeueInterval: powerStateDurationsUpdateInterval,
+			expectRequeueAfter:        powerStateDurationsUpdateInterval,
+			expectedPowerState:        "",
+		},
 		{
 			name: "cluster with running condition due for hibernate",
 			cd: cdBuilder.Build(
@@ -590,6 +1028,8 @@ func TestHibernateAfter(t *testing.T) {
 					return mockBuilder
 				},
 				csrUtil: mockCSRHelper,
+
+				powerStateRequeueInterval: test.powerStateRequeueInterval,
 			}
 			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
 				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
@@ -636,6 +1076,12 @@ func (*clusterDeploymentOptions) notInstalled(cd *hivev1.ClusterDeployment) {
 func (*clusterDeploymentOptions) shouldHibernate(cd *hivev1.ClusterDeployment) {
 	cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
 }
+func (*clusterDeploymentOptions) shouldHibernateWorkers(cd *hivev1.ClusterDeployment) {
+	cd.Spec.PowerState = hivev1.WorkersHibernatingClusterPowerState
+}
+func (*clusterDeploymentOptions) workersMode(cd *hivev1.ClusterDeployment) {
+	cd.Status.HibernationMode = hivev1.WorkersHibernatingClusterPowerState
+}
 func (*clusterDeploymentOptions) shouldRun(cd *hivev1.ClusterDeployment) {
 	cd.Spec.PowerState = hivev1.RunningClusterPowerState
 }
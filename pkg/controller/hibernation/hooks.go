@@ -0,0 +1,208 @@
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// hookKubeconfigDir is the directory where the admin kubeconfig of the cluster
+	// is mounted in the containers of hook Jobs
+	hookKubeconfigDir = "/etc/hibernation-hook"
+
+	// defaultHookTimeout is the time a hook Job is allowed to run when the hook
+	// does not specify a timeout
+	defaultHookTimeout = 30 * time.Minute
+
+	// hookStartRetryInterval is the interval after which the Job of a hook that could not be
+	// started is created again. Pod templates are not watched, so fixing the hook would not
+	// trigger a reconcile by itself.
+	hookStartRetryInterval = 2 * time.Minute
+)
+
+// hookState is the state of the Job running a hibernation hook
+type hookState int
+
+const (
+	hookUnknown hookState = iota
+	hookRunning
+	hookSucceeded
+	hookFailed
+)
+
+// runHook ensures that the Job for the given hibernation hook has been created and returns its state.
+// When the Job could not be created, hookFailed is returned along with the reason. When the state of
+// the Job could not be read, hookUnknown is returned along with the error.
+func (r *hibernationReconciler) runHook(cd *hivev1.ClusterDeployment, hook *hivev1.HibernationHook, jobType string, logger log.FieldLogger) (hookState, error) {
+	logger = logger.WithField("hook", jobType)
+	job := &batchv1.Job{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: hookJobName(cd, jobType)}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		template := &corev1.PodTemplate{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: hook.PodTemplateRef.Name}, template); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get hook pod template")
+			return hookFailed, errors.Wrapf(err, "failed to get pod template %s", hook.PodTemplateRef.Name)
+		}
+		job = generateHookJob(cd, hook, template, jobType)
+		if err := controllerutil.SetControllerReference(cd, job, r.Scheme()); err != nil {
+			logger.WithError(err).Error("failed to set controller reference on hook job")
+			return hookFailed, err
+		}
+		if err := r.Create(context.TODO(), job); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to create hook job")
+			return hookFailed, errors.Wrap(err, "failed to create hook job")
+		}
+		logger.WithField("job", job.Name).Info("created hook job")
+		return hookRunning, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get hook job")
+		return hookUnknown, errors.Wrap(err, "failed to get hook job")
+	}
+	switch {
+	case controllerutils.IsSuccessful(job):
+		logger.WithField("job", job.Name).Info("hook job completed")
+		return hookSucceeded, nil
+	case controllerutils.IsFailed(job):
+		logger.WithField("job", job.Name).Info("hook job failed")
+		return hookFailed, nil
+	default:
+		logger.WithField("job", job.Name).Debug("hook job is still running")
+		return hookRunning, nil
+	}
+}
+
+// deleteHookJob deletes the Job for a hibernation hook so that the hook is run again on the
+// next transition of the cluster. The Job is found by its labels rather than by the hooks of the
+// cluster, so that it is also cleaned up after the hook was removed from the ClusterDeployment.
+func (r *hibernationReconciler) deleteHookJob(cd *hivev1.ClusterDeployment, jobType string, logger log.FieldLogger) error {
+	jobs := &batchv1.JobList{}
+	if err := r.List(
+		context.TODO(),
+		jobs,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{
+			constants.ClusterDeploymentNameLabel: cd.Name,
+			constants.JobTypeLabel:               jobType,
+		},
+	); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list hook jobs")
+		return errors.Wrap(err, "failed to list hook jobs")
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.WithError(err).WithField("job", job.Name).Log(controllerutils.LogLevel(err), "failed to delete hook job")
+			return errors.Wrap(err, "failed to delete hook job")
+		}
+		logger.WithField("job", job.Name).Info("deleted hook job")
+	}
+	return nil
+}
+
+func hookJobName(cd *hivev1.ClusterDeployment, jobType string) string {
+	return apihelpers.GetResourceName(cd.Name, jobType)
+}
+
+func hookFailureIgnored(hook *hivev1.HibernationHook) bool {
+	return hook.FailurePolicy == hivev1.IgnoreHibernationHookFailurePolicy
+}
+
+// hookFailedMessage returns the message of the Hibernating condition for a failed hook. startErr is the
+// error returned by runHook when the Job of the hook could not be created.
+func hookFailedMessage(cd *hivev1.ClusterDeployment, jobType string, startErr error) string {
+	if startErr != nil {
+		return fmt.Sprintf("Failed to start hook job %s: %v", hookJobName(cd, jobType), startErr)
+	}
+	return fmt.Sprintf("Hook job %s failed, delete it to run the hook again", hookJobName(cd, jobType))
+}
+
+// hookFailedResult returns the result of a reconcile that stopped at a failed hook. Hooks whose Job
+// could not be created are retried periodically.
+func hookFailedResult(result reconcile.Result, startErr error) reconcile.Result {
+	if startErr != nil && result.RequeueAfter == 0 {
+		result.RequeueAfter = hookStartRetryInterval
+	}
+	return result
+}
+
+// generateHookJob creates the Job for a hibernation hook from the pod template of the hook, mounting the
+// admin kubeconfig of the cluster in all of its containers.
+func generateHookJob(cd *hivev1.ClusterDeployment, hook *hivev1.HibernationHook, template *corev1.PodTemplate, jobType string) *batchv1.Job {
+	labels := map[string]string{
+		constants.ClusterDeploymentNameLabel: cd.Name,
+		constants.JobTypeLabel:               jobType,
+	}
+	timeout := defaultHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+
+	podTemplate := template.Template.DeepCopy()
+	if podTemplate.Labels == nil {
+		podTemplate.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		podTemplate.Labels[k] = v
+	}
+	podSpec := &podTemplate.Spec
+	if podSpec.RestartPolicy == "" || podSpec.RestartPolicy == corev1.RestartPolicyAlways {
+		podSpec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "kubeconfig",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name,
+			},
+		},
+	})
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      "kubeconfig",
+				MountPath: hookKubeconfigDir,
+				ReadOnly:  true,
+			})
+			containers[i].Env = append(containers[i].Env, corev1.EnvVar{
+				Name:  "KUBECONFIG",
+				Value: filepath.Join(hookKubeconfigDir, constants.KubeconfigSecretKey),
+			})
+		}
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hookJobName(cd, jobType),
+			Namespace: cd.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: pointer.Int64Ptr(int64(timeout.Seconds())),
+			Template:              *podTemplate,
+		},
+	}
+}
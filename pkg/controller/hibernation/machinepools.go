@@ -0,0 +1,105 @@
+package hibernation
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// savedMachinePoolReplicas is the replica configuration of a MachinePool that is restored
+// when the workers of its cluster resume from hibernation.
+type savedMachinePoolReplicas struct {
+	Replicas    *int64                         `json:"replicas,omitempty"`
+	Autoscaling *hivev1.MachinePoolAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// scaleDownMachinePools scales all MachinePools of the given ClusterDeployment to zero, saving
+// their replica configuration in an annotation so that it can be restored on resume.
+func (r *hibernationReconciler) scaleDownMachinePools(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	pools, err := r.getMachinePools(cd, logger)
+	if err != nil {
+		return err
+	}
+	for i := range pools {
+		pool := &pools[i]
+		poolLog := logger.WithField("machinePool", pool.Name)
+		if _, saved := pool.Annotations[constants.HibernationSavedReplicasAnnotation]; saved {
+			poolLog.Debug("machine pool is already scaled down")
+			continue
+		}
+		saved, err := json.Marshal(savedMachinePoolReplicas{
+			Replicas:    pool.Spec.Replicas,
+			Autoscaling: pool.Spec.Autoscaling,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal machine pool replicas")
+		}
+		if pool.Annotations == nil {
+			pool.Annotations = map[string]string{}
+		}
+		pool.Annotations[constants.HibernationSavedReplicasAnnotation] = string(saved)
+		pool.Spec.Replicas = pointer.Int64Ptr(0)
+		pool.Spec.Autoscaling = nil
+		if err := r.Update(context.TODO(), pool); err != nil {
+			poolLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to scale down machine pool")
+			return errors.Wrapf(err, "failed to scale down machine pool %s", pool.Name)
+		}
+		poolLog.Info("scaled down machine pool")
+	}
+	return nil
+}
+
+// restoreMachinePools restores the replica configuration of the MachinePools of the given ClusterDeployment
+// that were scaled down by scaleDownMachinePools.
+func (r *hibernationReconciler) restoreMachinePools(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	pools, err := r.getMachinePools(cd, logger)
+	if err != nil {
+		return err
+	}
+	for i := range pools {
+		pool := &pools[i]
+		poolLog := logger.WithField("machinePool", pool.Name)
+		savedJSON, ok := pool.Annotations[constants.HibernationSavedReplicasAnnotation]
+		if !ok {
+			continue
+		}
+		saved := savedMachinePoolReplicas{}
+		if err := json.Unmarshal([]byte(savedJSON), &saved); err != nil {
+			poolLog.WithError(err).Error("failed to unmarshal saved machine pool replicas")
+			return errors.Wrapf(err, "failed to unmarshal saved replicas of machine pool %s", pool.Name)
+		}
+		pool.Spec.Replicas = saved.Replicas
+		pool.Spec.Autoscaling = saved.Autoscaling
+		delete(pool.Annotations, constants.HibernationSavedReplicasAnnotation)
+		if err := r.Update(context.TODO(), pool); err != nil {
+			poolLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to restore machine pool")
+			return errors.Wrapf(err, "failed to restore machine pool %s", pool.Name)
+		}
+		poolLog.Info("restored machine pool replicas")
+	}
+	return nil
+}
+
+func (r *hibernationReconciler) getMachinePools(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]hivev1.MachinePool, error) {
+	poolList := &hivev1.MachinePoolList{}
+	if err := r.List(context.TODO(), poolList, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list machine pools")
+		return nil, errors.Wrap(err, "failed to list machine pools")
+	}
+	var pools []hivev1.MachinePool
+	for _, pool := range poolList.Items {
+		if pool.Spec.ClusterDeploymentRef.Name == cd.Name {
+			pools = append(pools, pool)
+		}
+	}
+	return pools, nil
+}
@@ -0,0 +1,114 @@
+package hibernation
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// powerStateDurationsUpdateInterval is how often the power state durations of a cluster are
+	// accumulated when its power state does not change.
+	powerStateDurationsUpdateInterval = 30 * time.Minute
+
+	// maxPowerStateHistory is the number of power state changes kept in the status of a cluster.
+	maxPowerStateHistory = 100
+)
+
+var (
+	metricPowerStateSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cluster_deployment_power_state_seconds_total",
+		Help: "Total time clusters have spent in each power state.",
+	}, []string{"cluster_pool", "namespace", "platform", "power_state"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricPowerStateSeconds)
+}
+
+// currentPowerState returns the power state the machines of the cluster are in. Clusters that are
+// stopping or resuming still have running machines, so they are considered running.
+func currentPowerState(cd *hivev1.ClusterDeployment) hivev1.ClusterPowerState {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == hivev1.HibernatingHibernationReason {
+		return activeHibernationMode(cd)
+	}
+	return hivev1.RunningClusterPowerState
+}
+
+// updatePowerStateDurations accumulates the time spent in the previous power state of the cluster and
+// records power state changes. The status is only updated when the power state changed or when the
+// durations were last accumulated more than powerStateDurationsUpdateInterval ago.
+func (r *hibernationReconciler) updatePowerStateDurations(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	now := metav1.Now()
+	state := currentPowerState(cd)
+	durations := cd.Status.PowerStateDurations
+	if durations == nil {
+		logger.WithField("powerState", state).Info("starting to track power state durations")
+		cd.Status.PowerStateDurations = &hivev1.ClusterPowerStateDurations{
+			LastUpdateTime: now,
+			History:        []hivev1.ClusterPowerStateChange{{PowerState: state, Time: now}},
+		}
+		return r.updatePowerStateDurationsStatus(cd, logger)
+	}
+
+	previous := state
+	if len(durations.History) > 0 {
+		previous = durations.History[len(durations.History)-1].PowerState
+	}
+	elapsed := now.Sub(durations.LastUpdateTime.Time)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if previous == state && elapsed < powerStateDurationsUpdateInterval {
+		return nil
+	}
+
+	switch previous {
+	case hivev1.HibernatingClusterPowerState:
+		durations.Hibernating.Duration += elapsed
+	case hivev1.WorkersHibernatingClusterPowerState:
+		durations.WorkersHibernating.Duration += elapsed
+	default:
+		durations.Running.Duration += elapsed
+	}
+	durations.LastUpdateTime = now
+	if previous != state {
+		logger.WithField("previousPowerState", previous).WithField("powerState", state).Info("power state changed")
+		durations.History = append(durations.History, hivev1.ClusterPowerStateChange{PowerState: state, Time: now})
+		if len(durations.History) > maxPowerStateHistory {
+			durations.History = durations.History[len(durations.History)-maxPowerStateHistory:]
+		}
+	}
+	if err := r.updatePowerStateDurationsStatus(cd, logger); err != nil {
+		return err
+	}
+	// Only count the time once it has been persisted so that a failed update does not count it twice.
+	metricPowerStateSeconds.WithLabelValues(powerStateMetricLabels(cd, previous)...).Add(elapsed.Seconds())
+	return nil
+}
+
+func (r *hibernationReconciler) updatePowerStateDurationsStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update power state durations")
+		return err
+	}
+	return nil
+}
+
+func powerStateMetricLabels(cd *hivev1.ClusterDeployment, state hivev1.ClusterPowerState) []string {
+	pool := ""
+	if cd.Spec.ClusterPoolRef != nil {
+		pool = cd.Spec.ClusterPoolRef.PoolName
+	}
+	return []string{pool, cd.Namespace, cd.Labels[hivev1.HiveClusterPlatformLabel], string(state)}
+}
@@ -0,0 +1,160 @@
+package hibernation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestUpdatePowerStateDurations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	hibernated := func(mode hivev1.ClusterPowerState) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.HibernationMode = mode
+			cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: corev1.ConditionTrue,
+				Reason: hivev1.HibernatingHibernationReason,
+			}}
+		}
+	}
+	tracked := func(lastUpdate time.Duration, states ...hivev1.ClusterPowerState) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			durations := &hivev1.ClusterPowerStateDurations{
+				Running:        metav1.Duration{Duration: time.Hour},
+				LastUpdateTime: metav1.NewTime(time.Now().Add(-lastUpdate)),
+			}
+			for i, state := range states {
+				durations.History = append(durations.History, hivev1.ClusterPowerStateChange{
+					PowerState: state,
+					Time:       metav1.NewTime(time.Now().Add(-time.Duration(len(states)-i) * time.Hour)),
+				})
+			}
+			cd.Status.PowerStateDurations = durations
+		}
+	}
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(testcd.Installed())
+
+	tests := []struct {
+		name                       string
+		cd                         *hivev1.ClusterDeployment
+		expectedRunning            time.Duration
+		expectedHibernating        time.Duration
+		expectedWorkersHibernating time.Duration
+		expectedHistory            []hivev1.ClusterPowerState
+	}{
+		{
+			name:            "start tracking running cluster",
+			cd:              cdBuilder.Build(),
+			expectedHistory: []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState},
+		},
+		{
+			name:            "start tracking hibernating cluster",
+			cd:              cdBuilder.Build(hibernated(hivev1.HibernatingClusterPowerState)),
+			expectedHistory: []hivev1.ClusterPowerState{hivev1.HibernatingClusterPowerState},
+		},
+		{
+			name:            "unchanged power state within update interval",
+			cd:              cdBuilder.Build(tracked(5*time.Minute, hivev1.RunningClusterPowerState)),
+			expectedRunning: time.Hour,
+			expectedHistory: []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState},
+		},
+		{
+			name:            "unchanged power state after update interval",
+			cd:              cdBuilder.Build(tracked(2*time.Hour, hivev1.RunningClusterPowerState)),
+			expectedRunning: 3 * time.Hour,
+			expectedHistory: []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState},
+		},
+		{
+			name: "cluster hibernated",
+			cd: cdBuilder.Build(
+				tracked(5*time.Minute, hivev1.RunningClusterPowerState),
+				hibernated(hivev1.HibernatingClusterPowerState),
+			),
+			expectedRunning: time.Hour + 5*time.Minute,
+			expectedHistory: []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState, hivev1.HibernatingClusterPowerState},
+		},
+		{
+			name: "cluster workers hibernated",
+			cd: cdBuilder.Build(
+				tracked(5*time.Minute, hivev1.RunningClusterPowerState),
+				hibernated(hivev1.WorkersHibernatingClusterPowerState),
+			),
+			expectedRunning: time.Hour + 5*time.Minute,
+			expectedHistory: []hivev1.ClusterPowerState{hivev1.RunningClusterPowerState, hivev1.WorkersHibernatingClusterPowerState},
+		},
+		{
+			name:                "cluster resumed",
+			cd:                  cdBuilder.Build(tracked(2*time.Hour, hivev1.RunningClusterPowerState, hivev1.HibernatingClusterPowerState)),
+			expectedRunning:     time.Hour,
+			expectedHibernating: 2 * time.Hour,
+			expectedHistory: []hivev1.ClusterPowerState{
+				hivev1.RunningClusterPowerState,
+				hivev1.HibernatingClusterPowerState,
+				hivev1.RunningClusterPowerState,
+			},
+		},
+		{
+			name: "history is limited",
+			cd: func() *hivev1.ClusterDeployment {
+				states := make([]hivev1.ClusterPowerState, maxPowerStateHistory)
+				for i := range states {
+					states[i] = hivev1.HibernatingClusterPowerState
+					if i%2 == 1 {
+						states[i] = hivev1.RunningClusterPowerState
+					}
+				}
+				return cdBuilder.Build(tracked(time.Hour, states...), hibernated(hivev1.WorkersHibernatingClusterPowerState))
+			}(),
+			expectedRunning: 2 * time.Hour,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme, test.cd)
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+			}
+			require.NoError(t, reconciler.updatePowerStateDurations(test.cd, reconciler.logger))
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd))
+			durations := cd.Status.PowerStateDurations
+			require.NotNil(t, durations, "expected power state durations")
+			assertDurationNear(t, test.expectedRunning, durations.Running.Duration, "unexpected running duration")
+			assertDurationNear(t, test.expectedHibernating, durations.Hibernating.Duration, "unexpected hibernating duration")
+			assertDurationNear(t, test.expectedWorkersHibernating, durations.WorkersHibernating.Duration, "unexpected workers hibernating duration")
+			assert.WithinDuration(t, time.Now(), durations.LastUpdateTime.Time, 10*time.Minute, "unexpected last update time")
+			if test.expectedHistory != nil {
+				var history []hivev1.ClusterPowerState
+				for _, change := range durations.History {
+					history = append(history, change.PowerState)
+				}
+				assert.Equal(t, test.expectedHistory, history, "unexpected power state history")
+			} else {
+				assert.Len(t, durations.History, maxPowerStateHistory, "unexpected power state history length")
+				assert.Equal(t, hivev1.WorkersHibernatingClusterPowerState, durations.History[len(durations.History)-1].PowerState)
+			}
+		})
+	}
+}
+
+func assertDurationNear(t *testing.T, expected, actual time.Duration, msg string) {
+	assert.InDelta(t, expected.Seconds(), actual.Seconds(), 10, msg)
+}
@@ -0,0 +1,369 @@
+package machinemanagement
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	// machineControllersName is the name of the deployment, service account, role and role binding of the machine API
+	// controllers run in the target namespace.
+	machineControllersName = "machine-api-controllers"
+
+	// remoteMachineAPINamespace is the namespace of the machine API in the remote cluster.
+	remoteMachineAPINamespace = "openshift-machine-api"
+
+	// workerUserDataSecretName is the name of the secret holding the ignition config used by the worker machines.
+	workerUserDataSecretName = "worker-user-data"
+
+	// nodeMachineAnnotation is the annotation used by the machine API to link a node to its machine.
+	nodeMachineAnnotation = "machine.openshift.io/machine"
+
+	// centralSyncInterval is the interval at which the nodes and CSRs of the remote cluster are checked for centrally
+	// managed machines.
+	centralSyncInterval = 30 * time.Second
+)
+
+// reconcileCentralMachines runs the machine API controllers in the target namespace and registers the nodes of the
+// machines they create with the remote cluster. It returns whether the remote cluster should be checked again after the
+// central sync interval.
+func (r *ReconcileMachineManagement) reconcileCentralMachines(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (resync bool, returnErr error) {
+	if cd.Status.MachineAPIImages == nil {
+		cdLog.Debug("machine API images not yet resolved, waiting")
+		return false, nil
+	}
+	if err := r.syncMachineControllers(cd, cdLog); err != nil {
+		return false, err
+	}
+	if !cd.Spec.Installed {
+		cdLog.Debug("cluster is not yet installed, not syncing nodes")
+		return false, nil
+	}
+
+	remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(
+		cd,
+		r.remoteClusterAPIClientBuilder(cd),
+		r.Client,
+		cdLog,
+	)
+	if unreachable {
+		return requeue, nil
+	}
+
+	if err := r.syncWorkerUserData(cd, remoteClient, cdLog); err != nil {
+		return false, err
+	}
+
+	machineList := &machineapi.MachineList{}
+	if err := r.List(context.TODO(), machineList, client.InNamespace(cd.Spec.MachineManagement.TargetNamespace)); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to list machines in target namespace")
+		return false, errors.Wrap(err, "failed to list machines in target namespace")
+	}
+	if err := r.syncNodes(cd, machineList.Items, remoteClient, cdLog); err != nil {
+		return false, err
+	}
+	if err := r.approveCSRs(cd, machineList.Items, cdLog); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// syncMachineControllers deploys the machine API controllers in the target namespace.
+func (r *ReconcileMachineManagement) syncMachineControllers(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	targetNamespace := cd.Spec.MachineManagement.TargetNamespace
+	objectMeta := func() metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      machineControllersName,
+			Namespace: targetNamespace,
+			Labels:    map[string]string{constants.ClusterDeploymentNameLabel: cd.Name},
+		}
+	}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: objectMeta()}
+	role := &rbacv1.Role{ObjectMeta: objectMeta()}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: objectMeta()}
+	deployment := &appsv1.Deployment{ObjectMeta: objectMeta()}
+	for _, o := range []struct {
+		obj    client.Object
+		mutate func()
+	}{
+		{obj: sa, mutate: func() {}},
+		{obj: role, mutate: func() { role.Rules = machineControllersRules() }},
+		{obj: roleBinding, mutate: func() {
+			roleBinding.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     machineControllersName,
+			}
+			roleBinding.Subjects = []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      machineControllersName,
+				Namespace: targetNamespace,
+			}}
+		}},
+		{obj: deployment, mutate: func() { setMachineControllersDeploymentSpec(deployment, cd) }},
+	} {
+		mutate := o.mutate
+		result, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, o.obj, func() error {
+			mutate()
+			return nil
+		})
+		kind := reflect.TypeOf(o.obj).Elem().Name()
+		if err != nil {
+			cdLog.WithError(err).WithField("kind", kind).Log(controllerutils.LogLevel(err), "failed to sync machine API controllers")
+			return errors.Wrapf(err, "failed to sync machine API controllers %s", kind)
+		}
+		if result != controllerutil.OperationResultNone {
+			cdLog.WithField("kind", kind).WithField("operation", result).Info("synced machine API controllers")
+		}
+	}
+	return nil
+}
+
+func machineControllersRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{machineapi.SchemeGroupVersion.Group},
+			Resources: []string{"machines", "machines/status", "machinesets", "machinesets/status"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"events"},
+			Verbs:     []string{"create", "patch"},
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch"},
+		},
+		{
+			APIGroups: []string{"coordination.k8s.io"},
+			Resources: []string{"leases"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch"},
+		},
+	}
+}
+
+func setMachineControllersDeploymentSpec(deployment *appsv1.Deployment, cd *hivev1.ClusterDeployment) {
+	targetNamespace := cd.Spec.MachineManagement.TargetNamespace
+	labels := map[string]string{"app": machineControllersName}
+	args := []string{
+		"--logtostderr=true",
+		"--v=3",
+		"--namespace=" + targetNamespace,
+		"--leader-elect=true",
+		"--leader-elect-resource-namespace=" + targetNamespace,
+	}
+	deployment.Spec.Replicas = pointer.Int32Ptr(1)
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	deployment.Spec.Template.Labels = labels
+	deployment.Spec.Template.Spec.ServiceAccountName = machineControllersName
+	// The defaults of the API server are set on the containers so that the deployment is not updated on every sync.
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:                     "machineset-controller",
+			Image:                    cd.Status.MachineAPIImages.MachineAPIOperator,
+			Command:                  []string{"/machineset-controller"},
+			Args:                     args,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		{
+			Name:                     "machine-controller",
+			Image:                    cd.Status.MachineAPIImages.MachineControllers,
+			Command:                  []string{"/machine-controller-manager"},
+			Args:                     args,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+	}
+}
+
+// syncWorkerUserData copies the user data secret of the worker machines from the remote cluster to the target namespace,
+// so that the machines created from the hub can join the remote cluster.
+func (r *ReconcileMachineManagement) syncWorkerUserData(cd *hivev1.ClusterDeployment, remoteClient client.Client, cdLog log.FieldLogger) error {
+	remoteSecret := &corev1.Secret{}
+	if err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: remoteMachineAPINamespace, Name: workerUserDataSecretName}, remoteSecret); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to get worker user data from remote cluster")
+		return errors.Wrap(err, "failed to get worker user data from remote cluster")
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workerUserDataSecretName,
+			Namespace: cd.Spec.MachineManagement.TargetNamespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, secret, func() error {
+		secret.Type = remoteSecret.Type
+		secret.Data = remoteSecret.Data
+		return nil
+	})
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to sync worker user data to target namespace")
+		return errors.Wrap(err, "failed to sync worker user data to target namespace")
+	}
+	if result != controllerutil.OperationResultNone {
+		cdLog.WithField("operation", result).Info("synced worker user data to target namespace")
+	}
+	return nil
+}
+
+// syncNodes links the nodes of the remote cluster to the machines in the target namespace that back them, and deletes
+// the nodes whose machines are gone.
+func (r *ReconcileMachineManagement) syncNodes(cd *hivev1.ClusterDeployment, machines []machineapi.Machine, remoteClient client.Client, cdLog log.FieldLogger) error {
+	targetNamespace := cd.Spec.MachineManagement.TargetNamespace
+	nodeList := &corev1.NodeList{}
+	if err := remoteClient.List(context.TODO(), nodeList); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to list remote nodes")
+		return errors.Wrap(err, "failed to list remote nodes")
+	}
+	machinesByProviderID := map[string]*machineapi.Machine{}
+	machinesByName := map[string]bool{}
+	for i, machine := range machines {
+		machinesByName[machine.Name] = true
+		if machine.Spec.ProviderID != nil && *machine.Spec.ProviderID != "" {
+			machinesByProviderID[*machine.Spec.ProviderID] = &machines[i]
+		}
+	}
+
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		nodeLog := cdLog.WithField("node", node.Name)
+		machine := machinesByProviderID[node.Spec.ProviderID]
+		if machine == nil {
+			namespace, name, err := splitNodeMachineAnnotation(node.Annotations[nodeMachineAnnotation])
+			if err != nil || namespace != targetNamespace || machinesByName[name] {
+				continue
+			}
+			nodeLog.WithField("machine", name).Info("deleting node of deleted machine")
+			if err := remoteClient.Delete(context.TODO(), node); err != nil && !apierrors.IsNotFound(err) {
+				nodeLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to delete node")
+				return errors.Wrap(err, "failed to delete node")
+			}
+			continue
+		}
+
+		if machine.Status.NodeRef == nil || machine.Status.NodeRef.Name != node.Name {
+			nodeLog.WithField("machine", machine.Name).Info("linking machine to node")
+			machine.Status.NodeRef = &corev1.ObjectReference{
+				Kind: "Node",
+				Name: node.Name,
+				UID:  node.UID,
+			}
+			if err := r.Status().Update(context.TODO(), machine); err != nil {
+				nodeLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update machine status")
+				return errors.Wrap(err, "failed to update machine status")
+			}
+		}
+
+		if changed := syncNodeWithMachine(node, machine); changed {
+			nodeLog.WithField("machine", machine.Name).Info("updating node from machine")
+			if err := remoteClient.Update(context.TODO(), node); err != nil {
+				nodeLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update node")
+				return errors.Wrap(err, "failed to update node")
+			}
+		}
+	}
+	return nil
+}
+
+// syncNodeWithMachine sets the machine annotation on the node, along with the labels and taints of the machine.
+// It returns whether the node was changed.
+func syncNodeWithMachine(node *corev1.Node, machine *machineapi.Machine) bool {
+	changed := false
+	machineRef := fmt.Sprintf("%s/%s", machine.Namespace, machine.Name)
+	if node.Annotations[nodeMachineAnnotation] != machineRef {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[nodeMachineAnnotation] = machineRef
+		changed = true
+	}
+	for k, v := range machine.Spec.Labels {
+		if value, ok := node.Labels[k]; !ok || value != v {
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[k] = v
+			changed = true
+		}
+	}
+	for _, taint := range machine.Spec.Taints {
+		found := false
+		for _, nodeTaint := range node.Spec.Taints {
+			if nodeTaint.MatchTaint(&taint) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			node.Spec.Taints = append(node.Spec.Taints, taint)
+			changed = true
+		}
+	}
+	return changed
+}
+
+func splitNodeMachineAnnotation(value string) (namespace, name string, err error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid machine annotation %q", value)
+	}
+	return parts[0], parts[1], nil
+}
+
+// removeMachineFinalizers removes the finalizers from the machines in the target namespace, which the machine
+// controllers would otherwise never remove once the namespace, and them with it, is being deleted. The cloud resources
+// of the machines are removed with the rest of the cluster during deprovision.
+func (r *ReconcileMachineManagement) removeMachineFinalizers(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	machineList := &machineapi.MachineList{}
+	if err := r.List(context.TODO(), machineList, client.InNamespace(cd.Spec.MachineManagement.TargetNamespace)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to list machines in target namespace")
+	}
+	for i := range machineList.Items {
+		machine := &machineList.Items[i]
+		if len(machine.Finalizers) == 0 {
+			continue
+		}
+		cdLog.WithField("machine", machine.Name).Info("removing finalizers from machine")
+		machine.Finalizers = nil
+		if err := r.Update(context.TODO(), machine); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "failed to remove finalizers from machine")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package machinemanagement
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testMachineAPIOperatorImage = "example.com/machine-api-operator:latest"
+	testMachineControllersImage = "example.com/aws-machine-controllers:latest"
+)
+
+func TestReconcileCentralMachines(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	machineapi.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name                string
+		clusterDeployment   *hivev1.ClusterDeployment
+		existing            []runtime.Object
+		remoteExisting      []runtime.Object
+		csrs                []runtime.Object
+		expectDeployment    bool
+		expectRemote        bool
+		expectedNodes       []string
+		expectedNodeRefs    map[string]string
+		expectedApprovedCSR []string
+	}{
+		{
+			name:              "wait for machine API images",
+			clusterDeployment: testCentralClusterDeployment(false, false),
+		},
+		{
+			name:              "deploy machine controllers before install",
+			clusterDeployment: testCentralClusterDeployment(true, false),
+			expectDeployment:  true,
+		},
+		{
+			name:              "register nodes with remote cluster",
+			clusterDeployment: testCentralClusterDeployment(true, true),
+			existing: []runtime.Object{
+				testMachine("worker-1", "aws:///us-east-1a/i-1", "ip-10-0-1-1.ec2.internal"),
+				testMachine("worker-2", "", "ip-10-0-1-2.ec2.internal"),
+			},
+			remoteExisting: []runtime.Object{
+				testNode("ip-10-0-1-1.ec2.internal", "aws:///us-east-1a/i-1", ""),
+				testNode("ip-10-0-1-3.ec2.internal", "aws:///us-east-1a/i-3", targetNamespace+"/worker-3"),
+				testNode("master-0", "aws:///us-east-1a/i-0", "openshift-machine-api/master-0"),
+			},
+			csrs: []runtime.Object{
+				testClientCSR(t, "csr-worker-2", "ip-10-0-1-2.ec2.internal"),
+				testClientCSR(t, "csr-unknown", "ip-10-0-1-9.ec2.internal"),
+			},
+			expectDeployment:    true,
+			expectRemote:        true,
+			expectedNodes:       []string{"ip-10-0-1-1.ec2.internal", "master-0"},
+			expectedNodeRefs:    map[string]string{"worker-1": "ip-10-0-1-1.ec2.internal"},
+			expectedApprovedCSR: []string{"csr-worker-2"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := append([]runtime.Object{
+				tc.clusterDeployment,
+				testNs(targetNamespace),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeOpaque, credsSecret, "username", "test"),
+			}, tc.existing...)
+			c := fake.NewFakeClient(existing...)
+			remoteClient := fake.NewFakeClient(append([]runtime.Object{
+				testSecretWithNamespace(corev1.SecretTypeOpaque, workerUserDataSecretName, remoteMachineAPINamespace, "userData", "ignition"),
+			}, tc.remoteExisting...)...)
+			kubeClient := kubefake.NewSimpleClientset(tc.csrs...)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if tc.expectRemote {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
+				mockRemoteClientBuilder.EXPECT().BuildKubeClient().Return(kubeClient, nil)
+			}
+
+			r := &ReconcileMachineManagement{
+				Client:                        c,
+				scheme:                        scheme.Scheme,
+				logger:                        log.WithField("controller", "machineManagement"),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: testName, Namespace: testNamespace},
+			})
+			require.NoError(t, err)
+
+			deployment := &appsv1.Deployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: targetNamespace, Name: machineControllersName}, deployment)
+			if !tc.expectDeployment {
+				assert.True(t, apierrors.IsNotFound(err), "unexpected machine controllers deployment")
+				return
+			}
+			require.NoError(t, err, "missing machine controllers deployment")
+			if assert.Len(t, deployment.Spec.Template.Spec.Containers, 2, "unexpected containers") {
+				assert.Equal(t, testMachineAPIOperatorImage, deployment.Spec.Template.Spec.Containers[0].Image, "unexpected machineset controller image")
+				assert.Equal(t, testMachineControllersImage, deployment.Spec.Template.Spec.Containers[1].Image, "unexpected machine controller image")
+			}
+			for _, obj := range []client.Object{&corev1.ServiceAccount{}, &rbacv1.Role{}, &rbacv1.RoleBinding{}} {
+				assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: targetNamespace, Name: machineControllersName}, obj), "missing machine controllers %T", obj)
+			}
+
+			if !tc.expectRemote {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+				return
+			}
+			assert.Equal(t, centralSyncInterval, result.RequeueAfter, "unexpected requeue")
+
+			userData := &corev1.Secret{}
+			if assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: targetNamespace, Name: workerUserDataSecretName}, userData), "missing worker user data") {
+				assert.Equal(t, "ignition", string(userData.Data["userData"]), "unexpected worker user data")
+			}
+
+			nodes := &corev1.NodeList{}
+			require.NoError(t, remoteClient.List(context.TODO(), nodes))
+			var nodeNames []string
+			for _, node := range nodes.Items {
+				nodeNames = append(nodeNames, node.Name)
+				if node.Name == "ip-10-0-1-1.ec2.internal" {
+					assert.Equal(t, targetNamespace+"/worker-1", node.Annotations[nodeMachineAnnotation], "unexpected machine annotation")
+					assert.Equal(t, "", node.Labels["node-role.kubernetes.io/worker"], "missing machine label")
+					assert.Len(t, node.Spec.Taints, 1, "missing machine taint")
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedNodes, nodeNames, "unexpected nodes")
+
+			for machineName, nodeName := range tc.expectedNodeRefs {
+				machine := &machineapi.Machine{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: targetNamespace, Name: machineName}, machine))
+				if assert.NotNil(t, machine.Status.NodeRef, "missing node ref") {
+					assert.Equal(t, nodeName, machine.Status.NodeRef.Name, "unexpected node ref")
+				}
+			}
+
+			csrs, err := kubeClient.CertificatesV1beta1().CertificateSigningRequests().List(context.TODO(), metav1.ListOptions{})
+			require.NoError(t, err)
+			var approved []string
+			for i := range csrs.Items {
+				if isCSRFinished(&csrs.Items[i]) {
+					approved = append(approved, csrs.Items[i].Name)
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedApprovedCSR, approved, "unexpected approved CSRs")
+		})
+	}
+}
+
+func TestReconcileCentralMachinesDeleted(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	machineapi.AddToScheme(scheme.Scheme)
+
+	cd := testCentralClusterDeployment(true, true)
+	now := metav1.Now()
+	cd.DeletionTimestamp = &now
+	cd.Finalizers = append(cd.Finalizers, hivev1.FinalizerMachineManagementTargetNamespace)
+	machine := testMachine("worker-1", "aws:///us-east-1a/i-1", "ip-10-0-1-1.ec2.internal")
+	machine.Finalizers = []string{"machine.machine.openshift.io"}
+	c := fake.NewFakeClient(cd, testNs(targetNamespace), machine)
+
+	r := &ReconcileMachineManagement{
+		Client: c,
+		scheme: scheme.Scheme,
+		logger: log.WithField("controller", "machineManagement"),
+	}
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: testName, Namespace: testNamespace},
+	})
+	require.NoError(t, err)
+
+	machine = &machineapi.Machine{}
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: targetNamespace, Name: "worker-1"}, machine))
+	assert.Empty(t, machine.Finalizers, "unexpected machine finalizers")
+	err = c.Get(context.TODO(), client.ObjectKey{Name: targetNamespace}, &corev1.Namespace{})
+	assert.True(t, apierrors.IsNotFound(err), "expected target namespace to be deleted")
+}
+
+func testCentralClusterDeployment(withImages, installed bool) *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Installed = installed
+	cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+		Type:   hivev1.UnreachableCondition,
+		Status: corev1.ConditionFalse,
+	}}
+	cd.Spec.MachineManagement = &hivev1.MachineManagement{
+		Central:         &hivev1.CentralMachineManagement{},
+		TargetNamespace: targetNamespace,
+	}
+	if withImages {
+		cd.Status.MachineAPIImages = &hivev1.MachineAPIImages{
+			MachineAPIOperator: testMachineAPIOperatorImage,
+			MachineControllers: testMachineControllersImage,
+		}
+	}
+	return cd
+}
+
+func testMachine(name, providerID, internalDNS string) *machineapi.Machine {
+	m := &machineapi.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: targetNamespace,
+		},
+		Spec: machineapi.MachineSpec{
+			ObjectMeta: machineapi.ObjectMeta{
+				Labels: map[string]string{"node-role.kubernetes.io/worker": ""},
+			},
+			Taints: []corev1.Taint{{Key: "dedicated", Value: "worker", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: machineapi.MachineStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalDNS, Address: internalDNS}},
+		},
+	}
+	if providerID != "" {
+		m.Spec.ProviderID = pointer.StringPtr(providerID)
+	}
+	return m
+}
+
+func testNode(name, providerID, machine string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{ProviderID: providerID},
+	}
+	if machine != "" {
+		node.Annotations = map[string]string{nodeMachineAnnotation: machine}
+	}
+	return node
+}
+
+func testClientCSR(t *testing.T, name, nodeName string) *certificatesv1beta1.CertificateSigningRequest {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   nodeUserPrefix + nodeName,
+			Organization: []string{nodeGroup},
+		},
+	}, key)
+	require.NoError(t, err)
+	return &certificatesv1beta1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: certificatesv1beta1.CertificateSigningRequestSpec{
+			Request:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+			Username: nodeBootstrapperUsername,
+			Groups:   nodeBootstrapperGroups.List(),
+			Usages:   kubeletClientUsages,
+		},
+	}
+}
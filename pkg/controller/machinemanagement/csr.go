@@ -0,0 +1,318 @@
+package machinemanagement
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// copied from github.com/openshift/cluster-machine-approver/csr_check.go
+// with modifications for machines running from the hub, which the machine approver of the remote cluster does not see
+
+const (
+	nodeUser       = "system:node"
+	nodeGroup      = "system:nodes"
+	nodeUserPrefix = nodeUser + ":"
+
+	nodeBootstrapperUsername = "system:serviceaccount:openshift-machine-config-operator:node-bootstrapper"
+)
+
+var nodeBootstrapperGroups = sets.NewString(
+	"system:serviceaccounts:openshift-machine-config-operator",
+	"system:serviceaccounts",
+	"system:authenticated",
+)
+
+var kubeletClientUsages = []certificatesv1beta1.KeyUsage{
+	certificatesv1beta1.UsageKeyEncipherment,
+	certificatesv1beta1.UsageDigitalSignature,
+	certificatesv1beta1.UsageClientAuth,
+}
+
+var kubeletServerUsages = []certificatesv1beta1.KeyUsage{
+	certificatesv1beta1.UsageDigitalSignature,
+	certificatesv1beta1.UsageKeyEncipherment,
+	certificatesv1beta1.UsageServerAuth,
+}
+
+// approveCSRs approves the pending CSRs of the remote cluster issued by the nodes of the machines in the target
+// namespace.
+func (r *ReconcileMachineManagement) approveCSRs(cd *hivev1.ClusterDeployment, machines []machineapi.Machine, cdLog log.FieldLogger) error {
+	kubeClient, err := r.remoteClusterAPIClientBuilder(cd).BuildKubeClient()
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to get kube client to remote cluster")
+		return errors.Wrap(err, "failed to get kube client to remote cluster")
+	}
+	csrList, err := kubeClient.CertificatesV1beta1().CertificateSigningRequests().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to list CSRs")
+		return errors.Wrap(err, "failed to list CSRs")
+	}
+	for i := range csrList.Items {
+		csr := &csrList.Items[i]
+		csrLog := cdLog.WithField("csr", csr.Name)
+		if isCSRFinished(csr) {
+			continue
+		}
+		parsedCSR, err := parseCSR(csr)
+		if err != nil {
+			csrLog.WithError(err).Debug("failed to parse CSR")
+			continue
+		}
+		if err := authorizeCSR(machines, kubeClient, csr, parsedCSR); err != nil {
+			// The CSR may be meant for a machine of the remote cluster, which is approved by its own machine approver.
+			csrLog.WithError(err).Debug("CSR not authorized for a centrally managed machine")
+			continue
+		}
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1beta1.CertificateSigningRequestCondition{
+			Type:           certificatesv1beta1.CertificateApproved,
+			Reason:         "NodeCSRApprove",
+			Message:        "This CSR was automatically approved by Hive for a centrally managed machine",
+			LastUpdateTime: metav1.Now(),
+		})
+		if _, err := kubeClient.CertificatesV1beta1().CertificateSigningRequests().UpdateApproval(context.TODO(), csr, metav1.UpdateOptions{}); err != nil {
+			csrLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to approve CSR")
+			return errors.Wrap(err, "failed to approve CSR")
+		}
+		csrLog.Info("CSR approved")
+	}
+	return nil
+}
+
+func isCSRFinished(csr *certificatesv1beta1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1beta1.CertificateApproved || condition.Type == certificatesv1beta1.CertificateDenied {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCSR extracts the CSR from the API object and decodes it.
+func parseCSR(obj *certificatesv1beta1.CertificateSigningRequest) (*x509.CertificateRequest, error) {
+	// extract PEM from request object
+	block, _ := pem.Decode(obj.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("PEM block type must be CERTIFICATE REQUEST")
+	}
+	return x509.ParseCertificateRequest(block.Bytes)
+}
+
+// authorizeCSR authorizes the CertificateSigningRequest req for a node's client or server certificate.
+// csr should be the parsed CSR from req.Spec.Request.
+//
+// For client certificates:
+// The only information contained in the CSR is the future name of the node.  Thus we perform a best effort check:
+//
+// 1. User is the node bootstrapper
+// 2. Node does not exist
+// 3. Use machine API internal DNS to locate matching machine based on node name
+// 4. CSR is meant for node client auth based on usage, CN, etc
+//
+// For server certificates:
+// Names contained in the CSR are checked against addresses in the corresponding node's machine status.
+func authorizeCSR(
+	machines []machineapi.Machine,
+	client kubeclient.Interface,
+	req *certificatesv1beta1.CertificateSigningRequest,
+	csr *x509.CertificateRequest,
+) error {
+	if req == nil || csr == nil {
+		return fmt.Errorf("Invalid request")
+	}
+
+	if isNodeClientCert(req, csr) {
+		return authorizeNodeClientCSR(machines, client, req, csr)
+	}
+
+	// node serving cert validation after this point
+
+	nodeAsking, err := validateCSRContents(req, csr)
+	if err != nil {
+		return err
+	}
+
+	// Check that we have a registered node with the request name
+	targetMachine, ok := findMatchingMachineFromNodeRef(nodeAsking, machines)
+	if !ok {
+		return fmt.Errorf("No target machine for node %q", nodeAsking)
+	}
+
+	// SAN checks for both DNS and IPs, e.g.,
+	// DNS:ip-10-0-152-205, DNS:ip-10-0-152-205.ec2.internal, IP Address:10.0.152.205, IP Address:10.0.152.205
+	// All names in the request must correspond to addresses assigned to a single machine.
+	for _, san := range csr.DNSNames {
+		if len(san) == 0 {
+			continue
+		}
+		if !machineHasAddress(targetMachine, san, corev1.NodeInternalDNS, corev1.NodeExternalDNS, corev1.NodeHostName) {
+			return fmt.Errorf("DNS name '%s' not in machine names", san)
+		}
+	}
+
+	for _, san := range csr.IPAddresses {
+		if len(san) == 0 {
+			continue
+		}
+		if !machineHasAddress(targetMachine, san.String(), corev1.NodeInternalIP, corev1.NodeExternalIP) {
+			return fmt.Errorf("IP address '%s' not in machine addresses", san)
+		}
+	}
+
+	return nil
+}
+
+func machineHasAddress(machine machineapi.Machine, address string, types ...corev1.NodeAddressType) bool {
+	for _, addr := range machine.Status.Addresses {
+		for _, t := range types {
+			if addr.Type == t && addr.Address == address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateCSRContents(req *certificatesv1beta1.CertificateSigningRequest, csr *x509.CertificateRequest) (string, error) {
+	if !strings.HasPrefix(req.Spec.Username, nodeUserPrefix) {
+		return "", fmt.Errorf("%q doesn't match expected prefix: %q", req.Spec.Username, nodeUserPrefix)
+	}
+
+	nodeAsking := strings.TrimPrefix(req.Spec.Username, nodeUserPrefix)
+	if len(nodeAsking) == 0 {
+		return "", fmt.Errorf("Empty name")
+	}
+
+	// Check groups, we need at least:
+	// - system:nodes
+	// - system:authenticated
+	if len(req.Spec.Groups) < 2 {
+		return "", fmt.Errorf("Too few groups")
+	}
+	groupSet := sets.NewString(req.Spec.Groups...)
+	if !groupSet.HasAll(nodeGroup, "system:authenticated") {
+		return "", fmt.Errorf("%q not in %q and %q", groupSet, "system:authenticated", nodeGroup)
+	}
+
+	// Check usages, we need only:
+	// - digital signature
+	// - key encipherment
+	// - server auth
+	if !hasExactUsages(req, kubeletServerUsages) {
+		return "", fmt.Errorf("Unexpected usages: %v", req.Spec.Usages)
+	}
+
+	// Check subject: O = system:nodes, CN = system:node:ip-10-0-152-205.ec2.internal
+	if csr.Subject.CommonName != req.Spec.Username {
+		return "", fmt.Errorf("Mismatched CommonName %s != %s", csr.Subject.CommonName, req.Spec.Username)
+	}
+
+	var hasOrg bool
+	for i := range csr.Subject.Organization {
+		if csr.Subject.Organization[i] == nodeGroup {
+			hasOrg = true
+			break
+		}
+	}
+	if !hasOrg {
+		return "", fmt.Errorf("Organization %v doesn't include %s", csr.Subject.Organization, nodeGroup)
+	}
+
+	return nodeAsking, nil
+}
+
+func authorizeNodeClientCSR(machines []machineapi.Machine, client kubeclient.Interface, req *certificatesv1beta1.CertificateSigningRequest, csr *x509.CertificateRequest) error {
+	if !isReqFromNodeBootstrapper(req) {
+		return fmt.Errorf("CSR %s for node client cert has wrong user %s or groups %s", req.Name, req.Spec.Username, sets.NewString(req.Spec.Groups...))
+	}
+
+	nodeName := strings.TrimPrefix(csr.Subject.CommonName, nodeUserPrefix)
+	if len(nodeName) == 0 {
+		return fmt.Errorf("CSR %s has empty node name", req.Name)
+	}
+
+	_, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		return fmt.Errorf("node %s already exists, cannot approve client CSR", nodeName)
+	case apierrors.IsNotFound(err):
+		// good, the node does not exist yet
+	default:
+		return fmt.Errorf("failed to check if node %s already exists: %v", nodeName, err)
+	}
+
+	if _, ok := findMatchingMachineFromInternalDNS(nodeName, machines); !ok {
+		return fmt.Errorf("failed to find machine for node %s", nodeName)
+	}
+
+	return nil // approve node client cert
+}
+
+func isReqFromNodeBootstrapper(req *certificatesv1beta1.CertificateSigningRequest) bool {
+	return req.Spec.Username == nodeBootstrapperUsername &&
+		nodeBootstrapperGroups.Equal(sets.NewString(req.Spec.Groups...))
+}
+
+func findMatchingMachineFromNodeRef(nodeName string, machines []machineapi.Machine) (machineapi.Machine, bool) {
+	for _, machine := range machines {
+		if machine.Status.NodeRef != nil && machine.Status.NodeRef.Name == nodeName {
+			return machine, true
+		}
+	}
+	return machineapi.Machine{}, false
+}
+
+func findMatchingMachineFromInternalDNS(nodeName string, machines []machineapi.Machine) (machineapi.Machine, bool) {
+	for _, machine := range machines {
+		if machineHasAddress(machine, nodeName, corev1.NodeInternalDNS) {
+			return machine, true
+		}
+	}
+	return machineapi.Machine{}, false
+}
+
+func keyUsageSliceToStringSlice(usages []certificatesv1beta1.KeyUsage) []string {
+	result := make([]string, len(usages))
+	for i := range usages {
+		result[i] = string(usages[i])
+	}
+	return result
+}
+
+func hasExactUsages(csr *certificatesv1beta1.CertificateSigningRequest, usages []certificatesv1beta1.KeyUsage) bool {
+	return sets.NewString(keyUsageSliceToStringSlice(csr.Spec.Usages)...).Equal(sets.NewString(keyUsageSliceToStringSlice(usages)...))
+}
+
+func isNodeClientCert(csr *certificatesv1beta1.CertificateSigningRequest, x509cr *x509.CertificateRequest) bool {
+	if !reflect.DeepEqual([]string{nodeGroup}, x509cr.Subject.Organization) {
+		return false
+	}
+	if (len(x509cr.DNSNames) > 0) || (len(x509cr.EmailAddresses) > 0) || (len(x509cr.IPAddresses) > 0) {
+		return false
+	}
+	if !hasExactUsages(csr, kubeletClientUsages) {
+		return false
+	}
+	if !strings.HasPrefix(x509cr.Subject.CommonName, nodeUserPrefix) {
+		return false
+	}
+	return true
+}
@@ -177,6 +177,12 @@ func (r *ReconcileMachineManagement) reconcile(request reconcile.Request, cd *hi
 	if !cd.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(cd, hivev1.FinalizerMachineManagementTargetNamespace) {
 			if cd.Spec.MachineManagement != nil && cd.Spec.MachineManagement.TargetNamespace != "" {
+				// Release the machines, which would otherwise keep the namespace from being deleted
+				if err := r.removeMachineFinalizers(cd, cdLog); err != nil {
+					cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to remove machine finalizers")
+					return reconcile.Result{}, err
+				}
+
 				// Clean up namespace
 				cdLog.Info("Deleting target namespace ", cd.Spec.MachineManagement.TargetNamespace)
 				ns := &corev1.Namespace{
@@ -240,6 +246,15 @@ func (r *ReconcileMachineManagement) reconcile(request reconcile.Request, cd *hi
 				return reconcile.Result{}, err
 			}
 		}
+
+		// Run the machines from the target namespace and register their nodes with the cluster
+		resync, err := r.reconcileCentralMachines(cd, cdLog)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if resync {
+			return reconcile.Result{RequeueAfter: centralSyncInterval}, nil
+		}
 	}
 	return reconcile.Result{}, nil
 }
@@ -24,6 +24,7 @@ import (
 
 	openshiftapiv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -70,6 +71,7 @@ func TestMachineManagementReconcile(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	openshiftapiv1.Install(scheme.Scheme)
 	routev1.Install(scheme.Scheme)
+	machineapi.AddToScheme(scheme.Scheme)
 
 	getCD := func(c client.Client) *hivev1.ClusterDeployment {
 		cd := &hivev1.ClusterDeployment{}
@@ -76,6 +76,10 @@ var (
 		Name: "hive_syncsets_unapplied_total",
 		Help: "Total number of SyncSetsInstances referencing non-selector SyncSets that have not successfully applied all resources/patches/secrets.",
 	})
+	metricSyncSetDriftedResourcesTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_syncset_drifted_resources_total",
+		Help: "Total number of resources across all clusters that have drifted from each SyncSet or SelectorSyncSet with the Audit apply behavior.",
+	}, []string{"kind", "namespace", "name"})
 
 	// MetricClusterDeploymentDeprovisioningUnderwaySeconds is a prometheus metric for the number of seconds
 	// between when a still deprovisioning cluster was created and now.
@@ -138,6 +142,7 @@ func init() {
 	metrics.Registry.MustRegister(metricSelectorSyncSetClustersUnappliedTotal)
 	metrics.Registry.MustRegister(metricSyncSetsTotal)
 	metrics.Registry.MustRegister(metricSyncSetsUnappliedTotal)
+	metrics.Registry.MustRegister(metricSyncSetDriftedResourcesTotal)
 	metrics.Registry.MustRegister(metricControllerReconcileTime)
 
 	metrics.Registry.MustRegister(MetricClusterDeploymentDeprovisioningUnderwaySeconds)
@@ -332,18 +337,26 @@ func (mc *Calculator) calculateSelectorSyncSetMetrics(mcLog log.FieldLogger) {
 
 	ssInstancesTotal := 0
 	ssInstancesUnappliedTotal := 0
+	// Drifted resources keyed by the kind, namespace and name of the syncset
+	driftedResourcesTotal := map[[3]string]int{}
 	for _, cs := range clusterSyncList.Items {
 		for _, sss := range cs.Status.SelectorSyncSets {
 			sssInstancesTotal[sss.Name]++
 			if sss.Result != hiveintv1alpha1.SuccessSyncSetResult {
 				sssInstancesUnappliedTotal[sss.Name]++
 			}
+			if len(sss.DriftedResources) > 0 {
+				driftedResourcesTotal[[3]string{"SelectorSyncSet", "", sss.Name}] += len(sss.DriftedResources)
+			}
 		}
 		for _, ss := range cs.Status.SyncSets {
 			ssInstancesTotal++
 			if ss.Result != hiveintv1alpha1.SuccessSyncSetResult {
 				ssInstancesUnappliedTotal++
 			}
+			if len(ss.DriftedResources) > 0 {
+				driftedResourcesTotal[[3]string{"SyncSet", cs.Namespace, ss.Name}] += len(ss.DriftedResources)
+			}
 		}
 	}
 	for k, v := range sssInstancesTotal {
@@ -363,6 +376,11 @@ func (mc *Calculator) calculateSelectorSyncSetMetrics(mcLog log.FieldLogger) {
 	}
 	metricSyncSetsTotal.Set(float64(ssInstancesTotal))
 	metricSyncSetsUnappliedTotal.Set(float64(ssInstancesUnappliedTotal))
+	// Clear the metric for syncsets that no longer have drifted resources.
+	metricSyncSetDriftedResourcesTotal.Reset()
+	for k, v := range driftedResourcesTotal {
+		metricSyncSetDriftedResourcesTotal.WithLabelValues(k[0], k[1], k[2]).Set(float64(v))
+	}
 }
 
 func processJobs(jobs []batchv1.Job) (runningTotal, succeededTotal, failedTotal map[string]int) {
@@ -0,0 +1,376 @@
+package remotemachineset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// agentHostsResyncInterval is the interval at which the Agents of a machine pool are checked while hosts are
+	// missing or being installed, as the Agents are not watched.
+	agentHostsResyncInterval = time.Minute
+
+	// agentInstalledCondition is the condition of Agents that is true once the host has been installed.
+	agentInstalledCondition = "Installed"
+
+	// agentBareMetalHostLabel is the label of Agents with the name of the BareMetalHost of the host.
+	agentBareMetalHostLabel = "agent-install.openshift.io/bmh"
+
+	// bareMetalHostRebootAnnotation is the annotation that has the bare metal operator reboot a BareMetalHost.
+	bareMetalHostRebootAnnotation = "reboot.metal3.io"
+)
+
+var (
+	agentGVK         = schema.GroupVersionKind{Group: "agent-install.openshift.io", Version: "v1beta1", Kind: "Agent"}
+	bareMetalHostGVK = schema.GroupVersionKind{Group: "metal3.io", Version: "v1alpha1", Kind: "BareMetalHost"}
+)
+
+// agentActuator scales the machine pools of clusters installed with the agent install strategy by binding inventory
+// hosts to the cluster, and unbinding them, rather than through MachineSets. An Agent is bound to the cluster by
+// setting its clusterDeploymentName, and belongs to the machine pool through the machine pool name label. Only the
+// Agents in the namespace of the cluster deployment are considered.
+type agentActuator struct {
+	client       client.Client
+	remoteClient client.Client
+	logger       log.FieldLogger
+}
+
+// agentHost is the state of the Agent of an inventory host.
+type agentHost struct {
+	agent     *unstructured.Unstructured
+	hostname  string
+	installed bool
+}
+
+func newAgentHost(agent *unstructured.Unstructured) *agentHost {
+	h := &agentHost{agent: agent}
+	h.hostname, _, _ = unstructured.NestedString(agent.Object, "spec", "hostname")
+	if h.hostname == "" {
+		h.hostname, _, _ = unstructured.NestedString(agent.Object, "status", "inventory", "hostname")
+	}
+	conditions, _, _ := unstructured.NestedSlice(agent.Object, "status", "conditions")
+	for _, c := range conditions {
+		if c, ok := c.(map[string]interface{}); ok && c["type"] == agentInstalledCondition {
+			h.installed = c["status"] == string(corev1.ConditionTrue)
+		}
+	}
+	return h
+}
+
+// boundTo returns whether the Agent is bound to the cluster deployment, and whether it is bound to any.
+func (h *agentHost) boundTo(cd *hivev1.ClusterDeployment) (toCD, toAny bool) {
+	ref, found, _ := unstructured.NestedStringMap(h.agent.Object, "spec", "clusterDeploymentName")
+	if !found || ref["name"] == "" {
+		return false, false
+	}
+	return ref["name"] == cd.Name && ref["namespace"] == cd.Namespace, true
+}
+
+// syncHosts binds and unbinds hosts to reach the replicas of the machine pool. It returns the hosts bound for the
+// machine pool, and the number of hosts missing for lack of unbound hosts matching the agent selector.
+func (a *agentActuator) syncHosts(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool) ([]*agentHost, int, error) {
+	bound, err := a.listHosts(cd, client.MatchingLabels{machinePoolNameLabel: pool.Spec.Name})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not list the Agents of the machine pool")
+	}
+	var poolHosts []*agentHost
+	for _, h := range bound {
+		if toCD, _ := h.boundTo(cd); toCD {
+			poolHosts = append(poolHosts, h)
+		}
+	}
+
+	replicas := 0
+	if pool.DeletionTimestamp == nil {
+		replicas = 1
+		if pool.Spec.Replicas != nil {
+			replicas = int(*pool.Spec.Replicas)
+		}
+	}
+
+	// Unbind the hosts that are not yet installed first, then the last ones by name.
+	sort.SliceStable(poolHosts, func(i, j int) bool {
+		if poolHosts[i].installed != poolHosts[j].installed {
+			return poolHosts[i].installed
+		}
+		return agentKey(poolHosts[i]) < agentKey(poolHosts[j])
+	})
+	for len(poolHosts) > replicas {
+		h := poolHosts[len(poolHosts)-1]
+		if err := a.unbind(h); err != nil {
+			return nil, 0, err
+		}
+		poolHosts = poolHosts[:len(poolHosts)-1]
+	}
+	if len(poolHosts) == replicas {
+		return poolHosts, 0, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&pool.Spec.Platform.Agent.AgentSelector)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "invalid agent selector")
+	}
+	candidates, err := a.listHosts(cd, client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not list the Agents matching the agent selector")
+	}
+	sort.Slice(candidates, func(i, j int) bool { return agentKey(candidates[i]) < agentKey(candidates[j]) })
+	for _, h := range candidates {
+		if len(poolHosts) == replicas {
+			break
+		}
+		if _, toAny := h.boundTo(cd); toAny {
+			continue
+		}
+		if _, ok := h.agent.GetLabels()[machinePoolNameLabel]; ok {
+			continue
+		}
+		if err := a.bind(cd, pool, h); err != nil {
+			return nil, 0, err
+		}
+		poolHosts = append(poolHosts, h)
+	}
+	return poolHosts, replicas - len(poolHosts), nil
+}
+
+// listHosts lists the hosts whose Agents are in the namespace of the cluster deployment.
+func (a *agentActuator) listHosts(cd *hivev1.ClusterDeployment, opts ...client.ListOption) ([]*agentHost, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(agentGVK.GroupVersion().WithKind(agentGVK.Kind + "List"))
+	opts = append(opts, client.InNamespace(cd.Namespace))
+	if err := a.client.List(context.TODO(), list, opts...); err != nil {
+		return nil, err
+	}
+	hosts := make([]*agentHost, len(list.Items))
+	for i := range list.Items {
+		hosts[i] = newAgentHost(&list.Items[i])
+	}
+	return hosts, nil
+}
+
+func (a *agentActuator) bind(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, h *agentHost) error {
+	logger := a.logger.WithField("agent", agentKey(h))
+	logger.Info("binding host to the cluster")
+	labels := h.agent.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[machinePoolNameLabel] = pool.Spec.Name
+	h.agent.SetLabels(labels)
+	if err := unstructured.SetNestedStringMap(h.agent.Object, map[string]string{"name": cd.Name, "namespace": cd.Namespace}, "spec", "clusterDeploymentName"); err != nil {
+		return errors.Wrap(err, "could not set the cluster deployment of the Agent")
+	}
+	if err := unstructured.SetNestedField(h.agent.Object, "worker", "spec", "role"); err != nil {
+		return errors.Wrap(err, "could not set the role of the Agent")
+	}
+	if err := unstructured.SetNestedField(h.agent.Object, true, "spec", "approved"); err != nil {
+		return errors.Wrap(err, "could not approve the Agent")
+	}
+	if err := a.client.Update(context.TODO(), h.agent); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not bind host")
+		return errors.Wrap(err, "could not bind host")
+	}
+	return nil
+}
+
+// unbind releases the host back to the inventory. An installed host has its node deleted from the remote cluster, and
+// is rebooted into the discovery image so that its Agent registers again and it can be bound to another cluster.
+func (a *agentActuator) unbind(h *agentHost) error {
+	logger := a.logger.WithField("agent", agentKey(h))
+	logger.Info("unbinding host from the cluster")
+	labels := h.agent.GetLabels()
+	delete(labels, machinePoolNameLabel)
+	h.agent.SetLabels(labels)
+	unstructured.RemoveNestedField(h.agent.Object, "spec", "clusterDeploymentName")
+	if err := a.client.Update(context.TODO(), h.agent); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not unbind host")
+		return errors.Wrap(err, "could not unbind host")
+	}
+	if !h.installed || h.hostname == "" {
+		return nil
+	}
+	logger.WithField("node", h.hostname).Info("deleting node of unbound host")
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: h.hostname}}
+	if err := a.remoteClient.Delete(context.TODO(), node); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete node of unbound host")
+		return errors.Wrap(err, "could not delete node of unbound host")
+	}
+	return a.reboot(h, logger)
+}
+
+// reboot reboots an unbound host through its BareMetalHost. Hosts without a BareMetalHost cannot be rebooted by Hive,
+// and stay installed until they are rebooted into the discovery image by other means.
+func (a *agentActuator) reboot(h *agentHost, logger log.FieldLogger) error {
+	bmhName := h.agent.GetLabels()[agentBareMetalHostLabel]
+	if bmhName == "" {
+		logger.Warn("unbound host has no BareMetalHost, it must be rebooted into the discovery image to be reused")
+		return nil
+	}
+	logger = logger.WithField("bareMetalHost", bmhName)
+	bmh := &unstructured.Unstructured{}
+	bmh.SetGroupVersionKind(bareMetalHostGVK)
+	switch err := a.client.Get(context.TODO(), client.ObjectKey{Namespace: h.agent.GetNamespace(), Name: bmhName}, bmh); {
+	case apierrors.IsNotFound(err):
+		logger.Warn("BareMetalHost of unbound host not found, it must be rebooted into the discovery image to be reused")
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get BareMetalHost of unbound host")
+		return errors.Wrap(err, "could not get BareMetalHost of unbound host")
+	}
+	annotations := bmh.GetAnnotations()
+	if _, ok := annotations[bareMetalHostRebootAnnotation]; ok {
+		return nil
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[bareMetalHostRebootAnnotation] = ""
+	bmh.SetAnnotations(annotations)
+	logger.Info("rebooting unbound host")
+	if err := a.client.Update(context.TODO(), bmh); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not reboot unbound host")
+		return errors.Wrap(err, "could not reboot unbound host")
+	}
+	return nil
+}
+
+// syncNodes applies the labels and taints of the machine pool to the nodes of the installed hosts.
+func (a *agentActuator) syncNodes(pool *hivev1.MachinePool, hosts []*agentHost) error {
+	for _, h := range hosts {
+		if !h.installed || h.hostname == "" {
+			continue
+		}
+		node := &corev1.Node{}
+		switch err := a.remoteClient.Get(context.TODO(), client.ObjectKey{Name: h.hostname}, node); {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return errors.Wrap(err, "could not get node of host")
+		}
+		changed := false
+		for k, v := range pool.Spec.Labels {
+			if value, ok := node.Labels[k]; !ok || value != v {
+				if node.Labels == nil {
+					node.Labels = map[string]string{}
+				}
+				node.Labels[k] = v
+				changed = true
+			}
+		}
+		for i := range pool.Spec.Taints {
+			taint := &pool.Spec.Taints[i]
+			found := false
+			for _, nodeTaint := range node.Spec.Taints {
+				if nodeTaint.MatchTaint(taint) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				node.Spec.Taints = append(node.Spec.Taints, *taint)
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		a.logger.WithField("node", node.Name).Info("updating labels and taints of node")
+		if err := a.remoteClient.Update(context.TODO(), node); err != nil {
+			return errors.Wrap(err, "could not update node of host")
+		}
+	}
+	return nil
+}
+
+func agentKey(h *agentHost) string {
+	return h.agent.GetNamespace() + "/" + h.agent.GetName()
+}
+
+// reconcileAgentMachinePool reconciles a machine pool of a cluster installed with the agent install strategy.
+func (r *ReconcileRemoteMachineSet) reconcileAgentMachinePool(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) (reconcile.Result, error) {
+	if pool.Spec.Platform.Agent == nil {
+		logger.Error("machine pool of an agent cluster has no agent platform")
+		return reconcile.Result{}, nil
+	}
+	actuator := &agentActuator{
+		client:       r.Client,
+		remoteClient: remoteClusterAPIClient,
+		logger:       logger,
+	}
+	hosts, missing, err := actuator.syncHosts(cd, pool)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not sync hosts")
+		return reconcile.Result{}, err
+	}
+	if pool.DeletionTimestamp != nil {
+		return r.removeFinalizer(pool, logger)
+	}
+	if err := actuator.syncNodes(pool, hosts); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not sync nodes of hosts")
+		return reconcile.Result{}, err
+	}
+
+	origPool := pool.DeepCopy()
+	pool.Status.Replicas = int32(len(hosts))
+	pool.Status.MachineSets = nil
+	pool.Status.Machines = nil
+	pool.Status.Hosts = nil
+	resync := missing > 0
+	for _, h := range hosts {
+		pool.Status.Hosts = append(pool.Status.Hosts, hivev1.MachinePoolHostStatus{
+			Name:      h.agent.GetName(),
+			Namespace: h.agent.GetNamespace(),
+			Hostname:  h.hostname,
+			Installed: h.installed,
+		})
+		resync = resync || !h.installed
+	}
+	sort.Slice(pool.Status.Hosts, func(i, j int) bool {
+		return pool.Status.Hosts[i].Namespace+"/"+pool.Status.Hosts[i].Name < pool.Status.Hosts[j].Namespace+"/"+pool.Status.Hosts[j].Name
+	})
+	status, reason, message := corev1.ConditionFalse, "EnoughHosts", "The machine pool has enough hosts"
+	if missing > 0 {
+		status, reason = corev1.ConditionTrue, "InventoryExhausted"
+		message = fmt.Sprintf("%d more hosts are needed, but no more unbound hosts match the agent selector", missing)
+	}
+	pool.Status.Conditions = controllerutils.SetMachinePoolCondition(
+		pool.Status.Conditions,
+		hivev1.InsufficientHostsMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !reflect.DeepEqual(origPool.Status, pool.Status) {
+		if err := r.Status().Update(context.TODO(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update pool status")
+			return reconcile.Result{}, err
+		}
+	}
+	if resync {
+		return reconcile.Result{RequeueAfter: agentHostsResyncInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
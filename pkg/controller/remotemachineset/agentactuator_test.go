@@ -0,0 +1,332 @@
+package remotemachineset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+func TestRemoteMachineSetReconcileAgent(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	addAgentTypesToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name                 string
+		replicas             int64
+		deleted              bool
+		agents               []runtime.Object
+		remoteExisting       []runtime.Object
+		expectedBound        []string
+		expectedHosts        []hivev1.MachinePoolHostStatus
+		expectedInsufficient corev1.ConditionStatus
+		expectedRequeue      bool
+		expectedNodes        []string
+		expectedLabeledNodes []string
+		expectedRebooted     []string
+	}{
+		{
+			name:     "bind hosts",
+			replicas: 2,
+			agents: []runtime.Object{
+				testAgent("agent-1", true, "", "", false),
+				testAgent("agent-2", true, "", "", false),
+				testAgent("agent-3", true, "", "", false),
+				testAgent("other-cluster", true, "other", "", false),
+				testAgent("not-selected", false, "", "", false),
+				inNamespace(testAgent("other-namespace", true, "", "", false), "other"),
+			},
+			expectedBound: []string{"agent-1", "agent-2"},
+			expectedHosts: []hivev1.MachinePoolHostStatus{
+				{Name: "agent-1", Namespace: testNamespace, Hostname: "agent-1.example.com"},
+				{Name: "agent-2", Namespace: testNamespace, Hostname: "agent-2.example.com"},
+			},
+			expectedInsufficient: corev1.ConditionFalse,
+			expectedRequeue:      true,
+		},
+		{
+			name:     "inventory exhausted",
+			replicas: 3,
+			agents: []runtime.Object{
+				testAgent("agent-1", true, testName, testPoolName, true),
+				testAgent("agent-2", true, "", "", false),
+				testAgent("other-cluster", true, "other", "", false),
+			},
+			remoteExisting: []runtime.Object{testNode("agent-1.example.com", corev1.ConditionTrue)},
+			expectedBound:  []string{"agent-1", "agent-2"},
+			expectedHosts: []hivev1.MachinePoolHostStatus{
+				{Name: "agent-1", Namespace: testNamespace, Hostname: "agent-1.example.com", Installed: true},
+				{Name: "agent-2", Namespace: testNamespace, Hostname: "agent-2.example.com"},
+			},
+			expectedInsufficient: corev1.ConditionTrue,
+			expectedRequeue:      true,
+			expectedNodes:        []string{"agent-1.example.com"},
+			expectedLabeledNodes: []string{"agent-1.example.com"},
+		},
+		{
+			name:     "unbind hosts not yet installed first",
+			replicas: 1,
+			agents: []runtime.Object{
+				testAgent("agent-1", true, testName, testPoolName, false),
+				testAgent("agent-2", true, testName, testPoolName, true),
+			},
+			remoteExisting: []runtime.Object{testNode("agent-2.example.com", corev1.ConditionTrue)},
+			expectedBound:  []string{"agent-2"},
+			expectedHosts: []hivev1.MachinePoolHostStatus{
+				{Name: "agent-2", Namespace: testNamespace, Hostname: "agent-2.example.com", Installed: true},
+			},
+			expectedInsufficient: corev1.ConditionFalse,
+			expectedNodes:        []string{"agent-2.example.com"},
+			expectedLabeledNodes: []string{"agent-2.example.com"},
+		},
+		{
+			name:     "unbind installed host and delete its node",
+			replicas: 1,
+			agents: []runtime.Object{
+				testAgent("agent-1", true, testName, testPoolName, true),
+				testAgent("agent-2", true, testName, testPoolName, true),
+				testAgent("initial-worker", true, testName, "", true),
+			},
+			remoteExisting: []runtime.Object{
+				testNode("agent-1.example.com", corev1.ConditionTrue),
+				testNode("agent-2.example.com", corev1.ConditionTrue),
+				testNode("initial-worker.example.com", corev1.ConditionTrue),
+			},
+			expectedBound: []string{"agent-1"},
+			expectedHosts: []hivev1.MachinePoolHostStatus{
+				{Name: "agent-1", Namespace: testNamespace, Hostname: "agent-1.example.com", Installed: true},
+			},
+			expectedInsufficient: corev1.ConditionFalse,
+			expectedNodes:        []string{"agent-1.example.com", "initial-worker.example.com"},
+			expectedLabeledNodes: []string{"agent-1.example.com"},
+		},
+		{
+			name:     "unbind installed host and reboot it",
+			replicas: 0,
+			agents: []runtime.Object{
+				withBareMetalHost(testAgent("agent-1", true, testName, testPoolName, true), "bmh-1"),
+				testBareMetalHost("bmh-1"),
+			},
+			remoteExisting:       []runtime.Object{testNode("agent-1.example.com", corev1.ConditionTrue)},
+			expectedInsufficient: corev1.ConditionFalse,
+			expectedRebooted:     []string{"bmh-1"},
+		},
+		{
+			name:     "ignore hosts in other namespaces",
+			replicas: 1,
+			agents: []runtime.Object{
+				inNamespace(testAgent("agent-1", true, testName, testPoolName, true), "other"),
+				inNamespace(testAgent("agent-2", true, "", "", false), "other"),
+			},
+			// The agent in the other namespace is neither part of the pool nor unbound.
+			expectedBound:        []string{"agent-1"},
+			expectedInsufficient: corev1.ConditionTrue,
+			expectedRequeue:      true,
+		},
+		{
+			name:     "unbind hosts of deleted pool",
+			replicas: 2,
+			deleted:  true,
+			agents: []runtime.Object{
+				testAgent("agent-1", true, testName, testPoolName, false),
+				testAgent("agent-2", true, testName, testPoolName, false),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testAgentMachinePool(tc.replicas)
+			if tc.deleted {
+				now := metav1.Now()
+				pool.DeletionTimestamp = &now
+			}
+			c := fake.NewClientBuilder().WithRuntimeObjects(append([]runtime.Object{testAgentClusterDeployment(), pool}, tc.agents...)...).Build()
+			remoteClient := fake.NewClientBuilder().WithRuntimeObjects(tc.remoteExisting...).Build()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
+
+			logger := log.WithField("controller", "remotemachineset")
+			r := &ReconcileRemoteMachineSet{
+				Client:                        c,
+				scheme:                        scheme.Scheme,
+				logger:                        logger,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				expectations:                  controllerutils.NewExpectations(logger),
+			}
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: pool.Name, Namespace: testNamespace},
+			})
+			require.NoError(t, err)
+
+			agents := &unstructured.UnstructuredList{}
+			agents.SetGroupVersionKind(agentGVK.GroupVersion().WithKind("AgentList"))
+			require.NoError(t, c.List(context.TODO(), agents))
+			var bound []string
+			for i := range agents.Items {
+				agent := &agents.Items[i]
+				ref, _, _ := unstructured.NestedStringMap(agent.Object, "spec", "clusterDeploymentName")
+				poolName, inPool := agent.GetLabels()[machinePoolNameLabel]
+				if ref["name"] == testName && inPool {
+					assert.Equal(t, testPoolName, poolName, "unexpected machine pool of agent %s", agent.GetName())
+					role, _, _ := unstructured.NestedString(agent.Object, "spec", "role")
+					assert.Equal(t, "worker", role, "unexpected role of agent %s", agent.GetName())
+					bound = append(bound, agent.GetName())
+				} else {
+					assert.False(t, inPool, "unexpected machine pool label on unbound agent %s", agent.GetName())
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedBound, bound, "unexpected bound agents")
+
+			bmhs := &unstructured.UnstructuredList{}
+			bmhs.SetGroupVersionKind(bareMetalHostGVK.GroupVersion().WithKind("BareMetalHostList"))
+			require.NoError(t, c.List(context.TODO(), bmhs))
+			var rebooted []string
+			for _, bmh := range bmhs.Items {
+				if _, ok := bmh.GetAnnotations()[bareMetalHostRebootAnnotation]; ok {
+					rebooted = append(rebooted, bmh.GetName())
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedRebooted, rebooted, "unexpected rebooted hosts")
+
+			nodes := &corev1.NodeList{}
+			require.NoError(t, remoteClient.List(context.TODO(), nodes))
+			var nodeNames, labeled []string
+			for _, node := range nodes.Items {
+				nodeNames = append(nodeNames, node.Name)
+				if _, ok := node.Labels["node-role.kubernetes.io/infra"]; ok && len(node.Spec.Taints) == 1 {
+					labeled = append(labeled, node.Name)
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedNodes, nodeNames, "unexpected nodes")
+			assert.ElementsMatch(t, tc.expectedLabeledNodes, labeled, "unexpected labeled nodes")
+
+			updatedPool := &hivev1.MachinePool{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: pool.Name}, updatedPool)
+			if tc.deleted {
+				if err == nil {
+					assert.NotContains(t, updatedPool.Finalizers, finalizer, "expected finalizer to be removed")
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHosts, updatedPool.Status.Hosts, "unexpected hosts")
+			assert.Equal(t, int32(len(tc.expectedHosts)), updatedPool.Status.Replicas, "unexpected replicas")
+			cond := controllerutils.FindMachinePoolCondition(updatedPool.Status.Conditions, hivev1.InsufficientHostsMachinePoolCondition)
+			if assert.NotNil(t, cond, "missing insufficient hosts condition") {
+				assert.Equal(t, tc.expectedInsufficient, cond.Status, "unexpected insufficient hosts condition")
+			}
+			if tc.expectedRequeue {
+				assert.Equal(t, agentHostsResyncInterval, result.RequeueAfter, "expected requeue")
+			} else {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			}
+		})
+	}
+}
+
+func addAgentTypesToScheme(s *runtime.Scheme) {
+	for _, gvk := range []schema.GroupVersionKind{agentGVK, bareMetalHostGVK} {
+		if s.Recognizes(gvk) {
+			continue
+		}
+		s.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		s.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+}
+
+func testAgentClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform = hivev1.Platform{
+		AgentBareMetal: &hivev1agent.BareMetalPlatform{},
+	}
+	return cd
+}
+
+func testAgentMachinePool(replicas int64) *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Name = fmt.Sprintf("%s-infra", testName)
+	pool.Spec.Name = testPoolName
+	pool.Spec.Replicas = pointer.Int64Ptr(replicas)
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		Agent: &hivev1agent.MachinePool{
+			AgentSelector: metav1.LabelSelector{MatchLabels: map[string]string{"location": "rack-1"}},
+		},
+	}
+	pool.Spec.Labels = map[string]string{"node-role.kubernetes.io/infra": ""}
+	return pool
+}
+
+func testAgent(name string, selected bool, clusterDeployment, poolName string, installed bool) *unstructured.Unstructured {
+	agent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"hostname": name + ".example.com",
+		},
+	}}
+	agent.SetGroupVersionKind(agentGVK)
+	agent.SetNamespace(testNamespace)
+	agent.SetName(name)
+	labels := map[string]string{}
+	if selected {
+		labels["location"] = "rack-1"
+	}
+	if poolName != "" {
+		labels[machinePoolNameLabel] = poolName
+	}
+	agent.SetLabels(labels)
+	if clusterDeployment != "" {
+		unstructured.SetNestedStringMap(agent.Object, map[string]string{"name": clusterDeployment, "namespace": testNamespace}, "spec", "clusterDeploymentName")
+		unstructured.SetNestedField(agent.Object, "worker", "spec", "role")
+	}
+	if installed {
+		unstructured.SetNestedSlice(agent.Object, []interface{}{
+			map[string]interface{}{"type": agentInstalledCondition, "status": "True"},
+		}, "status", "conditions")
+	}
+	return agent
+}
+
+func inNamespace(agent *unstructured.Unstructured, namespace string) *unstructured.Unstructured {
+	agent.SetNamespace(namespace)
+	return agent
+}
+
+func withBareMetalHost(agent *unstructured.Unstructured, bmhName string) *unstructured.Unstructured {
+	labels := agent.GetLabels()
+	labels[agentBareMetalHostLabel] = bmhName
+	agent.SetLabels(labels)
+	return agent
+}
+
+func testBareMetalHost(name string) *unstructured.Unstructured {
+	bmh := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	bmh.SetGroupVersionKind(bareMetalHostGVK)
+	bmh.SetNamespace(testNamespace)
+	bmh.SetName(name)
+	return bmh
+}
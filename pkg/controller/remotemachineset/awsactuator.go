@@ -219,6 +219,10 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		a.updateProviderConfig(ms, cd.Spec.ClusterMetadata.InfraID, pool)
 	}
 
+	if err := setZoneReplicas(pool, installerMachineSets, computePool.Platform.AWS.Zones); err != nil {
+		return nil, false, err
+	}
+
 	return installerMachineSets, true, nil
 }
 
@@ -73,6 +73,29 @@ func TestAWSActuator(t *testing.T) {
 				generateAWSMachineSetName("zone3"): 1,
 			},
 		},
+		{
+			name:              "generate machinesets with zone distribution",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+						{Zone: "zone1", Replicas: pointer.Int32Ptr(2)},
+						{Zone: "zone2", Weight: pointer.Int32Ptr(0)},
+					}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1", "zone2", "zone3"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 2,
+				generateAWSMachineSetName("zone2"): 0,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+		},
 		{
 			name:              "generate machinesets for specified zones",
 			clusterDeployment: testClusterDeployment(),
@@ -11,12 +11,14 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	azureproviderv1beta1 "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
 
 	installazure "github.com/openshift/installer/pkg/asset/machines/azure"
 	installertypes "github.com/openshift/installer/pkg/types"
 	installertypesazure "github.com/openshift/installer/pkg/types/azure"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/azureclient"
 )
 
@@ -95,7 +97,31 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	if spotVMOptions := pool.Spec.Platform.Azure.SpotVMOptions; spotVMOptions != nil {
+		// The machine API always deallocates evicted Spot VMs, so the eviction policy is not set in the provider spec.
+		if policy := spotVMOptions.EvictionPolicy; policy != "" && policy != hivev1azure.SpotEvictionPolicyDeallocate {
+			return nil, false, fmt.Errorf("unsupported spot VM eviction policy %q", policy)
+		}
+		for _, ms := range installerMachineSets {
+			providerSpec, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureproviderv1beta1.AzureMachineProviderSpec)
+			if !ok {
+				return nil, false, errors.New("unable to convert ProviderSpec to AzureMachineProviderSpec")
+			}
+			providerSpec.SpotVMOptions = &azureproviderv1beta1.SpotVMOptions{
+				MaxPrice: spotVMOptions.MaxPrice,
+			}
+		}
+	}
+
+	if err := setZoneReplicas(pool, installerMachineSets, computePool.Platform.Azure.Zones); err != nil {
+		return nil, false, err
+	}
+
+	return installerMachineSets, true, nil
 }
 
 func (a *AzureActuator) getZones(region string, instanceType string) ([]string, error) {
@@ -26,6 +26,7 @@ func TestAzureActuator(t *testing.T) {
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
 		expectedMachineSetReplicas map[string]int64
+		expectedSpotVMOptions      *azureprovider.SpotVMOptions
 		expectedErr                bool
 	}{
 		{
@@ -99,6 +100,51 @@ func TestAzureActuator(t *testing.T) {
 				generateAzureMachineSetName("zone5"): 0,
 			},
 		},
+		{
+			name:              "generate spot machinesets",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Zones = []string{"zone1"}
+				p.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{
+					MaxPrice:       pointer.StringPtr("0.05"),
+					EvictionPolicy: hivev1azure.SpotEvictionPolicyDeallocate,
+				}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+			expectedSpotVMOptions: &azureprovider.SpotVMOptions{MaxPrice: pointer.StringPtr("0.05")},
+		},
+		{
+			name:              "generate spot machinesets with default eviction policy",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Zones = []string{"zone1"}
+				p.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+			expectedSpotVMOptions: &azureprovider.SpotVMOptions{},
+		},
+		{
+			name:              "unsupported spot eviction policy",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testAzurePool()
+				p.Spec.Platform.Azure.Zones = []string{"zone1"}
+				p.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{EvictionPolicy: "Delete"}
+				return p
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedErr:     true,
+		},
 		{
 			name:              "list zones returns zero",
 			clusterDeployment: testAzureClusterDeployment(),
@@ -131,13 +177,13 @@ func TestAzureActuator(t *testing.T) {
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
-				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedSpotVMOptions)
 			}
 		})
 	}
 }
 
-func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64) {
+func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedSpotVMOptions *azureprovider.SpotVMOptions) {
 	assert.Equal(t, len(expectedMSReplicas), len(mSets), "different number of machine sets generated than expected")
 
 	for _, ms := range mSets {
@@ -149,6 +195,7 @@ func validateAzureMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expe
 		azureProvider, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
 		if assert.True(t, ok, "failed to convert to azureProviderSpec") {
 			assert.Equal(t, testInstanceType, azureProvider.VMSize, "unexpected instance type")
+			assert.Equal(t, expectedSpotVMOptions, azureProvider.SpotVMOptions, "unexpected spot VM options")
 		}
 	}
 }
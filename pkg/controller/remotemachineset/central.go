@@ -0,0 +1,86 @@
+package remotemachineset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	awsproviderv1beta1 "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// centralizeMachineSet moves the MachineSet to the target namespace of the hub, where it is reconciled by the machine
+// API controllers that Hive runs for the cluster, with the credentials of the cluster deployment rather than those of
+// the remote cluster.
+func centralizeMachineSet(ms *machineapi.MachineSet, cd *hivev1.ClusterDeployment, scheme *runtime.Scheme) error {
+	if cd.Spec.Platform.AWS == nil {
+		return errors.New("central machine management is only supported on AWS")
+	}
+	providerSpec := &ms.Spec.Template.Spec.ProviderSpec
+	providerConfig, ok := providerSpec.Value.Object.(*awsproviderv1beta1.AWSMachineProviderConfig)
+	if !ok {
+		var err error
+		if providerConfig, err = decodeAWSMachineProviderSpec(providerSpec.Value, scheme); err != nil {
+			return errors.Wrapf(err, "could not decode provider spec of machineset %s", ms.Name)
+		}
+		providerSpec.Value = &runtime.RawExtension{Object: providerConfig}
+	}
+	ms.Namespace = cd.Spec.MachineManagement.TargetNamespace
+	providerConfig.CredentialsSecret = &corev1.LocalObjectReference{Name: controllerutils.CredentialsSecretName(cd)}
+	return nil
+}
+
+// ensureCentralMachineManagementSupported ensures that the machine pool only uses features supported when the
+// machines of the cluster are managed centrally. Auto-scaling and health checks rely on controllers of the remote
+// cluster that only see the machines of the remote cluster. If the reconcile.Result returned is non-nil, then the
+// reconciliation loop should stop, returning that result.
+func (r *ReconcileRemoteMachineSet) ensureCentralMachineManagementSupported(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	logger log.FieldLogger,
+) (*reconcile.Result, error) {
+	if cd.Spec.MachineManagement.TargetNamespace == "" {
+		logger.Debug("waiting for the target namespace of central machine management")
+		return &reconcile.Result{}, nil
+	}
+	if pool.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	var unsupported []string
+	if pool.Spec.Autoscaling != nil {
+		unsupported = append(unsupported, "auto-scaling")
+	}
+	if pool.Spec.HealthCheck != nil {
+		unsupported = append(unsupported, "health checks")
+	}
+	if len(unsupported) == 0 {
+		return nil, nil
+	}
+	logger.WithField("unsupported", unsupported).Warning("machine pool uses features not supported with central machine management")
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.UnsupportedConfigurationMachinePoolCondition,
+		corev1.ConditionTrue,
+		"UnsupportedCentralMachineManagement",
+		fmt.Sprintf("The machines of the cluster are managed centrally, which does not support %s", strings.Join(unsupported, " and ")),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Error("failed to update MachinePool conditions")
+			return &reconcile.Result{}, err
+		}
+	}
+	return &reconcile.Result{}, nil
+}
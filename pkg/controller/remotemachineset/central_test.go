@@ -0,0 +1,168 @@
+package remotemachineset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	awsproviderapis "sigs.k8s.io/cluster-api-provider-aws/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/remotemachineset/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const testTargetNamespace = "foo-targetns-abcde"
+
+func TestRemoteMachineSetReconcileCentral(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	machineapi.SchemeBuilder.AddToScheme(scheme.Scheme)
+	awsproviderapis.AddToScheme(scheme.Scheme)
+	addMachineConfigTypesToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name                    string
+		clusterDeployment       *hivev1.ClusterDeployment
+		machinePool             *hivev1.MachinePool
+		existing                []runtime.Object
+		generatedMachineSets    []*machineapi.MachineSet
+		expectedMachineSets     []string
+		expectUnsupportedConfig bool
+	}{
+		{
+			name:              "create machinesets in target namespace",
+			clusterDeployment: testCentralClusterDeployment(testTargetNamespace),
+			machinePool:       testMachinePool(),
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+			},
+			expectedMachineSets: []string{"foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"},
+		},
+		{
+			name:              "delete machinesets in target namespace",
+			clusterDeployment: testCentralClusterDeployment(testTargetNamespace),
+			machinePool:       testMachinePool(),
+			existing: []runtime.Object{
+				testCentralMachineSet("foo-12345-worker-us-east-1a"),
+				testCentralMachineSet("foo-12345-worker-us-east-1c"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+			},
+			expectedMachineSets: []string{"foo-12345-worker-us-east-1a"},
+		},
+		{
+			name:              "wait for target namespace",
+			clusterDeployment: testCentralClusterDeployment(""),
+			machinePool:       testMachinePool(),
+		},
+		{
+			name:                    "auto-scaling not supported",
+			clusterDeployment:       testCentralClusterDeployment(testTargetNamespace),
+			machinePool:             testAutoscalingMachinePool(3, 5),
+			expectUnsupportedConfig: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hubExisting := append([]runtime.Object{tc.clusterDeployment, tc.machinePool}, tc.existing...)
+			hubClient := fake.NewClientBuilder().WithRuntimeObjects(hubExisting...).Build()
+			// The remote cluster has a MachineSet of the same name as a MachineSet of the hub, which must be left
+			// untouched.
+			remoteClient := fake.NewClientBuilder().WithRuntimeObjects(
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			).Build()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockActuator := mock.NewMockActuator(mockCtrl)
+			if tc.generatedMachineSets != nil {
+				mockActuator.EXPECT().
+					GenerateMachineSets(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(tc.generatedMachineSets, true, nil)
+			}
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil).AnyTimes()
+
+			logger := log.WithField("controller", "remotemachineset")
+			r := &ReconcileRemoteMachineSet{
+				Client:                        hubClient,
+				scheme:                        scheme.Scheme,
+				logger:                        logger,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				actuatorBuilder: func(*hivev1.ClusterDeployment, *hivev1.MachinePool, *machineapi.Machine, []machineapi.MachineSet, log.FieldLogger) (Actuator, error) {
+					return mockActuator, nil
+				},
+				expectations: controllerutils.NewExpectations(logger),
+			}
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: fmt.Sprintf("%s-worker", testName), Namespace: testNamespace},
+			})
+			require.NoError(t, err)
+
+			machineSets := &machineapi.MachineSetList{}
+			require.NoError(t, hubClient.List(context.TODO(), machineSets, client.InNamespace(testTargetNamespace)))
+			var names []string
+			for _, ms := range machineSets.Items {
+				names = append(names, ms.Name)
+				providerConfig, err := decodeAWSMachineProviderSpec(ms.Spec.Template.Spec.ProviderSpec.Value, scheme.Scheme)
+				if assert.NoError(t, err) && assert.NotNil(t, providerConfig.CredentialsSecret, "missing credentials secret") {
+					assert.Equal(t, "aws-credentials", providerConfig.CredentialsSecret.Name, "unexpected credentials secret")
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedMachineSets, names, "unexpected machinesets in target namespace")
+
+			remoteMachineSets := &machineapi.MachineSetList{}
+			require.NoError(t, remoteClient.List(context.TODO(), remoteMachineSets))
+			require.Len(t, remoteMachineSets.Items, 1, "unexpected machinesets in remote cluster")
+
+			pool := &hivev1.MachinePool{}
+			require.NoError(t, hubClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: tc.machinePool.Name}, pool))
+			cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.UnsupportedConfigurationMachinePoolCondition)
+			if tc.expectUnsupportedConfig {
+				if assert.NotNil(t, cond, "missing unsupported configuration condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, "UnsupportedCentralMachineManagement", cond.Reason, "unexpected condition reason")
+				}
+			} else if cond != nil {
+				assert.NotEqual(t, corev1.ConditionTrue, cond.Status, "unexpected unsupported configuration")
+			}
+		})
+	}
+}
+
+func testCentralClusterDeployment(targetNamespace string) *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.MachineManagement = &hivev1.MachineManagement{
+		Central:         &hivev1.CentralMachineManagement{},
+		TargetNamespace: targetNamespace,
+	}
+	return cd
+}
+
+func testCentralMachineSet(name string) *machineapi.MachineSet {
+	ms := testMachineSet(name, "worker", false, 1, 0)
+	if err := centralizeMachineSet(ms, testCentralClusterDeployment(testTargetNamespace), scheme.Scheme); err != nil {
+		log.WithError(err).Fatal("error centralizing machineset")
+	}
+	return ms
+}
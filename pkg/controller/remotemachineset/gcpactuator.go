@@ -236,7 +236,25 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	if poolGCP.Preemptible {
+		for _, ms := range installerMachineSets {
+			providerSpec, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpproviderv1beta1.GCPMachineProviderSpec)
+			if !ok {
+				return nil, false, errors.New("unable to convert ProviderSpec to GCPMachineProviderSpec")
+			}
+			providerSpec.Preemptible = true
+		}
+	}
+
+	if err := setZoneReplicas(pool, installerMachineSets, computePool.Platform.GCP.Zones); err != nil {
+		return nil, false, err
+	}
+
+	return installerMachineSets, true, nil
 }
 
 func (a *GCPActuator) getZones(region string) ([]string, error) {
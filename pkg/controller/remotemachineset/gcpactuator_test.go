@@ -145,6 +145,20 @@ func TestGCPActuator(t *testing.T) {
 				generateGCPMachineSetName("worker", "zone1"): 3,
 			},
 		},
+		{
+			name: "generate preemptible machinesets",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Preemptible = true
+				return pool
+			}(),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+		},
 		{
 			name: "generate machinesets with KMS disk encryption",
 			pool: func() *hivev1.MachinePool {
@@ -234,6 +248,8 @@ func TestGCPActuator(t *testing.T) {
 					assert.Equal(t, ga.network, gcpProvider.NetworkInterfaces[0].Network)
 					assert.Equal(t, ga.subnet, gcpProvider.NetworkInterfaces[0].Subnetwork)
 
+					assert.Equal(t, test.pool.Spec.Platform.GCP.Preemptible, gcpProvider.Preemptible, "unexpected preemptible")
+
 					// Ensure GCP disk type and size was correctly set or defaulted and made it to the resulting MachineSets:
 					expectedDiskType := test.pool.Spec.Platform.GCP.OSDisk.DiskType
 					expectedDiskSizeGB := test.pool.Spec.Platform.GCP.OSDisk.DiskSizeGB
@@ -0,0 +1,210 @@
+package remotemachineset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// maxMachineStatuses is the maximum number of machines reported in the status of a machine pool, to bound its size.
+	maxMachineStatuses = 50
+
+	// maxMachinesInConditionMessage is the maximum number of machines named in the message of a condition.
+	maxMachinesInConditionMessage = 5
+
+	machinePhaseFailed = "Failed"
+)
+
+// getMachineStatuses returns the status of the machines of the machine sets, along with the readiness of their nodes in
+// the remote cluster.
+func getMachineStatuses(machineClient, remoteClusterAPIClient client.Client, machineSets []*machineapi.MachineSet) ([]hivev1.MachineStatus, error) {
+	machinesByMachineSet, err := getMachineSetMachines(machineClient, machineSets)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []hivev1.MachineStatus
+	hasNodes := false
+	for _, ms := range machineSets {
+		for _, m := range machinesByMachineSet[ms.Name] {
+			s := hivev1.MachineStatus{
+				Name:       m.Name,
+				MachineSet: ms.Name,
+			}
+			if m.Status.Phase != nil {
+				s.Phase = *m.Status.Phase
+			}
+			if m.Spec.ProviderID != nil {
+				s.ProviderID = *m.Spec.ProviderID
+			}
+			if m.Status.NodeRef != nil {
+				s.NodeName = m.Status.NodeRef.Name
+				hasNodes = true
+			}
+			switch {
+			case m.Status.ErrorMessage != nil:
+				s.FailureMessage = *m.Status.ErrorMessage
+			case m.Status.ErrorReason != nil:
+				s.FailureMessage = string(*m.Status.ErrorReason)
+			}
+			statuses = append(statuses, s)
+		}
+	}
+
+	if hasNodes {
+		nodes := &corev1.NodeList{}
+		if err := remoteClusterAPIClient.List(context.TODO(), nodes); err != nil {
+			return nil, errors.Wrap(err, "failed to list nodes")
+		}
+		ready := make(map[string]bool, len(nodes.Items))
+		for _, node := range nodes.Items {
+			for _, cond := range node.Status.Conditions {
+				if cond.Type == corev1.NodeReady {
+					ready[node.Name] = cond.Status == corev1.ConditionTrue
+					break
+				}
+			}
+		}
+		for i := range statuses {
+			statuses[i].NodeReady = ready[statuses[i].NodeName]
+		}
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if pi, pj := machineStatusPriority(statuses[i]), machineStatusPriority(statuses[j]); pi != pj {
+			return pi < pj
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses, nil
+}
+
+// getMachineSetMachines returns the machines of the machine sets, by machine set name. The machine sets are all in the
+// same namespace: the machine API namespace of the remote cluster, or the target namespace of the hub.
+func getMachineSetMachines(machineClient client.Client, machineSets []*machineapi.MachineSet) (map[string][]machineapi.Machine, error) {
+	if len(machineSets) == 0 {
+		return nil, nil
+	}
+	machines := &machineapi.MachineList{}
+	if err := machineClient.List(context.TODO(), machines, client.InNamespace(machineSets[0].Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list machines")
+	}
+	machinesByMachineSet := make(map[string][]machineapi.Machine, len(machineSets))
+	for _, ms := range machineSets {
+		sel, err := metav1.LabelSelectorAsSelector(&ms.Spec.Selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create label selector for machineset %s", ms.Name)
+		}
+		for _, m := range machines.Items {
+			if m.Namespace == ms.Namespace && sel.Matches(labels.Set(m.Labels)) {
+				machinesByMachineSet[ms.Name] = append(machinesByMachineSet[ms.Name], m)
+			}
+		}
+	}
+	return machinesByMachineSet, nil
+}
+
+func machineFailed(s hivev1.MachineStatus) bool {
+	return s.Phase == machinePhaseFailed || s.FailureMessage != ""
+}
+
+func nodeNotReady(s hivev1.MachineStatus) bool {
+	return s.NodeName != "" && !s.NodeReady
+}
+
+// machineStatusPriority orders the machines with problems first, so that they are reported when the machines are
+// truncated.
+func machineStatusPriority(s hivev1.MachineStatus) int {
+	switch {
+	case machineFailed(s):
+		return 0
+	case nodeNotReady(s):
+		return 1
+	case s.NodeName == "":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// setMachineStatuses sets the machines in the status of the machine pool, and the conditions for the machines that
+// failed and the nodes that are not ready.
+func setMachineStatuses(pool *hivev1.MachinePool, statuses []hivev1.MachineStatus) {
+	var failed, notReady []string
+	for _, s := range statuses {
+		if machineFailed(s) {
+			failed = append(failed, s.Name)
+		}
+		if nodeNotReady(s) {
+			notReady = append(notReady, s.Name)
+		}
+	}
+
+	if len(statuses) > maxMachineStatuses {
+		statuses = statuses[:maxMachineStatuses]
+	}
+	pool.Status.Machines = statuses
+
+	status, reason, message := corev1.ConditionFalse, "NoMachinesFailed", "No machines failed to provision"
+	if len(failed) > 0 {
+		status, reason, message = corev1.ConditionTrue, "MachinesFailed", "Machines failed to provision: "+summarizeMachineNames(failed)
+	}
+	pool.Status.Conditions = controllerutils.SetMachinePoolCondition(
+		pool.Status.Conditions,
+		hivev1.MachinesFailedToProvisionMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+
+	status, reason, message = corev1.ConditionFalse, "NodesReady", "The nodes of the machines are ready"
+	if len(notReady) > 0 {
+		status, reason, message = corev1.ConditionTrue, "NodesNotReady", "Nodes of machines are not ready: "+summarizeMachineNames(notReady)
+	}
+	pool.Status.Conditions = controllerutils.SetMachinePoolCondition(
+		pool.Status.Conditions,
+		hivev1.NodesNotReadyMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+func summarizeMachineNames(names []string) string {
+	if len(names) <= maxMachinesInConditionMessage {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxMachinesInConditionMessage], ", "), len(names)-maxMachinesInConditionMessage)
+}
+
+func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachines(
+	pool *hivev1.MachinePool,
+	machineSets []*machineapi.MachineSet,
+	machineClient client.Client,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) {
+	statuses, err := getMachineStatuses(machineClient, remoteClusterAPIClient, machineSets)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get machine statuses")
+		return
+	}
+	setMachineStatuses(pool, statuses)
+}
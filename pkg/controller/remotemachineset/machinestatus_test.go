@@ -0,0 +1,174 @@
+package remotemachineset
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func Test_getMachineStatuses(t *testing.T) {
+	machineapi.SchemeBuilder.AddToScheme(scheme.Scheme)
+	msA, msB := "foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"
+	existing := []runtime.Object{
+		testMachine("master1", "master"),
+		withMachineNode(testMachineSetMachine("machine-a1", "worker", msA), "node-a1"),
+		withMachineNode(testMachineSetMachine("machine-a2", "worker", msA), "node-a2"),
+		func() *machineapi.Machine {
+			m := testMachineSetMachine("machine-b1", "worker", msB)
+			m.Status.Phase = pointer.StringPtr("Failed")
+			m.Status.ErrorReason = (*machineapi.MachineStatusError)(pointer.StringPtr("InsufficientResources"))
+			m.Status.ErrorMessage = pointer.StringPtr("No available quota")
+			return m
+		}(),
+		func() *machineapi.Machine {
+			m := testMachineSetMachine("machine-b2", "worker", msB)
+			m.Status.Phase = pointer.StringPtr("Provisioning")
+			return m
+		}(),
+		testMachineSetMachine("machine-c1", "worker", "foo-12345-other-us-east-1c"),
+		testNode("node-a1", corev1.ConditionTrue),
+		testNode("node-a2", corev1.ConditionFalse),
+	}
+	c := fake.NewClientBuilder().WithRuntimeObjects(existing...).Build()
+
+	statuses, err := getMachineStatuses(c, c, []*machineapi.MachineSet{
+		testMachineSet(msA, "worker", false, 2, 0),
+		testMachineSet(msB, "worker", false, 2, 0),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []hivev1.MachineStatus{
+		{
+			Name:           "machine-b1",
+			MachineSet:     msB,
+			Phase:          "Failed",
+			FailureMessage: "No available quota",
+		},
+		{
+			Name:       "machine-a2",
+			MachineSet: msA,
+			Phase:      "Running",
+			ProviderID: "aws:///us-east-1a/i-machine-a2",
+			NodeName:   "node-a2",
+		},
+		{
+			Name:       "machine-b2",
+			MachineSet: msB,
+			Phase:      "Provisioning",
+		},
+		{
+			Name:       "machine-a1",
+			MachineSet: msA,
+			Phase:      "Running",
+			ProviderID: "aws:///us-east-1a/i-machine-a1",
+			NodeName:   "node-a1",
+			NodeReady:  true,
+		},
+	}, statuses)
+}
+
+func Test_setMachineStatuses(t *testing.T) {
+	cases := []struct {
+		name                     string
+		statuses                 []hivev1.MachineStatus
+		expectedMachines         int
+		expectedFailedStatus     corev1.ConditionStatus
+		expectedFailedMessage    string
+		expectedNodesNotReady    corev1.ConditionStatus
+		expectedNodesNotReadyMsg string
+	}{
+		{
+			name:                     "no machines",
+			expectedFailedStatus:     corev1.ConditionFalse,
+			expectedFailedMessage:    "No machines failed to provision",
+			expectedNodesNotReady:    corev1.ConditionFalse,
+			expectedNodesNotReadyMsg: "The nodes of the machines are ready",
+		},
+		{
+			name: "healthy machines",
+			statuses: []hivev1.MachineStatus{
+				{Name: "machine-1", NodeName: "node-1", NodeReady: true},
+				{Name: "machine-2", Phase: "Provisioning"},
+			},
+			expectedMachines:         2,
+			expectedFailedStatus:     corev1.ConditionFalse,
+			expectedFailedMessage:    "No machines failed to provision",
+			expectedNodesNotReady:    corev1.ConditionFalse,
+			expectedNodesNotReadyMsg: "The nodes of the machines are ready",
+		},
+		{
+			name: "failed machine and node not ready",
+			statuses: []hivev1.MachineStatus{
+				{Name: "machine-1", Phase: "Failed", FailureMessage: "No available quota"},
+				{Name: "machine-2", NodeName: "node-2"},
+			},
+			expectedMachines:         2,
+			expectedFailedStatus:     corev1.ConditionTrue,
+			expectedFailedMessage:    "Machines failed to provision: machine-1",
+			expectedNodesNotReady:    corev1.ConditionTrue,
+			expectedNodesNotReadyMsg: "Nodes of machines are not ready: machine-2",
+		},
+		{
+			name: "many failed machines",
+			statuses: func() []hivev1.MachineStatus {
+				var statuses []hivev1.MachineStatus
+				for i := 0; i < 60; i++ {
+					statuses = append(statuses, hivev1.MachineStatus{Name: fmt.Sprintf("machine-%02d", i), Phase: "Failed"})
+				}
+				return statuses
+			}(),
+			expectedMachines:         maxMachineStatuses,
+			expectedFailedStatus:     corev1.ConditionTrue,
+			expectedFailedMessage:    "Machines failed to provision: machine-00, machine-01, machine-02, machine-03, machine-04 and 55 more",
+			expectedNodesNotReady:    corev1.ConditionFalse,
+			expectedNodesNotReadyMsg: "The nodes of the machines are ready",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			setMachineStatuses(pool, tc.statuses)
+			assert.Len(t, pool.Status.Machines, tc.expectedMachines, "unexpected number of machines")
+			if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.MachinesFailedToProvisionMachinePoolCondition); assert.NotNil(t, cond) {
+				assert.Equal(t, tc.expectedFailedStatus, cond.Status, "unexpected status of MachinesFailedToProvision")
+				assert.Equal(t, tc.expectedFailedMessage, cond.Message, "unexpected message of MachinesFailedToProvision")
+			}
+			if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.NodesNotReadyMachinePoolCondition); assert.NotNil(t, cond) {
+				assert.Equal(t, tc.expectedNodesNotReady, cond.Status, "unexpected status of NodesNotReady")
+				assert.Equal(t, tc.expectedNodesNotReadyMsg, cond.Message, "unexpected message of NodesNotReady")
+			}
+		})
+	}
+}
+
+func withMachineNode(m *machineapi.Machine, nodeName string) *machineapi.Machine {
+	m.Spec.ProviderID = pointer.StringPtr(fmt.Sprintf("aws:///us-east-1a/i-%s", m.Name))
+	m.Status.Phase = pointer.StringPtr("Running")
+	m.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+	return m
+}
+
+func testNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{
+				Type:   corev1.NodeReady,
+				Status: ready,
+			}},
+		},
+	}
+}
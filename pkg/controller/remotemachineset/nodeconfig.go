@@ -0,0 +1,365 @@
+package remotemachineset
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// machineConfigRoleLabel is the label of MachineConfigs selected by the MachineConfigPools of the role.
+	machineConfigRoleLabel = "machineconfiguration.openshift.io/role"
+
+	// nodeRoleLabelPrefix is the prefix of the role labels of nodes, selected by the MachineConfigPools of the role.
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+	// sysctlsMachineConfigName is the name, within the machine pool, of the MachineConfig for the sysctls.
+	sysctlsMachineConfigName = "sysctls"
+
+	// ignitionVersion is the version of the Ignition config of the MachineConfig for the sysctls.
+	ignitionVersion = "3.1.0"
+)
+
+var (
+	machineConfigPoolGVK = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfigPool"}
+	kubeletConfigGVK     = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "KubeletConfig"}
+	machineConfigGVK     = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfig"}
+)
+
+// nodeRoleLabel returns the role label of the nodes of the machine pool, selected by its MachineConfigPool.
+func nodeRoleLabel(pool *hivev1.MachinePool) string {
+	return nodeRoleLabelPrefix + pool.Spec.Name
+}
+
+// machineConfigName returns the name of the MachineConfig of the machine pool in the remote cluster.
+func machineConfigName(pool *hivev1.MachinePool, name string) string {
+	return fmt.Sprintf("99-%s-%s", pool.Spec.Name, name)
+}
+
+// syncNodeConfig syncs the MachineConfigPool, KubeletConfig and MachineConfigs for the node configuration of the
+// machine pool, and the role label of the nodes of the machine pool that puts them in the MachineConfigPool. When the
+// node configuration is removed, the role label is removed from the nodes before the objects are deleted, so that the
+// nodes go back to the worker MachineConfigPool.
+func (r *ReconcileRemoteMachineSet) syncNodeConfig(
+	pool *hivev1.MachinePool,
+	machineSets []*machineapi.MachineSet,
+	machineClient client.Client,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	var desired []*unstructured.Unstructured
+	if pool.DeletionTimestamp == nil && pool.Spec.NodeConfig != nil {
+		var err error
+		if desired, err = generateNodeConfig(pool); err != nil {
+			return errors.Wrap(err, "could not generate node configuration")
+		}
+	}
+
+	gvks := []schema.GroupVersionKind{machineConfigPoolGVK, kubeletConfigGVK, machineConfigGVK}
+	remote := make(map[schema.GroupVersionKind][]unstructured.Unstructured, len(gvks))
+	for _, gvk := range gvks {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := remoteClusterAPIClient.List(
+			context.Background(),
+			list,
+			client.MatchingLabels{machinePoolNameLabel: pool.Spec.Name, constants.HiveManagedLabel: "true"},
+		); err != nil {
+			logger.WithError(err).WithField("kind", gvk.Kind).Error("unable to fetch remote node configuration")
+			return err
+		}
+		remote[gvk] = list.Items
+	}
+
+	if desired == nil {
+		if len(remote[machineConfigPoolGVK]) > 0 {
+			if err := removeNodeRoleLabels(pool, remoteClusterAPIClient, logger); err != nil {
+				return err
+			}
+		}
+	} else if err := addNodeRoleLabels(pool, machineSets, machineClient, remoteClusterAPIClient, logger); err != nil {
+		return err
+	}
+
+	for _, gvk := range gvks {
+		desiredByName := map[string]*unstructured.Unstructured{}
+		for _, obj := range desired {
+			if obj.GroupVersionKind() == gvk {
+				desiredByName[obj.GetName()] = obj
+			}
+		}
+		for i := range remote[gvk] {
+			rObj := &remote[gvk][i]
+			objLog := logger.WithField("kind", gvk.Kind).WithField("name", rObj.GetName())
+			obj, ok := desiredByName[rObj.GetName()]
+			if !ok {
+				objLog.Info("deleting node configuration")
+				if err := remoteClusterAPIClient.Delete(context.Background(), rObj); err != nil {
+					objLog.WithError(err).Error("unable to delete node configuration")
+					return err
+				}
+				continue
+			}
+			delete(desiredByName, rObj.GetName())
+			if !mergeNodeConfig(rObj, obj) {
+				continue
+			}
+			objLog.Info("updating node configuration")
+			if err := remoteClusterAPIClient.Update(context.Background(), rObj); err != nil {
+				objLog.WithError(err).Error("unable to update node configuration")
+				return err
+			}
+		}
+		for _, obj := range desired {
+			if _, ok := desiredByName[obj.GetName()]; !ok || obj.GroupVersionKind() != gvk {
+				continue
+			}
+			objLog := logger.WithField("kind", gvk.Kind).WithField("name", obj.GetName())
+			objLog.Info("creating node configuration")
+			if err := remoteClusterAPIClient.Create(context.Background(), obj); err != nil {
+				objLog.WithError(err).Error("unable to create node configuration")
+				return err
+			}
+		}
+	}
+
+	logger.Info("done reconciling node configuration for machine pool")
+	return nil
+}
+
+// mergeNodeConfig sets the labels and the fields of the spec of the desired object on the remote object, and returns
+// whether the remote object was modified. Only the fields set by Hive are compared, as the machine config operator
+// sets other fields of the spec of MachineConfigPools.
+func mergeNodeConfig(remote, desired *unstructured.Unstructured) bool {
+	modified := false
+	labels := remote.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range desired.GetLabels() {
+		if labels[k] != v {
+			labels[k] = v
+			modified = true
+		}
+	}
+	remote.SetLabels(labels)
+
+	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	remoteSpec, _, _ := unstructured.NestedMap(remote.Object, "spec")
+	if remoteSpec == nil {
+		remoteSpec = map[string]interface{}{}
+	}
+	for k, v := range desiredSpec {
+		if !equality.Semantic.DeepEqual(remoteSpec[k], v) {
+			remoteSpec[k] = v
+			modified = true
+		}
+	}
+	if modified {
+		unstructured.SetNestedMap(remote.Object, remoteSpec, "spec")
+	}
+	return modified
+}
+
+// generateNodeConfig generates the MachineConfigPool, KubeletConfig and MachineConfigs for the node configuration of
+// the machine pool. Like custom MachineConfigPools, the MachineConfigPool selects the MachineConfigs of the worker
+// role as well as those of the machine pool.
+func generateNodeConfig(pool *hivev1.MachinePool) ([]*unstructured.Unstructured, error) {
+	nodeConfig := pool.Spec.NodeConfig
+	labels := map[string]interface{}{
+		machinePoolNameLabel:       pool.Spec.Name,
+		constants.HiveManagedLabel: "true",
+	}
+	objects := []map[string]interface{}{{
+		"apiVersion": machineConfigPoolGVK.GroupVersion().String(),
+		"kind":       machineConfigPoolGVK.Kind,
+		"metadata": map[string]interface{}{
+			"name":   pool.Spec.Name,
+			"labels": labels,
+		},
+		"spec": map[string]interface{}{
+			"machineConfigSelector": map[string]interface{}{
+				"matchExpressions": []interface{}{map[string]interface{}{
+					"key":      machineConfigRoleLabel,
+					"operator": "In",
+					"values":   []interface{}{"worker", pool.Spec.Name},
+				}},
+			},
+			"nodeSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{nodeRoleLabel(pool): ""},
+			},
+		},
+	}}
+
+	if nodeConfig.Kubelet != nil {
+		var kubelet interface{}
+		if err := json.Unmarshal(nodeConfig.Kubelet.Raw, &kubelet); err != nil {
+			return nil, errors.Wrap(err, "could not decode kubelet configuration")
+		}
+		objects = append(objects, map[string]interface{}{
+			"apiVersion": kubeletConfigGVK.GroupVersion().String(),
+			"kind":       kubeletConfigGVK.Kind,
+			"metadata": map[string]interface{}{
+				"name":   pool.Spec.Name,
+				"labels": labels,
+			},
+			"spec": map[string]interface{}{
+				"machineConfigPoolSelector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{machinePoolNameLabel: pool.Spec.Name},
+				},
+				"kubeletConfig": kubelet,
+			},
+		})
+	}
+
+	machineConfigLabels := map[string]interface{}{machineConfigRoleLabel: pool.Spec.Name}
+	for k, v := range labels {
+		machineConfigLabels[k] = v
+	}
+	machineConfig := func(name string, spec interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": machineConfigGVK.GroupVersion().String(),
+			"kind":       machineConfigGVK.Kind,
+			"metadata": map[string]interface{}{
+				"name":   machineConfigName(pool, name),
+				"labels": machineConfigLabels,
+			},
+			"spec": spec,
+		}
+	}
+
+	if len(nodeConfig.Sysctls) > 0 {
+		objects = append(objects, machineConfig(sysctlsMachineConfigName, map[string]interface{}{
+			"config": map[string]interface{}{
+				"ignition": map[string]interface{}{"version": ignitionVersion},
+				"storage": map[string]interface{}{
+					"files": []interface{}{map[string]interface{}{
+						"path":      fmt.Sprintf("/etc/sysctl.d/99-%s.conf", pool.Spec.Name),
+						"mode":      0644,
+						"overwrite": true,
+						"contents": map[string]interface{}{
+							"source": "data:text/plain;charset=utf-8;base64," + base64.StdEncoding.EncodeToString([]byte(sysctlsConf(nodeConfig.Sysctls))),
+						},
+					}},
+				},
+			},
+		}))
+	}
+
+	for _, mc := range nodeConfig.MachineConfigs {
+		var spec interface{}
+		if err := json.Unmarshal(mc.Spec.Raw, &spec); err != nil {
+			return nil, errors.Wrapf(err, "could not decode machine config %s", mc.Name)
+		}
+		objects = append(objects, machineConfig(mc.Name, spec))
+	}
+
+	// The objects are round-tripped through JSON so that their fields have the types of the objects read from the
+	// remote cluster, such as int64 rather than float64 for numbers, and compare equal to them.
+	result := make([]*unstructured.Unstructured, len(objects))
+	for i, obj := range objects {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode node configuration")
+		}
+		result[i] = &unstructured.Unstructured{}
+		if err := result[i].UnmarshalJSON(raw); err != nil {
+			return nil, errors.Wrap(err, "could not decode node configuration")
+		}
+	}
+	return result, nil
+}
+
+// sysctlsConf returns the content of a sysctl.d file for the sysctls, sorted by name.
+func sysctlsConf(sysctls map[string]string) string {
+	names := make([]string, 0, len(sysctls))
+	for name := range sysctls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s = %s\n", name, sysctls[name])
+	}
+	return b.String()
+}
+
+// addNodeRoleLabels adds the role label of the machine pool to the nodes of its machines. The role label is also in
+// the template of the MachineSets, so that the nodes of new machines get it when they join the cluster.
+func addNodeRoleLabels(
+	pool *hivev1.MachinePool,
+	machineSets []*machineapi.MachineSet,
+	machineClient client.Client,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	machinesByMachineSet, err := getMachineSetMachines(machineClient, machineSets)
+	if err != nil {
+		return err
+	}
+	label := nodeRoleLabel(pool)
+	for _, machines := range machinesByMachineSet {
+		for _, m := range machines {
+			if m.Status.NodeRef == nil {
+				continue
+			}
+			node := &corev1.Node{}
+			if err := remoteClusterAPIClient.Get(context.Background(), client.ObjectKey{Name: m.Status.NodeRef.Name}, node); err != nil {
+				logger.WithError(err).WithField("node", m.Status.NodeRef.Name).Warn("unable to fetch node of machine")
+				continue
+			}
+			if _, ok := node.Labels[label]; ok {
+				continue
+			}
+			patch := client.MergeFrom(node.DeepCopy())
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[label] = ""
+			logger.WithField("node", node.Name).Info("adding role label to node")
+			if err := remoteClusterAPIClient.Patch(context.Background(), node, patch); err != nil {
+				logger.WithError(err).WithField("node", node.Name).Error("unable to add role label to node")
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeNodeRoleLabels removes the role label of the machine pool from the nodes.
+func removeNodeRoleLabels(pool *hivev1.MachinePool, remoteClusterAPIClient client.Client, logger log.FieldLogger) error {
+	label := nodeRoleLabel(pool)
+	nodes := &corev1.NodeList{}
+	if err := remoteClusterAPIClient.List(context.Background(), nodes, client.HasLabels{label}); err != nil {
+		logger.WithError(err).Error("unable to fetch nodes")
+		return err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		patch := client.MergeFrom(node.DeepCopy())
+		delete(node.Labels, label)
+		logger.WithField("node", node.Name).Info("removing role label from node")
+		if err := remoteClusterAPIClient.Patch(context.Background(), node, patch); err != nil {
+			logger.WithError(err).WithField("node", node.Name).Error("unable to remove role label from node")
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package remotemachineset
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestSyncNodeConfig(t *testing.T) {
+	machineapi.SchemeBuilder.AddToScheme(scheme.Scheme)
+	addMachineConfigTypesToScheme(scheme.Scheme)
+	msName := "foo-12345-infra-us-east-1a"
+	cases := []struct {
+		name                   string
+		nodeConfig             *hivev1.MachinePoolNodeConfig
+		deleted                bool
+		remoteExisting         []runtime.Object
+		expectedMachineConfigs []string
+		expectedKubeletConfig  bool
+		expectedPool           bool
+		expectedConfiguration  string
+		expectedLabeledNodes   []string
+	}{
+		{
+			name: "create node config",
+			nodeConfig: &hivev1.MachinePoolNodeConfig{
+				Kubelet: &runtime.RawExtension{Raw: []byte(`{"maxPods": 250}`)},
+				Sysctls: map[string]string{"net.core.somaxconn": "1024"},
+				MachineConfigs: []hivev1.MachinePoolMachineConfig{{
+					Name: "kargs",
+					Spec: runtime.RawExtension{Raw: []byte(`{"kernelArguments": ["nosmt"]}`)},
+				}},
+			},
+			remoteExisting: []runtime.Object{
+				withMachineNode(testMachineSetMachine("machine-1", "infra", msName), "node-1"),
+				testNode("node-1", corev1.ConditionTrue),
+				testNode("node-2", corev1.ConditionTrue),
+			},
+			expectedMachineConfigs: []string{"99-infra-kargs", "99-infra-sysctls"},
+			expectedKubeletConfig:  true,
+			expectedPool:           true,
+			expectedLabeledNodes:   []string{"node-1"},
+		},
+		{
+			name: "update node config",
+			nodeConfig: &hivev1.MachinePoolNodeConfig{
+				Kubelet: &runtime.RawExtension{Raw: []byte(`{"maxPods": 250}`)},
+			},
+			remoteExisting: []runtime.Object{
+				testRemoteMachineConfigPool(),
+				testRemoteNodeConfigObject(kubeletConfigGVK, "infra", map[string]interface{}{"kubeletConfig": map[string]interface{}{"maxPods": int64(100)}}),
+				testRemoteNodeConfigObject(machineConfigGVK, "99-infra-kargs", map[string]interface{}{"kernelArguments": []interface{}{"nosmt"}}),
+			},
+			expectedKubeletConfig: true,
+			expectedPool:          true,
+			// The fields set by the machine config operator are kept.
+			expectedConfiguration: "rendered-infra-1234",
+		},
+		{
+			name: "remove node config",
+			remoteExisting: []runtime.Object{
+				testRemoteMachineConfigPool(),
+				testRemoteNodeConfigObject(kubeletConfigGVK, "infra", map[string]interface{}{"kubeletConfig": map[string]interface{}{"maxPods": int64(100)}}),
+				testRemoteNodeConfigObject(machineConfigGVK, "99-infra-kargs", map[string]interface{}{"kernelArguments": []interface{}{"nosmt"}}),
+				withNodeRoleLabel(testNode("node-1", corev1.ConditionTrue)),
+			},
+		},
+		{
+			name: "remove node config for deleted machine pool",
+			nodeConfig: &hivev1.MachinePoolNodeConfig{
+				Sysctls: map[string]string{"net.core.somaxconn": "1024"},
+			},
+			deleted: true,
+			remoteExisting: []runtime.Object{
+				testRemoteMachineConfigPool(),
+				testRemoteNodeConfigObject(machineConfigGVK, "99-infra-sysctls", map[string]interface{}{}),
+				withNodeRoleLabel(testNode("node-1", corev1.ConditionTrue)),
+			},
+		},
+		{
+			name: "no node config",
+			remoteExisting: []runtime.Object{
+				withNodeRoleLabel(testNode("node-1", corev1.ConditionTrue)),
+			},
+			expectedLabeledNodes: []string{"node-1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			pool.Spec.Name = "infra"
+			pool.Spec.NodeConfig = tc.nodeConfig
+			if tc.deleted {
+				now := metav1.Now()
+				pool.DeletionTimestamp = &now
+			}
+			c := fake.NewClientBuilder().WithRuntimeObjects(tc.remoteExisting...).Build()
+			r := &ReconcileRemoteMachineSet{}
+
+			err := r.syncNodeConfig(pool, []*machineapi.MachineSet{testMachineSet(msName, "infra", false, 1, 0)}, c, c, log.WithField("test", tc.name))
+			require.NoError(t, err)
+
+			mcp := listNodeConfigObjects(t, c, machineConfigPoolGVK)
+			if tc.expectedPool {
+				if assert.Len(t, mcp, 1, "expected a machine config pool") {
+					assert.Equal(t, "infra", mcp[0].GetName())
+					selector, _, _ := unstructured.NestedStringMap(mcp[0].Object, "spec", "nodeSelector", "matchLabels")
+					assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, selector, "unexpected node selector")
+					if tc.expectedConfiguration != "" {
+						configuration, _, _ := unstructured.NestedString(mcp[0].Object, "spec", "configuration", "name")
+						assert.Equal(t, tc.expectedConfiguration, configuration, "unexpected configuration")
+					}
+				}
+			} else {
+				assert.Empty(t, mcp, "unexpected machine config pool")
+			}
+
+			kc := listNodeConfigObjects(t, c, kubeletConfigGVK)
+			if tc.expectedKubeletConfig {
+				if assert.Len(t, kc, 1, "expected a kubelet config") {
+					maxPods, _, _ := unstructured.NestedInt64(kc[0].Object, "spec", "kubeletConfig", "maxPods")
+					assert.Equal(t, int64(250), maxPods, "unexpected max pods")
+				}
+			} else {
+				assert.Empty(t, kc, "unexpected kubelet config")
+			}
+
+			var mcNames []string
+			for _, mc := range listNodeConfigObjects(t, c, machineConfigGVK) {
+				mcNames = append(mcNames, mc.GetName())
+				assert.Equal(t, "infra", mc.GetLabels()[machineConfigRoleLabel], "unexpected role of machine config")
+			}
+			assert.ElementsMatch(t, tc.expectedMachineConfigs, mcNames, "unexpected machine configs")
+
+			nodes := &corev1.NodeList{}
+			require.NoError(t, c.List(context.TODO(), nodes, client.HasLabels{"node-role.kubernetes.io/infra"}))
+			var labeled []string
+			for _, node := range nodes.Items {
+				labeled = append(labeled, node.Name)
+			}
+			assert.ElementsMatch(t, tc.expectedLabeledNodes, labeled, "unexpected labeled nodes")
+		})
+	}
+}
+
+func TestSysctlsConf(t *testing.T) {
+	assert.Equal(t, "kernel.pid_max = 4194304\nnet.core.somaxconn = 1024\n", sysctlsConf(map[string]string{
+		"net.core.somaxconn": "1024",
+		"kernel.pid_max":     "4194304",
+	}))
+}
+
+// addMachineConfigTypesToScheme registers the machine config operator types as unstructured types, so that the fake
+// client can store them.
+func addMachineConfigTypesToScheme(s *runtime.Scheme) {
+	for _, gvk := range []schema.GroupVersionKind{machineConfigPoolGVK, kubeletConfigGVK, machineConfigGVK} {
+		if s.Recognizes(gvk) {
+			continue
+		}
+		s.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		s.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+}
+
+func listNodeConfigObjects(t *testing.T, c client.Client, gvk schema.GroupVersionKind) []unstructured.Unstructured {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	require.NoError(t, c.List(context.TODO(), list), "error listing %s", gvk.Kind)
+	return list.Items
+}
+
+func testRemoteNodeConfigObject(gvk schema.GroupVersionKind, name string, spec map[string]interface{}) runtime.Object {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	labels := map[string]string{
+		machinePoolNameLabel:       "infra",
+		constants.HiveManagedLabel: "true",
+	}
+	if gvk == machineConfigGVK {
+		labels[machineConfigRoleLabel] = "infra"
+	}
+	obj.SetLabels(labels)
+	return obj
+}
+
+func testRemoteMachineConfigPool() runtime.Object {
+	return testRemoteNodeConfigObject(machineConfigPoolGVK, "infra", map[string]interface{}{
+		"configuration": map[string]interface{}{"name": "rendered-infra-1234"},
+	})
+}
+
+func withNodeRoleLabel(node *corev1.Node) *corev1.Node {
+	node.Labels = map[string]string{"node-role.kubernetes.io/infra": ""}
+	return node
+}
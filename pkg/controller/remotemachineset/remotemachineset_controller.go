@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,18 +50,37 @@ const (
 	machinePoolNameLabel       = "hive.openshift.io/machine-pool"
 	finalizer                  = "hive.openshift.io/remotemachineset"
 	masterMachineLabelSelector = "machine.openshift.io/cluster-api-machine-type=master"
+	machineSetNameLabel        = "machine.openshift.io/cluster-api-machineset"
+	machineAPINamespace        = "openshift-machine-api"
 )
 
 var (
 	// controllerKind contains the schema.GroupVersionKind for this controller type.
 	controllerKind = hivev1.SchemeGroupVersion.WithKind("MachinePool")
 
+	// defaultUnhealthyConditions are the conditions of the MachineHealthChecks of the machine pools that do not
+	// specify any.
+	defaultUnhealthyConditions = []machineapi.UnhealthyCondition{
+		{
+			Type:    corev1.NodeReady,
+			Status:  corev1.ConditionFalse,
+			Timeout: metav1.Duration{Duration: 5 * time.Minute},
+		},
+		{
+			Type:    corev1.NodeReady,
+			Status:  corev1.ConditionUnknown,
+			Timeout: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+
 	// remoteMachineSetConditions are the Machine Pool conditions controlled by remote machineset controller
 	remoteMachineSetConditions = []hivev1.MachinePoolConditionType{
 		hivev1.NotEnoughReplicasMachinePoolCondition,
 		hivev1.NoMachinePoolNameLeasesAvailable,
 		hivev1.InvalidSubnetsMachinePoolCondition,
 		hivev1.UnsupportedConfigurationMachinePoolCondition,
+		hivev1.MachinesFailedToProvisionMachinePoolCondition,
+		hivev1.NodesNotReadyMachinePoolCondition,
 	}
 )
 
@@ -302,12 +323,32 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 
 	logger.Info("reconciling machine pool for cluster deployment")
 
+	// The workers of clusters installed with the agent install strategy are inventory hosts rather than machines.
+	if cd.Spec.Platform.AgentBareMetal != nil {
+		return r.reconcileAgentMachinePool(pool, cd, remoteClusterAPIClient, logger)
+	}
+
+	// The MachineSets and machines of clusters whose machines are managed centrally are in the target namespace of
+	// the hub rather than in the remote cluster.
+	machineClient, machineNamespace := remoteClusterAPIClient, machineAPINamespace
+	central := controllerutils.IsMachineManagementCentral(cd)
+	if central {
+		switch result, err := r.ensureCentralMachineManagementSupported(pool, cd, logger); {
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not ensureCentralMachineManagementSupported")
+			return reconcile.Result{}, err
+		case result != nil:
+			return *result, nil
+		}
+		machineClient, machineNamespace = r.Client, cd.Spec.MachineManagement.TargetNamespace
+	}
+
 	masterMachine, err := r.getMasterMachine(cd, remoteClusterAPIClient, logger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	remoteMachineSets, err := r.getRemoteMachineSets(remoteClusterAPIClient, logger)
+	remoteMachineSets, err := r.getRemoteMachineSets(machineClient, machineNamespace, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not getRemoteMachineSets")
 		return reconcile.Result{}, err
@@ -330,19 +371,37 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		return *result, nil
 	}
 
-	machineSets, err := r.syncMachineSets(pool, cd, generatedMachineSets, remoteMachineSets, remoteClusterAPIClient, logger)
+	var rollout *machinePoolRollout
+	if isRollingUpdate(pool) && pool.DeletionTimestamp == nil {
+		rollout = planRollout(pool, cd, generatedMachineSets, remoteMachineSets)
+	}
+
+	machineSets, err := r.syncMachineSets(pool, cd, generatedMachineSets, remoteMachineSets, rollout, machineClient, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineSets")
 		return reconcile.Result{}, err
 	}
 
-	if err := r.syncMachineAutoscalers(pool, cd, machineSets, remoteClusterAPIClient, logger); err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineAutoscalers")
-		return reconcile.Result{}, err
+	// Auto-scaling and health checks are not supported with central machine management.
+	if !central {
+		if err := r.syncMachineAutoscalers(pool, cd, machineSets, remoteClusterAPIClient, logger); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineAutoscalers")
+			return reconcile.Result{}, err
+		}
+
+		if err := r.syncClusterAutoscaler(pool, cd, remoteClusterAPIClient, logger); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncClusterAutoscaler")
+			return reconcile.Result{}, err
+		}
+
+		if err := r.syncMachineHealthChecks(pool, cd, machineSets, remoteClusterAPIClient, logger); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineHealthChecks")
+			return reconcile.Result{}, err
+		}
 	}
 
-	if err := r.syncClusterAutoscaler(pool, cd, remoteClusterAPIClient, logger); err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncClusterAutoscaler")
+	if err := r.syncNodeConfig(pool, machineSets, machineClient, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncNodeConfig")
 		return reconcile.Result{}, err
 	}
 
@@ -350,7 +409,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		return r.removeFinalizer(pool, logger)
 	}
 
-	return r.updatePoolStatusForMachineSets(pool, machineSets, remoteClusterAPIClient, logger)
+	return r.updatePoolStatusForMachineSets(pool, machineSets, rollout, machineClient, remoteClusterAPIClient, logger)
 }
 
 func (r *ReconcileRemoteMachineSet) getMasterMachine(
@@ -382,16 +441,17 @@ func (r *ReconcileRemoteMachineSet) getMasterMachine(
 }
 
 func (r *ReconcileRemoteMachineSet) getRemoteMachineSets(
-	remoteClusterAPIClient client.Client,
+	machineClient client.Client,
+	namespace string,
 	logger log.FieldLogger,
 ) (*machineapi.MachineSetList, error) {
 	remoteMachineSets := &machineapi.MachineSetList{}
 	tm := metav1.TypeMeta{}
 	tm.SetGroupVersionKind(machineapi.SchemeGroupVersion.WithKind("MachineSet"))
-	if err := remoteClusterAPIClient.List(
+	if err := machineClient.List(
 		context.Background(),
 		remoteMachineSets,
-		&client.ListOptions{Raw: &metav1.ListOptions{TypeMeta: tm}},
+		&client.ListOptions{Namespace: namespace, Raw: &metav1.ListOptions{TypeMeta: tm}},
 	); err != nil {
 		logger.WithError(err).Error("unable to fetch remote machine sets")
 		return nil, err
@@ -445,8 +505,29 @@ func (r *ReconcileRemoteMachineSet) generateMachineSets(
 			ms.Spec.Template.Spec.ObjectMeta.Labels[key] = value
 		}
 
+		// Put the nodes of the MachineSet in the MachineConfigPool of the MachinePool.
+		if pool.Spec.NodeConfig != nil {
+			ms.Spec.Template.Spec.ObjectMeta.Labels[nodeRoleLabel(pool)] = ""
+		}
+
 		// Apply hive MachinePool taints to MachineSet MachineSpec.
 		ms.Spec.Template.Spec.Taints = pool.Spec.Taints
+
+		if controllerutils.IsMachineManagementCentral(cd) {
+			if err := centralizeMachineSet(ms, cd, r.scheme); err != nil {
+				return nil, false, err
+			}
+		}
+
+		// Record the platform configuration of the MachineSet to detect when it changes.
+		hash, err := machineSetTemplateHash(ms)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "could not hash machineset %s", ms.Name)
+		}
+		if ms.Annotations == nil {
+			ms.Annotations = make(map[string]string, 1)
+		}
+		ms.Annotations[templateHashAnnotation] = hash
 	}
 
 	logger.Infof("generated %v worker machine sets", len(generatedMachineSets))
@@ -516,10 +597,12 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 	cd *hivev1.ClusterDeployment,
 	generatedMachineSets []*machineapi.MachineSet,
 	remoteMachineSets *machineapi.MachineSetList,
-	remoteClusterAPIClient client.Client,
+	rollout *machinePoolRollout,
+	machineClient client.Client,
 	logger log.FieldLogger,
 ) ([]*machineapi.MachineSet, error) {
 	result := make([]*machineapi.MachineSet, len(generatedMachineSets))
+	rollingOut := rollout != nil && len(rollout.outdatedReplicas) > 0
 
 	machineSetsToDelete := []*machineapi.MachineSet{}
 	machineSetsToCreate := []*machineapi.MachineSet{}
@@ -533,10 +616,29 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 				found = true
 				objectModified := false
 				objectMetaModified := false
-				resourcemerge.EnsureObjectMeta(&objectMetaModified, &rMS.ObjectMeta, ms.ObjectMeta)
+				// The template hash records the platform configuration of the template of the remote MachineSet, so
+				// it is only updated along with the template.
+				desiredMeta := ms.ObjectMeta.DeepCopy()
+				delete(desiredMeta.Annotations, templateHashAnnotation)
+				resourcemerge.EnsureObjectMeta(&objectMetaModified, &rMS.ObjectMeta, *desiredMeta)
 				msLog := logger.WithField("machineset", rMS.Name)
 
-				if pool.Spec.Autoscaling == nil {
+				// With the OnDelete update strategy, the new platform configuration is applied to the template of the
+				// MachineSet, and used for the machines created afterwards.
+				desiredTemplateHash, observedTemplateHash := ms.Annotations[templateHashAnnotation], observedTemplateHash(&rMS)
+				if isOnDeleteUpdate(pool) && observedTemplateHash != desiredTemplateHash {
+					msLog.WithField("desired", desiredTemplateHash).
+						WithField("observed", observedTemplateHash).
+						Info("platform configuration out of sync")
+					rMS.Spec.Template.Spec.ProviderSpec = ms.Spec.Template.Spec.ProviderSpec
+					if rMS.Annotations == nil {
+						rMS.Annotations = map[string]string{}
+					}
+					rMS.Annotations[templateHashAnnotation] = desiredTemplateHash
+					objectModified = true
+				}
+
+				if pool.Spec.Autoscaling == nil || rollingOut {
 					if *rMS.Spec.Replicas != *ms.Spec.Replicas {
 						msLog.WithFields(log.Fields{
 							"desired":  *ms.Spec.Replicas,
@@ -615,6 +717,19 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 				}
 			}
 		}
+		// Outdated MachineSets are scaled down by the rollout, and deleted once they have no machines left.
+		if replicas, ok := rollout.outdated(rMS.Name); delete && ok {
+			switch {
+			case rMS.Spec.Replicas == nil || *rMS.Spec.Replicas != replicas:
+				logger.WithField("machineset", rMS.Name).WithField("replicas", replicas).Info("scaling down outdated machineset")
+				remoteMachineSets.Items[i].Spec.Replicas = &replicas
+				remoteMachineSets.Items[i].Generation++
+				machineSetsToUpdate = append(machineSetsToUpdate, &remoteMachineSets.Items[i])
+				continue
+			case replicas > 0 || rMS.Status.Replicas > 0:
+				continue
+			}
+		}
 		if delete {
 			machineSetsToDelete = append(machineSetsToDelete, &remoteMachineSets.Items[i])
 		}
@@ -622,7 +737,7 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 
 	for _, ms := range machineSetsToCreate {
 		logger.WithField("machineset", ms.Name).Info("creating machineset")
-		if err := remoteClusterAPIClient.Create(context.Background(), ms); err != nil {
+		if err := machineClient.Create(context.Background(), ms); err != nil {
 			logger.WithError(err).Error("unable to create machine set")
 			return nil, err
 		}
@@ -630,7 +745,7 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 
 	for _, ms := range machineSetsToUpdate {
 		logger.WithField("machineset", ms.Name).Info("updating machineset")
-		if err := remoteClusterAPIClient.Update(context.Background(), ms); err != nil {
+		if err := machineClient.Update(context.Background(), ms); err != nil {
 			logger.WithError(err).Error("unable to update machine set")
 			return nil, err
 		}
@@ -638,7 +753,7 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 
 	for _, ms := range machineSetsToDelete {
 		logger.WithField("machineset", ms.Name).Info("deleting machineset")
-		if err := remoteClusterAPIClient.Delete(context.Background(), ms); err != nil {
+		if err := machineClient.Delete(context.Background(), ms); err != nil {
 			logger.WithError(err).Error("unable to delete machine set")
 			return nil, err
 		}
@@ -839,14 +954,151 @@ func (r *ReconcileRemoteMachineSet) syncClusterAutoscaler(
 	return nil
 }
 
+func (r *ReconcileRemoteMachineSet) syncMachineHealthChecks(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	machineSets []*machineapi.MachineSet,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	// List MachineHealthChecks from remote cluster
+	remoteMachineHealthChecks := &machineapi.MachineHealthCheckList{}
+	tm := metav1.TypeMeta{}
+	tm.SetGroupVersionKind(machineapi.SchemeGroupVersion.WithKind("MachineHealthCheck"))
+	if err := remoteClusterAPIClient.List(
+		context.Background(),
+		remoteMachineHealthChecks,
+		&client.ListOptions{Raw: &metav1.ListOptions{TypeMeta: tm}},
+	); err != nil {
+		logger.WithError(err).Error("unable to fetch remote machine health checks")
+		return err
+	}
+	logger.Infof("found %v remote machine health checks", len(remoteMachineHealthChecks.Items))
+
+	var desired *machineapi.MachineHealthCheck
+	if pool.DeletionTimestamp == nil && pool.Spec.HealthCheck != nil && len(machineSets) > 0 {
+		desired = generateMachineHealthCheck(pool, cd, machineSets)
+	}
+
+	found := false
+	for i := range remoteMachineHealthChecks.Items {
+		rMHC := &remoteMachineHealthChecks.Items[i]
+		// Only the label is checked: the name of the machine health check of a pool can be a prefix of the name of
+		// the machine health check of another pool.
+		if rMHC.Labels[machinePoolNameLabel] != pool.Spec.Name {
+			continue
+		}
+		mhcLog := logger.WithField("machinehealthcheck", rMHC.Name)
+		if desired == nil || rMHC.Namespace != desired.Namespace || rMHC.Name != desired.Name {
+			mhcLog.Info("deleting machinehealthcheck")
+			if err := remoteClusterAPIClient.Delete(context.Background(), rMHC); err != nil {
+				logger.WithError(err).Error("unable to delete machine health check")
+				return err
+			}
+			continue
+		}
+		found = true
+		objectMetaModified := false
+		resourcemerge.EnsureObjectMeta(&objectMetaModified, &rMHC.ObjectMeta, desired.ObjectMeta)
+		specModified := !reflect.DeepEqual(rMHC.Spec, desired.Spec)
+		if specModified {
+			mhcLog.WithField("desired", desired.Spec).WithField("observed", rMHC.Spec).Info("spec out of sync")
+			rMHC.Spec = desired.Spec
+		}
+		if objectMetaModified || specModified {
+			mhcLog.Info("updating machinehealthcheck")
+			if err := remoteClusterAPIClient.Update(context.Background(), rMHC); err != nil {
+				logger.WithError(err).Error("unable to update machine health check")
+				return err
+			}
+		}
+	}
+
+	if desired != nil && !found {
+		logger.WithField("machinehealthcheck", desired.Name).Info("creating machinehealthcheck")
+		if err := remoteClusterAPIClient.Create(context.Background(), desired); err != nil {
+			logger.WithError(err).Error("unable to create machine health check")
+			return err
+		}
+	}
+
+	logger.Info("done reconciling machine health checks for machine pool")
+	return nil
+}
+
+// generateMachineHealthCheck generates the MachineHealthCheck for the health check of the machine pool. The
+// MachineHealthCheck selects the machines of the MachineSets of the machine pool. The values defaulted by the
+// remote cluster are set explicitly so that the MachineHealthCheck does not appear out of sync afterwards.
+func generateMachineHealthCheck(pool *hivev1.MachinePool, cd *hivev1.ClusterDeployment, machineSets []*machineapi.MachineSet) *machineapi.MachineHealthCheck {
+	healthCheck := pool.Spec.HealthCheck
+
+	machineSetNames := make([]string, len(machineSets))
+	for i, ms := range machineSets {
+		machineSetNames[i] = ms.Name
+	}
+	sort.Strings(machineSetNames)
+
+	unhealthyConditions := defaultUnhealthyConditions
+	if len(healthCheck.UnhealthyConditions) > 0 {
+		unhealthyConditions = make([]machineapi.UnhealthyCondition, len(healthCheck.UnhealthyConditions))
+		for i, c := range healthCheck.UnhealthyConditions {
+			unhealthyConditions[i] = machineapi.UnhealthyCondition{
+				Type:    c.Type,
+				Status:  c.Status,
+				Timeout: c.Timeout,
+			}
+		}
+	}
+
+	maxUnhealthy := intstr.FromString("100%")
+	if healthCheck.MaxUnhealthy != nil {
+		maxUnhealthy = *healthCheck.MaxUnhealthy
+	}
+
+	nodeStartupTimeout := metav1.Duration{Duration: 10 * time.Minute}
+	if healthCheck.NodeStartupTimeout != nil {
+		nodeStartupTimeout = *healthCheck.NodeStartupTimeout
+	}
+
+	return &machineapi.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: machineAPINamespace,
+			Name:      fmt.Sprintf("%s-%s", cd.Spec.ClusterMetadata.InfraID, pool.Spec.Name),
+			Labels: map[string]string{
+				machinePoolNameLabel:       pool.Spec.Name,
+				constants.HiveManagedLabel: "true",
+			},
+		},
+		Spec: machineapi.MachineHealthCheckSpec{
+			Selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      machineSetNameLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   machineSetNames,
+				}},
+			},
+			UnhealthyConditions: unhealthyConditions,
+			MaxUnhealthy:        &maxUnhealthy,
+			NodeStartupTimeout:  nodeStartupTimeout,
+		},
+	}
+}
+
 func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 	pool *hivev1.MachinePool,
 	machineSets []*machineapi.MachineSet,
+	rollout *machinePoolRollout,
+	machineClient client.Client,
 	remoteClusterAPIClient client.Client,
 	logger log.FieldLogger,
 ) (reconcile.Result, error) {
 	origPool := pool.DeepCopy()
 
+	pool.Status.Rollout = nil
+	if rollout != nil {
+		pool.Status.Rollout = rollout.status
+	}
+
 	pool.Status.MachineSets = make([]hivev1.MachineSetStatus, len(machineSets))
 	pool.Status.Replicas = 0
 	for i, ms := range machineSets {
@@ -867,7 +1119,7 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 			ErrorMessage:  ms.Status.ErrorMessage,
 		}
 		if s.Replicas != s.ReadyReplicas && s.ErrorReason == nil {
-			r, m := summarizeMachinesError(remoteClusterAPIClient, ms, logger)
+			r, m := summarizeMachinesError(machineClient, ms, logger)
 			s.ErrorReason = &r
 			s.ErrorMessage = &m
 		}
@@ -876,6 +1128,8 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 		pool.Status.Replicas += *ms.Spec.Replicas
 	}
 
+	r.updatePoolStatusForMachines(pool, machineSets, machineClient, remoteClusterAPIClient, logger)
+
 	var requeueAfter time.Duration
 	for _, ms := range pool.Status.MachineSets {
 		if ms.Replicas != ms.ReadyReplicas {
@@ -886,6 +1140,17 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 			break
 		}
 	}
+	for _, m := range pool.Status.Machines {
+		if machineFailed(m) || nodeNotReady(m) {
+			// likewise, remote machines and nodes cannot trigger reconcile.
+			requeueAfter = 10 * time.Minute
+			break
+		}
+	}
+	if rollout != nil && len(rollout.outdatedReplicas) > 0 {
+		// The rollout progresses as the machines of the remote cluster become ready, which cannot trigger reconcile.
+		requeueAfter = time.Minute
+	}
 
 	if (len(origPool.Status.MachineSets) == 0 && len(pool.Status.MachineSets) == 0) ||
 		reflect.DeepEqual(origPool.Status, pool.Status) {
@@ -898,7 +1163,7 @@ func (r *ReconcileRemoteMachineSet) updatePoolStatusForMachineSets(
 // summarizeMachinesError returns reason and message for error state of machineSets by
 // summarizing error reasons and messages from machines the belong to the machineset.
 // If all the machines are in good state, it returns empty reason and message.
-func summarizeMachinesError(remoteClusterAPIClient client.Client, machineSet *machineapi.MachineSet, logger log.FieldLogger) (string, string) {
+func summarizeMachinesError(machineClient client.Client, machineSet *machineapi.MachineSet, logger log.FieldLogger) (string, string) {
 	msLog := logger.WithField("machineSet", machineSet.Name)
 
 	sel, err := metav1.LabelSelectorAsSelector(&machineSet.Spec.Selector)
@@ -908,7 +1173,7 @@ func summarizeMachinesError(remoteClusterAPIClient client.Client, machineSet *ma
 	}
 
 	list := &machineapi.MachineList{}
-	err = remoteClusterAPIClient.List(context.TODO(), list,
+	err = machineClient.List(context.TODO(), list,
 		client.InNamespace(machineSet.GetNamespace()),
 		client.MatchingLabelsSelector{Selector: sel})
 	if err != nil {
@@ -1043,15 +1308,9 @@ func (r *ReconcileRemoteMachineSet) removeFinalizer(pool *hivev1.MachinePool, lo
 }
 
 func getMinMaxReplicasForMachineSet(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet, machineSetIndex int) (min, max int32) {
-	noOfMachineSets := int32(len(machineSets))
-	min = pool.Spec.Autoscaling.MinReplicas / noOfMachineSets
-	if int32(machineSetIndex) < pool.Spec.Autoscaling.MinReplicas%noOfMachineSets {
-		min++
-	}
-	max = pool.Spec.Autoscaling.MaxReplicas / noOfMachineSets
-	if int32(machineSetIndex) < pool.Spec.Autoscaling.MaxReplicas%noOfMachineSets {
-		max++
-	}
+	zones := machineSetZones(machineSets)
+	min = distributeReplicas(pool.Spec.Autoscaling.MinReplicas, zones, pool.Spec.ZoneDistribution)[machineSetIndex]
+	max = distributeReplicas(pool.Spec.Autoscaling.MaxReplicas, zones, pool.Spec.ZoneDistribution)[machineSetIndex]
 	if max < min {
 		max = min
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 
@@ -19,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 	awsproviderapis "sigs.k8s.io/cluster-api-provider-aws/pkg/apis"
@@ -41,15 +43,14 @@ import (
 )
 
 const (
-	testName            = "foo"
-	testNamespace       = "default"
-	testClusterID       = "foo-12345-uuid"
-	testInfraID         = "foo-12345"
-	machineAPINamespace = "openshift-machine-api"
-	testAMI             = "ami-totallyfake"
-	testRegion          = "test-region"
-	testPoolName        = "worker"
-	testInstanceType    = "test-instance-type"
+	testName         = "foo"
+	testNamespace    = "default"
+	testClusterID    = "foo-12345-uuid"
+	testInfraID      = "foo-12345"
+	testAMI          = "ami-totallyfake"
+	testRegion       = "test-region"
+	testPoolName     = "worker"
+	testInstanceType = "test-instance-type"
 )
 
 func init() {
@@ -105,18 +106,31 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		return nil, err
 	}
 
+	// Utility function to list test MachineHealthChecks from the fake client
+	getRMHCL := func(rc client.Client) (*machineapi.MachineHealthCheckList, error) {
+		rMHCL := &machineapi.MachineHealthCheckList{}
+		tm := metav1.TypeMeta{}
+		tm.SetGroupVersionKind(machineapi.SchemeGroupVersion.WithKind("MachineHealthCheck"))
+		err := rc.List(context.TODO(), rMHCL, &client.ListOptions{Raw: &metav1.ListOptions{TypeMeta: tm}})
+		if err == nil {
+			return rMHCL, err
+		}
+		return nil, err
+	}
+
 	tests := []struct {
-		name                             string
-		clusterDeployment                *hivev1.ClusterDeployment
-		machinePool                      *hivev1.MachinePool
-		remoteExisting                   []runtime.Object
-		generatedMachineSets             []*machineapi.MachineSet
-		actuatorDoNotProceed             bool
-		expectErr                        bool
-		expectNoFinalizer                bool
-		expectedRemoteMachineSets        []*machineapi.MachineSet
-		expectedRemoteMachineAutoscalers []autoscalingv1beta1.MachineAutoscaler
-		expectedRemoteClusterAutoscalers []autoscalingv1.ClusterAutoscaler
+		name                              string
+		clusterDeployment                 *hivev1.ClusterDeployment
+		machinePool                       *hivev1.MachinePool
+		remoteExisting                    []runtime.Object
+		generatedMachineSets              []*machineapi.MachineSet
+		actuatorDoNotProceed              bool
+		expectErr                         bool
+		expectNoFinalizer                 bool
+		expectedRemoteMachineSets         []*machineapi.MachineSet
+		expectedRemoteMachineAutoscalers  []autoscalingv1beta1.MachineAutoscaler
+		expectedRemoteClusterAutoscalers  []autoscalingv1.ClusterAutoscaler
+		expectedRemoteMachineHealthChecks []machineapi.MachineHealthCheck
 	}{
 		{
 			name: "Cluster not installed yet",
@@ -644,6 +658,272 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				*testClusterAutoscaler("1"),
 			},
 		},
+		{
+			name:              "Create machine health check",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       withHealthCheck(testMachinePool()),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineHealthChecks: []machineapi.MachineHealthCheck{
+				*testMachineHealthCheck("1", "40%", "foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"),
+			},
+		},
+		{
+			name:              "Create machine health check with defaults",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				p := testMachinePool()
+				p.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{}
+				return p
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineHealthChecks: []machineapi.MachineHealthCheck{
+				func() machineapi.MachineHealthCheck {
+					mhc := testMachineHealthCheck("1", "100%", "foo-12345-worker-us-east-1a")
+					mhc.Spec.UnhealthyConditions = defaultUnhealthyConditions
+					mhc.Spec.NodeStartupTimeout = metav1.Duration{Duration: 10 * time.Minute}
+					return *mhc
+				}(),
+			},
+		},
+		{
+			name:              "Update machine health check",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       withHealthCheck(testMachinePool()),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineHealthCheck("1", "1", "foo-12345-worker-us-east-1a"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineHealthChecks: []machineapi.MachineHealthCheck{
+				*testMachineHealthCheck("2", "40%", "foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"),
+			},
+		},
+		{
+			name:              "No-op machine health check",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       withHealthCheck(testMachinePool()),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineHealthCheck("1", "40%", "foo-12345-worker-us-east-1a"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineHealthChecks: []machineapi.MachineHealthCheck{
+				*testMachineHealthCheck("1", "40%", "foo-12345-worker-us-east-1a"),
+			},
+		},
+		{
+			name:              "Delete machine health check when health check removed",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineHealthCheck("1", "40%", "foo-12345-worker-us-east-1a"),
+				func() *machineapi.MachineHealthCheck {
+					mhc := testMachineHealthCheck("1", "40%", "foo-12345-infra-us-east-1a")
+					mhc.Name = "foo-12345-infra"
+					mhc.Labels[machinePoolNameLabel] = "infra"
+					return mhc
+				}(),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+			},
+			expectedRemoteMachineHealthChecks: []machineapi.MachineHealthCheck{
+				func() machineapi.MachineHealthCheck {
+					mhc := testMachineHealthCheck("1", "40%", "foo-12345-infra-us-east-1a")
+					mhc.Name = "foo-12345-infra"
+					mhc.Labels[machinePoolNameLabel] = "infra"
+					return *mhc
+				}(),
+			},
+		},
+		{
+			name:              "Delete machine health check for deleted machinepool",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				mp := withHealthCheck(testMachinePool())
+				now := metav1.Now()
+				mp.DeletionTimestamp = &now
+				return mp
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineHealthCheck("1", "40%", "foo-12345-worker-us-east-1a"),
+			},
+			expectNoFinalizer: true,
+		},
+		{
+			name:              "Rolling update creates replacement machineset",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testRollingUpdateMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withReplicaStatus(withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old"), 3, 3),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old"),
+				testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 1, 0),
+			},
+		},
+		{
+			name:              "Rolling update scales down outdated machineset",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testRollingUpdateMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withReplicaStatus(withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old"), 3, 3),
+				withReplicaStatus(testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 1, 0), 1, 1),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 2, 1), "ami-old"),
+				testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 1, 0),
+			},
+		},
+		{
+			name:              "Rolling update waits for outdated machines to be deleted",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testRollingUpdateMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withReplicaStatus(withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 0, 0), "ami-old"), 1, 0),
+				withReplicaStatus(testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 3, 0), 3, 3),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 0, 0), "ami-old"),
+				testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 3, 0),
+			},
+		},
+		{
+			name:              "Rolling update deletes drained outdated machineset",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testRollingUpdateMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 0, 0), "ami-old"),
+				withReplicaStatus(testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 3, 0), 3, 3),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 3, 0),
+			},
+		},
+		{
+			name:              "Rolling update adopts current machinesets without template hash",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testRollingUpdateMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withoutTemplateHash(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0)),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withoutTemplateHash(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0)),
+			},
+		},
+		{
+			name:              "Rolling update replaces outdated machinesets without template hash",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testRollingUpdateMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withReplicaStatus(withoutTemplateHash(withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old")), 3, 3),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withoutTemplateHash(withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old")),
+				testMachineSet(replacementMachineSetNameForTest("foo-12345-worker-us-east-1a"), "worker", false, 1, 0),
+			},
+		},
+		{
+			name:              "No update strategy keeps template and template hash",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old"),
+			},
+		},
+		{
+			name:              "OnDelete update updates machineset template",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				p := testMachinePool()
+				p.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{Type: hivev1.OnDeleteMachinePoolUpdateStrategyType}
+				return p
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 0), "ami-old"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 3, 1),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -651,6 +931,7 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		machineapi.SchemeBuilder.AddToScheme(scheme.Scheme)
 		autoscalingv1.SchemeBuilder.AddToScheme(scheme.Scheme)
 		autoscalingv1beta1.SchemeBuilder.AddToScheme(scheme.Scheme)
+		addMachineConfigTypesToScheme(scheme.Scheme)
 		t.Run(test.name, func(t *testing.T) {
 			localExisting := []runtime.Object{}
 			if test.clusterDeployment != nil {
@@ -774,10 +1055,86 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 			if rCAL, err := getRCAL(remoteFakeClient); assert.NoError(t, err, "error getting cluster autoscalers") {
 				assert.ElementsMatch(t, test.expectedRemoteClusterAutoscalers, rCAL.Items, "unexpected remote cluster autoscalers")
 			}
+
+			if rMHCL, err := getRMHCL(remoteFakeClient); assert.NoError(t, err, "error getting machine health checks") {
+				assert.ElementsMatch(t, test.expectedRemoteMachineHealthChecks, rMHCL.Items, "unexpected remote machine health checks")
+			}
 		})
 	}
 }
 
+// TestRemoteMachineSetReconcileUpdateStrategyEnabled tests that a platform configuration changed while the machine pool
+// has no update strategy is rolled out once an update strategy is enabled.
+func TestRemoteMachineSetReconcileUpdateStrategyEnabled(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	machineapi.SchemeBuilder.AddToScheme(scheme.Scheme)
+	autoscalingv1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	autoscalingv1beta1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	addMachineConfigTypesToScheme(scheme.Scheme)
+
+	cd, pool := testClusterDeployment(), testMachinePool()
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(cd, pool).Build()
+	remoteFakeClient := fake.NewClientBuilder().WithRuntimeObjects(
+		testMachine("master1", "master"),
+		withReplicaStatus(withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0), "ami-old"), 3, 3),
+	).Build()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockActuator := mock.NewMockActuator(mockCtrl)
+	mockActuator.EXPECT().GenerateMachineSets(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(*hivev1.ClusterDeployment, *hivev1.MachinePool, log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+			return []*machineapi.MachineSet{testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0)}, true, nil
+		}).Times(2)
+	mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+	mockRemoteClientBuilder.EXPECT().Build().Return(remoteFakeClient, nil).AnyTimes()
+
+	logger := log.WithField("controller", "remotemachineset")
+	rcd := &ReconcileRemoteMachineSet{
+		Client:                        fakeClient,
+		scheme:                        scheme.Scheme,
+		logger:                        logger,
+		remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+		actuatorBuilder: func(*hivev1.ClusterDeployment, *hivev1.MachinePool, *machineapi.Machine, []machineapi.MachineSet, log.FieldLogger) (Actuator, error) {
+			return mockActuator, nil
+		},
+		expectations: controllerutils.NewExpectations(logger),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: pool.Name}}
+	getMachineSets := func() []machineapi.MachineSet {
+		rMSL := &machineapi.MachineSetList{}
+		require.NoError(t, remoteFakeClient.List(context.TODO(), rMSL), "unexpected error listing machinesets")
+		return rMSL.Items
+	}
+
+	// Without an update strategy, the template of the machineset is left as is, and so is its template hash.
+	_, err := rcd.Reconcile(context.TODO(), request)
+	require.NoError(t, err, "unexpected error reconciling without update strategy")
+	machineSets := getMachineSets()
+	require.Len(t, machineSets, 1, "unexpected machinesets")
+	assert.Equal(t,
+		withAMI(testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0), "ami-old").Annotations[templateHashAnnotation],
+		machineSets[0].Annotations[templateHashAnnotation],
+		"unexpected template hash without update strategy",
+	)
+
+	// Once the rolling update strategy is enabled, the outdated machineset is replaced.
+	require.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, pool), "unexpected error getting machinepool")
+	pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{Type: hivev1.RollingUpdateMachinePoolUpdateStrategyType}
+	require.NoError(t, fakeClient.Update(context.TODO(), pool), "unexpected error updating machinepool")
+	_, err = rcd.Reconcile(context.TODO(), request)
+	require.NoError(t, err, "unexpected error reconciling with rolling update strategy")
+	names := []string{}
+	for _, ms := range getMachineSets() {
+		names = append(names, ms.Name)
+	}
+	assert.ElementsMatch(t,
+		[]string{"foo-12345-worker-us-east-1a", replacementMachineSetNameForTest("foo-12345-worker-us-east-1a")},
+		names,
+		"expected replacement machineset",
+	)
+}
+
 func Test_summarizeMachinesError(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -909,6 +1266,14 @@ func testMachinePool() *hivev1.MachinePool {
 					Status: corev1.ConditionUnknown,
 					Type:   hivev1.UnsupportedConfigurationMachinePoolCondition,
 				},
+				{
+					Status: corev1.ConditionUnknown,
+					Type:   hivev1.MachinesFailedToProvisionMachinePoolCondition,
+				},
+				{
+					Status: corev1.ConditionUnknown,
+					Type:   hivev1.NodesNotReadyMachinePoolCondition,
+				},
 			},
 		},
 	}
@@ -1026,11 +1391,16 @@ func testMachineSet(name string, machineType string, unstompedAnnotation bool, r
 			},
 		},
 	}
+	hash, err := machineSetTemplateHash(&ms)
+	if err != nil {
+		log.WithError(err).Fatal("error hashing machineset template")
+	}
+	ms.Annotations = map[string]string{
+		templateHashAnnotation: hash,
+	}
 	// Add a pre-existing annotation which we will ensure remains in updated machinesets.
 	if unstompedAnnotation {
-		ms.Annotations = map[string]string{
-			"hive.openshift.io/unstomped": "true",
-		}
+		ms.Annotations["hive.openshift.io/unstomped"] = "true"
 	}
 	return &ms
 }
@@ -1071,6 +1441,97 @@ func testClusterAutoscaler(resourceVersion string) *autoscalingv1.ClusterAutosca
 	}
 }
 
+func testRollingUpdateMachinePool() *hivev1.MachinePool {
+	p := testMachinePool()
+	p.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{Type: hivev1.RollingUpdateMachinePoolUpdateStrategyType}
+	return p
+}
+
+// withAMI sets the AMI of the machineset, as if it had been generated for another platform configuration.
+func withAMI(ms *machineapi.MachineSet, ami string) *machineapi.MachineSet {
+	providerSpec := testAWSProviderSpec()
+	providerSpec.AMI.ID = aws.String(ami)
+	raw, err := encodeAWSMachineProviderSpec(providerSpec, scheme.Scheme)
+	if err != nil {
+		log.WithError(err).Fatal("error encoding AWS machine provider spec")
+	}
+	ms.Spec.Template.Spec.ProviderSpec.Value = raw
+	hash, err := machineSetTemplateHash(ms)
+	if err != nil {
+		log.WithError(err).Fatal("error hashing machineset template")
+	}
+	ms.Annotations[templateHashAnnotation] = hash
+	return ms
+}
+
+// withoutTemplateHash removes the template hash of the machineset, as if it had been created before the hash was
+// recorded.
+func withoutTemplateHash(ms *machineapi.MachineSet) *machineapi.MachineSet {
+	delete(ms.Annotations, templateHashAnnotation)
+	return ms
+}
+
+func withReplicaStatus(ms *machineapi.MachineSet, replicas, readyReplicas int) *machineapi.MachineSet {
+	ms.Status.Replicas = int32(replicas)
+	ms.Status.ReadyReplicas = int32(readyReplicas)
+	return ms
+}
+
+// replacementMachineSetNameForTest returns the name of the machineset replacing the machineset of the given name.
+func replacementMachineSetNameForTest(name string) string {
+	hash := testMachineSet(name, "worker", false, 0, 0).Annotations[templateHashAnnotation]
+	return fmt.Sprintf("%s-%s", name, hash[:templateHashSuffixLength])
+}
+
+func withHealthCheck(pool *hivev1.MachinePool) *hivev1.MachinePool {
+	maxUnhealthy := intstr.FromString("40%")
+	pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{
+		UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{
+			{
+				Type:    corev1.NodeReady,
+				Status:  corev1.ConditionFalse,
+				Timeout: metav1.Duration{Duration: 8 * time.Minute},
+			},
+		},
+		MaxUnhealthy:       &maxUnhealthy,
+		NodeStartupTimeout: &metav1.Duration{Duration: 20 * time.Minute},
+	}
+	return pool
+}
+
+func testMachineHealthCheck(resourceVersion string, maxUnhealthy string, machineSetNames ...string) *machineapi.MachineHealthCheck {
+	mu := intstr.Parse(maxUnhealthy)
+	return &machineapi.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       machineAPINamespace,
+			Name:            "foo-12345-worker",
+			ResourceVersion: resourceVersion,
+			Labels: map[string]string{
+				machinePoolNameLabel:       "worker",
+				constants.HiveManagedLabel: "true",
+			},
+		},
+		Spec: machineapi.MachineHealthCheckSpec{
+			Selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      machineSetNameLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   machineSetNames,
+				}},
+			},
+			UnhealthyConditions: []machineapi.UnhealthyCondition{
+				{
+					Type:    corev1.NodeReady,
+					Status:  corev1.ConditionFalse,
+					Timeout: metav1.Duration{Duration: 8 * time.Minute},
+				},
+			},
+			MaxUnhealthy:       &mu,
+			NodeStartupTimeout: metav1.Duration{Duration: 20 * time.Minute},
+		},
+	}
+}
+
 func testClusterDeployment() *hivev1.ClusterDeployment {
 	return &hivev1.ClusterDeployment{
 		TypeMeta: metav1.TypeMeta{
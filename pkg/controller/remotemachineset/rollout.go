@@ -0,0 +1,249 @@
+package remotemachineset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// templateHashAnnotation is the hash of the platform configuration of the MachineSets generated for a machine
+	// pool. It tells apart the MachineSets with the current platform configuration from the outdated ones.
+	templateHashAnnotation = "hive.openshift.io/machineset-template-hash"
+
+	// templateHashSuffixLength is the length of the suffix of the names of replacement MachineSets.
+	templateHashSuffixLength = 5
+)
+
+// machineSetTemplateHash returns the hash of the provider spec of the MachineSet. The provider spec is re-encoded
+// with sorted keys so that the hash does not depend on the order of its fields.
+func machineSetTemplateHash(ms *machineapi.MachineSet) (string, error) {
+	raw, err := json.Marshal(ms.Spec.Template.Spec.ProviderSpec.Value)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode provider spec")
+	}
+	var providerSpec interface{}
+	if err := json.Unmarshal(raw, &providerSpec); err != nil {
+		return "", errors.Wrap(err, "could not decode provider spec")
+	}
+	raw, err = json.Marshal(providerSpec)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode provider spec")
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+func isRollingUpdate(pool *hivev1.MachinePool) bool {
+	return pool.Spec.UpdateStrategy != nil && pool.Spec.UpdateStrategy.Type == hivev1.RollingUpdateMachinePoolUpdateStrategyType
+}
+
+func isOnDeleteUpdate(pool *hivev1.MachinePool) bool {
+	return pool.Spec.UpdateStrategy != nil && pool.Spec.UpdateStrategy.Type == hivev1.OnDeleteMachinePoolUpdateStrategyType
+}
+
+// machinePoolRollout is a step of the replacement of the outdated MachineSets of a machine pool.
+type machinePoolRollout struct {
+	// outdatedReplicas are the replicas of the outdated MachineSets for this step, by name.
+	outdatedReplicas map[string]int32
+	status           *hivev1.MachinePoolRolloutStatus
+}
+
+// outdated returns the replicas of the outdated MachineSet for this step of the rollout, and whether the MachineSet is
+// outdated.
+func (r *machinePoolRollout) outdated(name string) (int32, bool) {
+	if r == nil {
+		return 0, false
+	}
+	replicas, ok := r.outdatedReplicas[name]
+	return replicas, ok
+}
+
+// planRollout plans the next step of the rollout of the machine pool. The generated MachineSets whose platform
+// configuration differs from the one of the remote MachineSet of the same name are renamed, so that they are created
+// as replacements, and their replicas are set for this step. As with Deployments, the replacement MachineSets are
+// scaled up as long as the total replicas stay within the replicas of the pool plus maxSurge, and the outdated
+// MachineSets are scaled down as long as the ready replicas stay above the replicas of the pool minus maxUnavailable.
+func planRollout(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	generatedMachineSets []*machineapi.MachineSet,
+	remoteMachineSets *machineapi.MachineSetList,
+) *machinePoolRollout {
+	remoteByName := map[string]*machineapi.MachineSet{}
+	for i, rMS := range remoteMachineSets.Items {
+		if isControlledByMachinePool(cd, pool, &rMS) {
+			remoteByName[rMS.Name] = &remoteMachineSets.Items[i]
+		}
+	}
+
+	current := map[string]bool{}
+	var desiredReplicas, newReplicas, newReadyReplicas int32
+	currentReplicas := make([]int32, len(generatedMachineSets))
+	for i, ms := range generatedMachineSets {
+		hash := ms.Annotations[templateHashAnnotation]
+		ms.Name = replacementMachineSetName(ms.Name, hash, remoteByName)
+		current[ms.Name] = true
+		desiredReplicas += *ms.Spec.Replicas
+		if rMS, ok := remoteByName[ms.Name]; ok && rMS.Spec.Replicas != nil {
+			currentReplicas[i] = *rMS.Spec.Replicas
+			newReadyReplicas += rMS.Status.ReadyReplicas
+		}
+		newReplicas += currentReplicas[i]
+	}
+
+	var outdated []*machineapi.MachineSet
+	var outdatedReplicas, outdatedReadyReplicas int32
+	for name, rMS := range remoteByName {
+		if current[name] {
+			continue
+		}
+		outdated = append(outdated, rMS)
+		if rMS.Spec.Replicas != nil {
+			outdatedReplicas += *rMS.Spec.Replicas
+		}
+		outdatedReadyReplicas += rMS.Status.ReadyReplicas
+	}
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i].Name < outdated[j].Name })
+
+	rollout := &machinePoolRollout{
+		outdatedReplicas: map[string]int32{},
+		status:           &hivev1.MachinePoolRolloutStatus{},
+	}
+	if prev := pool.Status.Rollout; prev != nil {
+		rollout.status.StartTime = prev.StartTime
+		rollout.status.CompletionTime = prev.CompletionTime
+	}
+
+	if len(outdated) == 0 {
+		for _, ms := range generatedMachineSets {
+			rollout.status.UpdatedReplicas += *ms.Spec.Replicas
+		}
+		rollout.status.UpdatedReadyReplicas = newReadyReplicas
+		if rollout.status.StartTime != nil && rollout.status.CompletionTime == nil {
+			now := metav1.Now()
+			rollout.status.CompletionTime = &now
+		}
+		return rollout
+	}
+
+	if rollout.status.StartTime == nil || rollout.status.CompletionTime != nil {
+		now := metav1.Now()
+		rollout.status.StartTime = &now
+		rollout.status.CompletionTime = nil
+	}
+
+	maxSurge, maxUnavailable := resolveRollingUpdateLimits(pool.Spec.UpdateStrategy.RollingUpdate, desiredReplicas)
+
+	// Scale up the replacement MachineSets within the surge.
+	room := desiredReplicas + maxSurge - newReplicas - outdatedReplicas
+	for i, ms := range generatedMachineSets {
+		replicas := currentReplicas[i]
+		switch target := *ms.Spec.Replicas; {
+		case replicas > target:
+			replicas = target
+		case replicas < target && room > 0:
+			add := target - replicas
+			if add > room {
+				add = room
+			}
+			replicas += add
+			room -= add
+		}
+		r := replicas
+		ms.Spec.Replicas = &r
+		rollout.status.UpdatedReplicas += replicas
+	}
+	rollout.status.UpdatedReadyReplicas = newReadyReplicas
+
+	// Scale down the outdated MachineSets within the availability. Replicas that are not ready do not count towards
+	// the availability, so they are removed first.
+	toRemove := newReadyReplicas + outdatedReadyReplicas - (desiredReplicas - maxUnavailable)
+	for _, rMS := range outdated {
+		replicas := rMS.Status.ReadyReplicas
+		if rMS.Spec.Replicas != nil && *rMS.Spec.Replicas < replicas {
+			replicas = *rMS.Spec.Replicas
+		}
+		if toRemove > 0 {
+			remove := replicas
+			if remove > toRemove {
+				remove = toRemove
+			}
+			replicas -= remove
+			toRemove -= remove
+		}
+		rollout.outdatedReplicas[rMS.Name] = replicas
+		rollout.status.OutdatedReplicas += replicas
+		rollout.status.OutdatedMachineSets = append(rollout.status.OutdatedMachineSets, rMS.Name)
+	}
+
+	return rollout
+}
+
+// replacementMachineSetName returns the name of the MachineSet with the platform configuration of the hash. It is the
+// generated name, unless a MachineSet with a different platform configuration already has it, in which case the
+// replacement MachineSet gets the hash as a suffix.
+func replacementMachineSetName(name, hash string, remoteByName map[string]*machineapi.MachineSet) string {
+	suffixed := fmt.Sprintf("%s-%s", name, hash[:templateHashSuffixLength])
+	for _, candidate := range []string{name, suffixed} {
+		if rMS, ok := remoteByName[candidate]; ok && observedTemplateHash(rMS) == hash {
+			return candidate
+		}
+	}
+	if _, ok := remoteByName[name]; !ok {
+		return name
+	}
+	return suffixed
+}
+
+// observedTemplateHash returns the hash of the platform configuration of the template of the remote MachineSet. The
+// hash is recorded when the template is written. For MachineSets created before the hash was recorded, it is computed
+// from the template, so that they are adopted when their platform configuration is current.
+func observedTemplateHash(rMS *machineapi.MachineSet) string {
+	if hash := rMS.Annotations[templateHashAnnotation]; hash != "" {
+		return hash
+	}
+	hash, err := machineSetTemplateHash(rMS)
+	if err != nil {
+		return ""
+	}
+	return hash
+}
+
+// resolveRollingUpdateLimits returns the maximum number of replicas above, and of unavailable replicas below, the
+// replicas of the machine pool. The surge is at least 1 when no replica may be unavailable, so that the rollout
+// progresses.
+func resolveRollingUpdateLimits(rollingUpdate *hivev1.MachinePoolRollingUpdate, replicas int32) (maxSurge, maxUnavailable int32) {
+	surge, unavailable := intstr.FromInt(1), intstr.FromInt(0)
+	if rollingUpdate != nil {
+		if rollingUpdate.MaxSurge != nil {
+			surge = *rollingUpdate.MaxSurge
+		}
+		if rollingUpdate.MaxUnavailable != nil {
+			unavailable = *rollingUpdate.MaxUnavailable
+		}
+	}
+	s, err := intstr.GetScaledValueFromIntOrPercent(&surge, int(replicas), true)
+	if err != nil || s < 0 {
+		s = 1
+	}
+	u, err := intstr.GetScaledValueFromIntOrPercent(&unavailable, int(replicas), false)
+	if err != nil || u < 0 {
+		u = 0
+	}
+	if s == 0 && u == 0 {
+		s = 1
+	}
+	return int32(s), int32(u)
+}
@@ -0,0 +1,184 @@
+package remotemachineset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	awsproviderapis "sigs.k8s.io/cluster-api-provider-aws/pkg/apis"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestPlanRollout(t *testing.T) {
+	awsproviderapis.AddToScheme(scheme.Scheme)
+	zoneA, zoneB := "foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"
+	intOrStr := func(s string) *intstr.IntOrString {
+		v := intstr.Parse(s)
+		return &v
+	}
+	cases := []struct {
+		name                     string
+		rollingUpdate            *hivev1.MachinePoolRollingUpdate
+		remote                   []*machineapi.MachineSet
+		expectedNames            []string
+		expectedReplicas         []int32
+		expectedOutdatedReplicas map[string]int32
+		expectedStatus           hivev1.MachinePoolRolloutStatus
+	}{
+		{
+			name: "up to date",
+			remote: []*machineapi.MachineSet{
+				withReplicaStatus(testMachineSet(zoneA, "worker", false, 2, 0), 2, 2),
+				withReplicaStatus(testMachineSet(zoneB, "worker", false, 2, 0), 2, 1),
+			},
+			expectedNames:            []string{zoneA, zoneB},
+			expectedReplicas:         []int32{2, 2},
+			expectedOutdatedReplicas: map[string]int32{},
+			expectedStatus: hivev1.MachinePoolRolloutStatus{
+				UpdatedReplicas:      4,
+				UpdatedReadyReplicas: 3,
+			},
+		},
+		{
+			name: "start with default surge",
+			remote: []*machineapi.MachineSet{
+				withReplicaStatus(withAMI(testMachineSet(zoneA, "worker", false, 2, 0), "ami-old"), 2, 2),
+				withReplicaStatus(withAMI(testMachineSet(zoneB, "worker", false, 2, 0), "ami-old"), 2, 2),
+			},
+			expectedNames:            []string{replacementMachineSetNameForTest(zoneA), replacementMachineSetNameForTest(zoneB)},
+			expectedReplicas:         []int32{1, 0},
+			expectedOutdatedReplicas: map[string]int32{zoneA: 2, zoneB: 2},
+			expectedStatus: hivev1.MachinePoolRolloutStatus{
+				UpdatedReplicas:     1,
+				OutdatedReplicas:    4,
+				OutdatedMachineSets: []string{zoneA, zoneB},
+			},
+		},
+		{
+			name:          "start with max unavailable",
+			rollingUpdate: &hivev1.MachinePoolRollingUpdate{MaxSurge: intOrStr("0"), MaxUnavailable: intOrStr("50%")},
+			remote: []*machineapi.MachineSet{
+				withReplicaStatus(withAMI(testMachineSet(zoneA, "worker", false, 2, 0), "ami-old"), 2, 2),
+				withReplicaStatus(withAMI(testMachineSet(zoneB, "worker", false, 2, 0), "ami-old"), 2, 2),
+			},
+			expectedNames:            []string{replacementMachineSetNameForTest(zoneA), replacementMachineSetNameForTest(zoneB)},
+			expectedReplicas:         []int32{0, 0},
+			expectedOutdatedReplicas: map[string]int32{zoneA: 0, zoneB: 2},
+			expectedStatus: hivev1.MachinePoolRolloutStatus{
+				OutdatedReplicas:    2,
+				OutdatedMachineSets: []string{zoneA, zoneB},
+			},
+		},
+		{
+			name: "unready outdated replicas removed",
+			remote: []*machineapi.MachineSet{
+				withReplicaStatus(withAMI(testMachineSet(zoneA, "worker", false, 2, 0), "ami-old"), 2, 1),
+				withReplicaStatus(withAMI(testMachineSet(zoneB, "worker", false, 2, 0), "ami-old"), 2, 2),
+				withReplicaStatus(testMachineSet(replacementMachineSetNameForTest(zoneA), "worker", false, 1, 0), 1, 1),
+			},
+			expectedNames:            []string{replacementMachineSetNameForTest(zoneA), replacementMachineSetNameForTest(zoneB)},
+			expectedReplicas:         []int32{1, 0},
+			expectedOutdatedReplicas: map[string]int32{zoneA: 1, zoneB: 2},
+			expectedStatus: hivev1.MachinePoolRolloutStatus{
+				UpdatedReplicas:      1,
+				UpdatedReadyReplicas: 1,
+				OutdatedReplicas:     3,
+				OutdatedMachineSets:  []string{zoneA, zoneB},
+			},
+		},
+		{
+			name: "removed zone",
+			remote: []*machineapi.MachineSet{
+				withReplicaStatus(testMachineSet(zoneA, "worker", false, 2, 0), 2, 2),
+				withReplicaStatus(testMachineSet(zoneB, "worker", false, 2, 0), 2, 2),
+				withReplicaStatus(testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 2, 0), 2, 2),
+			},
+			expectedNames:            []string{zoneA, zoneB},
+			expectedReplicas:         []int32{2, 2},
+			expectedOutdatedReplicas: map[string]int32{"foo-12345-worker-us-east-1c": 0},
+			expectedStatus: hivev1.MachinePoolRolloutStatus{
+				UpdatedReplicas:      4,
+				UpdatedReadyReplicas: 4,
+				OutdatedMachineSets:  []string{"foo-12345-worker-us-east-1c"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testRollingUpdateMachinePool()
+			pool.Spec.UpdateStrategy.RollingUpdate = tc.rollingUpdate
+			remote := &machineapi.MachineSetList{}
+			for _, ms := range tc.remote {
+				remote.Items = append(remote.Items, *ms)
+			}
+			generated := []*machineapi.MachineSet{
+				testMachineSet(zoneA, "worker", false, 2, 0),
+				testMachineSet(zoneB, "worker", false, 2, 0),
+			}
+
+			rollout := planRollout(pool, testClusterDeployment(), generated, remote)
+
+			for i, ms := range generated {
+				assert.Equal(t, tc.expectedNames[i], ms.Name, "unexpected name of machineset %d", i)
+				assert.Equal(t, tc.expectedReplicas[i], *ms.Spec.Replicas, "unexpected replicas of machineset %d", i)
+			}
+			assert.Equal(t, tc.expectedOutdatedReplicas, rollout.outdatedReplicas, "unexpected outdated replicas")
+			if len(tc.expectedOutdatedReplicas) > 0 {
+				assert.NotNil(t, rollout.status.StartTime, "expected start time")
+			}
+			rollout.status.StartTime = nil
+			assert.Equal(t, tc.expectedStatus, *rollout.status, "unexpected status")
+		})
+	}
+}
+
+func TestResolveRollingUpdateLimits(t *testing.T) {
+	intOrStr := func(s string) *intstr.IntOrString {
+		v := intstr.Parse(s)
+		return &v
+	}
+	cases := []struct {
+		name                   string
+		rollingUpdate          *hivev1.MachinePoolRollingUpdate
+		replicas               int32
+		expectedMaxSurge       int32
+		expectedMaxUnavailable int32
+	}{
+		{
+			name:             "defaults",
+			replicas:         10,
+			expectedMaxSurge: 1,
+		},
+		{
+			name:                   "percentages",
+			rollingUpdate:          &hivev1.MachinePoolRollingUpdate{MaxSurge: intOrStr("25%"), MaxUnavailable: intOrStr("25%")},
+			replicas:               10,
+			expectedMaxSurge:       3,
+			expectedMaxUnavailable: 2,
+		},
+		{
+			name:                   "counts",
+			rollingUpdate:          &hivev1.MachinePoolRollingUpdate{MaxSurge: intOrStr("0"), MaxUnavailable: intOrStr("2")},
+			replicas:               10,
+			expectedMaxUnavailable: 2,
+		},
+		{
+			name:             "no progress",
+			rollingUpdate:    &hivev1.MachinePoolRollingUpdate{MaxSurge: intOrStr("0"), MaxUnavailable: intOrStr("10%")},
+			replicas:         5,
+			expectedMaxSurge: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			maxSurge, maxUnavailable := resolveRollingUpdateLimits(tc.rollingUpdate, tc.replicas)
+			assert.Equal(t, tc.expectedMaxSurge, maxSurge, "unexpected max surge")
+			assert.Equal(t, tc.expectedMaxUnavailable, maxUnavailable, "unexpected max unavailable")
+		})
+	}
+}
@@ -85,19 +85,73 @@ func (a *VSphereActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool
 		},
 	}
 
-	installerMachineSets, err := installvsphere.MachineSets(
-		cd.Spec.ClusterMetadata.InfraID,
-		ic,
-		computePool,
-		a.osImage,
-		workerRole,
-		workerUserDataName,
-	)
-	if err != nil {
-		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	zones := pool.Spec.Platform.VSphere.Zones
+	if len(zones) == 0 {
+		installerMachineSets, err := installvsphere.MachineSets(
+			cd.Spec.ClusterMetadata.InfraID,
+			ic,
+			computePool,
+			a.osImage,
+			workerRole,
+			workerUserDataName,
+		)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to generate machinesets")
+		}
+		return installerMachineSets, true, nil
+	}
+
+	// The installer does not know about zones on vSphere, so a MachineSet is generated for each zone with the cluster,
+	// datastore and network of the zone.
+	zoneNames := make([]string, len(zones))
+	for i, zone := range zones {
+		zoneNames[i] = zone.Name
+	}
+	var total int32
+	if pool.Spec.Replicas != nil {
+		total = int32(*pool.Spec.Replicas)
+	}
+	zoneReplicas := distributeReplicas(total, zoneNames, nil)
+	machineSets := make([]*machineapi.MachineSet, 0, len(zones))
+	for i, zone := range zones {
+		zonePlatform := *ic.Platform.VSphere
+		zonePlatform.Cluster = zone.Cluster
+		if zone.Datastore != "" {
+			zonePlatform.DefaultDatastore = zone.Datastore
+		}
+		if zone.Network != "" {
+			zonePlatform.Network = zone.Network
+		}
+		zoneIC := &installertypes.InstallConfig{
+			Platform: installertypes.Platform{VSphere: &zonePlatform},
+		}
+		zoneMachineSets, err := installvsphere.MachineSets(
+			cd.Spec.ClusterMetadata.InfraID,
+			zoneIC,
+			computePool,
+			a.osImage,
+			workerRole,
+			workerUserDataName,
+		)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to generate machinesets for zone %s", zone.Name)
+		}
+		for _, ms := range zoneMachineSets {
+			name := fmt.Sprintf("%s-%s", ms.Name, zone.Name)
+			ms.Name = name
+			ms.Spec.Selector.MatchLabels[machineSetNameLabel] = name
+			ms.Spec.Template.ObjectMeta.Labels[machineSetNameLabel] = name
+			replicas := zoneReplicas[i]
+			ms.Spec.Replicas = &replicas
+			machineSets = append(machineSets, ms)
+		}
+	}
+
+	if err := setZoneReplicas(pool, machineSets, zoneNames); err != nil {
+		return nil, false, err
 	}
 
-	return installerMachineSets, true, nil
+	return machineSets, true, nil
 }
 
 // Get the OS image from an existing master machine.
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 
 	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 	vsphereprovider "github.com/openshift/machine-api-operator/pkg/apis/vsphereprovider/v1beta1"
@@ -24,6 +25,7 @@ func TestVSphereActuator(t *testing.T) {
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
 		expectedMachineSetReplicas map[string]int64
+		expectedDatastores         map[string]string
 		expectedErr                bool
 	}{
 		{
@@ -34,6 +36,32 @@ func TestVSphereActuator(t *testing.T) {
 				fmt.Sprintf("%s-worker", testInfraID): 3,
 			},
 		},
+		{
+			name:              "generate machinesets for zones",
+			clusterDeployment: testVSphereClusterDeployment(),
+			pool:              testVSpherePool(testVSphereZone("a", ""), testVSphereZone("b", "datastore-b")),
+			expectedMachineSetReplicas: map[string]int64{
+				fmt.Sprintf("%s-worker-a", testInfraID): 2,
+				fmt.Sprintf("%s-worker-b", testInfraID): 1,
+			},
+			expectedDatastores: map[string]string{
+				fmt.Sprintf("%s-worker-a", testInfraID): "default-datastore",
+				fmt.Sprintf("%s-worker-b", testInfraID): "datastore-b",
+			},
+		},
+		{
+			name:              "distribute replicas across zones",
+			clusterDeployment: testVSphereClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				p := testVSpherePool(testVSphereZone("a", ""), testVSphereZone("b", ""))
+				p.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{{Zone: "b", Weight: pointer.Int32Ptr(2)}}
+				return p
+			}(),
+			expectedMachineSetReplicas: map[string]int64{
+				fmt.Sprintf("%s-worker-a", testInfraID): 1,
+				fmt.Sprintf("%s-worker-b", testInfraID): 2,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -52,13 +80,13 @@ func TestVSphereActuator(t *testing.T) {
 				assert.Error(t, err, "expected error for test case")
 			} else {
 				require.NoError(t, err, "unexpected error for test cast")
-				validateVSphereMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
+				validateVSphereMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedDatastores)
 			}
 		})
 	}
 }
 
-func validateVSphereMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64) {
+func validateVSphereMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedDatastores map[string]string) {
 	assert.Equal(t, len(expectedMSReplicas), len(mSets), "different number of machine sets generated than expected")
 
 	for _, ms := range mSets {
@@ -73,11 +101,14 @@ func validateVSphereMachineSets(t *testing.T, mSets []*machineapi.MachineSet, ex
 			assert.Equal(t, int32(4), vsphereProvider.NumCPUs, "unexpected NumCPUs")
 			assert.Equal(t, int32(4), vsphereProvider.NumCoresPerSocket, "unexpected NumCoresPerSocket")
 			assert.Equal(t, int32(512), vsphereProvider.DiskGiB, "unexpected DiskGiB")
+			if expectedDatastore, ok := expectedDatastores[ms.Name]; ok && assert.NotNil(t, vsphereProvider.Workspace, "missing workspace") {
+				assert.Equal(t, expectedDatastore, vsphereProvider.Workspace.Datastore, "unexpected datastore")
+			}
 		}
 	}
 }
 
-func testVSpherePool() *hivev1.MachinePool {
+func testVSpherePool(zones ...hivev1vsphere.MachinePoolZone) *hivev1.MachinePool {
 	p := testMachinePool()
 	p.Spec.Platform = hivev1.MachinePoolPlatform{
 		VSphere: &hivev1vsphere.MachinePool{
@@ -87,11 +118,20 @@ func testVSpherePool() *hivev1.MachinePool {
 			OSDisk: hivev1vsphere.OSDisk{
 				DiskSizeGB: 512,
 			},
+			Zones: zones,
 		},
 	}
 	return p
 }
 
+func testVSphereZone(name, datastore string) hivev1vsphere.MachinePoolZone {
+	return hivev1vsphere.MachinePoolZone{
+		Name:      name,
+		Cluster:   "cluster-" + name,
+		Datastore: datastore,
+	}
+}
+
 func testVSphereClusterDeployment() *hivev1.ClusterDeployment {
 	cd := testClusterDeployment()
 	cd.Spec.Platform = hivev1.Platform{
@@ -99,6 +139,7 @@ func testVSphereClusterDeployment() *hivev1.ClusterDeployment {
 			CredentialsSecretRef: corev1.LocalObjectReference{
 				Name: "vsphere-credentials",
 			},
+			DefaultDatastore: "default-datastore",
 		},
 	}
 	return cd
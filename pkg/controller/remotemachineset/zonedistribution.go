@@ -0,0 +1,121 @@
+package remotemachineset
+
+import (
+	"fmt"
+	"sort"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// zoneAnnotation is the zone of a MachineSet generated for a machine pool. It is used to distribute the replicas
+	// of the machine pool across its zones.
+	zoneAnnotation = "hive.openshift.io/machineset-zone"
+)
+
+// setZoneReplicas records the zones of the MachineSets generated by the installer, which generates a MachineSet for
+// each of the zones in order, and distributes the replicas of the machine pool across them.
+func setZoneReplicas(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet, zones []string) error {
+	if len(machineSets) != len(zones) {
+		return fmt.Errorf("expected a machineset for each of the %d zones, got %d machinesets", len(zones), len(machineSets))
+	}
+	for i, ms := range machineSets {
+		if ms.Annotations == nil {
+			ms.Annotations = make(map[string]string, 1)
+		}
+		ms.Annotations[zoneAnnotation] = zones[i]
+	}
+	if pool.Spec.Replicas == nil || len(pool.Spec.ZoneDistribution) == 0 {
+		return nil
+	}
+	for i, replicas := range distributeReplicas(int32(*pool.Spec.Replicas), zones, pool.Spec.ZoneDistribution) {
+		r := replicas
+		machineSets[i].Spec.Replicas = &r
+	}
+	return nil
+}
+
+// machineSetZones returns the zones of the MachineSets, as recorded when they were generated.
+func machineSetZones(machineSets []*machineapi.MachineSet) []string {
+	zones := make([]string, len(machineSets))
+	for i, ms := range machineSets {
+		zones[i] = ms.Annotations[zoneAnnotation]
+	}
+	return zones
+}
+
+// distributeReplicas distributes the replicas across the zones. The zones with fixed replicas get them first, and the
+// remaining replicas are distributed across the other zones in proportion to their weight, with the largest
+// remainders rounded up. If all of the zones have fixed replicas, the remaining replicas are split evenly across
+// them. Ties go to the zones that come first, so that without a distribution the replicas are split evenly as the
+// installer does.
+func distributeReplicas(total int32, zones []string, distribution []hivev1.MachinePoolZoneDistribution) []int32 {
+	byZone := make(map[string]hivev1.MachinePoolZoneDistribution, len(distribution))
+	for _, d := range distribution {
+		byZone[d.Zone] = d
+	}
+
+	replicas := make([]int32, len(zones))
+	weights := make([]int64, len(zones))
+	var weighted []int
+	var totalWeight int64
+	remaining := int64(total)
+	for i, zone := range zones {
+		d := byZone[zone]
+		if d.Replicas != nil {
+			replicas[i] = *d.Replicas
+			remaining -= int64(*d.Replicas)
+			continue
+		}
+		weights[i] = 1
+		if d.Weight != nil {
+			weights[i] = int64(*d.Weight)
+		}
+		weighted = append(weighted, i)
+		totalWeight += weights[i]
+	}
+	if remaining <= 0 {
+		return replicas
+	}
+	if len(weighted) == 0 {
+		// Rather than dropping the replicas, spread the replicas beyond the fixed ones evenly across all the zones when
+		// all of the zones have fixed replicas.
+		for i := range zones {
+			weighted = append(weighted, i)
+			weights[i] = 1
+		}
+		totalWeight = int64(len(zones))
+	}
+	if totalWeight == 0 {
+		// Rather than dropping the replicas, distribute them evenly when all the weighted zones have a weight of 0.
+		for _, i := range weighted {
+			weights[i] = 1
+		}
+		totalWeight = int64(len(weighted))
+	}
+
+	remainders := make(map[int]int64, len(weighted))
+	leftover := remaining
+	for _, i := range weighted {
+		share := remaining * weights[i] / totalWeight
+		replicas[i] += int32(share)
+		remainders[i] = remaining * weights[i] % totalWeight
+		leftover -= share
+	}
+	sort.SliceStable(weighted, func(a, b int) bool {
+		return remainders[weighted[a]] > remainders[weighted[b]]
+	})
+	for _, i := range weighted {
+		if leftover == 0 {
+			break
+		}
+		if weights[i] == 0 {
+			continue
+		}
+		replicas[i]++
+		leftover--
+	}
+	return replicas
+}
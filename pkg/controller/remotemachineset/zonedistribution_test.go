@@ -0,0 +1,127 @@
+package remotemachineset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/utils/pointer"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestDistributeReplicas(t *testing.T) {
+	zones := []string{"zone1", "zone2", "zone3"}
+	cases := []struct {
+		name         string
+		total        int32
+		distribution []hivev1.MachinePoolZoneDistribution
+		expected     []int32
+	}{
+		{
+			name:     "even",
+			total:    5,
+			expected: []int32{2, 2, 1},
+		},
+		{
+			name:  "weights",
+			total: 10,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone1", Weight: pointer.Int32Ptr(3)},
+				{Zone: "zone3", Weight: pointer.Int32Ptr(2)},
+			},
+			expected: []int32{5, 2, 3},
+		},
+		{
+			name:  "largest remainders rounded up",
+			total: 4,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone1", Weight: pointer.Int32Ptr(1)},
+				{Zone: "zone2", Weight: pointer.Int32Ptr(2)},
+				{Zone: "zone3", Weight: pointer.Int32Ptr(3)},
+			},
+			expected: []int32{1, 1, 2},
+		},
+		{
+			name:  "zero weight",
+			total: 4,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone2", Weight: pointer.Int32Ptr(0)},
+			},
+			expected: []int32{2, 0, 2},
+		},
+		{
+			name:  "fixed replicas",
+			total: 7,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone2", Replicas: pointer.Int32Ptr(4)},
+			},
+			expected: []int32{2, 4, 1},
+		},
+		{
+			name:  "fixed replicas above total",
+			total: 2,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone1", Replicas: pointer.Int32Ptr(3)},
+			},
+			expected: []int32{3, 0, 0},
+		},
+		{
+			name:  "all zones fixed below total",
+			total: 8,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone1", Replicas: pointer.Int32Ptr(1)},
+				{Zone: "zone2", Replicas: pointer.Int32Ptr(2)},
+				{Zone: "zone3", Replicas: pointer.Int32Ptr(1)},
+			},
+			expected: []int32{3, 3, 2},
+		},
+		{
+			name:  "all weights zero",
+			total: 3,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone1", Weight: pointer.Int32Ptr(0)},
+				{Zone: "zone2", Weight: pointer.Int32Ptr(0)},
+				{Zone: "zone3", Replicas: pointer.Int32Ptr(1)},
+			},
+			expected: []int32{1, 1, 1},
+		},
+		{
+			name:  "unknown zone",
+			total: 3,
+			distribution: []hivev1.MachinePoolZoneDistribution{
+				{Zone: "zone4", Replicas: pointer.Int32Ptr(3)},
+			},
+			expected: []int32{1, 1, 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, distributeReplicas(tc.total, zones, tc.distribution))
+		})
+	}
+}
+
+func TestGetMinMaxReplicasForMachineSetWithZoneDistribution(t *testing.T) {
+	pool := testMachinePool()
+	pool.Spec.Replicas = nil
+	pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 4, MaxReplicas: 10}
+	pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+		{Zone: "zone1", Replicas: pointer.Int32Ptr(1)},
+		{Zone: "zone2", Weight: pointer.Int32Ptr(2)},
+	}
+	var machineSets []*machineapi.MachineSet
+	for _, zone := range []string{"zone1", "zone2", "zone3"} {
+		ms := testMachineSet("foo-12345-worker-"+zone, "worker", false, 1, 0)
+		ms.Annotations[zoneAnnotation] = zone
+		machineSets = append(machineSets, ms)
+	}
+
+	expected := [][2]int32{{1, 1}, {2, 6}, {1, 3}}
+	for i := range machineSets {
+		min, max := getMinMaxReplicasForMachineSet(pool, machineSets, i)
+		assert.Equal(t, expected[i], [2]int32{min, max}, "unexpected min and max replicas of machineset %d", i)
+	}
+}
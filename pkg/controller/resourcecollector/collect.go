@@ -0,0 +1,197 @@
+package resourcecollector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// collectResources collects the resources of the ResourceCollector from the remote cluster into the status of the
+// CollectedResourceSet. Resources are collected until the size limit of the ResourceCollector is reached.
+func collectResources(c client.Client, collector *hivev1.ResourceCollector, set *hivev1.CollectedResourceSet, logger log.FieldLogger) {
+	now := metav1.Now()
+	status := hivev1.CollectedResourceSetStatus{
+		ObservedGeneration: collector.Generation,
+		LastAttemptTime:    &now,
+		LastCollectionTime: set.Status.LastCollectionTime,
+	}
+	maxSize := maxSizeBytes(collector)
+	failed := 0
+	for _, selector := range collector.Spec.Resources {
+		resources := hivev1.CollectedResourceList{
+			APIVersion: selector.APIVersion,
+			Kind:       selector.Kind,
+		}
+		items, err := collectSelector(c, selector)
+		if err != nil {
+			logger.WithError(err).WithField("kind", selector.Kind).Log(controllerutils.LogLevel(err), "failed to collect resources")
+			resources.Error = err.Error()
+			failed++
+		}
+		for _, item := range items {
+			size, err := itemSize(item)
+			if err != nil {
+				resources.Error = err.Error()
+				failed++
+				break
+			}
+			if status.SizeBytes+size > maxSize {
+				resources.Truncated = true
+				status.Truncated = true
+				break
+			}
+			status.SizeBytes += size
+			resources.Items = append(resources.Items, item)
+		}
+		status.Resources = append(status.Resources, resources)
+	}
+	if failed == 0 {
+		status.LastCollectionTime = &now
+	} else {
+		status.FailureMessage = fmt.Sprintf("failed to collect %d of %d resources", failed, len(collector.Spec.Resources))
+	}
+	set.Status = status
+}
+
+// collectSelector lists the resources matching the selector in the remote cluster, and returns them sorted by
+// namespace and name.
+func collectSelector(c client.Client, selector hivev1.CollectedResourceSelector) ([]hivev1.CollectedResource, error) {
+	gv, err := schema.ParseGroupVersion(selector.APIVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid apiVersion")
+	}
+	if gv.Group == "" && selector.Kind == "Secret" {
+		return nil, errors.New("collecting Secrets is not allowed")
+	}
+	fields, err := parseFields(selector.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []client.ListOption
+	if selector.Namespace != "" {
+		opts = append(opts, client.InNamespace(selector.Namespace))
+	}
+	if selector.LabelSelector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid labelSelector")
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: labelSelector})
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gv.WithKind(selector.Kind + "List"))
+	if err := c.List(context.TODO(), list, opts...); err != nil {
+		return nil, errors.Wrap(err, "failed to list resources")
+	}
+
+	items := make([]hivev1.CollectedResource, len(list.Items))
+	for i := range list.Items {
+		obj := &list.Items[i]
+		items[i] = hivev1.CollectedResource{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}
+		if len(fields) == 0 {
+			obj.SetManagedFields(nil)
+			raw, err := json.Marshal(obj)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to encode %s", obj.GetName())
+			}
+			items[i].Object = &runtime.RawExtension{Raw: raw}
+			continue
+		}
+		items[i].Fields = map[string]string{}
+		for _, field := range fields {
+			value, err := evaluateField(field.jsonPath, obj)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to evaluate field %s of %s", field.name, obj.GetName())
+			}
+			items[i].Fields[field.name] = value
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items, nil
+}
+
+type parsedField struct {
+	name     string
+	jsonPath *jsonpath.JSONPath
+}
+
+func parseFields(fields []hivev1.CollectedField) ([]parsedField, error) {
+	parsed := make([]parsedField, len(fields))
+	for i, field := range fields {
+		expression := field.JSONPath
+		if !strings.HasPrefix(expression, "{") {
+			expression = "{" + expression + "}"
+		}
+		jp := jsonpath.New(field.Name).AllowMissingKeys(true)
+		if err := jp.Parse(expression); err != nil {
+			return nil, errors.Wrapf(err, "invalid jsonPath of field %s", field.Name)
+		}
+		parsed[i] = parsedField{name: field.Name, jsonPath: jp}
+	}
+	return parsed, nil
+}
+
+// evaluateField returns the value of the JSONPath expression for the object. Strings are returned as is, other values
+// are encoded as JSON. Multiple values are separated by spaces.
+func evaluateField(jp *jsonpath.JSONPath, obj *unstructured.Unstructured) (string, error) {
+	results, err := jp.FindResults(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if value.Kind() == reflect.Interface {
+				value = value.Elem()
+			}
+			if !value.IsValid() {
+				continue
+			}
+			if s, ok := value.Interface().(string); ok {
+				values = append(values, s)
+				continue
+			}
+			b := &bytes.Buffer{}
+			if err := json.NewEncoder(b).Encode(value.Interface()); err != nil {
+				return "", err
+			}
+			values = append(values, strings.TrimSpace(b.String()))
+		}
+	}
+	return strings.Join(values, " "), nil
+}
+
+func itemSize(item hivev1.CollectedResource) (int64, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to encode %s", item.Name)
+	}
+	return int64(len(raw)), nil
+}
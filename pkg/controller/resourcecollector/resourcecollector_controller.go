@@ -0,0 +1,349 @@
+package resourcecollector
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.ResourceCollectorControllerName
+
+	// defaultCollectionInterval is the time between two collections when the ResourceCollector does not specify one.
+	defaultCollectionInterval = 10 * time.Minute
+
+	// defaultMaxSizeBytes is the maximum size of the collected resources when the ResourceCollector does not specify
+	// one.
+	defaultMaxSizeBytes = 256 * 1024
+)
+
+// Add creates a new ResourceCollector controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileResourceCollector {
+	r := &ReconcileResourceCollector{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+		logger: log.WithField("controller", ControllerName),
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileResourceCollector, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("resourcecollector-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new resourcecollector controller")
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	// Watch for changes to ResourceCollectors
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ResourceCollector{}},
+		handler.EnqueueRequestsFromMapFunc(requestsForResourceCollector(r.Client, r.logger)),
+	); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching resource collectors")
+		return err
+	}
+
+	// Watch for changes to CollectedResourceSets
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.CollectedResourceSet{}},
+		&handler.EnqueueRequestForOwner{OwnerType: &hivev1.ClusterDeployment{}, IsController: true},
+	); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Error("Error watching collected resource sets")
+		return err
+	}
+	return nil
+}
+
+func requestsForResourceCollector(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		collector, ok := o.(*hivev1.ResourceCollector)
+		if !ok {
+			return nil
+		}
+		logger := logger.WithField("resourceCollector", collector.Name)
+		labelSelector, err := metav1.LabelSelectorAsSelector(&collector.Spec.ClusterDeploymentSelector)
+		if err != nil {
+			logger.WithError(err).Warn("cannot parse ClusterDeployment selector")
+			return nil
+		}
+		cds := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.Background(), cds, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments matching ResourceCollector")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(cds.Items))
+		for i, cd := range cds.Items {
+			requests[i].Namespace = cd.Namespace
+			requests[i].Name = cd.Name
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileResourceCollector{}
+
+// ReconcileResourceCollector reconciles a ClusterDeployment object to collect the resources of the ResourceCollectors
+// selecting it into CollectedResourceSets.
+type ReconcileResourceCollector struct {
+	client.Client
+	scheme *runtime.Scheme
+	logger log.FieldLogger
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile collects the resources of the ResourceCollectors selecting the ClusterDeployment whose collection
+// interval has elapsed, and deletes the CollectedResourceSets of the ResourceCollectors no longer selecting it.
+func (r *ReconcileResourceCollector) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster deployment not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("Error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+	if !cd.DeletionTimestamp.IsZero() {
+		logger.Debug("ClusterDeployment resource has been deleted")
+		return reconcile.Result{}, nil
+	}
+
+	collectors, err := r.getResourceCollectors(cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	sets, err := r.cleanupCollectedResourceSets(cd, collectors, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(collectors) == 0 {
+		logger.Debug("no resource collectors select the cluster deployment")
+		return reconcile.Result{}, nil
+	}
+
+	if !cd.Spec.Installed {
+		logger.Debug("ClusterDeployment is not yet ready")
+		return reconcile.Result{}, nil
+	}
+	if cd.Spec.ClusterMetadata == nil {
+		logger.Error("installed cluster with no cluster metadata")
+		return reconcile.Result{}, nil
+	}
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		logger.Debug("skipping cluster with unreachable condition")
+		return reconcile.Result{}, nil
+	}
+
+	var remoteClient client.Client
+	var requeueAfter time.Duration
+	for i := range collectors {
+		collector := &collectors[i]
+		logger := logger.WithField("resourceCollector", collector.Name)
+		interval := collectionInterval(collector)
+
+		set := sets[collector.Name]
+		if set != nil && set.Status.ObservedGeneration == collector.Generation && set.Status.LastAttemptTime != nil {
+			if wait := interval - time.Since(set.Status.LastAttemptTime.Time); wait > 0 {
+				logger.WithField("wait", wait).Debug("collection interval has not elapsed")
+				requeueAfter = minRequeueAfter(requeueAfter, wait)
+				continue
+			}
+		}
+
+		if remoteClient == nil {
+			var unreachable, requeue bool
+			remoteClient, unreachable, requeue = remoteclient.ConnectToRemoteCluster(
+				cd,
+				r.remoteClusterAPIClientBuilder(cd),
+				r.Client,
+				logger,
+			)
+			if unreachable {
+				return reconcile.Result{Requeue: requeue}, nil
+			}
+		}
+
+		if set == nil {
+			if set, err = r.createCollectedResourceSet(cd, collector, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+
+		collectResources(remoteClient, collector, set, logger)
+		if err := r.Status().Update(context.TODO(), set); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update collected resource set")
+			return reconcile.Result{}, err
+		}
+		logger.WithField("sizeBytes", set.Status.SizeBytes).Info("collected resources")
+		requeueAfter = minRequeueAfter(requeueAfter, interval)
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// getResourceCollectors returns the ResourceCollectors selecting the ClusterDeployment, sorted by name.
+func (r *ReconcileResourceCollector) getResourceCollectors(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]hivev1.ResourceCollector, error) {
+	collectorList := &hivev1.ResourceCollectorList{}
+	if err := r.List(context.TODO(), collectorList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list resource collectors")
+		return nil, err
+	}
+	var collectors []hivev1.ResourceCollector
+	for _, collector := range collectorList.Items {
+		labelSelector, err := metav1.LabelSelectorAsSelector(&collector.Spec.ClusterDeploymentSelector)
+		if err != nil {
+			logger.WithError(err).WithField("resourceCollector", collector.Name).Warn("cannot parse ClusterDeployment selector")
+			continue
+		}
+		if labelSelector.Matches(labels.Set(cd.Labels)) {
+			collectors = append(collectors, collector)
+		}
+	}
+	sort.Slice(collectors, func(i, j int) bool { return collectors[i].Name < collectors[j].Name })
+	return collectors, nil
+}
+
+// cleanupCollectedResourceSets deletes the CollectedResourceSets of the ClusterDeployment that belong to
+// ResourceCollectors no longer selecting it. It returns the remaining CollectedResourceSets keyed by the name of their
+// ResourceCollector.
+func (r *ReconcileResourceCollector) cleanupCollectedResourceSets(
+	cd *hivev1.ClusterDeployment,
+	collectors []hivev1.ResourceCollector,
+	logger log.FieldLogger,
+) (map[string]*hivev1.CollectedResourceSet, error) {
+	setList := &hivev1.CollectedResourceSetList{}
+	if err := r.List(
+		context.TODO(),
+		setList,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{constants.ClusterDeploymentNameLabel: cd.Name},
+	); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list collected resource sets")
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, collector := range collectors {
+		selected[collector.Name] = true
+	}
+	sets := map[string]*hivev1.CollectedResourceSet{}
+	for i := range setList.Items {
+		set := &setList.Items[i]
+		if selected[set.Spec.ResourceCollector] {
+			sets[set.Spec.ResourceCollector] = set
+			continue
+		}
+		logger.WithField("collectedResourceSet", set.Name).Info("deleting collected resource set of unselected resource collector")
+		if err := r.Delete(context.TODO(), set); err != nil && !apierrors.IsNotFound(err) {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to delete collected resource set")
+			return nil, err
+		}
+	}
+	return sets, nil
+}
+
+func (r *ReconcileResourceCollector) createCollectedResourceSet(
+	cd *hivev1.ClusterDeployment,
+	collector *hivev1.ResourceCollector,
+	logger log.FieldLogger,
+) (*hivev1.CollectedResourceSet, error) {
+	set := &hivev1.CollectedResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      apihelpers.GetResourceName(cd.Name, collector.Name),
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: cd.Name,
+				constants.ResourceCollectorNameLabel: collector.Name,
+			},
+		},
+		Spec: hivev1.CollectedResourceSetSpec{
+			ResourceCollector: collector.Name,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, set, r.scheme); err != nil {
+		logger.WithError(err).Error("error setting controller reference on collected resource set")
+		return nil, err
+	}
+	logger.WithField("collectedResourceSet", set.Name).Info("creating collected resource set")
+	if err := r.Create(context.TODO(), set); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to create collected resource set")
+		return nil, err
+	}
+	return set, nil
+}
+
+func collectionInterval(collector *hivev1.ResourceCollector) time.Duration {
+	if collector.Spec.Interval != nil && collector.Spec.Interval.Duration > 0 {
+		return collector.Spec.Interval.Duration
+	}
+	return defaultCollectionInterval
+}
+
+func maxSizeBytes(collector *hivev1.ResourceCollector) int64 {
+	if collector.Spec.MaxSizeBytes != nil {
+		return *collector.Spec.MaxSizeBytes
+	}
+	return defaultMaxSizeBytes
+}
+
+func minRequeueAfter(current, d time.Duration) time.Duration {
+	if current == 0 || d < current {
+		return d
+	}
+	return current
+}
@@ -0,0 +1,423 @@
+package resourcecollector
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testName                 = "cluster1"
+	testNamespace            = "cluster1namespace"
+	testKubeconfigSecretName = "kubeconfig-secret"
+	testCollectorName        = "collector1"
+	testSetName              = testName + "-" + testCollectorName
+)
+
+func TestResourceCollectorReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	log.SetLevel(log.DebugLevel)
+
+	tests := []struct {
+		name                 string
+		existing             []runtime.Object
+		remote               []runtime.Object
+		noRemoteCall         bool
+		expectNoSet          bool
+		expectRequeueAfter   time.Duration
+		expectUnchanged      bool
+		expectTruncated      bool
+		expectFailureMessage string
+		validate             func(*testing.T, *hivev1.CollectedResourceSet)
+	}{
+		{
+			name: "collect whole resources",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				testResourceCollector(configMapSelector()),
+			},
+			remote:             []runtime.Object{testConfigMap("ns1", "cm1"), testConfigMap("ns1", "cm2"), testConfigMap("ns2", "cm3")},
+			expectRequeueAfter: defaultCollectionInterval,
+			validate: func(t *testing.T, set *hivev1.CollectedResourceSet) {
+				assert.Equal(t, testName, set.Labels[constants.ClusterDeploymentNameLabel], "unexpected cluster deployment label")
+				assert.Equal(t, testCollectorName, set.Labels[constants.ResourceCollectorNameLabel], "unexpected resource collector label")
+				require.Len(t, set.Status.Resources, 1, "unexpected number of resource lists")
+				items := set.Status.Resources[0].Items
+				require.Len(t, items, 2, "unexpected number of collected resources")
+				assert.Equal(t, "cm1", items[0].Name, "unexpected first resource")
+				assert.Equal(t, "cm2", items[1].Name, "unexpected second resource")
+				cm := &corev1.ConfigMap{}
+				require.NotNil(t, items[0].Object, "expected whole resource")
+				require.NoError(t, json.Unmarshal(items[0].Object.Raw, cm), "could not decode collected resource")
+				assert.Equal(t, "bar", cm.Data["foo"], "unexpected data")
+				assert.Empty(t, cm.ManagedFields, "expected managed fields to be dropped")
+			},
+		},
+		{
+			name: "collect fields",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				testResourceCollector(hivev1.CollectedResourceSelector{
+					APIVersion:    "v1",
+					Kind:          "Node",
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+					Fields: []hivev1.CollectedField{
+						{Name: "kubelet", JSONPath: "{.status.nodeInfo.kubeletVersion}"},
+						{Name: "labels", JSONPath: ".metadata.labels"},
+						{Name: "missing", JSONPath: ".status.missing"},
+					},
+				}),
+			},
+			remote:             []runtime.Object{testNode("node1", "worker"), testNode("node2", "master")},
+			expectRequeueAfter: defaultCollectionInterval,
+			validate: func(t *testing.T, set *hivev1.CollectedResourceSet) {
+				require.Len(t, set.Status.Resources, 1, "unexpected number of resource lists")
+				items := set.Status.Resources[0].Items
+				require.Len(t, items, 1, "unexpected number of collected resources")
+				assert.Equal(t, "node1", items[0].Name, "unexpected resource")
+				assert.Nil(t, items[0].Object, "unexpected whole resource")
+				assert.Equal(t, map[string]string{
+					"kubelet": "v1.20.0",
+					"labels":  `{"role":"worker"}`,
+					"missing": "",
+				}, items[0].Fields, "unexpected fields")
+			},
+		},
+		{
+			name: "interval not elapsed",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				testResourceCollector(configMapSelector()),
+				testCollectedResourceSet(time.Now().Add(-4 * time.Minute)),
+			},
+			noRemoteCall:       true,
+			expectRequeueAfter: 6 * time.Minute,
+			expectUnchanged:    true,
+		},
+		{
+			name: "interval elapsed",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				testResourceCollector(configMapSelector()),
+				testCollectedResourceSet(time.Now().Add(-11 * time.Minute)),
+			},
+			remote:             []runtime.Object{testConfigMap("ns1", "cm1")},
+			expectRequeueAfter: defaultCollectionInterval,
+			validate: func(t *testing.T, set *hivev1.CollectedResourceSet) {
+				require.Len(t, set.Status.Resources, 1, "unexpected number of resource lists")
+				assert.Len(t, set.Status.Resources[0].Items, 1, "unexpected number of collected resources")
+			},
+		},
+		{
+			name: "resource collector changed",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				func() runtime.Object {
+					c := testResourceCollector(configMapSelector())
+					c.Generation = 2
+					return c
+				}(),
+				testCollectedResourceSet(time.Now().Add(-1 * time.Minute)),
+			},
+			remote:             []runtime.Object{testConfigMap("ns1", "cm1")},
+			expectRequeueAfter: defaultCollectionInterval,
+			validate: func(t *testing.T, set *hivev1.CollectedResourceSet) {
+				assert.Equal(t, int64(2), set.Status.ObservedGeneration, "unexpected observed generation")
+			},
+		},
+		{
+			name: "truncated",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				func() runtime.Object {
+					c := testResourceCollector(configMapSelector())
+					c.Spec.MaxSizeBytes = pointer.Int64Ptr(1024)
+					return c
+				}(),
+			},
+			remote:             []runtime.Object{testConfigMap("ns1", "cm1"), testConfigMap("ns1", "cm2"), testConfigMap("ns1", "cm3")},
+			expectRequeueAfter: defaultCollectionInterval,
+			expectTruncated:    true,
+			validate: func(t *testing.T, set *hivev1.CollectedResourceSet) {
+				require.Len(t, set.Status.Resources, 1, "unexpected number of resource lists")
+				assert.True(t, set.Status.Resources[0].Truncated, "expected resource list to be truncated")
+				assert.NotEmpty(t, set.Status.Resources[0].Items, "expected some resources to be collected")
+				assert.Less(t, len(set.Status.Resources[0].Items), 3, "expected some resources to be dropped")
+				assert.LessOrEqual(t, set.Status.SizeBytes, int64(1024), "unexpected size")
+			},
+		},
+		{
+			name: "secrets refused",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				testResourceCollector(
+					hivev1.CollectedResourceSelector{APIVersion: "v1", Kind: "Secret"},
+					configMapSelector(),
+				),
+			},
+			remote:               []runtime.Object{testConfigMap("ns1", "cm1")},
+			expectRequeueAfter:   defaultCollectionInterval,
+			expectFailureMessage: "failed to collect 1 of 2 resources",
+			validate: func(t *testing.T, set *hivev1.CollectedResourceSet) {
+				require.Len(t, set.Status.Resources, 2, "unexpected number of resource lists")
+				assert.Equal(t, "collecting Secrets is not allowed", set.Status.Resources[0].Error, "unexpected error")
+				assert.Len(t, set.Status.Resources[1].Items, 1, "unexpected number of collected resources")
+				assert.Nil(t, set.Status.LastCollectionTime, "unexpected last collection time")
+			},
+		},
+		{
+			name: "invalid jsonpath",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				testResourceCollector(hivev1.CollectedResourceSelector{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Fields:     []hivev1.CollectedField{{Name: "bad", JSONPath: "{.data["}},
+				}),
+			},
+			remote:               []runtime.Object{testConfigMap("ns1", "cm1")},
+			expectRequeueAfter:   defaultCollectionInterval,
+			expectFailureMessage: "failed to collect 1 of 1 resources",
+			validate: func(t *testing.T, set *hivev1.CollectedResourceSet) {
+				require.Len(t, set.Status.Resources, 1, "unexpected number of resource lists")
+				assert.Contains(t, set.Status.Resources[0].Error, "invalid jsonPath of field bad", "unexpected error")
+			},
+		},
+		{
+			name: "cluster not selected",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+				func() runtime.Object {
+					c := testResourceCollector(configMapSelector())
+					c.Spec.ClusterDeploymentSelector.MatchLabels = map[string]string{"collect": "false"}
+					return c
+				}(),
+				testCollectedResourceSet(time.Now()),
+			},
+			noRemoteCall: true,
+			expectNoSet:  true,
+		},
+		{
+			name: "cluster not installed",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Spec.Installed = false
+					return cd
+				}(),
+				testResourceCollector(configMapSelector()),
+			},
+			noRemoteCall: true,
+			expectNoSet:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, test.existing...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if !test.noRemoteCall {
+				mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme.Scheme, test.remote...), nil)
+			}
+			r := &ReconcileResourceCollector{
+				Client:                        fakeClient,
+				scheme:                        scheme.Scheme,
+				logger:                        log.WithField("controller", ControllerName),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+
+			var existingSet *hivev1.CollectedResourceSet
+			if test.expectUnchanged {
+				existingSet = getCollectedResourceSet(t, fakeClient)
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error")
+			assert.InDelta(t, test.expectRequeueAfter, result.RequeueAfter, float64(time.Second), "unexpected requeue after")
+
+			set := getCollectedResourceSet(t, fakeClient)
+			if test.expectNoSet {
+				assert.Nil(t, set, "unexpected collected resource set")
+				return
+			}
+			require.NotNil(t, set, "expected collected resource set")
+			if test.expectUnchanged {
+				assert.Equal(t, existingSet.ResourceVersion, set.ResourceVersion, "expected collected resource set to be unchanged")
+				return
+			}
+			assert.Equal(t, testCollectorName, set.Spec.ResourceCollector, "unexpected resource collector")
+			assert.NotNil(t, set.Status.LastAttemptTime, "expected last attempt time")
+			assert.Equal(t, test.expectTruncated, set.Status.Truncated, "unexpected truncated")
+			assert.Equal(t, test.expectFailureMessage, set.Status.FailureMessage, "unexpected failure message")
+			if test.expectFailureMessage == "" {
+				assert.NotNil(t, set.Status.LastCollectionTime, "expected last collection time")
+			}
+			if test.validate != nil {
+				test.validate(t, set)
+			}
+		})
+	}
+}
+
+func getCollectedResourceSet(t *testing.T, c client.Client) *hivev1.CollectedResourceSet {
+	sets := &hivev1.CollectedResourceSetList{}
+	require.NoError(t, c.List(context.TODO(), sets, client.InNamespace(testNamespace)), "could not list collected resource sets")
+	switch len(sets.Items) {
+	case 0:
+		return nil
+	case 1:
+		return &sets.Items[0]
+	}
+	t.Fatalf("unexpected number of collected resource sets: %d", len(sets.Items))
+	return nil
+}
+
+func configMapSelector() hivev1.CollectedResourceSelector {
+	return hivev1.CollectedResourceSelector{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "ns1",
+	}
+}
+
+func testResourceCollector(selectors ...hivev1.CollectedResourceSelector) *hivev1.ResourceCollector {
+	return &hivev1.ResourceCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testCollectorName,
+			Generation: 1,
+		},
+		Spec: hivev1.ResourceCollectorSpec{
+			ClusterDeploymentSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"collect": "true"},
+			},
+			Resources: selectors,
+		},
+	}
+}
+
+func testCollectedResourceSet(lastAttemptTime time.Time) *hivev1.CollectedResourceSet {
+	t := metav1.NewTime(lastAttemptTime)
+	return &hivev1.CollectedResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testSetName,
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: testName,
+				constants.ResourceCollectorNameLabel: testCollectorName,
+			},
+		},
+		Spec: hivev1.CollectedResourceSetSpec{
+			ResourceCollector: testCollectorName,
+		},
+		Status: hivev1.CollectedResourceSetStatus{
+			ObservedGeneration: 1,
+			LastAttemptTime:    &t,
+			LastCollectionTime: &t,
+		},
+	}
+}
+
+func testClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+			Labels:    map[string]string{"collect": "true"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{
+					Name: testKubeconfigSecretName,
+				},
+			},
+			Installed: true,
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionFalse,
+			}},
+		},
+	}
+}
+
+func testKubeconfigSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testKubeconfigSecretName,
+		},
+		Data: map[string][]byte{
+			"kubeconfig": []byte("kubeconfig-data"),
+		},
+	}
+}
+
+func testConfigMap(namespace, name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:   "test",
+				Operation: metav1.ManagedFieldsOperationApply,
+			}},
+		},
+		Data: map[string]string{
+			"foo": "bar",
+			"baz": strings.Repeat("0123456789", 40),
+		},
+	}
+}
+
+func testNode(name, role string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"role": role},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion: "v1.20.0",
+			},
+		},
+	}
+}
@@ -0,0 +1,296 @@
+// Package selectorsyncsetrollout provides a controller which rolls out new generations of SelectorSyncSets with a
+// rollout strategy progressively. It decides which of the matching clusters are in the rollout, based on how the
+// clusters already in the rollout have applied the new generation, and reports the progress in the status of the
+// SelectorSyncSet. The clustersync controller only applies the latest generation to clusters in the rollout.
+package selectorsyncsetrollout
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.SelectorSyncSetRolloutControllerName
+)
+
+// Add creates a new SelectorSyncSetRollout controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileSelectorSyncSetRollout{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("selectorsyncsetrollout-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to SelectorSyncSets
+	if err := c.Watch(&source.Kind{Type: &hivev1.SelectorSyncSet{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs, which record the generation of each SelectorSyncSet applied to the cluster
+	if err := c.Watch(
+		&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}},
+		handler.EnqueueRequestsFromMapFunc(requestsForClusterSync)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func requestsForClusterSync(o client.Object) []reconcile.Request {
+	clusterSync, ok := o.(*hiveintv1alpha1.ClusterSync)
+	if !ok {
+		return nil
+	}
+	requests := make([]reconcile.Request, len(clusterSync.Status.SelectorSyncSets))
+	for i, status := range clusterSync.Status.SelectorSyncSets {
+		requests[i].Name = status.Name
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileSelectorSyncSetRollout{}
+
+// ReconcileSelectorSyncSetRollout reconciles the rollout of a SelectorSyncSet
+type ReconcileSelectorSyncSetRollout struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile advances the rollout of the latest generation of a SelectorSyncSet to the matching clusters.
+func (r *ReconcileSelectorSyncSetRollout) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "selectorSyncSet", request.NamespacedName)
+	logger.Info("reconciling selectorsyncset")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	sss := &hivev1.SelectorSyncSet{}
+	if err := r.Get(ctx, request.NamespacedName, sss); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("selectorsyncset not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get selectorsyncset")
+		return reconcile.Result{}, err
+	}
+
+	if sss.Spec.RolloutStrategy == nil {
+		if sss.Status.Rollout == nil {
+			return reconcile.Result{}, nil
+		}
+		logger.Info("clearing rollout status since the selectorsyncset no longer has a rollout strategy")
+		sss.Status.Rollout = nil
+		return reconcile.Result{}, r.updateStatus(sss, logger)
+	}
+
+	cds, err := r.getMatchingClusterDeployments(sss, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	syncStatuses, err := r.getSyncStatuses(sss, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	oldRollout := sss.Status.Rollout.DeepCopy()
+	requeueAfter := advanceRollout(sss, cds, syncStatuses, time.Now(), logger)
+	if !reflect.DeepEqual(oldRollout, sss.Status.Rollout) {
+		if err := r.updateStatus(sss, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// advanceRollout updates the rollout status of the SelectorSyncSet based on the sync statuses of the matching clusters.
+// Batches are added to the rollout as long as all of the clusters in the rollout have applied the latest generation,
+// not too many of them have failed, and the pause since the last batch completed has passed. It returns how long to
+// wait for the pause to pass, if any.
+func advanceRollout(
+	sss *hivev1.SelectorSyncSet,
+	cds []*hivev1.ClusterDeployment,
+	syncStatuses map[types.NamespacedName]hiveintv1alpha1.SyncStatus,
+	now time.Time,
+	logger log.FieldLogger,
+) time.Duration {
+	strategy := sss.Spec.RolloutStrategy
+	rollout := sss.Status.Rollout
+	if rollout == nil || rollout.Generation != sss.Generation {
+		logger.WithField("generation", sss.Generation).Info("starting rollout of new generation")
+		rollout = &hivev1.SelectorSyncSetRolloutStatus{
+			Generation:     sss.Generation,
+			Phase:          hivev1.ProgressingSelectorSyncSetRolloutPhase,
+			BatchStartTime: &metav1.Time{Time: now},
+		}
+		if strategy.CanarySelector == nil {
+			rollout.AdmittedPercent = batchPercent(strategy, 0)
+		}
+		sss.Status.Rollout = rollout
+	}
+
+	for {
+		rollout.TotalClusters = len(cds)
+		rollout.AdmittedClusters, rollout.UpdatedClusters, rollout.FailedClusters = 0, 0, 0
+		for _, cd := range cds {
+			if !controllerutils.IsAdmittedToRollout(sss, cd) {
+				continue
+			}
+			rollout.AdmittedClusters++
+			status, ok := syncStatuses[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}]
+			if !ok || status.ObservedGeneration != sss.Generation {
+				continue
+			}
+			switch status.Result {
+			case hiveintv1alpha1.SuccessSyncSetResult:
+				rollout.UpdatedClusters++
+			case hiveintv1alpha1.FailureSyncSetResult:
+				rollout.FailedClusters++
+			}
+		}
+
+		switch {
+		case rollout.Phase == hivev1.HaltedSelectorSyncSetRolloutPhase:
+			return 0
+		case rollout.AdmittedClusters > 0 && rollout.FailedClusters*100 > strategy.MaxFailurePercent*rollout.AdmittedClusters:
+			logger.WithField("failedClusters", rollout.FailedClusters).Warn("halting rollout since too many clusters failed")
+			rollout.Phase = hivev1.HaltedSelectorSyncSetRolloutPhase
+			rollout.Message = fmt.Sprintf("%d of %d clusters failed to apply generation %d, which exceeds %d%%",
+				rollout.FailedClusters, rollout.AdmittedClusters, rollout.Generation, strategy.MaxFailurePercent)
+			return 0
+		case rollout.UpdatedClusters+rollout.FailedClusters < rollout.AdmittedClusters:
+			rollout.Phase = hivev1.ProgressingSelectorSyncSetRolloutPhase
+			rollout.BatchCompletionTime = nil
+			rollout.Message = fmt.Sprintf("%d of %d clusters have applied generation %d",
+				rollout.UpdatedClusters+rollout.FailedClusters, rollout.AdmittedClusters, rollout.Generation)
+			return 0
+		case rollout.AdmittedPercent >= 100:
+			rollout.Phase = hivev1.CompleteSelectorSyncSetRolloutPhase
+			rollout.BatchCompletionTime = nil
+			rollout.Message = fmt.Sprintf("generation %d has been rolled out to all clusters", rollout.Generation)
+			return 0
+		}
+
+		// All of the clusters in the rollout have applied the latest generation, so the batch is complete.
+		if rollout.BatchCompletionTime == nil {
+			rollout.BatchCompletionTime = &metav1.Time{Time: now}
+		}
+		if strategy.PauseDuration != nil {
+			if remaining := rollout.BatchCompletionTime.Add(strategy.PauseDuration.Duration).Sub(now); remaining > 0 {
+				rollout.Phase = hivev1.PausedSelectorSyncSetRolloutPhase
+				rollout.Message = fmt.Sprintf("pausing before rolling out generation %d to more clusters", rollout.Generation)
+				return remaining
+			}
+		}
+		rollout.AdmittedPercent = batchPercent(strategy, rollout.AdmittedPercent)
+		logger.WithField("admittedPercent", rollout.AdmittedPercent).Info("adding batch to rollout")
+		rollout.Phase = hivev1.ProgressingSelectorSyncSetRolloutPhase
+		rollout.BatchStartTime = &metav1.Time{Time: now}
+		rollout.BatchCompletionTime = nil
+	}
+}
+
+// batchPercent returns the percentage of clusters admitted to the rollout after adding the next batch.
+func batchPercent(strategy *hivev1.SelectorSyncSetRolloutStrategy, admittedPercent int) int {
+	percent := strategy.BatchPercent
+	if percent <= 0 {
+		percent = 100
+	}
+	admittedPercent += percent
+	if admittedPercent > 100 {
+		admittedPercent = 100
+	}
+	return admittedPercent
+}
+
+func (r *ReconcileSelectorSyncSetRollout) getMatchingClusterDeployments(sss *hivev1.SelectorSyncSet, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		logger.WithError(err).Error("unable to convert selector")
+		return nil, err
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+		return nil, err
+	}
+	var cds []*hivev1.ClusterDeployment
+	for i, cd := range cdList.Items {
+		// Only installed clusters are synced.
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil {
+			continue
+		}
+		cds = append(cds, &cdList.Items[i])
+	}
+	return cds, nil
+}
+
+// getSyncStatuses returns the sync status of the SelectorSyncSet for each cluster, keyed by the namespace and name of
+// the cluster.
+func (r *ReconcileSelectorSyncSetRollout) getSyncStatuses(sss *hivev1.SelectorSyncSet, logger log.FieldLogger) (map[types.NamespacedName]hiveintv1alpha1.SyncStatus, error) {
+	clusterSyncList := &hiveintv1alpha1.ClusterSyncList{}
+	if err := r.List(context.Background(), clusterSyncList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterSyncs")
+		return nil, err
+	}
+	statuses := map[types.NamespacedName]hiveintv1alpha1.SyncStatus{}
+	for _, clusterSync := range clusterSyncList.Items {
+		for _, status := range clusterSync.Status.SelectorSyncSets {
+			if status.Name == sss.Name {
+				statuses[types.NamespacedName{Namespace: clusterSync.Namespace, Name: clusterSync.Name}] = status
+				break
+			}
+		}
+	}
+	return statuses, nil
+}
+
+func (r *ReconcileSelectorSyncSetRollout) updateStatus(sss *hivev1.SelectorSyncSet, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.Background(), sss); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update selectorsyncset status")
+		return err
+	}
+	return nil
+}
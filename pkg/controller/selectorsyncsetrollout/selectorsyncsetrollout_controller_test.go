@@ -0,0 +1,218 @@
+package selectorsyncsetrollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+)
+
+const (
+	testSelectorSyncSetName = "test-selectorsyncset"
+	testCanaryName          = "canary"
+)
+
+var testClusterNames = []string{testCanaryName, "cluster-1", "cluster-2"}
+
+func TestReconcileSelectorSyncSetRollout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	canaryStrategy := &hivev1.SelectorSyncSetRolloutStrategy{
+		CanarySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+		BatchPercent:   100,
+	}
+	pausedStrategy := canaryStrategy.DeepCopy()
+	pausedStrategy.PauseDuration = &metav1.Duration{Duration: time.Hour}
+	canaryRollout := &hivev1.SelectorSyncSetRolloutStatus{
+		Generation: 2,
+		Phase:      hivev1.ProgressingSelectorSyncSetRolloutPhase,
+	}
+	fullRollout := &hivev1.SelectorSyncSetRolloutStatus{
+		Generation:      2,
+		Phase:           hivev1.ProgressingSelectorSyncSetRolloutPhase,
+		AdmittedPercent: 100,
+	}
+	pauseCompleteRollout := canaryRollout.DeepCopy()
+	pauseCompleteRollout.BatchCompletionTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+
+	cases := []struct {
+		name            string
+		strategy        *hivev1.SelectorSyncSetRolloutStrategy
+		rollout         *hivev1.SelectorSyncSetRolloutStatus
+		syncStatuses    map[string]hiveintv1alpha1.SyncSetResult
+		expectedRollout *hivev1.SelectorSyncSetRolloutStatus
+		expectRequeue   bool
+	}{
+		{
+			name:     "new generation starts with canary",
+			strategy: canaryStrategy,
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      1,
+				Phase:           hivev1.CompleteSelectorSyncSetRolloutPhase,
+				AdmittedPercent: 100,
+			},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:       2,
+				Phase:            hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				TotalClusters:    3,
+				AdmittedClusters: 1,
+			},
+		},
+		{
+			name:         "canary updated",
+			strategy:     canaryStrategy,
+			rollout:      canaryRollout,
+			syncStatuses: map[string]hiveintv1alpha1.SyncSetResult{testCanaryName: hiveintv1alpha1.SuccessSyncSetResult},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:       2,
+				Phase:            hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				AdmittedPercent:  100,
+				TotalClusters:    3,
+				AdmittedClusters: 3,
+				UpdatedClusters:  1,
+			},
+		},
+		{
+			name:         "pause after canary",
+			strategy:     pausedStrategy,
+			rollout:      canaryRollout,
+			syncStatuses: map[string]hiveintv1alpha1.SyncSetResult{testCanaryName: hiveintv1alpha1.SuccessSyncSetResult},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:       2,
+				Phase:            hivev1.PausedSelectorSyncSetRolloutPhase,
+				TotalClusters:    3,
+				AdmittedClusters: 1,
+				UpdatedClusters:  1,
+			},
+			expectRequeue: true,
+		},
+		{
+			name:         "pause passed",
+			strategy:     pausedStrategy,
+			rollout:      pauseCompleteRollout,
+			syncStatuses: map[string]hiveintv1alpha1.SyncSetResult{testCanaryName: hiveintv1alpha1.SuccessSyncSetResult},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:       2,
+				Phase:            hivev1.ProgressingSelectorSyncSetRolloutPhase,
+				AdmittedPercent:  100,
+				TotalClusters:    3,
+				AdmittedClusters: 3,
+				UpdatedClusters:  1,
+			},
+		},
+		{
+			name:         "canary failed",
+			strategy:     canaryStrategy,
+			rollout:      canaryRollout,
+			syncStatuses: map[string]hiveintv1alpha1.SyncSetResult{testCanaryName: hiveintv1alpha1.FailureSyncSetResult},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:       2,
+				Phase:            hivev1.HaltedSelectorSyncSetRolloutPhase,
+				TotalClusters:    3,
+				AdmittedClusters: 1,
+				FailedClusters:   1,
+			},
+		},
+		{
+			name:     "all clusters updated",
+			strategy: canaryStrategy,
+			rollout:  fullRollout,
+			syncStatuses: map[string]hiveintv1alpha1.SyncSetResult{
+				testCanaryName: hiveintv1alpha1.SuccessSyncSetResult,
+				"cluster-1":    hiveintv1alpha1.SuccessSyncSetResult,
+				"cluster-2":    hiveintv1alpha1.SuccessSyncSetResult,
+			},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:       2,
+				Phase:            hivev1.CompleteSelectorSyncSetRolloutPhase,
+				AdmittedPercent:  100,
+				TotalClusters:    3,
+				AdmittedClusters: 3,
+				UpdatedClusters:  3,
+			},
+		},
+		{
+			name:    "rollout strategy removed",
+			rollout: fullRollout,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := []runtime.Object{
+				testselectorsyncset.FullBuilder(testSelectorSyncSetName, scheme).Build(
+					testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+					testselectorsyncset.WithGeneration(2),
+					testselectorsyncset.WithRolloutStrategy(tc.strategy),
+					testselectorsyncset.WithRolloutStatus(tc.rollout.DeepCopy()),
+				),
+			}
+			for _, name := range testClusterNames {
+				cdOpts := []testcd.Option{
+					testcd.Installed(),
+					testcd.WithLabel("test-label-key", "test-label-value"),
+				}
+				if name == testCanaryName {
+					cdOpts = append(cdOpts, testcd.WithLabel("canary", "true"))
+				}
+				existing = append(existing, testcd.FullBuilder(name, name, scheme).Build(cdOpts...))
+				if result, ok := tc.syncStatuses[name]; ok {
+					existing = append(existing, testcs.FullBuilder(name, name, scheme).Build(
+						testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{
+							Name:               testSelectorSyncSetName,
+							ObservedGeneration: 2,
+							Result:             result,
+						}),
+					))
+				}
+			}
+			c := fake.NewFakeClientWithScheme(scheme, existing...)
+			r := &ReconcileSelectorSyncSetRollout{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: testSelectorSyncSetName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			if tc.expectRequeue {
+				assert.Greater(t, int64(result.RequeueAfter), int64(0), "expected requeue after")
+			} else {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+			}
+
+			sss := &hivev1.SelectorSyncSet{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: testSelectorSyncSetName}, sss), "unexpected error getting selectorsyncset")
+			if tc.expectedRollout == nil {
+				assert.Nil(t, sss.Status.Rollout, "expected no rollout status")
+				return
+			}
+			actual := sss.Status.Rollout
+			require.NotNil(t, actual, "expected rollout status")
+			assert.Equal(t, tc.expectedRollout.Generation, actual.Generation, "unexpected generation")
+			assert.Equal(t, tc.expectedRollout.Phase, actual.Phase, "unexpected phase")
+			assert.Equal(t, tc.expectedRollout.AdmittedPercent, actual.AdmittedPercent, "unexpected admitted percent")
+			assert.Equal(t, tc.expectedRollout.TotalClusters, actual.TotalClusters, "unexpected total clusters")
+			assert.Equal(t, tc.expectedRollout.AdmittedClusters, actual.AdmittedClusters, "unexpected admitted clusters")
+			assert.Equal(t, tc.expectedRollout.UpdatedClusters, actual.UpdatedClusters, "unexpected updated clusters")
+			assert.Equal(t, tc.expectedRollout.FailedClusters, actual.FailedClusters, "unexpected failed clusters")
+		})
+	}
+}
@@ -98,3 +98,9 @@ func CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
 		return ""
 	}
 }
+
+// IsMachineManagementCentral returns true if the machines of the cluster are managed centrally, from the target
+// namespace of the hub, rather than by the machine API of the cluster.
+func IsMachineManagementCentral(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.MachineManagement != nil && cd.Spec.MachineManagement.Central != nil
+}
@@ -482,6 +482,52 @@ func SetDNSZoneConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// SetDNSRecordConditionWithChangeCheck sets a condition on a DNSRecord resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetDNSRecordConditionWithChangeCheck(
+	conditions []hivev1.DNSRecordCondition,
+	conditionType hivev1.DNSRecordConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.DNSRecordCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindDNSRecordCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.DNSRecordCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
 // InitializeMachinePoolConditions initializes the given set of conditions for the first time, set with Status Unknown
 func InitializeMachinePoolConditions(existingConditions []hivev1.MachinePoolCondition, conditionsToBeAdded []hivev1.MachinePoolConditionType) []hivev1.MachinePoolCondition {
 	now := metav1.Now()
@@ -750,6 +796,17 @@ func FindDNSZoneCondition(conditions []hivev1.DNSZoneCondition, conditionType hi
 	return nil
 }
 
+// FindDNSRecordCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindDNSRecordCondition(conditions []hivev1.DNSRecordCondition, conditionType hivev1.DNSRecordConditionType) *hivev1.DNSRecordCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
 // FindMachinePoolCondition finds in the condition that has the
 // specified condition type in the given list. If none exists, then returns nil.
 func FindMachinePoolCondition(conditions []hivev1.MachinePoolCondition, conditionType hivev1.MachinePoolConditionType) *hivev1.MachinePoolCondition {
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"hash/fnv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// IsAdmittedToRollout returns true if the latest generation of the SelectorSyncSet may be applied to the cluster. This
+// is always the case for SelectorSyncSets without a rollout strategy. Otherwise, the cluster must be a canary cluster
+// or fall within the percentage of clusters admitted to the rollout of the latest generation.
+func IsAdmittedToRollout(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) bool {
+	if sss.Spec.RolloutStrategy == nil {
+		return true
+	}
+	rollout := sss.Status.Rollout
+	if rollout == nil || rollout.Generation != sss.Generation {
+		return false
+	}
+	if IsRolloutCanary(sss, cd) {
+		return true
+	}
+	return RolloutBucket(sss, cd) < rollout.AdmittedPercent
+}
+
+// IsRolloutCanary returns true if the cluster is selected by the canary selector of the rollout strategy of the
+// SelectorSyncSet.
+func IsRolloutCanary(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) bool {
+	strategy := sss.Spec.RolloutStrategy
+	if strategy == nil || strategy.CanarySelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(strategy.CanarySelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(cd.Labels))
+}
+
+// RolloutBucket returns the bucket, from 0 to 99, of the cluster in the rollouts of the SelectorSyncSet. A cluster is
+// in the rollout when its bucket is below the admitted percentage. Buckets are stable, and differ between
+// SelectorSyncSets so that the same clusters are not always first.
+func RolloutBucket(sss *hivev1.SelectorSyncSet, cd *hivev1.ClusterDeployment) int {
+	h := fnv.New32a()
+	h.Write([]byte(sss.Name + "/" + cd.Namespace + "/" + cd.Name))
+	return int(h.Sum32() % 100)
+}
@@ -41,6 +41,10 @@ type Client interface {
 
 	DeleteManagedZone(managedZone string) error
 
+	PatchManagedZone(managedZone string, patch *dns.ManagedZone) error
+
+	ListDNSKeys(managedZone string) ([]*dns.DnsKey, error)
+
 	ListComputeZones(ListComputeZonesOptions) (*compute.ZoneList, error)
 
 	ListComputeImages(ListComputeImagesOptions) (*compute.ImageList, error)
@@ -125,6 +129,24 @@ func (c *gcpClient) DeleteManagedZone(managedZone string) error {
 	return c.dnsClient.ManagedZones.Delete(c.projectName, managedZone).Context(ctx).Do()
 }
 
+func (c *gcpClient) PatchManagedZone(managedZone string, patch *dns.ManagedZone) error {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	_, err := c.dnsClient.ManagedZones.Patch(c.projectName, managedZone, patch).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) ListDNSKeys(managedZone string) ([]*dns.DnsKey, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	var keys []*dns.DnsKey
+	err := c.dnsClient.DnsKeys.List(c.projectName, managedZone).Pages(ctx, func(resp *dns.DnsKeysListResponse) error {
+		keys = append(keys, resp.DnsKeys...)
+		return nil
+	})
+	return keys, err
+}
+
 func (c *gcpClient) ListResourceRecordSets(managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
 	ctx, cancel := contextWithTimeout(context.TODO())
 	defer cancel()
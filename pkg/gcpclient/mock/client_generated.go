@@ -165,6 +165,35 @@ func (mr *MockClientMockRecorder) DeleteManagedZone(managedZone interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManagedZone", reflect.TypeOf((*MockClient)(nil).DeleteManagedZone), managedZone)
 }
 
+// PatchManagedZone mocks base method
+func (m *MockClient) PatchManagedZone(managedZone string, patch *dns.ManagedZone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchManagedZone", managedZone, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchManagedZone indicates an expected call of PatchManagedZone
+func (mr *MockClientMockRecorder) PatchManagedZone(managedZone, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchManagedZone", reflect.TypeOf((*MockClient)(nil).PatchManagedZone), managedZone, patch)
+}
+
+// ListDNSKeys mocks base method
+func (m *MockClient) ListDNSKeys(managedZone string) ([]*dns.DnsKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDNSKeys", managedZone)
+	ret0, _ := ret[0].([]*dns.DnsKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDNSKeys indicates an expected call of ListDNSKeys
+func (mr *MockClientMockRecorder) ListDNSKeys(managedZone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDNSKeys", reflect.TypeOf((*MockClient)(nil).ListDNSKeys), managedZone)
+}
+
 // ListComputeZones mocks base method
 func (m *MockClient) ListComputeZones(arg0 gcpclient.ListComputeZonesOptions) (*compute.ZoneList, error) {
 	m.ctrl.T.Helper()
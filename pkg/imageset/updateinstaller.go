@@ -187,6 +187,15 @@ func (o *UpdateInstallerImageOptions) Run() (returnErr error) {
 	}
 	o.log.WithField("cliImage", cliImage).Info("cli image found")
 
+	var machineAPIImages *hivev1.MachineAPIImages
+	if mm := cd.Spec.MachineManagement; mm != nil && mm.Central != nil {
+		machineAPIImages, err = findMachineAPIImages(is, cd)
+		if err != nil {
+			return errors.Wrap(err, "could not get machine API images")
+		}
+		o.log.WithField("machineAPIImages", *machineAPIImages).Info("machine API images found")
+	}
+
 	releaseMetadataRaw, err := ioutil.ReadFile(filepath.Join(o.WorkDir, releaseMetadataFilename))
 	if err != nil {
 		return errors.Wrapf(err, "could not read %s file", releaseMetadataFilename)
@@ -206,6 +215,7 @@ func (o *UpdateInstallerImageOptions) Run() (returnErr error) {
 
 	cd.Status.InstallerImage = &installerImage
 	cd.Status.CLIImage = &cliImage
+	cd.Status.MachineAPIImages = machineAPIImages
 	cd.Status.InstallVersion = &releaseVersion
 	// Set InstallerImageResolutionFailedCondition to false
 	cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
@@ -222,6 +232,30 @@ func (o *UpdateInstallerImageOptions) Run() (returnErr error) {
 	)
 }
 
+// findMachineAPIImages finds the images of the machine API controllers that Hive runs for clusters whose machines are
+// managed centrally.
+func findMachineAPIImages(is *imageapi.ImageStream, cd *hivev1.ClusterDeployment) (*hivev1.MachineAPIImages, error) {
+	var machineControllersTagName string
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		machineControllersTagName = "aws-machine-controllers"
+	default:
+		return nil, errors.New("central machine management is not supported on the platform of the cluster")
+	}
+	machineAPIOperatorImage, err := findImageSpec(is, "machine-api-operator")
+	if err != nil {
+		return nil, err
+	}
+	machineControllersImage, err := findImageSpec(is, machineControllersTagName)
+	if err != nil {
+		return nil, err
+	}
+	return &hivev1.MachineAPIImages{
+		MachineAPIOperator: machineAPIOperatorImage,
+		MachineControllers: machineControllersImage,
+	}, nil
+}
+
 func findImageSpec(image *imageapi.ImageStream, tagName string) (string, error) {
 	for _, tag := range image.Spec.Tags {
 		if tag.Name == tagName {
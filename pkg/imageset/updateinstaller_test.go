@@ -22,6 +22,7 @@ import (
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/baremetal"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -30,6 +31,9 @@ const (
 	testInstallerImage = "registry.io/test-installer-image:latest"
 	testCLIImage       = "registry.io/test-cli-image:latest"
 	testReleaseVersion = "v0.0.0-test-version"
+
+	testMachineAPIOperatorImage = "registry.io/test-machine-api-operator-image:latest"
+	testMachineControllersImage = "registry.io/test-aws-machine-controllers-image:latest"
 )
 
 func TestUpdateInstallerImageCommand(t *testing.T) {
@@ -94,6 +98,44 @@ func TestUpdateInstallerImageCommand(t *testing.T) {
 			version:                   testReleaseVersion,
 			validateClusterDeployment: validateSuccessfulExecution,
 		},
+		{
+			name:                      "successful execution with central machine management",
+			existingClusterDeployment: testCentralMachineManagementClusterDeployment(),
+			images: map[string]string{
+				"installer":               testInstallerImage,
+				"cli":                     testCLIImage,
+				"machine-api-operator":    testMachineAPIOperatorImage,
+				"aws-machine-controllers": testMachineControllersImage,
+			},
+			validateClusterDeployment: func(t *testing.T, clusterDeployment *hivev1.ClusterDeployment) {
+				validateSuccessfulExecution(t, clusterDeployment)
+				if images := clusterDeployment.Status.MachineAPIImages; images == nil ||
+					images.MachineAPIOperator != testMachineAPIOperatorImage ||
+					images.MachineControllers != testMachineControllersImage {
+					t.Errorf("did not get expected machine API images in status")
+				}
+			},
+		},
+		{
+			name:                      "failure execution with central machine management missing machine controllers",
+			existingClusterDeployment: testCentralMachineManagementClusterDeployment(),
+			images: map[string]string{
+				"installer":            testInstallerImage,
+				"cli":                  testCLIImage,
+				"machine-api-operator": testMachineAPIOperatorImage,
+			},
+			validateClusterDeployment: func(t *testing.T, clusterDeployment *hivev1.ClusterDeployment) {
+				condition := controllerutils.FindClusterDeploymentCondition(clusterDeployment.Status.Conditions, hivev1.InstallerImageResolutionFailedCondition)
+				if condition == nil || condition.Status != corev1.ConditionTrue {
+					t.Errorf("expected failure condition")
+					return
+				}
+				if !strings.Contains(condition.Message, "could not get machine API images") {
+					t.Errorf("condition message does not contain expected error message: %s", condition.Message)
+				}
+			},
+			expectError: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -134,6 +176,13 @@ func TestUpdateInstallerImageCommand(t *testing.T) {
 	}
 }
 
+func testCentralMachineManagementClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform.AWS = &hivev1aws.Platform{Region: "us-east-1"}
+	cd.Spec.MachineManagement = &hivev1.MachineManagement{Central: &hivev1.CentralMachineManagement{}}
+	return cd
+}
+
 func testClusterDeploymentWithErrorCondition() *hivev1.ClusterDeployment {
 	cis := testClusterDeployment()
 	cis.Status.Conditions = []hivev1.ClusterDeploymentCondition{
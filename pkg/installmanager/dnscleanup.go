@@ -90,7 +90,11 @@ func cleanupAzureDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error
 		return err
 	}
 
-	if err := dns.DeleteAzureRecordSets(azureClient, dnsZone, logger); err != nil {
+	deleteRecordSets := dns.DeleteAzureRecordSets
+	if dnsZone.Spec.Private {
+		deleteRecordSets = dns.DeleteAzurePrivateRecordSets
+	}
+	if err := deleteRecordSets(azureClient, dnsZone, logger); err != nil {
 		logger.WithError(err).Error("failed to clean up DNS Zone")
 		return err
 	}
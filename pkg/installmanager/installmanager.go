@@ -56,6 +56,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	contributils "github.com/openshift/hive/contrib/pkg/utils"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/resource"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
@@ -314,6 +315,19 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
 		return err
 	}
+	hostedZoneID, err := m.getAWSPrivateHostedZoneID(cd)
+	if err != nil {
+		m.log.WithError(err).Error("error getting private hosted zone of managed DNS zone")
+		return err
+	}
+	if hostedZoneID != "" {
+		m.log.WithField("hostedZone", hostedZoneID).Info("installing into the private hosted zone of the managed DNS zone")
+		icData, err = pasteInAWSHostedZone(icData, hostedZoneID)
+		if err != nil {
+			m.log.WithError(err).Error("error adding hosted zone to install-config.yaml")
+			return err
+		}
+	}
 	destInstallConfigPath := filepath.Join(m.WorkDir, "install-config.yaml")
 	if err := ioutil.WriteFile(destInstallConfigPath, icData, 0644); err != nil {
 		m.log.WithError(err).Error("error writing install-config.yaml")
@@ -1439,6 +1453,46 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// getAWSPrivateHostedZoneID returns the ID of the private hosted zone of the managed DNS zone of an AWS cluster that
+// is published internally, or an empty string if the cluster has no such zone.
+func (m *InstallManager) getAWSPrivateHostedZoneID(cd *hivev1.ClusterDeployment) (string, error) {
+	if !cd.Spec.ManageDNS || cd.Spec.Platform.AWS == nil {
+		return "", nil
+	}
+	dnsZone := &hivev1.DNSZone{}
+	if err := m.DynamicClient.Get(
+		context.TODO(),
+		types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)},
+		dnsZone,
+	); err != nil {
+		return "", errors.Wrap(err, "could not get managed DNS zone")
+	}
+	if !dnsZone.Spec.Private {
+		return "", nil
+	}
+	if dnsZone.Status.AWS == nil || dnsZone.Status.AWS.ZoneID == nil {
+		return "", errors.New("private managed DNS zone has no zone ID")
+	}
+	return *dnsZone.Status.AWS.ZoneID, nil
+}
+
+// pasteInAWSHostedZone sets the hosted zone of the AWS platform of the install config, so that the installer creates
+// the records of the cluster in the private hosted zone of the managed DNS zone rather than in a private hosted zone
+// of its own, which would conflict with it.
+func pasteInAWSHostedZone(icData []byte, hostedZoneID string) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	platform, _ := icRaw["platform"].(map[string]interface{})
+	awsPlatform, ok := platform["aws"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("InstallConfig has no AWS platform")
+	}
+	awsPlatform["hostedZone"] = hostedZoneID
+	return yaml.Marshal(icRaw)
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
@@ -27,6 +27,7 @@ import (
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -712,3 +713,124 @@ func Test_pasteInPullSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_pasteInAWSHostedZone(t *testing.T) {
+	cases := []struct {
+		name      string
+		icData    string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name: "aws platform",
+			icData: `baseDomain: example.com
+platform:
+  aws:
+    region: us-east-1
+publish: Internal
+`,
+			expected: `baseDomain: example.com
+platform:
+  aws:
+    hostedZone: Z0123456789
+    region: us-east-1
+publish: Internal
+`,
+		},
+		{
+			name: "no aws platform",
+			icData: `baseDomain: example.com
+platform:
+  gcp:
+    region: us-east1
+`,
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := pasteInAWSHostedZone([]byte(tc.icData), "Z0123456789")
+			if tc.expectErr {
+				assert.Error(t, err, "expected error pasting in hosted zone")
+				return
+			}
+			require.NoError(t, err, "unexpected error pasting in hosted zone")
+			assert.Equal(t, tc.expected, string(actual), "unexpected InstallConfig with pasted hosted zone")
+		})
+	}
+}
+
+func TestGetAWSPrivateHostedZoneID(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	cases := []struct {
+		name       string
+		manageDNS  bool
+		dnsZone    *hivev1.DNSZone
+		expectedID string
+		expectErr  bool
+	}{
+		{
+			name:       "private zone",
+			manageDNS:  true,
+			dnsZone:    testAWSDNSZone(true, pointer.StringPtr("Z0123456789")),
+			expectedID: "Z0123456789",
+		},
+		{
+			name:      "public zone",
+			manageDNS: true,
+			dnsZone:   testAWSDNSZone(false, pointer.StringPtr("Z0123456789")),
+		},
+		{
+			name:      "private zone without zone ID",
+			manageDNS: true,
+			dnsZone:   testAWSDNSZone(true, nil),
+			expectErr: true,
+		},
+		{
+			name:      "managed DNS zone not found",
+			manageDNS: true,
+			expectErr: true,
+		},
+		{
+			name: "no managed DNS",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var existing []runtime.Object
+			if tc.dnsZone != nil {
+				existing = append(existing, tc.dnsZone)
+			}
+			mocks := setupDefaultMocks(t, existing...)
+			defer mocks.mockCtrl.Finish()
+			cd := testClusterDeployment()
+			cd.Spec.ManageDNS = tc.manageDNS
+			cd.Spec.Platform.AWS = &hivev1aws.Platform{Region: "us-east-1"}
+			m := &InstallManager{DynamicClient: mocks.fakeKubeClient}
+			actual, err := m.getAWSPrivateHostedZoneID(cd)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error getting hosted zone ID")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting hosted zone ID")
+			assert.Equal(t, tc.expectedID, actual, "unexpected hosted zone ID")
+		})
+	}
+}
+
+func testAWSDNSZone(private bool, zoneID *string) *hivev1.DNSZone {
+	return &hivev1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testDeploymentName + "-zone",
+		},
+		Spec: hivev1.DNSZoneSpec{
+			Zone:    "test.example.com",
+			Private: private,
+			AWS:     &hivev1.AWSDNSZoneSpec{},
+		},
+		Status: hivev1.DNSZoneStatus{
+			AWS: &hivev1.AWSDNSZoneStatus{ZoneID: zoneID},
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -29,3 +30,21 @@ func ReadManagedDomainsFile() ([]hivev1.ManageDNSConfig, error) {
 
 	return domains, nil
 }
+
+// FindManagedDomain returns the managed domain configuration for the given base domain, which must be a direct
+// child of one of the managed domains. Returns nil when the base domain is not under any managed domain.
+func FindManagedDomain(managedDomains []hivev1.ManageDNSConfig, baseDomain string) *hivev1.ManageDNSConfig {
+	i := strings.Index(baseDomain, ".")
+	if i < 0 {
+		return nil
+	}
+	parentDomain := baseDomain[i+1:]
+	for j, md := range managedDomains {
+		for _, domain := range md.Domains {
+			if domain == parentDomain {
+				return &managedDomains[j]
+			}
+		}
+	}
+	return nil
+}
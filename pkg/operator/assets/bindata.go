@@ -908,11 +908,68 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - apps
+  resources:
+  - deployments
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - machine.openshift.io
+  resources:
+  - machines
+  - machines/status
+  - machinesets
+  - machinesets/status
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - agent-install.openshift.io
+  resources:
+  - agents
+  verbs:
+  - get
+  - list
+  - watch
+  - update
+  - patch
+- apiGroups:
+  - metal3.io
+  resources:
+  - baremetalhosts
+  verbs:
+  - get
+  - update
+  - patch
+- apiGroups:
+  - coordination.k8s.io
+  resources:
+  - leases
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
 - apiGroups:
   - ""
   resources:
   - pods
   - pods/log
+  - podtemplates
   verbs:
   - get
   - list
@@ -1072,6 +1129,7 @@ rules:
   resources:
   - clusterdeployments
   - clusterprovisions
+  - dnsrecords
   - dnszones
   - machinepools
   - machinepoolnameleases
@@ -1083,6 +1141,7 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - collectedresourcesets
   verbs:
   - get
   - list
@@ -1092,6 +1151,7 @@ rules:
   resources:
   - clusterimagesets
   - hiveconfigs
+  - resourcecollectors
   - selectorsyncsets
   - selectorsyncidentityproviders
   verbs:
@@ -1313,6 +1373,7 @@ rules:
   resources:
   - clusterdeployments
   - clusterprovisions
+  - dnsrecords
   - dnszones
   - machinepools
   - selectorsyncidentityproviders
@@ -1323,6 +1384,8 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - collectedresourcesets
+  - resourcecollectors
   verbs:
   - get
   - list
@@ -1449,6 +1512,7 @@ rules:
   resources:
   - clusterdeployments
   - clusterprovisions
+  - dnsrecords
   - dnszones
   - machinepools
   - selectorsyncidentityproviders
@@ -1460,6 +1524,8 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - collectedresourcesets
+  - resourcecollectors
   verbs:
   - get
   - list
@@ -2,7 +2,11 @@ package hive
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -23,6 +27,11 @@ const (
 	defaultClustersyncReplicas = 1
 )
 
+var (
+	// replicaOrdinalRegex matches the ordinals of the replicas of the clustersync StatefulSet.
+	replicaOrdinalRegex = regexp.MustCompile(`^[0-9]+$`)
+)
+
 func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource.Helper, hiveconfig *hivev1.HiveConfig, hiveControllersConfigHash string) error {
 	asset := assets.MustAsset("config/clustersync/statefulset.yaml")
 	hLog.Debug("reading statefulset")
@@ -65,6 +74,13 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
 
+	if weights := hiveconfig.Spec.ClusterSyncReplicaWeights; len(weights) > 0 {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  "CLUSTERSYNC_REPLICA_WEIGHTS",
+			Value: formatReplicaWeights(weights, hLog),
+		})
+	}
+
 	hiveNSName := getHiveNamespace(hiveconfig)
 
 	if newClusterSyncStatefulSet.Spec.Template.Annotations == nil {
@@ -180,3 +196,18 @@ func hasStatefulSetSpecChanged(existingClusterSyncStatefulSet, newClusterSyncSta
 	hLog.Debug("existing clustersync statefulset Spec hasn't changed")
 	return false
 }
+
+// formatReplicaWeights formats the weights of the clustersync replicas as a comma-separated list of ordinal=weight
+// pairs. Weights that are not keyed by an ordinal, or that are negative, are ignored.
+func formatReplicaWeights(weights map[string]hivev1.ClusterSyncReplicaWeight, hLog log.FieldLogger) string {
+	pairs := make([]string, 0, len(weights))
+	for ordinal, weight := range weights {
+		if !replicaOrdinalRegex.MatchString(ordinal) || weight < 0 {
+			hLog.WithField("ordinal", ordinal).WithField("weight", weight).Warn("ignoring invalid clustersync replica weight")
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%d", ordinal, weight))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,171 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// MissingResourceDiff is the diff of a resource that does not exist in the target cluster.
+	MissingResourceDiff = "resource does not exist"
+
+	// maxDiffLength is the maximum length of the summary returned by Diff.
+	maxDiffLength = 1024
+
+	// maxDiffValueLength is the maximum length of each value in the summary returned by Diff.
+	maxDiffValueLength = 64
+)
+
+// Diff compares the given resource bytes with the resource in the target cluster and returns a summary of the
+// fields that differ, or an empty string if there are none. Only the fields that are set in the given resource
+// are compared, so that fields defaulted by the server or reported in the status do not count as differences.
+// Of the metadata, only labels and annotations are compared.
+func (r *helper) Diff(obj []byte) (string, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		return "", errors.Wrap(err, "could not get factory")
+	}
+	info, err := r.getResourceInternalInfo(factory, obj)
+	if err != nil {
+		return "", err
+	}
+	desired, ok := info.Object.DeepCopyObject().(*unstructured.Unstructured)
+	if !ok {
+		return "", fmt.Errorf("unexpected object type %T", info.Object)
+	}
+	if err := info.Get(); err != nil {
+		if apierrors.IsNotFound(err) {
+			return MissingResourceDiff, nil
+		}
+		return "", err
+	}
+	live, ok := info.Object.(*unstructured.Unstructured)
+	if !ok {
+		return "", fmt.Errorf("unexpected object type %T", info.Object)
+	}
+	return summarizeDiff(diffObjects(desired, live)), nil
+}
+
+// diffObjects returns the differences between the fields set in the desired object and the live object.
+func diffObjects(desired, live *unstructured.Unstructured) []string {
+	desiredContent := desired.UnstructuredContent()
+	liveContent := live.UnstructuredContent()
+	var differences []string
+	for _, key := range sortedKeys(desiredContent) {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			for _, field := range []string{"labels", "annotations"} {
+				desiredValue, _, _ := unstructured.NestedFieldNoCopy(desiredContent, "metadata", field)
+				liveValue, _, _ := unstructured.NestedFieldNoCopy(liveContent, "metadata", field)
+				differences = append(differences, diffValues("metadata."+field, desiredValue, liveValue)...)
+			}
+		default:
+			differences = append(differences, diffValues(key, desiredContent[key], liveContent[key])...)
+		}
+	}
+	return differences
+}
+
+// diffValues returns the differences between a desired value and a live value. Fields of maps that are not set in
+// the desired value are ignored. Lists must have the same length, and their elements are compared in order.
+func diffValues(path string, desired, live interface{}) []string {
+	switch d := desired.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		var differences []string
+		for _, key := range sortedKeys(d) {
+			differences = append(differences, diffValues(path+"."+key, d[key], l[key])...)
+		}
+		return differences
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			break
+		}
+		var differences []string
+		for i := range d {
+			differences = append(differences, diffValues(fmt.Sprintf("%s[%d]", path, i), d[i], l[i])...)
+		}
+		return differences
+	default:
+		if equalScalars(desired, live) {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("%s: desired %s, live %s", path, formatDiffValue(desired), formatDiffValue(live))}
+}
+
+// equalScalars compares two scalar values, treating numbers decoded from JSON as either int64 or float64 as equal
+// if they have the same value.
+func equalScalars(a, b interface{}) bool {
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		return ok && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	s := string(b)
+	if len(s) > maxDiffValueLength {
+		s = s[:maxDiffValueLength] + "..."
+	}
+	return s
+}
+
+// summarizeDiff joins the differences, leaving out those that do not fit in maxDiffLength.
+func summarizeDiff(differences []string) string {
+	var summary strings.Builder
+	for i, difference := range differences {
+		if summary.Len() > 0 && summary.Len()+len(difference)+2 > maxDiffLength {
+			fmt.Fprintf(&summary, "; and %d more", len(differences)-i)
+			break
+		}
+		if summary.Len() > 0 {
+			summary.WriteString("; ")
+		}
+		summary.WriteString(difference)
+	}
+	return summary.String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -66,6 +67,11 @@ func (r *fakeHelper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Sch
 	return ConfiguredApplyResult, nil
 }
 
+func (r *fakeHelper) ServerSideApply(obj []byte, force bool) (ApplyResult, error) {
+	r.fakeApplySleep()
+	return ConfiguredApplyResult, nil
+}
+
 func (r *fakeHelper) Info(obj []byte) (*Info, error) {
 	// TODO: Do we need to fake this better?
 	return &Info{}, nil
@@ -78,3 +84,26 @@ func (fakeHelper) Patch(name types.NamespacedName, kind, apiVersion string, patc
 func (fakeHelper) Delete(apiVersion, kind, namespace, name string) error {
 	return nil
 }
+
+// Diff reports that resources do not differ from the resources in fake clusters.
+func (fakeHelper) Diff(obj []byte) (string, error) {
+	return "", nil
+}
+
+// Get returns an object of the requested type which reports all of the conditions commonly used to signal readiness
+// as true, so that fake clusters do not wait on resources.
+func (fakeHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	var conditions []interface{}
+	for _, condType := range []string{"Established", "Available", "Ready"} {
+		conditions = append(conditions, map[string]interface{}{"type": condType, "status": "True"})
+	}
+	if err := unstructured.SetNestedSlice(obj.Object, conditions, "status", "conditions"); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -31,12 +32,18 @@ type Helper interface {
 	CreateOrUpdate(obj []byte) (ApplyResult, error)
 	CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
 	Create(obj []byte) (ApplyResult, error)
+	// ServerSideApply applies the given resource bytes to the target cluster using server-side apply with the hive field manager
+	ServerSideApply(obj []byte, force bool) (ApplyResult, error)
 	CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
 	// Info determines the name/namespace and type of the passed in resource bytes
 	Info(obj []byte) (*Info, error)
 	// Patch invokes the kubectl patch command with the given resource, patch and patch type
 	Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
 	Delete(apiVersion, kind, namespace, name string) error
+	// Get gets the resource with the given type, namespace and name from the target cluster
+	Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
+	// Diff returns a summary of the differences between the given resource bytes and the resource in the target cluster
+	Diff(obj []byte) (string, error)
 }
 
 // helper contains configuration for apply and patch operations
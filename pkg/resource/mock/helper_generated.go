@@ -7,6 +7,7 @@ package mock
 import (
 	gomock "github.com/golang/mock/gomock"
 	resource "github.com/openshift/hive/pkg/resource"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
 	reflect "reflect"
@@ -110,6 +111,21 @@ func (mr *MockHelperMockRecorder) Create(obj interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHelper)(nil).Create), obj)
 }
 
+// ServerSideApply mocks base method
+func (m *MockHelper) ServerSideApply(obj []byte, force bool) (resource.ApplyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerSideApply", obj, force)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerSideApply indicates an expected call of ServerSideApply
+func (mr *MockHelperMockRecorder) ServerSideApply(obj, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerSideApply", reflect.TypeOf((*MockHelper)(nil).ServerSideApply), obj, force)
+}
+
 // CreateRuntimeObject mocks base method
 func (m *MockHelper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
 	m.ctrl.T.Helper()
@@ -167,3 +183,33 @@ func (mr *MockHelperMockRecorder) Delete(apiVersion, kind, namespace, name inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHelper)(nil).Delete), apiVersion, kind, namespace, name)
 }
+
+// Get mocks base method
+func (m *MockHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", apiVersion, kind, namespace, name)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockHelperMockRecorder) Get(apiVersion, kind, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHelper)(nil).Get), apiVersion, kind, namespace, name)
+}
+
+// Diff mocks base method
+func (m *MockHelper) Diff(obj []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", obj)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff
+func (mr *MockHelperMockRecorder) Diff(obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockHelper)(nil).Diff), obj)
+}
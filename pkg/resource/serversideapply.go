@@ -0,0 +1,87 @@
+package resource
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// HiveFieldManager is the field manager used by hive for server-side apply.
+const HiveFieldManager = "hive"
+
+// ServerSideApply applies the given resource bytes to the target cluster using server-side apply with the hive
+// field manager. When force is true, hive takes ownership of fields that conflict with other field managers.
+// Otherwise, conflicts are returned as an error for which IsApplyConflict is true.
+func (r *helper) ServerSideApply(obj []byte, force bool) (ApplyResult, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		r.logger.WithError(err).Error("failed to obtain factory for apply")
+		return "", err
+	}
+	info, err := r.getResourceInternalInfo(factory, obj)
+	if err != nil {
+		return "", err
+	}
+	c, err := factory.DynamicClient()
+	if err != nil {
+		return "", errors.Wrap(err, "could not create dynamic client")
+	}
+
+	result := ConfiguredApplyResult
+	var oldResourceVersion string
+	if err := info.Get(); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		result = CreatedApplyResult
+	} else {
+		oldResourceVersion = info.ResourceVersion
+	}
+
+	gvr := info.ResourceMapping().Resource
+	applied, err := c.Resource(gvr).Namespace(info.Namespace).Patch(
+		context.TODO(),
+		info.Name,
+		types.ApplyPatchType,
+		obj,
+		metav1.PatchOptions{FieldManager: HiveFieldManager, Force: &force},
+	)
+	if err != nil {
+		r.logger.WithError(err).Warn("running the server-side apply failed")
+		return "", err
+	}
+	if result == ConfiguredApplyResult && applied.GetResourceVersion() == oldResourceVersion {
+		result = UnchangedApplyResult
+	}
+	return result, nil
+}
+
+// IsApplyConflict returns true if the error is due to fields of the resource being owned by other field managers.
+func IsApplyConflict(err error) bool {
+	return apierrors.IsConflict(err) && len(ApplyConflictFields(err)) > 0
+}
+
+// ApplyConflictFields returns the fields that conflict with other field managers from a server-side apply error.
+func ApplyConflictFields(err error) []string {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) {
+		return nil
+	}
+	details := statusErr.Status().Details
+	if details == nil {
+		return nil
+	}
+	var fields []string
+	for _, cause := range details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			fields = append(fields, cause.Field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,240 @@
+package rfc2136client
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultPort = "53"
+	timeout     = 30 * time.Second
+	tsigFudge   = 300
+)
+
+// Client is a wrapper object for the DNS queries, zone transfers and dynamic updates (RFC 2136) sent to a
+// name server, authenticated with a TSIG key.
+type Client interface {
+	// GetZone returns the SOA record of the zone of the name server that contains the given name. It returns
+	// nil when the name server is not authoritative for the name.
+	GetZone(name string) (*dns.SOA, error)
+
+	// GetRecords returns the records of the given name and type.
+	GetRecords(name string, rrType uint16) ([]dns.RR, error)
+
+	// TransferZone returns the records of the given zone, without the trailing SOA record of the transfer.
+	TransferZone(zone string) ([]dns.RR, error)
+
+	// UpdateZone removes the record sets of the given records, then inserts the records to insert, in a
+	// single dynamic update of the zone.
+	UpdateZone(zone string, removeRRsets, insert []dns.RR) error
+}
+
+// RcodeError is the error returned when the name server answers with an error rcode.
+type RcodeError struct {
+	Rcode int
+}
+
+func (e *RcodeError) Error() string {
+	return "name server returned " + dns.RcodeToString[e.Rcode]
+}
+
+// IsAuthenticationError returns true if the error is due to the name server rejecting the TSIG key, or the
+// client failing to verify the signature of the name server.
+func IsAuthenticationError(err error) bool {
+	switch cause := errors.Cause(err); cause {
+	case dns.ErrSig, dns.ErrSecret, dns.ErrTime, dns.ErrKeyAlg:
+		return true
+	default:
+		if rcodeErr, ok := cause.(*RcodeError); ok {
+			return rcodeErr.Rcode == dns.RcodeNotAuth || rcodeErr.Rcode == dns.RcodeRefused
+		}
+		return false
+	}
+}
+
+type rfc2136Client struct {
+	server     string
+	keyName    string
+	algorithm  string
+	dnsClient  *dns.Client
+	tsigSecret map[string]string
+}
+
+var _ Client = (*rfc2136Client)(nil)
+
+// NewClient creates a client for the given name server, authenticating with the given TSIG key. The secret
+// is base64 encoded. The algorithm defaults to hmac-sha256.
+func NewClient(server, keyName, secret, algorithm string) (Client, error) {
+	if server == "" {
+		return nil, errors.New("name server address is required")
+	}
+	if keyName == "" || secret == "" {
+		return nil, errors.New("TSIG key name and secret are required")
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, defaultPort)
+	}
+	if algorithm == "" {
+		algorithm = dns.HmacSHA256
+	}
+	keyName = canonicalName(keyName)
+	tsigSecret := map[string]string{keyName: secret}
+	return &rfc2136Client{
+		server:    server,
+		keyName:   keyName,
+		algorithm: canonicalName(algorithm),
+		dnsClient: &dns.Client{
+			Net:        "tcp",
+			Timeout:    timeout,
+			TsigSecret: tsigSecret,
+		},
+		tsigSecret: tsigSecret,
+	}, nil
+}
+
+// NewClientFromSecret creates a client for the given name server from a secret containing the TSIG key.
+func NewClientFromSecret(server string, secret *corev1.Secret) (Client, error) {
+	keyName, ok := secret.Data[constants.TSIGKeyNameSecretKey]
+	if !ok {
+		return nil, errors.Errorf("secret does not contain %q data", constants.TSIGKeyNameSecretKey)
+	}
+	tsigSecret, ok := secret.Data[constants.TSIGSecretSecretKey]
+	if !ok {
+		return nil, errors.Errorf("secret does not contain %q data", constants.TSIGSecretSecretKey)
+	}
+	algorithm := secret.Data[constants.TSIGAlgorithmSecretKey]
+	return NewClient(
+		server,
+		strings.TrimSpace(string(keyName)),
+		strings.TrimSpace(string(tsigSecret)),
+		strings.TrimSpace(string(algorithm)),
+	)
+}
+
+// GetZone implements Client.GetZone.
+func (c *rfc2136Client) GetZone(name string) (*dns.SOA, error) {
+	m := &dns.Msg{}
+	m.SetQuestion(dns.Fqdn(name), dns.TypeSOA)
+	in, err := c.exchange(m)
+	if rcodeErr, ok := err.(*RcodeError); ok {
+		switch rcodeErr.Rcode {
+		case dns.RcodeNameError:
+			// The SOA record of the zone of a name that does not exist is in the authority section.
+			return findSOA(in.Ns), nil
+		case dns.RcodeRefused:
+			// Name servers refuse queries for names outside of their zones.
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !in.Authoritative {
+		return nil, nil
+	}
+	if soa := findSOA(in.Answer); soa != nil {
+		return soa, nil
+	}
+	return findSOA(in.Ns), nil
+}
+
+// GetRecords implements Client.GetRecords.
+func (c *rfc2136Client) GetRecords(name string, rrType uint16) ([]dns.RR, error) {
+	m := &dns.Msg{}
+	m.SetQuestion(dns.Fqdn(name), rrType)
+	in, err := c.exchange(m)
+	if err != nil {
+		if rcodeErr, ok := err.(*RcodeError); ok && rcodeErr.Rcode == dns.RcodeNameError {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var records []dns.RR
+	for _, rr := range in.Answer {
+		if rr.Header().Rrtype == rrType && strings.EqualFold(rr.Header().Name, dns.Fqdn(name)) {
+			records = append(records, rr)
+		}
+	}
+	return records, nil
+}
+
+// TransferZone implements Client.TransferZone.
+func (c *rfc2136Client) TransferZone(zone string) ([]dns.RR, error) {
+	m := &dns.Msg{}
+	m.SetAxfr(dns.Fqdn(zone))
+	c.sign(m)
+	t := &dns.Transfer{
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+		TsigSecret:   c.tsigSecret,
+	}
+	envelopes, err := t.In(m, c.server)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start zone transfer")
+	}
+	var records []dns.RR
+	for e := range envelopes {
+		if e.Error != nil {
+			return nil, errors.Wrap(e.Error, "zone transfer failed")
+		}
+		records = append(records, e.RR...)
+	}
+	// The transfer starts and ends with the SOA record of the zone.
+	if n := len(records); n > 1 {
+		records = records[:n-1]
+	}
+	return records, nil
+}
+
+// UpdateZone implements Client.UpdateZone.
+func (c *rfc2136Client) UpdateZone(zone string, removeRRsets, insert []dns.RR) error {
+	m := &dns.Msg{}
+	m.SetUpdate(dns.Fqdn(zone))
+	if len(removeRRsets) > 0 {
+		m.RemoveRRset(removeRRsets)
+	}
+	if len(insert) > 0 {
+		m.Insert(insert)
+	}
+	_, err := c.exchange(m)
+	return err
+}
+
+func (c *rfc2136Client) sign(m *dns.Msg) {
+	m.SetTsig(c.keyName, c.algorithm, tsigFudge, time.Now().Unix())
+}
+
+// exchange sends the signed message to the name server. When the name server answers with an error rcode,
+// the answer is returned along with an RcodeError.
+func (c *rfc2136Client) exchange(m *dns.Msg) (*dns.Msg, error) {
+	c.sign(m)
+	in, _, err := c.dnsClient.Exchange(m, c.server)
+	if err != nil {
+		return nil, err
+	}
+	if in.Rcode != dns.RcodeSuccess {
+		return in, &RcodeError{Rcode: in.Rcode}
+	}
+	return in, nil
+}
+
+func canonicalName(name string) string {
+	return strings.ToLower(dns.Fqdn(name))
+}
+
+func findSOA(records []dns.RR) *dns.SOA {
+	for _, rr := range records {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa
+		}
+	}
+	return nil
+}
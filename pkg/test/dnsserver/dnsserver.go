@@ -0,0 +1,312 @@
+package dnsserver
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// KeyName is the name of the TSIG key of the server.
+	KeyName = "hive-test."
+
+	// Secret is the base64 encoded secret of the TSIG key of the server.
+	Secret = "aGl2ZS10ZXN0LXRzaWctc2VjcmV0"
+
+	// Algorithm is the algorithm of the TSIG key of the server.
+	Algorithm = dns.HmacSHA256
+
+	defaultTTL = 60
+)
+
+// Server is an in-process authoritative name server for tests. It answers queries for its zones, and zone
+// transfers and dynamic updates (RFC 2136) authenticated with its TSIG key, over TCP.
+type Server struct {
+	// Addr is the address the server listens on.
+	Addr string
+
+	mu     sync.Mutex
+	zones  map[string][]dns.RR
+	server *dns.Server
+}
+
+// Start starts a server serving the given zones, each with a SOA record and an NS record for the ns1 name
+// of the zone. The server is shut down at the end of the test.
+func Start(t *testing.T, zones ...string) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &Server{
+		Addr:  listener.Addr().String(),
+		zones: map[string][]dns.RR{},
+	}
+	for _, zone := range zones {
+		s.AddZone(zone)
+	}
+	started := make(chan struct{})
+	s.server = &dns.Server{
+		Listener:          listener,
+		Net:               "tcp",
+		Handler:           dns.HandlerFunc(s.serveDNS),
+		TsigSecret:        map[string]string{KeyName: Secret},
+		MsgAcceptFunc:     acceptMsg,
+		NotifyStartedFunc: func() { close(started) },
+	}
+	go s.server.ActivateAndServe()
+	select {
+	case <-started:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the DNS server to start")
+	}
+	t.Cleanup(func() { s.server.Shutdown() })
+	return s
+}
+
+// AddZone adds a zone to the server, with a SOA record and an NS record for the ns1 name of the zone.
+func (s *Server) AddZone(zone string) {
+	zone = canonical(zone)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.zones[zone] = []dns.RR{
+		&dns.SOA{
+			Hdr:     dns.RR_Header{Name: zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: defaultTTL},
+			Ns:      "ns1." + zone,
+			Mbox:    "hostmaster." + zone,
+			Serial:  1,
+			Refresh: 3600,
+			Retry:   600,
+			Expire:  86400,
+			Minttl:  defaultTTL,
+		},
+		&dns.NS{
+			Hdr: dns.RR_Header{Name: zone, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: defaultTTL},
+			Ns:  "ns1." + zone,
+		},
+	}
+}
+
+// AddRecords adds the records, given in zone file format, to the zones of the server that contain them.
+func (s *Server) AddRecords(t *testing.T, records ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			t.Fatalf("invalid record %q: %v", record, err)
+		}
+		zone := s.findZone(rr.Header().Name)
+		if zone == "" {
+			t.Fatalf("no zone for record %q", record)
+		}
+		s.zones[zone] = append(s.zones[zone], rr)
+	}
+}
+
+// Records returns the records of the given name and type, or of all types for dns.TypeANY, sorted by
+// their zone file format.
+func (s *Server) Records(name string, rrType uint16) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name = canonical(name)
+	var records []string
+	for _, rr := range s.zones[s.findZone(name)] {
+		if strings.EqualFold(rr.Header().Name, name) && (rrType == dns.TypeANY || rr.Header().Rrtype == rrType) {
+			records = append(records, rr.String())
+		}
+	}
+	sort.Strings(records)
+	return records
+}
+
+func acceptMsg(dh dns.Header) dns.MsgAcceptAction {
+	if dh.Bits&(1<<15) != 0 {
+		// Ignore responses.
+		return dns.MsgIgnore
+	}
+	switch opcode := int(dh.Bits>>11) & 0xF; opcode {
+	case dns.OpcodeQuery, dns.OpcodeUpdate:
+		return dns.MsgAccept
+	default:
+		return dns.MsgRejectNotImplemented
+	}
+}
+
+func (s *Server) serveDNS(w dns.ResponseWriter, r *dns.Msg) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := &dns.Msg{}
+	m.SetReply(r)
+	signed := r.IsTsig() != nil
+	if signed && w.TsigStatus() != nil {
+		m.SetRcode(r, dns.RcodeNotAuth)
+		w.WriteMsg(m)
+		return
+	}
+	switch {
+	case len(r.Question) != 1:
+		m.SetRcode(r, dns.RcodeFormatError)
+	case r.Opcode == dns.OpcodeUpdate:
+		if !signed {
+			m.SetRcode(r, dns.RcodeRefused)
+			break
+		}
+		m.SetRcode(r, s.update(r))
+	case r.Question[0].Qtype == dns.TypeAXFR:
+		zone := canonical(r.Question[0].Name)
+		records, ok := s.zones[zone]
+		if !signed || !ok {
+			m.SetRcode(r, dns.RcodeRefused)
+			break
+		}
+		ch := make(chan *dns.Envelope, 1)
+		ch <- &dns.Envelope{RR: append(append([]dns.RR{}, records...), records[0])}
+		close(ch)
+		(&dns.Transfer{}).Out(w, r, ch)
+		return
+	default:
+		s.query(r.Question[0], m)
+	}
+	if tsig := r.IsTsig(); tsig != nil {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsig.Fudge, time.Now().Unix())
+	}
+	w.WriteMsg(m)
+}
+
+// query answers the question, following the delegations of the zone of the question.
+func (s *Server) query(q dns.Question, m *dns.Msg) {
+	name := canonical(q.Name)
+	zone := s.findZone(name)
+	if zone == "" {
+		m.Rcode = dns.RcodeRefused
+		return
+	}
+	records := s.zones[zone]
+	// Refer names at or below a delegation to the name servers of the delegation. The DS records of a delegation
+	// are served by the parent zone.
+	for cut := name; cut != zone && dns.IsSubDomain(zone, cut); cut = parent(cut) {
+		if cut == name && q.Qtype == dns.TypeDS {
+			continue
+		}
+		if delegation := matching(records, cut, dns.TypeNS); len(delegation) > 0 {
+			m.Ns = delegation
+			return
+		}
+	}
+	m.Authoritative = true
+	m.Answer = matching(records, name, q.Qtype)
+	if len(m.Answer) > 0 {
+		return
+	}
+	m.Ns = []dns.RR{records[0]}
+	for _, rr := range records {
+		if dns.IsSubDomain(name, canonical(rr.Header().Name)) {
+			// The name exists, without records of the type.
+			return
+		}
+	}
+	m.Rcode = dns.RcodeNameError
+}
+
+// update applies the update section of a dynamic update, ignoring its prerequisites.
+func (s *Server) update(r *dns.Msg) int {
+	zone := canonical(r.Question[0].Name)
+	records, ok := s.zones[zone]
+	if !ok {
+		return dns.RcodeNotAuth
+	}
+	for _, rr := range r.Ns {
+		if !dns.IsSubDomain(zone, canonical(rr.Header().Name)) {
+			return dns.RcodeNotZone
+		}
+	}
+	for _, u := range r.Ns {
+		h := u.Header()
+		name := canonical(h.Name)
+		apexRecord := func(rr dns.RR) bool {
+			t := rr.Header().Rrtype
+			return name == zone && (t == dns.TypeSOA || t == dns.TypeNS)
+		}
+		switch h.Class {
+		case dns.ClassANY:
+			// Delete the record sets of the name, or the record set of the type.
+			records = filter(records, func(rr dns.RR) bool {
+				return canonical(rr.Header().Name) != name ||
+					(h.Rrtype != dns.TypeANY && rr.Header().Rrtype != h.Rrtype) ||
+					apexRecord(rr)
+			})
+		case dns.ClassNONE:
+			// Delete the record.
+			records = filter(records, func(rr dns.RR) bool {
+				rr2 := dns.Copy(u)
+				rr2.Header().Class = dns.ClassINET
+				return !dns.IsDuplicate(rr, rr2) || rr.Header().Rrtype == dns.TypeSOA
+			})
+		case dns.ClassINET:
+			duplicate := false
+			for _, rr := range records {
+				if dns.IsDuplicate(rr, u) {
+					duplicate = true
+				}
+			}
+			if !duplicate {
+				records = append(records, dns.Copy(u))
+			}
+		default:
+			return dns.RcodeFormatError
+		}
+	}
+	records[0].(*dns.SOA).Serial++
+	s.zones[zone] = records
+	return dns.RcodeSuccess
+}
+
+// findZone returns the closest zone of the server that contains the name.
+func (s *Server) findZone(name string) string {
+	for n := canonical(name); ; n = parent(n) {
+		if _, ok := s.zones[n]; ok {
+			return n
+		}
+		if n == "." {
+			return ""
+		}
+	}
+}
+
+func matching(records []dns.RR, name string, rrType uint16) []dns.RR {
+	var result []dns.RR
+	for _, rr := range records {
+		if canonical(rr.Header().Name) == name && (rrType == dns.TypeANY || rr.Header().Rrtype == rrType) {
+			result = append(result, rr)
+		}
+	}
+	return result
+}
+
+func filter(records []dns.RR, keep func(dns.RR) bool) []dns.RR {
+	var result []dns.RR
+	for _, rr := range records {
+		if keep(rr) {
+			result = append(result, rr)
+		}
+	}
+	return result
+}
+
+func parent(name string) string {
+	i, end := dns.NextLabel(name, 0)
+	if end {
+		return "."
+	}
+	return name[i:]
+}
+
+func canonical(name string) string {
+	return strings.ToLower(dns.Fqdn(name))
+}
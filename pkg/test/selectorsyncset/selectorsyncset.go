@@ -105,6 +105,24 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithTemplateMode(templateMode hivev1.SyncSetTemplateMode) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.TemplateMode = templateMode
+	}
+}
+
+func WithHealthCheckMode(healthCheckMode hivev1.SyncSetHealthCheckMode) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.HealthCheckMode = healthCheckMode
+	}
+}
+
+func WithDependsOn(dependencies ...hivev1.SyncSetDependency) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.DependsOn = dependencies
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
 		selectorSyncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
@@ -125,3 +143,21 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		selectorSyncSet.Spec.Patches = patches
 	}
 }
+
+func WithRolloutStrategy(strategy *hivev1.SelectorSyncSetRolloutStrategy) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.RolloutStrategy = strategy
+	}
+}
+
+func WithRolloutStatus(rollout *hivev1.SelectorSyncSetRolloutStatus) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Status.Rollout = rollout
+	}
+}
+
+func WithKustomize(kustomize *hivev1.KustomizeSource) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.Kustomize = kustomize
+	}
+}
@@ -107,6 +107,24 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithTemplateMode(templateMode hivev1.SyncSetTemplateMode) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.TemplateMode = templateMode
+	}
+}
+
+func WithHealthCheckMode(healthCheckMode hivev1.SyncSetHealthCheckMode) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.HealthCheckMode = healthCheckMode
+	}
+}
+
+func WithDependsOn(dependencies ...hivev1.SyncSetDependency) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.DependsOn = dependencies
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
@@ -127,3 +145,9 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		syncSet.Spec.Patches = patches
 	}
 }
+
+func WithKustomize(kustomize *hivev1.KustomizeSource) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.Kustomize = kustomize
+	}
+}
@@ -44,17 +44,20 @@ const (
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "MachineManagement"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "HibernationHooks", "InstallAttemptsLimit", "MachineManagement"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder *admission.Decoder
 
-	validManagedDomains  []string
-	fs                   *featureSet
-	awsPrivateLinkConfig *hivev1.AWSPrivateLinkConfig
-	supportedContracts   contracts.SupportedContractImplementationsList
+	validManagedDomains []string
+	// rfc2136ManagedDomains are the managed domains whose DNS is managed with RFC 2136 dynamic updates, which
+	// allows managing DNS for clusters on any platform.
+	rfc2136ManagedDomains []string
+	fs                    *featureSet
+	awsPrivateLinkConfig  *hivev1.AWSPrivateLinkConfig
+	supportedContracts    contracts.SupportedContractImplementationsList
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
@@ -65,8 +68,12 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 		logger.WithError(err).Fatal("Unable to read managedDomains file")
 	}
 	domains := []string{}
+	rfc2136Domains := []string{}
 	for _, md := range managedDomains {
 		domains = append(domains, md.Domains...)
+		if md.RFC2136 != nil {
+			rfc2136Domains = append(rfc2136Domains, md.Domains...)
+		}
 	}
 
 	aplConfig, err := awsprivatelink.ReadAWSPrivateLinkControllerConfigFile()
@@ -82,18 +89,22 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 
 	logger.WithField("managedDomains", domains).Info("Read managed domains")
 	return &ClusterDeploymentValidatingAdmissionHook{
-		decoder:              decoder,
-		validManagedDomains:  domains,
-		fs:                   newFeatureSet(),
-		awsPrivateLinkConfig: aplConfig,
-		supportedContracts:   supportContractsConfig,
+		decoder:               decoder,
+		validManagedDomains:   domains,
+		rfc2136ManagedDomains: rfc2136Domains,
+		fs:                    newFeatureSet(),
+		awsPrivateLinkConfig:  aplConfig,
+		supportedContracts:    supportContractsConfig,
 	}
 }
 
 // ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
-//                    webhook is accessed by the kube apiserver.
+//
+//	webhook is accessed by the kube apiserver.
+//
 // For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterdeploymentvalidators".
-//              When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+//
+//	When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
 func (a *ClusterDeploymentValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
 	log.WithFields(log.Fields{
 		"group":    clusterDeploymentAdmissionGroup,
@@ -288,7 +299,8 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 	}
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath.Child("platform"), cd.Spec.Platform)...)
-	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec, a.rfc2136ManagedDomains)...)
+	allErrs = append(allErrs, validateHibernationHooks(specPath.Child("hibernationHooks"), cd.Spec.HibernationHooks)...)
 
 	if cd.Spec.Platform.AWS != nil {
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
@@ -323,6 +335,14 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		if targetNamespace := machineManagement.TargetNamespace; targetNamespace != "" {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("machineManagement", "targetNamespace"), targetNamespace, "cannot set targetNamespace during create, targetNamespace is created and set by controllers"))
 		}
+		if machineManagement.Central != nil {
+			switch aws := cd.Spec.Platform.AWS; {
+			case aws == nil:
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("machineManagement", "central"), "central machine management is only supported on AWS"))
+			case aws.CredentialsSecretRef.Name == "":
+				allErrs = append(allErrs, field.Required(specPath.Child("platform", "aws", "credentialsSecretRef", "name"), "central machine management requires a credentials secret"))
+			}
+		}
 	}
 
 	if len(allErrs) > 0 {
@@ -541,7 +561,28 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 	return allErrs
 }
 
-func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+func validateHibernationHooks(path *field.Path, hooks *hivev1.HibernationHooks) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hooks == nil {
+		return allErrs
+	}
+	validateHook := func(hookPath *field.Path, hook *hivev1.HibernationHook) {
+		if hook == nil {
+			return
+		}
+		if hook.PodTemplateRef.Name == "" {
+			allErrs = append(allErrs, field.Required(hookPath.Child("podTemplateRef", "name"), "must specify the pod template of the hook"))
+		}
+		if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(hookPath.Child("timeout"), hook.Timeout.Duration.String(), "timeout must be positive"))
+		}
+	}
+	validateHook(path.Child("preStop"), hooks.PreStop)
+	validateHook(path.Child("postResume"), hooks.PostResume)
+	return allErrs
+}
+
+func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec, rfc2136ManagedDomains []string) field.ErrorList {
 	allErrs := field.ErrorList{}
 	canManageDNS := false
 	if spec.Platform.AWS != nil {
@@ -553,6 +594,10 @@ func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.Cl
 	if spec.Platform.GCP != nil {
 		canManageDNS = true
 	}
+	// DNS for domains managed with RFC 2136 dynamic updates can be managed regardless of the platform.
+	if validateDomain(spec.BaseDomain, rfc2136ManagedDomains) {
+		canManageDNS = true
+	}
 	if !canManageDNS && spec.ManageDNS {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("manageDNS"), spec.ManageDNS, "cannot manage DNS for the selected platform"))
 	}
@@ -669,6 +714,8 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		allErrs = append(allErrs, field.Invalid(specPath.Child("clusterPoolRef"), newPoolRef, "cannot add clusterPoolRef"))
 	}
 
+	allErrs = append(allErrs, validateHibernationHooks(specPath.Child("hibernationHooks"), cd.Spec.HibernationHooks)...)
+
 	// Validate cd.Spec.MachineManagement.TargetNamespace
 	if cd.Spec.MachineManagement != nil {
 		switch oldTargetNamespace, newTargetNamespace := oldObject.Spec.MachineManagement.TargetNamespace, cd.Spec.MachineManagement.TargetNamespace; {
@@ -35,6 +35,10 @@ var validTestManagedDomains = []string{
 	"ccc.com",
 }
 
+var validTestRFC2136ManagedDomains = []string{
+	"ccc.com",
+}
+
 func clusterDeploymentTemplate() *hivev1.ClusterDeployment {
 	return &hivev1.ClusterDeployment{
 		Spec: hivev1.ClusterDeploymentSpec{
@@ -375,6 +379,35 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name:      "Test Update HibernationHooks",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationHooks = &hivev1.HibernationHooks{
+					PreStop: &hivev1.HibernationHook{
+						PodTemplateRef: corev1.LocalObjectReference{Name: "etcd-backup"},
+						FailurePolicy:  hivev1.IgnoreHibernationHookFailurePolicy,
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test HibernationHooks without pod template",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationHooks = &hivev1.HibernationHooks{
+					PostResume: &hivev1.HibernationHook{},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test Update Operation is NOT allowed with different immutable data",
 			oldObject:       validAWSClusterDeployment(),
@@ -525,6 +558,28 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test managed DNS is valid on vSphere for RFC 2136 managed domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validVSphereClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.ccc.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test managed DNS is not valid on vSphere for other managed domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validVSphereClusterDeployment()
+				cd.Spec.ManageDNS = true
+				cd.Spec.BaseDomain = "bar.foo.aaa.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:      "Test allow modifying controlPlaneConfig",
 			oldObject: validAWSClusterDeployment(),
@@ -989,6 +1044,47 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed:     false,
 			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
 		},
+		{
+			name: "central machine management create valid",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central: &hivev1.CentralMachineManagement{},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
+		{
+			name: "central machine management not supported on GCP",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central: &hivev1.CentralMachineManagement{},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
+		{
+			name: "central machine management requires credentials secret",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = ""
+				cd.Spec.Platform.AWS.CredentialsAssumeRole = &hivev1aws.AssumeRole{RoleARN: "arn:aws:iam::123456789:role/hive"}
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central: &hivev1.CentralMachineManagement{},
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
 		{
 			name: "targetNamespace can be set if unset",
 			oldObject: func() *hivev1.ClusterDeployment {
@@ -1115,8 +1211,9 @@ func TestClusterDeploymentValidate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			data := ClusterDeploymentValidatingAdmissionHook{
-				decoder:             createDecoder(t),
-				validManagedDomains: validTestManagedDomains,
+				decoder:               createDecoder(t),
+				validManagedDomains:   validTestManagedDomains,
+				rfc2136ManagedDomains: validTestRFC2136ManagedDomains,
 				fs: &featureSet{
 					FeatureGatesEnabled: &hivev1.FeatureGatesEnabled{
 						Enabled: tc.enabledFeatureGates,
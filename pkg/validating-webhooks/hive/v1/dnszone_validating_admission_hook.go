@@ -168,6 +168,28 @@ func (a *DNSZoneValidatingAdmissionHook) validateCreate(admissionSpec *admission
 		}
 	}
 
+	if message := validateDNSZoneDNSSEC(&newObject.Spec); message != "" {
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
+	if message := validateDNSZonePrivate(&newObject.Spec); message != "" {
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -227,6 +249,40 @@ func (a *DNSZoneValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 			},
 		}
 	}
+	if oldObject.Spec.Private != newObject.Spec.Private {
+		message := "DNSZone.Spec.Private is immutable"
+		contextLogger.Infof("Failed validation: %v", message)
+
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
+	if message := validateDNSZoneDNSSEC(&newObject.Spec); message != "" {
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
+	if message := validateDNSZonePrivate(&newObject.Spec); message != "" {
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
 
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
@@ -234,3 +290,49 @@ func (a *DNSZoneValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 		Allowed: true,
 	}
 }
+
+// validateDNSZoneDNSSEC returns a failure message if DNSSEC is enabled for a DNSZone that cannot be signed.
+func validateDNSZoneDNSSEC(spec *hivev1.DNSZoneSpec) string {
+	switch {
+	case !spec.DNSSEC:
+		return ""
+	case spec.AWS != nil:
+		if spec.AWS.DNSSECKMSKeyARN == "" {
+			return "DNSZone.Spec.AWS.DNSSECKMSKeyARN is required when DNSSEC is enabled"
+		}
+		return ""
+	case spec.GCP != nil:
+		return ""
+	default:
+		return "DNSSEC is only supported for AWS and GCP DNSZones"
+	}
+}
+
+// validateDNSZonePrivate returns a failure message if a private DNSZone cannot be created, or if the networks of
+// private zones are set for a public DNSZone.
+func validateDNSZonePrivate(spec *hivev1.DNSZoneSpec) string {
+	if !spec.Private {
+		switch {
+		case spec.AWS != nil && len(spec.AWS.VPCs) > 0:
+			return "DNSZone.Spec.AWS.VPCs can only be set for private DNSZones"
+		case spec.GCP != nil && len(spec.GCP.Networks) > 0:
+			return "DNSZone.Spec.GCP.Networks can only be set for private DNSZones"
+		case spec.Azure != nil && len(spec.Azure.VirtualNetworks) > 0:
+			return "DNSZone.Spec.Azure.VirtualNetworks can only be set for private DNSZones"
+		}
+		return ""
+	}
+	switch {
+	case spec.DNSSEC:
+		return "DNSSEC is not supported for private DNSZones"
+	case spec.AWS != nil:
+		if len(spec.AWS.VPCs) == 0 {
+			return "DNSZone.Spec.AWS.VPCs is required for private DNSZones"
+		}
+		return ""
+	case spec.GCP != nil, spec.Azure != nil:
+		return ""
+	default:
+		return "Private DNSZones are only supported for AWS, GCP and Azure"
+	}
+}
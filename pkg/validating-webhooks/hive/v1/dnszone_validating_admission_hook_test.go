@@ -46,6 +46,7 @@ func TestDNSZoneValidate(t *testing.T) {
 		name            string
 		newZoneStr      string
 		oldZoneStr      string
+		newSpec         *hivev1.DNSZoneSpec
 		newObjectRaw    []byte
 		oldObjectRaw    []byte
 		operation       admissionv1beta1.Operation
@@ -104,6 +105,96 @@ func TestDNSZoneValidate(t *testing.T) {
 
 			expectedAllowed: true,
 		},
+		{
+			name:       "Test DNSSEC allowed for AWS with KMS key",
+			newZoneStr: "this.is.a.valid.zone",
+			newSpec: &hivev1.DNSZoneSpec{
+				DNSSEC: true,
+				AWS:    &hivev1.AWSDNSZoneSpec{DNSSECKMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd"},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test DNSSEC not allowed for AWS without KMS key",
+			newZoneStr:      "this.is.a.valid.zone",
+			newSpec:         &hivev1.DNSZoneSpec{DNSSEC: true, AWS: &hivev1.AWSDNSZoneSpec{}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test DNSSEC allowed for GCP",
+			newZoneStr:      "this.is.a.valid.zone",
+			newSpec:         &hivev1.DNSZoneSpec{DNSSEC: true, GCP: &hivev1.GCPDNSZoneSpec{}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test DNSSEC not allowed for Azure",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newSpec:         &hivev1.DNSZoneSpec{DNSSEC: true, Azure: &hivev1.AzureDNSZoneSpec{}},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test private AWS zone with VPCs allowed",
+			newZoneStr: "this.is.a.valid.zone",
+			newSpec: &hivev1.DNSZoneSpec{
+				Private: true,
+				AWS:     &hivev1.AWSDNSZoneSpec{VPCs: []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test private AWS zone without VPCs not allowed",
+			newZoneStr:      "this.is.a.valid.zone",
+			newSpec:         &hivev1.DNSZoneSpec{Private: true, AWS: &hivev1.AWSDNSZoneSpec{}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test VPCs not allowed for public AWS zone",
+			newZoneStr: "this.is.a.valid.zone",
+			newSpec: &hivev1.DNSZoneSpec{
+				AWS: &hivev1.AWSDNSZoneSpec{VPCs: []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test private GCP zone allowed",
+			newZoneStr: "this.is.a.valid.zone",
+			newSpec: &hivev1.DNSZoneSpec{
+				Private: true,
+				GCP:     &hivev1.GCPDNSZoneSpec{Networks: []string{"https://www.googleapis.com/compute/v1/projects/p/global/networks/n"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test DNSSEC not allowed for private zones",
+			newZoneStr:      "this.is.a.valid.zone",
+			newSpec:         &hivev1.DNSZoneSpec{Private: true, DNSSEC: true, GCP: &hivev1.GCPDNSZoneSpec{}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test private zones not allowed for RFC 2136",
+			newZoneStr:      "this.is.a.valid.zone",
+			newSpec:         &hivev1.DNSZoneSpec{Private: true, RFC2136: &hivev1.RFC2136DNSZoneSpec{}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test DNSZone.Spec.Private is immutable",
+			newZoneStr:      "this.is.a.valid.zone",
+			oldZoneStr:      "this.is.a.valid.zone",
+			newSpec:         &hivev1.DNSZoneSpec{Private: true, Azure: &hivev1.AzureDNSZoneSpec{}},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test that we don't validate deletes",
 			operation:       admissionv1beta1.Delete,
@@ -147,6 +238,10 @@ func TestDNSZoneValidate(t *testing.T) {
 					Zone: tc.newZoneStr,
 				},
 			}
+			if tc.newSpec != nil {
+				newObject.Spec = *tc.newSpec
+				newObject.Spec.Zone = tc.newZoneStr
+			}
 			oldObject := &hivev1.DNSZone{
 				Spec: hivev1.DNSZoneSpec{
 					Zone: tc.oldZoneStr,
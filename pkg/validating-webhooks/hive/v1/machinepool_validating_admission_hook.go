@@ -1,8 +1,12 @@
 package v1
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -13,11 +17,15 @@ import (
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
@@ -34,8 +42,13 @@ const (
 	defaultMasterPoolName = "master"
 	defaultWorkerPoolName = "worker"
 	legacyWorkerPoolName  = "w"
+
+	// sysctlsMachineConfigName is the name of the MachineConfig generated for the sysctls of a machine pool.
+	sysctlsMachineConfigName = "sysctls"
 )
 
+var sysctlNameRegexp = regexp.MustCompile(`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?([./][a-z0-9]([-_a-z0-9]*[a-z0-9])?)*$`)
+
 // MachinePoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type MachinePoolValidatingAdmissionHook struct {
 	decoder *admission.Decoder
@@ -224,10 +237,23 @@ func validateMachinePoolUpdate(old, new *hivev1.MachinePool) field.ErrorList {
 	specPath := field.NewPath("spec")
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.ClusterDeploymentRef, old.Spec.ClusterDeploymentRef, specPath.Child("clusterDeploymentRef"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Name, old.Spec.Name, specPath.Child("name"))...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	// The platform configuration can be changed when the machine pool has an update strategy, as long as the platform
+	// itself stays the same.
+	if old.Spec.UpdateStrategy == nil || !samePlatform(&old.Spec.Platform, &new.Spec.Platform) {
+		allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	}
 	return allErrs
 }
 
+func samePlatform(old, new *hivev1.MachinePoolPlatform) bool {
+	return (old.AWS != nil) == (new.AWS != nil) &&
+		(old.Azure != nil) == (new.Azure != nil) &&
+		(old.GCP != nil) == (new.GCP != nil) &&
+		(old.OpenStack != nil) == (new.OpenStack != nil) &&
+		(old.VSphere != nil) == (new.VSphere != nil) &&
+		(old.Ovirt != nil) == (new.Ovirt != nil)
+}
+
 func validateMachinePoolName(pool *hivev1.MachinePool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if pool.Name != fmt.Sprintf("%s-%s", pool.Spec.ClusterDeploymentRef.Name, pool.Spec.Name) {
@@ -267,6 +293,8 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 	platformPath := fldPath.Child("platform")
 	platforms := []string{}
 	numberOfMachineSets := 0
+	var zones []string
+	zonesSupported := false
 
 	// set validZeroSizeAutoscalingMinReplicas to true for any platform where a zero-size minReplicas is allowed with autoscaling
 	validZeroSizeAutoscalingMinReplicas := false
@@ -275,18 +303,24 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		platforms = append(platforms, "aws")
 		allErrs = append(allErrs, validateAWSMachinePoolPlatformInvariants(p, platformPath.Child("aws"))...)
 		numberOfMachineSets = len(p.Zones)
+		zones = p.Zones
+		zonesSupported = true
 		validZeroSizeAutoscalingMinReplicas = true
 	}
 	if p := spec.Platform.Azure; p != nil {
 		platforms = append(platforms, "azure")
 		allErrs = append(allErrs, validateAzureMachinePoolPlatformInvariants(p, platformPath.Child("azure"))...)
 		numberOfMachineSets = len(p.Zones)
+		zones = p.Zones
+		zonesSupported = true
 		validZeroSizeAutoscalingMinReplicas = true
 	}
 	if p := spec.Platform.GCP; p != nil {
 		platforms = append(platforms, "gcp")
 		allErrs = append(allErrs, validateGCPMachinePoolPlatformInvariants(p, platformPath.Child("gcp"))...)
 		numberOfMachineSets = len(p.Zones)
+		zones = p.Zones
+		zonesSupported = true
 		validZeroSizeAutoscalingMinReplicas = true
 	}
 	if p := spec.Platform.OpenStack; p != nil {
@@ -297,11 +331,22 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 	if p := spec.Platform.VSphere; p != nil {
 		platforms = append(platforms, "vsphere")
 		allErrs = append(allErrs, validateVSphereMachinePoolPlatformInvariants(p, platformPath.Child("vsphere"))...)
+		numberOfMachineSets = len(p.Zones)
+		for _, zone := range p.Zones {
+			zones = append(zones, zone.Name)
+		}
+		zonesSupported = len(p.Zones) > 0
 	}
 	if p := spec.Platform.Ovirt; p != nil {
 		platforms = append(platforms, "ovirt")
 		allErrs = append(allErrs, validateOvirtMachinePoolPlatformInvariants(p, platformPath.Child("ovirt"))...)
 	}
+	agentPlatform := false
+	if p := spec.Platform.Agent; p != nil {
+		platforms = append(platforms, "agent")
+		allErrs = append(allErrs, validateAgentMachinePoolPlatformInvariants(p, platformPath.Child("agent"))...)
+		agentPlatform = true
+	}
 
 	switch len(platforms) {
 	case 0:
@@ -326,7 +371,205 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 			allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("minReplicas"), spec.Autoscaling.MinReplicas, "minimum replicas must not be greater than maximum replicas"))
 		}
 	}
+	if agentPlatform {
+		// The workers of agent machine pools are inventory hosts bound to the cluster, not machines.
+		if spec.Autoscaling != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("autoscaling"), "auto-scaling is not supported for agent machine pools"))
+		}
+		if spec.HealthCheck != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("healthCheck"), "health checks are not supported for agent machine pools"))
+		}
+		if spec.UpdateStrategy != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("updateStrategy"), "update strategies are not supported for agent machine pools"))
+		}
+		if spec.NodeConfig != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeConfig"), "node configuration is not supported for agent machine pools"))
+		}
+	}
 	allErrs = append(allErrs, metavalidation.ValidateLabels(spec.Labels, fldPath.Child("labels"))...)
+	if spec.HealthCheck != nil {
+		allErrs = append(allErrs, validateMachinePoolHealthCheck(spec.HealthCheck, fldPath.Child("healthCheck"))...)
+	}
+	if spec.UpdateStrategy != nil {
+		allErrs = append(allErrs, validateMachinePoolUpdateStrategy(spec.UpdateStrategy, fldPath.Child("updateStrategy"))...)
+	}
+	if len(spec.ZoneDistribution) > 0 {
+		if zonesSupported {
+			allErrs = append(allErrs, validateMachinePoolZoneDistribution(spec, zones, fldPath.Child("zoneDistribution"))...)
+		} else {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("zoneDistribution"), "zone distribution is only supported on AWS, Azure and GCP, and on vSphere with zones"))
+		}
+	}
+	if spec.NodeConfig != nil {
+		if spec.Name == defaultWorkerPoolName {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeConfig"), fmt.Sprintf("node configuration is not supported for the %q pool", defaultWorkerPoolName)))
+		} else {
+			allErrs = append(allErrs, validateMachinePoolNodeConfig(spec.NodeConfig, fldPath.Child("nodeConfig"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateMachinePoolNodeConfig(nodeConfig *hivev1.MachinePoolNodeConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if nodeConfig.Kubelet != nil {
+		allErrs = append(allErrs, validateJSONObject(nodeConfig.Kubelet, fldPath.Child("kubelet"))...)
+	}
+	for name, value := range nodeConfig.Sysctls {
+		sysctlPath := fldPath.Child("sysctls").Key(name)
+		if !sysctlNameRegexp.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(sysctlPath, name, "sysctl name must be a dot or slash separated path of alphanumeric segments"))
+		}
+		if value == "" || strings.ContainsAny(value, "\n\r") {
+			allErrs = append(allErrs, field.Invalid(sysctlPath, value, "sysctl value must be a single non-empty line"))
+		}
+	}
+	seen := sets.NewString()
+	for i, mc := range nodeConfig.MachineConfigs {
+		mcPath := fldPath.Child("machineConfigs").Index(i)
+		switch {
+		case mc.Name == "":
+			allErrs = append(allErrs, field.Required(mcPath.Child("name"), "machine config name is required"))
+		case mc.Name == sysctlsMachineConfigName:
+			allErrs = append(allErrs, field.Invalid(mcPath.Child("name"), mc.Name, fmt.Sprintf("machine config name %q is reserved for the sysctls", sysctlsMachineConfigName)))
+		case seen.Has(mc.Name):
+			allErrs = append(allErrs, field.Duplicate(mcPath.Child("name"), mc.Name))
+		default:
+			for _, msg := range validation.NameIsDNSSubdomain(mc.Name, false) {
+				allErrs = append(allErrs, field.Invalid(mcPath.Child("name"), mc.Name, msg))
+			}
+		}
+		seen.Insert(mc.Name)
+		allErrs = append(allErrs, validateJSONObject(&mc.Spec, mcPath.Child("spec"))...)
+	}
+	return allErrs
+}
+
+func validateJSONObject(raw *runtime.RawExtension, fldPath *field.Path) field.ErrorList {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw.Raw, &obj); err != nil || obj == nil {
+		return field.ErrorList{field.Invalid(fldPath, string(raw.Raw), "must be a JSON object")}
+	}
+	return nil
+}
+
+func validateMachinePoolZoneDistribution(spec *hivev1.MachinePoolSpec, zones []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	var fixedReplicas int32
+	for i, d := range spec.ZoneDistribution {
+		dPath := fldPath.Index(i)
+		switch {
+		case d.Zone == "":
+			allErrs = append(allErrs, field.Required(dPath.Child("zone"), "zone is required"))
+		case seen[d.Zone]:
+			allErrs = append(allErrs, field.Duplicate(dPath.Child("zone"), d.Zone))
+		case len(zones) > 0 && !sets.NewString(zones...).Has(d.Zone):
+			allErrs = append(allErrs, field.NotSupported(dPath.Child("zone"), d.Zone, zones))
+		}
+		seen[d.Zone] = true
+		if d.Weight != nil && d.Replicas != nil {
+			allErrs = append(allErrs, field.Invalid(dPath, d, "only one of weight and replicas may be specified"))
+		}
+		if d.Weight != nil && *d.Weight < 0 {
+			allErrs = append(allErrs, field.Invalid(dPath.Child("weight"), *d.Weight, "weight must not be negative"))
+		}
+		if d.Replicas != nil {
+			if *d.Replicas < 0 {
+				allErrs = append(allErrs, field.Invalid(dPath.Child("replicas"), *d.Replicas, "replicas count must not be negative"))
+			} else {
+				fixedReplicas += *d.Replicas
+			}
+		}
+	}
+	switch {
+	case spec.Autoscaling != nil && fixedReplicas > spec.Autoscaling.MinReplicas:
+		allErrs = append(allErrs, field.Invalid(fldPath, fixedReplicas, "the fixed replicas of the zones must not be greater than the minimum replicas"))
+	case spec.Replicas != nil && int64(fixedReplicas) > *spec.Replicas:
+		allErrs = append(allErrs, field.Invalid(fldPath, fixedReplicas, "the fixed replicas of the zones must not be greater than the replicas"))
+	case spec.Replicas != nil && int64(fixedReplicas) < *spec.Replicas && allZonesFixed(spec.ZoneDistribution, zones):
+		allErrs = append(allErrs, field.Invalid(fldPath, fixedReplicas, "the fixed replicas of the zones must add up to the replicas when all of the zones have fixed replicas"))
+	}
+	return allErrs
+}
+
+// allZonesFixed returns whether each of the zones has fixed replicas in the distribution. It is false when the zones
+// are not known, since they are then discovered by the controller.
+func allZonesFixed(distribution []hivev1.MachinePoolZoneDistribution, zones []string) bool {
+	if len(zones) == 0 {
+		return false
+	}
+	fixed := sets.NewString()
+	for _, d := range distribution {
+		if d.Replicas != nil {
+			fixed.Insert(d.Zone)
+		}
+	}
+	return fixed.HasAll(zones...)
+}
+
+func validateMachinePoolUpdateStrategy(strategy *hivev1.MachinePoolUpdateStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch strategy.Type {
+	case hivev1.OnDeleteMachinePoolUpdateStrategyType:
+		if strategy.RollingUpdate != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("rollingUpdate"), "rolling update can only be specified with the RollingUpdate strategy"))
+		}
+	case hivev1.RollingUpdateMachinePoolUpdateStrategyType:
+		if ru := strategy.RollingUpdate; ru != nil {
+			rollingUpdatePath := fldPath.Child("rollingUpdate")
+			allErrs = append(allErrs, validateIntOrPercent(ru.MaxSurge, rollingUpdatePath.Child("maxSurge"))...)
+			allErrs = append(allErrs, validateIntOrPercent(ru.MaxUnavailable, rollingUpdatePath.Child("maxUnavailable"))...)
+			// maxUnavailable defaults to 0
+			if isZeroIntOrPercent(ru.MaxSurge) && (ru.MaxUnavailable == nil || isZeroIntOrPercent(ru.MaxUnavailable)) {
+				allErrs = append(allErrs, field.Invalid(rollingUpdatePath.Child("maxUnavailable"), ru.MaxUnavailable, "max unavailable must not be zero when max surge is zero"))
+			}
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), strategy.Type, []string{
+			string(hivev1.OnDeleteMachinePoolUpdateStrategyType),
+			string(hivev1.RollingUpdateMachinePoolUpdateStrategyType),
+		}))
+	}
+	return allErrs
+}
+
+func validateIntOrPercent(value *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	if v, err := intstr.GetScaledValueFromIntOrPercent(value, 100, false); err != nil || v < 0 || (value.Type == intstr.String && v > 100) {
+		return field.ErrorList{field.Invalid(fldPath, value.String(), "must be a non-negative number or a percentage between 0% and 100%")}
+	}
+	return nil
+}
+
+func isZeroIntOrPercent(value *intstr.IntOrString) bool {
+	if value == nil {
+		return false
+	}
+	v, err := intstr.GetScaledValueFromIntOrPercent(value, 100, false)
+	return err == nil && v == 0
+}
+
+func validateMachinePoolHealthCheck(healthCheck *hivev1.MachinePoolHealthCheck, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, c := range healthCheck.UnhealthyConditions {
+		conditionPath := fldPath.Child("unhealthyConditions").Index(i)
+		if c.Type == "" {
+			allErrs = append(allErrs, field.Required(conditionPath.Child("type"), "condition type is required"))
+		}
+		if c.Status == "" {
+			allErrs = append(allErrs, field.Required(conditionPath.Child("status"), "condition status is required"))
+		}
+		if c.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(conditionPath.Child("timeout"), c.Timeout.Duration.String(), "timeout must be positive"))
+		}
+	}
+	allErrs = append(allErrs, validateIntOrPercent(healthCheck.MaxUnhealthy, fldPath.Child("maxUnhealthy"))...)
+	if t := healthCheck.NodeStartupTimeout; t != nil && t.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeStartupTimeout"), t.Duration.String(), "node startup timeout must not be negative"))
+	}
 	return allErrs
 }
 
@@ -382,6 +625,18 @@ func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePoo
 	if osDisk.DiskSizeGB <= 0 {
 		allErrs = append(allErrs, field.Invalid(osDiskPath.Child("iops"), osDisk.DiskSizeGB, "disk size must be positive"))
 	}
+	if spotVMOptions := platform.SpotVMOptions; spotVMOptions != nil {
+		spotVMOptionsPath := fldPath.Child("spotVMOptions")
+		if maxPrice := spotVMOptions.MaxPrice; maxPrice != nil {
+			if price, err := strconv.ParseFloat(*maxPrice, 64); err != nil || (price <= 0 && price != -1) {
+				allErrs = append(allErrs, field.Invalid(spotVMOptionsPath.Child("maxPrice"), *maxPrice, "max price must be a positive number of US dollars, or -1 for the On-Demand price"))
+			}
+		}
+		// The machine API creates single instance Spot VMs, which are always deallocated when evicted.
+		if policy := spotVMOptions.EvictionPolicy; policy != "" && policy != hivev1azure.SpotEvictionPolicyDeallocate {
+			allErrs = append(allErrs, field.NotSupported(spotVMOptionsPath.Child("evictionPolicy"), policy, []string{string(hivev1azure.SpotEvictionPolicyDeallocate)}))
+		}
+	}
 	return allErrs
 }
 
@@ -411,6 +666,26 @@ func validateVSphereMachinePoolPlatformInvariants(platform *hivev1vsphere.Machin
 		allErrs = append(allErrs, field.Required(fldPath.Child("diskSizeGB"), "disk size must be positive"))
 	}
 
+	seen := sets.NewString()
+	for i, zone := range platform.Zones {
+		zonePath := fldPath.Child("zones").Index(i)
+		switch {
+		case zone.Name == "":
+			allErrs = append(allErrs, field.Required(zonePath.Child("name"), "zone name is required"))
+		case seen.Has(zone.Name):
+			allErrs = append(allErrs, field.Duplicate(zonePath.Child("name"), zone.Name))
+		default:
+			// The name of the zone is part of the name of its MachineSet.
+			for _, msg := range utilvalidation.IsDNS1123Label(zone.Name) {
+				allErrs = append(allErrs, field.Invalid(zonePath.Child("name"), zone.Name, msg))
+			}
+		}
+		seen.Insert(zone.Name)
+		if zone.Cluster == "" {
+			allErrs = append(allErrs, field.Required(zonePath.Child("cluster"), "zone cluster is required"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -418,3 +693,13 @@ func validateOvirtMachinePoolPlatformInvariants(platform *hivev1ovirt.MachinePoo
 	allErrs := field.ErrorList{}
 	return allErrs
 }
+
+func validateAgentMachinePoolPlatformInvariants(platform *hivev1agent.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	selectorPath := fldPath.Child("agentSelector")
+	if len(platform.AgentSelector.MatchLabels) == 0 && len(platform.AgentSelector.MatchExpressions) == 0 {
+		allErrs = append(allErrs, field.Required(selectorPath, "agent selector must not be empty"))
+	}
+	allErrs = append(allErrs, metavalidation.ValidateLabelSelector(&platform.AgentSelector, selectorPath)...)
+	return allErrs
+}
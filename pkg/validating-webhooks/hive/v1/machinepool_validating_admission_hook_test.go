@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -11,9 +12,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
@@ -447,6 +450,215 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "Azure spot VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure spot VMs with max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{
+					MaxPrice:       pointer.StringPtr("0.05"),
+					EvictionPolicy: hivev1azure.SpotEvictionPolicyDeallocate,
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure spot VMs with On-Demand max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: pointer.StringPtr("-1")}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "invalid Azure spot VM max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: pointer.StringPtr("0")}
+				return pool
+			}(),
+		},
+		{
+			name: "unparsable Azure spot VM max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: pointer.StringPtr("cheap")}
+				return pool
+			}(),
+		},
+		{
+			name: "unsupported Azure spot VM eviction policy",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{EvictionPolicy: "Delete"}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1a", Weight: pointer.Int32Ptr(2)},
+					{Zone: "us-east-1b", Replicas: pointer.Int32Ptr(1)},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "zone distribution for unknown zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"us-east-1a"}
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1b", Weight: pointer.Int32Ptr(2)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "duplicate zone distribution",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1a", Weight: pointer.Int32Ptr(2)},
+					{Zone: "us-east-1a", Weight: pointer.Int32Ptr(1)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution with weight and replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1a", Weight: pointer.Int32Ptr(2), Replicas: pointer.Int32Ptr(1)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution with too many fixed replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(2)
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1a", Replicas: pointer.Int32Ptr(2)},
+					{Zone: "us-east-1b", Replicas: pointer.Int32Ptr(1)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution with too many fixed replicas when autoscaling",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Replicas = nil
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 5}
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1a", Replicas: pointer.Int32Ptr(2)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution with fixed replicas for all zones below replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(3)
+				pool.Spec.Platform.AWS.Zones = []string{"us-east-1a", "us-east-1b"}
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1a", Replicas: pointer.Int32Ptr(1)},
+					{Zone: "us-east-1b", Replicas: pointer.Int32Ptr(1)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution with fixed replicas for some zones below replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(3)
+				pool.Spec.Platform.AWS.Zones = []string{"us-east-1a", "us-east-1b"}
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "us-east-1a", Replicas: pointer.Int32Ptr(1)},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "zone distribution on vSphere without zones",
+			provision: func() *hivev1.MachinePool {
+				pool := testvSphereMachinePool()
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "zone1", Weight: pointer.Int32Ptr(2)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution on vSphere with zones",
+			provision: func() *hivev1.MachinePool {
+				pool := testvSphereMachinePool()
+				pool.Spec.Platform.VSphere.Zones = []hivev1vsphere.MachinePoolZone{
+					{Name: "zone1", Cluster: "cluster1"},
+					{Name: "zone2", Cluster: "cluster2", Datastore: "datastore2"},
+				}
+				pool.Spec.ZoneDistribution = []hivev1.MachinePoolZoneDistribution{
+					{Zone: "zone1", Weight: pointer.Int32Ptr(2)},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "vSphere zone without cluster",
+			provision: func() *hivev1.MachinePool {
+				pool := testvSphereMachinePool()
+				pool.Spec.Platform.VSphere.Zones = []hivev1vsphere.MachinePoolZone{{Name: "zone1"}}
+				return pool
+			}(),
+		},
+		{
+			name: "duplicate vSphere zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testvSphereMachinePool()
+				pool.Spec.Platform.VSphere.Zones = []hivev1vsphere.MachinePoolZone{
+					{Name: "zone1", Cluster: "cluster1"},
+					{Name: "zone1", Cluster: "cluster2"},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "invalid vSphere zone name",
+			provision: func() *hivev1.MachinePool {
+				pool := testvSphereMachinePool()
+				pool.Spec.Platform.VSphere.Zones = []hivev1vsphere.MachinePoolZone{{Name: "Zone_1", Cluster: "cluster1"}}
+				return pool
+			}(),
+		},
+		{
+			name: "GCP preemptible VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Preemptible = true
+				return pool
+			}(),
+			expectAllowed: true,
+		},
 		{
 			name: "valid labels",
 			provision: func() *hivev1.MachinePool {
@@ -464,6 +676,165 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "valid health check",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxUnhealthy := intstr.FromString("40%")
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{
+					UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{{
+						Type:    corev1.NodeReady,
+						Status:  corev1.ConditionFalse,
+						Timeout: metav1.Duration{Duration: 5 * time.Minute},
+					}},
+					MaxUnhealthy:       &maxUnhealthy,
+					NodeStartupTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "empty health check",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "health check condition without timeout",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{
+					UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionFalse,
+					}},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "health check condition without type",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{
+					UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{{
+						Status:  corev1.ConditionFalse,
+						Timeout: metav1.Duration{Duration: 5 * time.Minute},
+					}},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "health check max unhealthy count",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxUnhealthy := intstr.FromInt(2)
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{MaxUnhealthy: &maxUnhealthy}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "health check max unhealthy over 100%",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxUnhealthy := intstr.FromString("150%")
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{MaxUnhealthy: &maxUnhealthy}
+				return pool
+			}(),
+		},
+		{
+			name: "health check invalid max unhealthy",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxUnhealthy := intstr.FromString("some")
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{MaxUnhealthy: &maxUnhealthy}
+				return pool
+			}(),
+		},
+		{
+			name: "health check negative node startup timeout",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.HealthCheck = &hivev1.MachinePoolHealthCheck{
+					NodeStartupTimeout: &metav1.Duration{Duration: -time.Minute},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "OnDelete update strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{Type: hivev1.OnDeleteMachinePoolUpdateStrategyType}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "RollingUpdate update strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge, maxUnavailable := intstr.FromString("25%"), intstr.FromInt(1)
+				pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{
+					Type: hivev1.RollingUpdateMachinePoolUpdateStrategyType,
+					RollingUpdate: &hivev1.MachinePoolRollingUpdate{
+						MaxSurge:       &maxSurge,
+						MaxUnavailable: &maxUnavailable,
+					},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "unsupported update strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{Type: "Recreate"}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling update with OnDelete update strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{
+					Type:          hivev1.OnDeleteMachinePoolUpdateStrategyType,
+					RollingUpdate: &hivev1.MachinePoolRollingUpdate{},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling update with zero max surge and max unavailable",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge := intstr.FromInt(0)
+				pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{
+					Type:          hivev1.RollingUpdateMachinePoolUpdateStrategyType,
+					RollingUpdate: &hivev1.MachinePoolRollingUpdate{MaxSurge: &maxSurge},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling update with invalid max surge",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge := intstr.FromString("200%")
+				pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{
+					Type:          hivev1.RollingUpdateMachinePoolUpdateStrategyType,
+					RollingUpdate: &hivev1.MachinePoolRollingUpdate{MaxSurge: &maxSurge},
+				}
+				return pool
+			}(),
+		},
 		{
 			name: "zero autoscaling",
 			provision: func() *hivev1.MachinePool {
@@ -489,6 +860,117 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 			}(),
 			expectAllowed: true,
 		},
+		{
+			name: "node config",
+			provision: withNodeConfig(&hivev1.MachinePoolNodeConfig{
+				Kubelet: &runtime.RawExtension{Raw: []byte(`{"maxPods": 250}`)},
+				Sysctls: map[string]string{"net.core.somaxconn": "1024", "kernel/pid_max": "4194304"},
+				MachineConfigs: []hivev1.MachinePoolMachineConfig{{
+					Name: "kargs",
+					Spec: runtime.RawExtension{Raw: []byte(`{"kernelArguments": ["nosmt"]}`)},
+				}},
+			}),
+			expectAllowed: true,
+		},
+		{
+			name: "node config for worker pool",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.NodeConfig = &hivev1.MachinePoolNodeConfig{Sysctls: map[string]string{"net.core.somaxconn": "1024"}}
+				return pool
+			}(),
+		},
+		{
+			name: "node config with kubelet configuration not an object",
+			provision: withNodeConfig(&hivev1.MachinePoolNodeConfig{
+				Kubelet: &runtime.RawExtension{Raw: []byte(`["maxPods"]`)},
+			}),
+		},
+		{
+			name: "node config with invalid sysctl name",
+			provision: withNodeConfig(&hivev1.MachinePoolNodeConfig{
+				Sysctls: map[string]string{"net core somaxconn": "1024"},
+			}),
+		},
+		{
+			name: "node config with multi-line sysctl value",
+			provision: withNodeConfig(&hivev1.MachinePoolNodeConfig{
+				Sysctls: map[string]string{"net.core.somaxconn": "1024\nkernel.pid_max = 1"},
+			}),
+		},
+		{
+			name: "node config with duplicate machine configs",
+			provision: withNodeConfig(&hivev1.MachinePoolNodeConfig{
+				MachineConfigs: []hivev1.MachinePoolMachineConfig{
+					{Name: "kargs", Spec: runtime.RawExtension{Raw: []byte(`{}`)}},
+					{Name: "kargs", Spec: runtime.RawExtension{Raw: []byte(`{}`)}},
+				},
+			}),
+		},
+		{
+			name: "node config with machine config named sysctls",
+			provision: withNodeConfig(&hivev1.MachinePoolNodeConfig{
+				MachineConfigs: []hivev1.MachinePoolMachineConfig{
+					{Name: "sysctls", Spec: runtime.RawExtension{Raw: []byte(`{}`)}},
+				},
+			}),
+		},
+		{
+			name: "node config with invalid machine config name",
+			provision: withNodeConfig(&hivev1.MachinePoolNodeConfig{
+				MachineConfigs: []hivev1.MachinePoolMachineConfig{
+					{Name: "Kernel_Args", Spec: runtime.RawExtension{Raw: []byte(`{}`)}},
+				},
+			}),
+		},
+		{
+			name:          "agent platform",
+			provision:     testAgentMachinePool(),
+			expectAllowed: true,
+		},
+		{
+			name: "agent platform with empty agent selector",
+			provision: func() *hivev1.MachinePool {
+				pool := testAgentMachinePool()
+				pool.Spec.Platform.Agent.AgentSelector = metav1.LabelSelector{}
+				return pool
+			}(),
+		},
+		{
+			name: "agent platform with invalid agent selector",
+			provision: func() *hivev1.MachinePool {
+				pool := testAgentMachinePool()
+				pool.Spec.Platform.Agent.AgentSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+					{Key: "location", Operator: "Near"},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "agent platform with autoscaling",
+			provision: func() *hivev1.MachinePool {
+				pool := testAgentMachinePool()
+				pool.Spec.Replicas = nil
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 2}
+				return pool
+			}(),
+		},
+		{
+			name: "agent platform with update strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testAgentMachinePool()
+				pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{Type: hivev1.RollingUpdateMachinePoolUpdateStrategyType}
+				return pool
+			}(),
+		},
+		{
+			name: "agent and AWS platforms",
+			provision: func() *hivev1.MachinePool {
+				pool := testAgentMachinePool()
+				pool.Spec.Platform.AWS = validAWSMachinePoolPlatform()
+				return pool
+			}(),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -592,6 +1074,30 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "instance type changed with update strategy",
+			old:  withUpdateStrategy(testMachinePool()),
+			new: func() *hivev1.MachinePool {
+				pool := withUpdateStrategy(testMachinePool())
+				pool.Spec.Platform.AWS.InstanceType = "other-instance-type"
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "instance type changed when adding update strategy",
+			old:  testMachinePool(),
+			new: func() *hivev1.MachinePool {
+				pool := withUpdateStrategy(testMachinePool())
+				pool.Spec.Platform.AWS.InstanceType = "other-instance-type"
+				return pool
+			}(),
+		},
+		{
+			name: "platform changed with update strategy",
+			old:  withUpdateStrategy(testMachinePool()),
+			new:  withUpdateStrategy(testGCPMachinePool()),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -639,6 +1145,19 @@ func testMachinePool() *hivev1.MachinePool {
 	}
 }
 
+func withUpdateStrategy(pool *hivev1.MachinePool) *hivev1.MachinePool {
+	pool.Spec.UpdateStrategy = &hivev1.MachinePoolUpdateStrategy{Type: hivev1.RollingUpdateMachinePoolUpdateStrategyType}
+	return pool
+}
+
+func withNodeConfig(nodeConfig *hivev1.MachinePoolNodeConfig) *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Name = "test-deployment-infra"
+	pool.Spec.Name = "infra"
+	pool.Spec.NodeConfig = nodeConfig
+	return pool
+}
+
 func testAWSMachinePool() *hivev1.MachinePool {
 	pool := testMachinePool()
 	pool.Spec.Platform = hivev1.MachinePoolPlatform{
@@ -663,6 +1182,16 @@ func testAzureMachinePool() *hivev1.MachinePool {
 	return pool
 }
 
+func testAgentMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		Agent: &hivev1agent.MachinePool{
+			AgentSelector: metav1.LabelSelector{MatchLabels: map[string]string{"location": "rack-1"}},
+		},
+	}
+	return pool
+}
+
 func testvSphereMachinePool() *hivev1.MachinePool {
 	pool := testMachinePool()
 	pool.Spec.Platform = hivev1.MachinePoolPlatform{
@@ -699,6 +1228,9 @@ func validAzureMachinePoolPlatform() *hivev1azure.MachinePool {
 
 func validvSphereMachinePoolPlatform() *hivev1vsphere.MachinePool {
 	return &hivev1vsphere.MachinePool{
+		NumCPUs:           1,
+		NumCoresPerSocket: 1,
+		MemoryMiB:         1,
 		OSDisk: hivev1vsphere.OSDisk{
 			DiskSizeGB: 1,
 		},
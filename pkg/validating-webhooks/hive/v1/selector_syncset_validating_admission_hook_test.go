@@ -228,6 +228,80 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid kustomize create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.Kustomize = &hivev1.KustomizeSource{
+					ConfigMaps: []hivev1.KustomizeConfigMap{{Name: "base", Namespace: "somens"}},
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid kustomize configmap without namespace update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.Kustomize = &hivev1.KustomizeSource{
+					ConfigMaps: []hivev1.KustomizeConfigMap{{Name: "base"}},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid dependsOn create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Name: "other"}, {Kind: "SyncSet", Name: "test-selector-sync-set"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid dependsOn kind create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Kind: "ClusterDeployment", Name: "other"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid dependsOn no name create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Kind: "SyncSet"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid dependsOn self update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Name: "test-selector-sync-set"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid dependsOn self with kind update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Kind: "SelectorSyncSet", Name: "test-selector-sync-set"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable TypeMeta Resource create",
 			operation:       admissionv1beta1.Create,
@@ -2,16 +2,22 @@ package v1
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -43,6 +49,13 @@ var validPatchTypes = map[string]bool{
 
 var validPatchTypeSlice = []string{"json", "merge", "strategic"}
 
+var validDependencyKinds = map[string]bool{
+	"SyncSet":         true,
+	"SelectorSyncSet": true,
+}
+
+var validDependencyKindSlice = []string{"SyncSet", "SelectorSyncSet"}
+
 var (
 	validResourceApplyModes = map[hivev1.SyncSetResourceApplyMode]bool{
 		hivev1.UpsertResourceApplyMode: true,
@@ -195,7 +208,9 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateKustomize(newObject.Spec.Kustomize, newObject.Namespace, field.NewPath("spec", "kustomize"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateDependsOn(newObject.Spec.DependsOn, "SyncSet", newObject.Name, field.NewPath("spec", "dependsOn"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -243,7 +258,9 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
+	allErrs = append(allErrs, validateKustomize(newObject.Spec.Kustomize, newObject.Namespace, field.NewPath("spec", "kustomize"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateDependsOn(newObject.Spec.DependsOn, "SyncSet", newObject.Name, field.NewPath("spec", "dependsOn"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -308,8 +325,68 @@ func validateResource(resource runtime.RawExtension, fldPath *field.Path) field.
 func validateSecrets(secrets []hivev1.SecretMapping, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, secret := range secrets {
-		allErrs = append(allErrs, validateSecretRef(secret.SourceRef, fldPath.Index(i).Child("sourceRef"))...)
-		allErrs = append(allErrs, validateSecretRef(secret.TargetRef, fldPath.Index(i).Child("targetRef"))...)
+		path := fldPath.Index(i)
+		allErrs = append(allErrs, validateSecretRef(secret.SourceRef, path.Child("sourceRef"))...)
+		allErrs = append(allErrs, validateSecretRef(secret.TargetRef, path.Child("targetRef"))...)
+		// Target keys selected explicitly must be unique across all of the sources.
+		targetKeys := sets.NewString()
+		allErrs = append(allErrs, validateSecretKeys(secret.Keys, targetKeys, path.Child("keys"))...)
+		for j, source := range secret.AdditionalSources {
+			sourcePath := path.Child("additionalSources").Index(j)
+			allErrs = append(allErrs, validateSecretRef(source.SecretRef, sourcePath.Child("secretRef"))...)
+			allErrs = append(allErrs, validateSecretKeys(source.Keys, targetKeys, sourcePath.Child("keys"))...)
+		}
+		if len(secret.DockerConfigJSON) > 0 {
+			allErrs = append(allErrs, validateDockerConfigJSON(secret, targetKeys, path)...)
+		}
+	}
+	return allErrs
+}
+
+func validateDockerConfigJSON(secret hivev1.SecretMapping, targetKeys sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if secret.Type != "" && secret.Type != corev1.SecretTypeDockerConfigJson {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), secret.Type,
+			fmt.Sprintf("type must be %s when dockerConfigJSON is specified", corev1.SecretTypeDockerConfigJson)))
+	}
+	if targetKeys.Has(corev1.DockerConfigJsonKey) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keys"), corev1.DockerConfigJsonKey,
+			"key is built from dockerConfigJSON"))
+	}
+	for i, registry := range secret.DockerConfigJSON {
+		path := fldPath.Child("dockerConfigJSON").Index(i)
+		if (registry.Registry == "") == (registry.RegistryKey == "") {
+			allErrs = append(allErrs, field.Required(path, "exactly one of registry and registryKey is required"))
+		}
+		if registry.UsernameKey == "" {
+			allErrs = append(allErrs, field.Required(path.Child("usernameKey"), "usernameKey is required"))
+		}
+		if registry.PasswordKey == "" {
+			allErrs = append(allErrs, field.Required(path.Child("passwordKey"), "passwordKey is required"))
+		}
+	}
+	return allErrs
+}
+
+func validateSecretKeys(keys []hivev1.SecretKeyMapping, targetKeys sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, km := range keys {
+		path := fldPath.Index(i)
+		if len(km.Key) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("key"), "Key is required"))
+		}
+		targetKey := km.TargetKey
+		if targetKey == "" {
+			targetKey = km.Key
+		} else {
+			for _, msg := range validation.IsConfigMapKey(targetKey) {
+				allErrs = append(allErrs, field.Invalid(path.Child("targetKey"), targetKey, msg))
+			}
+		}
+		if targetKeys.Has(targetKey) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("targetKey"), targetKey))
+		}
+		targetKeys.Insert(targetKey)
 	}
 	return allErrs
 }
@@ -323,6 +400,76 @@ func validateSourceSecretInSyncSetNamespace(secrets []hivev1.SecretMapping, sync
 			allErrs = append(allErrs, field.Invalid(path.Child("namespace"), secret.SourceRef.Namespace,
 				"source secret reference must be in same namespace as SyncSet"))
 		}
+		for j, source := range secret.AdditionalSources {
+			if source.SecretRef.Namespace != syncSetNS && source.SecretRef.Namespace != "" {
+				path := fldPath.Index(i).Child("additionalSources").Index(j).Child("secretRef")
+
+				allErrs = append(allErrs, field.Invalid(path.Child("namespace"), source.SecretRef.Namespace,
+					"source secret reference must be in same namespace as SyncSet"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateKustomize validates the kustomization source of a syncset. The ConfigMaps must be in the namespace of a
+// SyncSet, and their namespace is required for a SelectorSyncSet, which has no namespace.
+func validateKustomize(kustomize *hivev1.KustomizeSource, syncSetNS string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if kustomize == nil {
+		return allErrs
+	}
+	allErrs = append(allErrs, validateKustomizePath(kustomize.Path, fldPath.Child("path"))...)
+	if len(kustomize.ConfigMaps) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("configMaps"), "at least one ConfigMap is required"))
+	}
+	for i, cm := range kustomize.ConfigMaps {
+		path := fldPath.Child("configMaps").Index(i)
+		if len(cm.Name) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "Name is required"))
+		}
+		switch {
+		case syncSetNS == "" && cm.Namespace == "":
+			allErrs = append(allErrs, field.Required(path.Child("namespace"), "Namespace is required"))
+		case syncSetNS != "" && cm.Namespace != "" && cm.Namespace != syncSetNS:
+			allErrs = append(allErrs, field.Invalid(path.Child("namespace"), cm.Namespace,
+				"ConfigMap must be in same namespace as SyncSet"))
+		}
+		allErrs = append(allErrs, validateKustomizePath(cm.Path, path.Child("path"))...)
+	}
+	return allErrs
+}
+
+func validateKustomizePath(p string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p == "" {
+		return allErrs
+	}
+	if filepath.IsAbs(p) {
+		allErrs = append(allErrs, field.Invalid(fldPath, p, "path must be relative to the root of the kustomization tree"))
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(p), "/") {
+		if elem == ".." {
+			allErrs = append(allErrs, field.Invalid(fldPath, p, "path must not contain '..'"))
+			break
+		}
+	}
+	return allErrs
+}
+
+func validateDependsOn(dependencies []hivev1.SyncSetDependency, kind, name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, dep := range dependencies {
+		path := fldPath.Index(i)
+		if dep.Kind != "" && !validDependencyKinds[dep.Kind] {
+			allErrs = append(allErrs, field.NotSupported(path.Child("kind"), dep.Kind, validDependencyKindSlice))
+		}
+		if len(dep.Name) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "Name is required"))
+		}
+		if (dep.Kind == "" || dep.Kind == kind) && dep.Name == name {
+			allErrs = append(allErrs, field.Invalid(path, dep.Name, fmt.Sprintf("%s cannot depend on itself", kind)))
+		}
 	}
 	return allErrs
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -132,6 +133,140 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: true,
 		},
+		{
+			name:      "Test valid SecretReference keys and additional sources create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Keys = []hivev1.SecretKeyMapping{{Key: "auth", TargetKey: ".dockerconfigjson"}}
+				ss.Spec.Secrets[0].AdditionalSources = []hivev1.SecretSource{{
+					SecretRef: hivev1.SecretReference{Name: "bar"},
+					Keys:      []hivev1.SecretKeyMapping{{Key: "username"}},
+				}}
+				ss.Spec.Secrets[0].Type = corev1.SecretTypeDockerConfigJson
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid SecretReference dockerConfigJSON create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{Registry: "quay.io", UsernameKey: "username", PasswordKey: "password"},
+					{RegistryKey: "server", UsernameKey: "user", PasswordKey: "token"},
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid SecretReference dockerConfigJSON both registry and registryKey create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{Registry: "quay.io", RegistryKey: "server", UsernameKey: "username", PasswordKey: "password"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference dockerConfigJSON no password key update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{Registry: "quay.io", UsernameKey: "username"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference dockerConfigJSON with other type create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Type = corev1.SecretTypeOpaque
+				ss.Spec.Secrets[0].DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{Registry: "quay.io", UsernameKey: "username", PasswordKey: "password"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference dockerConfigJSON with mapped dockerconfigjson key create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Keys = []hivev1.SecretKeyMapping{{Key: "auth", TargetKey: ".dockerconfigjson"}}
+				ss.Spec.Secrets[0].DockerConfigJSON = []hivev1.DockerRegistryCredentials{
+					{Registry: "quay.io", UsernameKey: "username", PasswordKey: "password"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference duplicate target key create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Keys = []hivev1.SecretKeyMapping{{Key: "auth"}}
+				ss.Spec.Secrets[0].AdditionalSources = []hivev1.SecretSource{{
+					SecretRef: hivev1.SecretReference{Name: "bar"},
+					Keys:      []hivev1.SecretKeyMapping{{Key: "username", TargetKey: "auth"}},
+				}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference no key create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Keys = []hivev1.SecretKeyMapping{{TargetKey: "auth"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference target key update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Keys = []hivev1.SecretKeyMapping{{Key: "auth", TargetKey: "not/valid"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference additional source not in SyncSet namespace",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].AdditionalSources = []hivev1.SecretSource{{
+					SecretRef: hivev1.SecretReference{Name: "bar", Namespace: "anotherns"},
+				}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference additional source no name update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].AdditionalSources = []hivev1.SecretSource{{}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:      "Test invalid SecretReference no target name create",
 			operation: admissionv1beta1.Create,
@@ -242,6 +377,106 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid dependsOn create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Name: "other"}, {Kind: "SelectorSyncSet", Name: "test-sync-set"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid dependsOn kind create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Kind: "ClusterDeployment", Name: "other"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid dependsOn no name create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Kind: "SyncSet"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid dependsOn self update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Name: "test-sync-set"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid dependsOn self with kind update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DependsOn = []hivev1.SyncSetDependency{{Kind: "SyncSet", Name: "test-sync-set"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid kustomize create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Kustomize = &hivev1.KustomizeSource{
+					Path: "overlays/prod",
+					ConfigMaps: []hivev1.KustomizeConfigMap{
+						{Name: "base", Path: "base"},
+						{Name: "prod", Namespace: syncSetNS, Path: "overlays/prod"},
+					},
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid kustomize no configmaps create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Kustomize = &hivev1.KustomizeSource{}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid kustomize configmap namespace update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Kustomize = &hivev1.KustomizeSource{
+					ConfigMaps: []hivev1.KustomizeConfigMap{{Name: "base", Namespace: "anotherns"}},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid kustomize path update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Kustomize = &hivev1.KustomizeSource{
+					ConfigMaps: []hivev1.KustomizeConfigMap{{Name: "base", Path: "../base"}},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable Resource create",
 			operation:       admissionv1beta1.Create,
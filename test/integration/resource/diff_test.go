@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/pkg/resource"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name         string
+		existing     []runtime.Object
+		diff         runtime.Object
+		expectedDiff string
+	}{
+		{
+			name:         "missing resource",
+			diff:         testConfigMap(),
+			expectedDiff: resource.MissingResourceDiff,
+		},
+		{
+			name:     "unchanged resource",
+			existing: []runtime.Object{testConfigMap()},
+			diff:     testConfigMap(),
+		},
+		{
+			name:     "changed data",
+			existing: []runtime.Object{testConfigMap()},
+			diff: func() runtime.Object {
+				cm := testConfigMap()
+				cm.Data["foo"] = "baz"
+				return cm
+			}(),
+			expectedDiff: `data.foo: desired "baz", live "bar"`,
+		},
+		{
+			name:     "added label",
+			existing: []runtime.Object{testConfigMap()},
+			diff: func() runtime.Object {
+				cm := testConfigMap()
+				cm.Labels = map[string]string{"test-label": "test-value"}
+				return cm
+			}(),
+			expectedDiff: `metadata.labels: desired {"test-label":"test-value"}, live <unset>`,
+		},
+		{
+			name: "extra live fields are ignored",
+			existing: []runtime.Object{func() runtime.Object {
+				cm := testConfigMap()
+				cm.Data["extra"] = "value"
+				cm.Annotations = map[string]string{"test-annotation": "test-value"}
+				return cm
+			}()},
+			diff: testConfigMap(),
+		},
+	}
+
+	configs := []string{"restconfig", "kubeconfig"}
+
+	for _, test := range tests {
+		for _, clientConfig := range configs {
+			t.Run(test.name, func(t *testing.T) {
+				logger := log.WithField("test", test.name)
+				namespace := &corev1.Namespace{}
+				namespace.GenerateName = "diff-test-"
+				err := c.Create(context.TODO(), namespace)
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				var h resource.Helper
+				if clientConfig == "kubeconfig" {
+					h, err = resource.NewHelper(kubeconfig, logger)
+				} else {
+					h, err = resource.NewHelperFromRESTConfig(cfg, logger)
+				}
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				accessor := meta.NewAccessor()
+				for _, obj := range test.existing {
+					o := obj.DeepCopyObject()
+					accessor.SetNamespace(o, namespace.Name)
+					_, err := h.CreateRuntimeObject(o, scheme.Scheme)
+					if err != nil {
+						t.Fatalf("unexpected err: %v", err)
+					}
+				}
+				accessor.SetNamespace(test.diff, namespace.Name)
+				data, err := resource.Serialize(test.diff, scheme.Scheme)
+				if err != nil {
+					t.Errorf("unexpected error calling serialize: %v", err)
+					return
+				}
+				diff, err := h.Diff(data)
+				if err != nil {
+					t.Errorf("unexpected error calling diff: %v", err)
+					return
+				}
+				if diff != test.expectedDiff {
+					t.Errorf("unexpected diff: %s", diff)
+				}
+			})
+		}
+	}
+}
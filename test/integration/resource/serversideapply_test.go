@@ -0,0 +1,121 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/hive/pkg/resource"
+)
+
+func TestServerSideApply(t *testing.T) {
+	tests := []struct {
+		name             string
+		existing         []runtime.Object
+		force            bool
+		expectedResult   resource.ApplyResult
+		expectedConflict bool
+		expectedFoo      string
+	}{
+		{
+			name:           "create resource",
+			expectedResult: resource.CreatedApplyResult,
+			expectedFoo:    "baz",
+		},
+		{
+			name:             "conflicting resource",
+			existing:         []runtime.Object{testConfigMap()},
+			expectedConflict: true,
+			expectedFoo:      "bar",
+		},
+		{
+			name:           "force conflicting resource",
+			existing:       []runtime.Object{testConfigMap()},
+			force:          true,
+			expectedResult: resource.ConfiguredApplyResult,
+			expectedFoo:    "baz",
+		},
+	}
+
+	configs := []string{"restconfig", "kubeconfig"}
+
+	for _, test := range tests {
+		for _, clientConfig := range configs {
+			t.Run(test.name, func(t *testing.T) {
+				logger := log.WithField("test", test.name)
+				namespace := &corev1.Namespace{}
+				namespace.GenerateName = "ssa-test-"
+				err := c.Create(context.TODO(), namespace)
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				var h resource.Helper
+				if clientConfig == "kubeconfig" {
+					h, err = resource.NewHelper(kubeconfig, logger)
+				} else {
+					h, err = resource.NewHelperFromRESTConfig(cfg, logger)
+				}
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				accessor := meta.NewAccessor()
+				for _, obj := range test.existing {
+					o := obj.DeepCopyObject()
+					accessor.SetNamespace(o, namespace.Name)
+					_, err := h.CreateRuntimeObject(o, scheme.Scheme)
+					if err != nil {
+						t.Fatalf("unexpected err: %v", err)
+					}
+				}
+				cm := testConfigMap()
+				cm.Namespace = namespace.Name
+				cm.Data["foo"] = "baz"
+				data, err := resource.Serialize(cm, scheme.Scheme)
+				if err != nil {
+					t.Errorf("unexpected error calling serialize: %v", err)
+					return
+				}
+				applyResult, err := h.ServerSideApply(data, test.force)
+				if test.expectedConflict {
+					if !resource.IsApplyConflict(err) {
+						t.Errorf("expected conflict, got: %v", err)
+					}
+				} else if err != nil {
+					t.Errorf("unexpected error calling server-side apply: %v", err)
+					return
+				}
+				if applyResult != test.expectedResult {
+					t.Errorf("unexpected apply result: %v", applyResult)
+				}
+
+				actual := &corev1.ConfigMap{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, actual); err != nil {
+					t.Errorf("unexpected error retrieving configmap: %v", err)
+					return
+				}
+				if actual.Data["foo"] != test.expectedFoo {
+					t.Errorf("unexpected data: %v", actual.Data)
+				}
+				if test.expectedConflict {
+					return
+				}
+				// Applying the same resource again does not change it.
+				applyResult, err = h.ServerSideApply(data, test.force)
+				if err != nil {
+					t.Errorf("unexpected error calling server-side apply: %v", err)
+					return
+				}
+				if applyResult != resource.UnchangedApplyResult {
+					t.Errorf("unexpected apply result of reapply: %v", applyResult)
+				}
+			})
+		}
+	}
+}